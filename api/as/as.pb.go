@@ -46,18 +46,21 @@ func (x RXWindow) String() string {
 	return proto.EnumName(RXWindow_name, int32(x))
 }
 func (RXWindow) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_as_4be3a1f5d6a68c9d, []int{0}
+	return fileDescriptor_as_895114a7ea19c9f3, []int{0}
 }
 
 type ErrorType int32
 
 const (
-	ErrorType_GENERIC                ErrorType = 0
-	ErrorType_OTAA                   ErrorType = 1
-	ErrorType_DATA_UP_FCNT           ErrorType = 2
-	ErrorType_DATA_UP_MIC            ErrorType = 3
-	ErrorType_DEVICE_QUEUE_ITEM_SIZE ErrorType = 4
-	ErrorType_DEVICE_QUEUE_ITEM_FCNT ErrorType = 5
+	ErrorType_GENERIC                   ErrorType = 0
+	ErrorType_OTAA                      ErrorType = 1
+	ErrorType_DATA_UP_FCNT              ErrorType = 2
+	ErrorType_DATA_UP_MIC               ErrorType = 3
+	ErrorType_DEVICE_QUEUE_ITEM_SIZE    ErrorType = 4
+	ErrorType_DEVICE_QUEUE_ITEM_FCNT    ErrorType = 5
+	ErrorType_DATA_DOWN_GATEWAY         ErrorType = 6
+	ErrorType_DATA_UP_GW_DIVERSITY      ErrorType = 7
+	ErrorType_DEVICE_QUEUE_ITEM_EXPIRED ErrorType = 8
 )
 
 var ErrorType_name = map[int32]string{
@@ -67,21 +70,52 @@ var ErrorType_name = map[int32]string{
 	3: "DATA_UP_MIC",
 	4: "DEVICE_QUEUE_ITEM_SIZE",
 	5: "DEVICE_QUEUE_ITEM_FCNT",
+	6: "DATA_DOWN_GATEWAY",
+	7: "DATA_UP_GW_DIVERSITY",
+	8: "DEVICE_QUEUE_ITEM_EXPIRED",
 }
 var ErrorType_value = map[string]int32{
-	"GENERIC":                0,
-	"OTAA":                   1,
-	"DATA_UP_FCNT":           2,
-	"DATA_UP_MIC":            3,
-	"DEVICE_QUEUE_ITEM_SIZE": 4,
-	"DEVICE_QUEUE_ITEM_FCNT": 5,
+	"GENERIC":                   0,
+	"OTAA":                      1,
+	"DATA_UP_FCNT":              2,
+	"DATA_UP_MIC":               3,
+	"DEVICE_QUEUE_ITEM_SIZE":    4,
+	"DEVICE_QUEUE_ITEM_FCNT":    5,
+	"DATA_DOWN_GATEWAY":         6,
+	"DATA_UP_GW_DIVERSITY":      7,
+	"DEVICE_QUEUE_ITEM_EXPIRED": 8,
 }
 
 func (x ErrorType) String() string {
 	return proto.EnumName(ErrorType_name, int32(x))
 }
 func (ErrorType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_as_4be3a1f5d6a68c9d, []int{1}
+	return fileDescriptor_as_895114a7ea19c9f3, []int{1}
+}
+
+type LocationSource int32
+
+const (
+	// RSSI weighted centroid of the receiving gateways.
+	LocationSource_RSSI_CENTROID LocationSource = 0
+	// Time difference of arrival.
+	LocationSource_TDOA LocationSource = 1
+)
+
+var LocationSource_name = map[int32]string{
+	0: "RSSI_CENTROID",
+	1: "TDOA",
+}
+var LocationSource_value = map[string]int32{
+	"RSSI_CENTROID": 0,
+	"TDOA":          1,
+}
+
+func (x LocationSource) String() string {
+	return proto.EnumName(LocationSource_name, int32(x))
+}
+func (LocationSource) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_as_895114a7ea19c9f3, []int{2}
 }
 
 type DeviceActivationContext struct {
@@ -98,7 +132,7 @@ func (m *DeviceActivationContext) Reset()         { *m = DeviceActivationContext
 func (m *DeviceActivationContext) String() string { return proto.CompactTextString(m) }
 func (*DeviceActivationContext) ProtoMessage()    {}
 func (*DeviceActivationContext) Descriptor() ([]byte, []int) {
-	return fileDescriptor_as_4be3a1f5d6a68c9d, []int{0}
+	return fileDescriptor_as_895114a7ea19c9f3, []int{0}
 }
 func (m *DeviceActivationContext) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeviceActivationContext.Unmarshal(m, b)
@@ -132,6 +166,81 @@ func (m *DeviceActivationContext) GetAppSKey() *common.KeyEnvelope {
 	return nil
 }
 
+type DeviceLocation struct {
+	// Latitude.
+	Latitude float64 `protobuf:"fixed64,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	// Longitude.
+	Longitude float64 `protobuf:"fixed64,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	// Altitude.
+	Altitude float64 `protobuf:"fixed64,3,opt,name=altitude,proto3" json:"altitude,omitempty"`
+	// Method used to resolve the location.
+	Source LocationSource `protobuf:"varint,4,opt,name=source,proto3,enum=as.LocationSource" json:"source,omitempty"`
+	// Number of gateways used to resolve the location.
+	GatewayCount         uint32   `protobuf:"varint,5,opt,name=gateway_count,json=gatewayCount,proto3" json:"gateway_count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeviceLocation) Reset()         { *m = DeviceLocation{} }
+func (m *DeviceLocation) String() string { return proto.CompactTextString(m) }
+func (*DeviceLocation) ProtoMessage()    {}
+func (*DeviceLocation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_as_895114a7ea19c9f3, []int{1}
+}
+func (m *DeviceLocation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeviceLocation.Unmarshal(m, b)
+}
+func (m *DeviceLocation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeviceLocation.Marshal(b, m, deterministic)
+}
+func (dst *DeviceLocation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeviceLocation.Merge(dst, src)
+}
+func (m *DeviceLocation) XXX_Size() int {
+	return xxx_messageInfo_DeviceLocation.Size(m)
+}
+func (m *DeviceLocation) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeviceLocation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeviceLocation proto.InternalMessageInfo
+
+func (m *DeviceLocation) GetLatitude() float64 {
+	if m != nil {
+		return m.Latitude
+	}
+	return 0
+}
+
+func (m *DeviceLocation) GetLongitude() float64 {
+	if m != nil {
+		return m.Longitude
+	}
+	return 0
+}
+
+func (m *DeviceLocation) GetAltitude() float64 {
+	if m != nil {
+		return m.Altitude
+	}
+	return 0
+}
+
+func (m *DeviceLocation) GetSource() LocationSource {
+	if m != nil {
+		return m.Source
+	}
+	return LocationSource_RSSI_CENTROID
+}
+
+func (m *DeviceLocation) GetGatewayCount() uint32 {
+	if m != nil {
+		return m.GatewayCount
+	}
+	return 0
+}
+
 type HandleUplinkDataRequest struct {
 	// DevEUI EUI (8 bytes).
 	DevEui []byte `protobuf:"bytes,1,opt,name=dev_eui,json=devEui,proto3" json:"dev_eui,omitempty"`
@@ -156,16 +265,26 @@ type HandleUplinkDataRequest struct {
 	// This field is only set on the first uplink frame when the security
 	// context has changed (e.g. a new OTAA (re)activation).
 	DeviceActivationContext *DeviceActivationContext `protobuf:"bytes,10,opt,name=device_activation_context,json=deviceActivationContext,proto3" json:"device_activation_context,omitempty"`
-	XXX_NoUnkeyedLiteral    struct{}                 `json:"-"`
-	XXX_unrecognized        []byte                   `json:"-"`
-	XXX_sizecache           int32                    `json:"-"`
+	// The uplink rate of the device exceeds the service-profile ULRate.
+	// This is only set when the ULRatePolicy is set to MARK.
+	RateLimitExceeded bool `protobuf:"varint,11,opt,name=rate_limit_exceeded,json=rateLimitExceeded,proto3" json:"rate_limit_exceeded,omitempty"`
+	// Location of the device, resolved by the network-server.
+	// This is only set when NwkGeoLoc is enabled in the service-profile and
+	// at least one of the receiving gateways has a location.
+	DeviceLocation *DeviceLocation `protobuf:"bytes,12,opt,name=device_location,json=deviceLocation,proto3" json:"device_location,omitempty"`
+	// The uplink was received by fewer gateways than the service-profile
+	// MinGWDiversity.
+	MinGwDiversityViolated bool     `protobuf:"varint,13,opt,name=min_gw_diversity_violated,json=minGwDiversityViolated,proto3" json:"min_gw_diversity_violated,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{} `json:"-"`
+	XXX_unrecognized       []byte   `json:"-"`
+	XXX_sizecache          int32    `json:"-"`
 }
 
 func (m *HandleUplinkDataRequest) Reset()         { *m = HandleUplinkDataRequest{} }
 func (m *HandleUplinkDataRequest) String() string { return proto.CompactTextString(m) }
 func (*HandleUplinkDataRequest) ProtoMessage()    {}
 func (*HandleUplinkDataRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_as_4be3a1f5d6a68c9d, []int{1}
+	return fileDescriptor_as_895114a7ea19c9f3, []int{2}
 }
 func (m *HandleUplinkDataRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_HandleUplinkDataRequest.Unmarshal(m, b)
@@ -255,6 +374,27 @@ func (m *HandleUplinkDataRequest) GetDeviceActivationContext() *DeviceActivation
 	return nil
 }
 
+func (m *HandleUplinkDataRequest) GetRateLimitExceeded() bool {
+	if m != nil {
+		return m.RateLimitExceeded
+	}
+	return false
+}
+
+func (m *HandleUplinkDataRequest) GetDeviceLocation() *DeviceLocation {
+	if m != nil {
+		return m.DeviceLocation
+	}
+	return nil
+}
+
+func (m *HandleUplinkDataRequest) GetMinGwDiversityViolated() bool {
+	if m != nil {
+		return m.MinGwDiversityViolated
+	}
+	return false
+}
+
 type HandleProprietaryUplinkRequest struct {
 	// MACPayload of the proprietary LoRaWAN frame.
 	MacPayload []byte `protobuf:"bytes,1,opt,name=mac_payload,json=macPayload,proto3" json:"mac_payload,omitempty"`
@@ -273,7 +413,7 @@ func (m *HandleProprietaryUplinkRequest) Reset()         { *m = HandleProprietar
 func (m *HandleProprietaryUplinkRequest) String() string { return proto.CompactTextString(m) }
 func (*HandleProprietaryUplinkRequest) ProtoMessage()    {}
 func (*HandleProprietaryUplinkRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_as_4be3a1f5d6a68c9d, []int{2}
+	return fileDescriptor_as_895114a7ea19c9f3, []int{3}
 }
 func (m *HandleProprietaryUplinkRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_HandleProprietaryUplinkRequest.Unmarshal(m, b)
@@ -339,7 +479,7 @@ func (m *HandleErrorRequest) Reset()         { *m = HandleErrorRequest{} }
 func (m *HandleErrorRequest) String() string { return proto.CompactTextString(m) }
 func (*HandleErrorRequest) ProtoMessage()    {}
 func (*HandleErrorRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_as_4be3a1f5d6a68c9d, []int{3}
+	return fileDescriptor_as_895114a7ea19c9f3, []int{4}
 }
 func (m *HandleErrorRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_HandleErrorRequest.Unmarshal(m, b)
@@ -403,7 +543,7 @@ func (m *HandleDownlinkACKRequest) Reset()         { *m = HandleDownlinkACKReque
 func (m *HandleDownlinkACKRequest) String() string { return proto.CompactTextString(m) }
 func (*HandleDownlinkACKRequest) ProtoMessage()    {}
 func (*HandleDownlinkACKRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_as_4be3a1f5d6a68c9d, []int{4}
+	return fileDescriptor_as_895114a7ea19c9f3, []int{5}
 }
 func (m *HandleDownlinkACKRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_HandleDownlinkACKRequest.Unmarshal(m, b)
@@ -456,17 +596,20 @@ type SetDeviceStatusRequest struct {
 	// The device margin status
 	// -32..32: The demodulation SNR ration in dB
 	// 256:     The device-status is not available.
-	Margin               int32    `protobuf:"varint,3,opt,name=margin,proto3" json:"margin,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Margin int32 `protobuf:"varint,3,opt,name=margin,proto3" json:"margin,omitempty"`
+	// Gateway diversity statistics.
+	// This is only set when the service-profile MinGWDiversity is set.
+	GwDiversity          *GatewayDiversityStats `protobuf:"bytes,4,opt,name=gw_diversity,json=gwDiversity,proto3" json:"gw_diversity,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
 }
 
 func (m *SetDeviceStatusRequest) Reset()         { *m = SetDeviceStatusRequest{} }
 func (m *SetDeviceStatusRequest) String() string { return proto.CompactTextString(m) }
 func (*SetDeviceStatusRequest) ProtoMessage()    {}
 func (*SetDeviceStatusRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_as_4be3a1f5d6a68c9d, []int{5}
+	return fileDescriptor_as_895114a7ea19c9f3, []int{6}
 }
 func (m *SetDeviceStatusRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SetDeviceStatusRequest.Unmarshal(m, b)
@@ -507,15 +650,92 @@ func (m *SetDeviceStatusRequest) GetMargin() int32 {
 	return 0
 }
 
+func (m *SetDeviceStatusRequest) GetGwDiversity() *GatewayDiversityStats {
+	if m != nil {
+		return m.GwDiversity
+	}
+	return nil
+}
+
+type GatewayDiversityStats struct {
+	// Number of uplinks.
+	UplinkCount uint32 `protobuf:"varint,1,opt,name=uplink_count,json=uplinkCount,proto3" json:"uplink_count,omitempty"`
+	// Number of uplinks received by fewer gateways than the service-profile
+	// MinGWDiversity.
+	ViolationCount uint32 `protobuf:"varint,2,opt,name=violation_count,json=violationCount,proto3" json:"violation_count,omitempty"`
+	// Average number of receiving gateways.
+	AvgGatewayCount float64 `protobuf:"fixed64,3,opt,name=avg_gateway_count,json=avgGatewayCount,proto3" json:"avg_gateway_count,omitempty"`
+	// Number of gateways that received the last uplink.
+	LastGatewayCount     uint32   `protobuf:"varint,4,opt,name=last_gateway_count,json=lastGatewayCount,proto3" json:"last_gateway_count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GatewayDiversityStats) Reset()         { *m = GatewayDiversityStats{} }
+func (m *GatewayDiversityStats) String() string { return proto.CompactTextString(m) }
+func (*GatewayDiversityStats) ProtoMessage()    {}
+func (*GatewayDiversityStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_as_895114a7ea19c9f3, []int{7}
+}
+func (m *GatewayDiversityStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GatewayDiversityStats.Unmarshal(m, b)
+}
+func (m *GatewayDiversityStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GatewayDiversityStats.Marshal(b, m, deterministic)
+}
+func (dst *GatewayDiversityStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GatewayDiversityStats.Merge(dst, src)
+}
+func (m *GatewayDiversityStats) XXX_Size() int {
+	return xxx_messageInfo_GatewayDiversityStats.Size(m)
+}
+func (m *GatewayDiversityStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_GatewayDiversityStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GatewayDiversityStats proto.InternalMessageInfo
+
+func (m *GatewayDiversityStats) GetUplinkCount() uint32 {
+	if m != nil {
+		return m.UplinkCount
+	}
+	return 0
+}
+
+func (m *GatewayDiversityStats) GetViolationCount() uint32 {
+	if m != nil {
+		return m.ViolationCount
+	}
+	return 0
+}
+
+func (m *GatewayDiversityStats) GetAvgGatewayCount() float64 {
+	if m != nil {
+		return m.AvgGatewayCount
+	}
+	return 0
+}
+
+func (m *GatewayDiversityStats) GetLastGatewayCount() uint32 {
+	if m != nil {
+		return m.LastGatewayCount
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*DeviceActivationContext)(nil), "as.DeviceActivationContext")
+	proto.RegisterType((*DeviceLocation)(nil), "as.DeviceLocation")
 	proto.RegisterType((*HandleUplinkDataRequest)(nil), "as.HandleUplinkDataRequest")
 	proto.RegisterType((*HandleProprietaryUplinkRequest)(nil), "as.HandleProprietaryUplinkRequest")
 	proto.RegisterType((*HandleErrorRequest)(nil), "as.HandleErrorRequest")
 	proto.RegisterType((*HandleDownlinkACKRequest)(nil), "as.HandleDownlinkACKRequest")
 	proto.RegisterType((*SetDeviceStatusRequest)(nil), "as.SetDeviceStatusRequest")
+	proto.RegisterType((*GatewayDiversityStats)(nil), "as.GatewayDiversityStats")
 	proto.RegisterEnum("as.RXWindow", RXWindow_name, RXWindow_value)
 	proto.RegisterEnum("as.ErrorType", ErrorType_name, ErrorType_value)
+	proto.RegisterEnum("as.LocationSource", LocationSource_name, LocationSource_value)
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -732,58 +952,80 @@ var _ApplicationServerService_serviceDesc = grpc.ServiceDesc{
 	Metadata: "as.proto",
 }
 
-func init() { proto.RegisterFile("as.proto", fileDescriptor_as_4be3a1f5d6a68c9d) }
-
-var fileDescriptor_as_4be3a1f5d6a68c9d = []byte{
-	// 795 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0xdd, 0x72, 0xe3, 0x34,
-	0x14, 0xae, 0x9d, 0xdf, 0x9e, 0x74, 0x77, 0x8d, 0x16, 0x52, 0x6f, 0x76, 0x07, 0x82, 0xaf, 0xca,
-	0xce, 0x60, 0x0f, 0x65, 0xb8, 0x66, 0x3c, 0x89, 0x59, 0x32, 0x9d, 0x5d, 0x8a, 0x93, 0xd2, 0x0e,
-	0x37, 0x1e, 0xc5, 0x52, 0x8c, 0xa8, 0x6d, 0x09, 0x45, 0x49, 0xea, 0x61, 0x78, 0x0c, 0x1e, 0x82,
-	0x97, 0xe2, 0x59, 0x18, 0xcb, 0x6e, 0xd3, 0xed, 0x5f, 0xb8, 0x89, 0xa4, 0x73, 0x8e, 0xbe, 0xf3,
-	0xe5, 0xd3, 0xe7, 0x03, 0x5d, 0xbc, 0x74, 0x85, 0xe4, 0x8a, 0x23, 0x13, 0x2f, 0x07, 0xaf, 0x13,
-	0xce, 0x93, 0x94, 0x7a, 0x3a, 0x32, 0x5f, 0x2d, 0x3c, 0x9a, 0x09, 0x55, 0x54, 0x05, 0x83, 0xef,
-	0x12, 0xa6, 0x7e, 0x5b, 0xcd, 0xdd, 0x98, 0x67, 0xde, 0x5c, 0xf2, 0x18, 0x63, 0xe9, 0xa5, 0x5c,
-	0xe2, 0x25, 0x95, 0x6b, 0x2a, 0x3d, 0x2c, 0x98, 0x17, 0xf3, 0x2c, 0xe3, 0x79, 0xbd, 0xd4, 0xd7,
-	0xbe, 0xde, 0x7d, 0x2d, 0xd9, 0x78, 0xc9, 0xa6, 0x2a, 0x77, 0x28, 0x1c, 0x8e, 0xe9, 0x9a, 0xc5,
-	0xd4, 0x8f, 0x15, 0x5b, 0x63, 0xc5, 0x78, 0x3e, 0xe2, 0xb9, 0xa2, 0x57, 0x0a, 0xbd, 0x82, 0x2e,
-	0xa1, 0xeb, 0x08, 0x13, 0x22, 0x6d, 0x63, 0x68, 0x1c, 0x1d, 0x84, 0x1d, 0x42, 0xd7, 0x3e, 0x21,
-	0x12, 0x79, 0xb0, 0x8f, 0x85, 0x88, 0x96, 0xd1, 0x25, 0x2d, 0x6c, 0x73, 0x68, 0x1c, 0xf5, 0x8e,
-	0x5f, 0xba, 0x35, 0x8d, 0x13, 0x5a, 0x04, 0xf9, 0x9a, 0xa6, 0x5c, 0xd0, 0xb0, 0x83, 0x85, 0x98,
-	0x9e, 0xd0, 0xc2, 0xf9, 0xd7, 0x84, 0xc3, 0x1f, 0x71, 0x4e, 0x52, 0x7a, 0x26, 0x52, 0x96, 0x5f,
-	0x8e, 0xb1, 0xc2, 0x21, 0xfd, 0x63, 0x45, 0x97, 0x0a, 0x1d, 0x42, 0x89, 0x1b, 0xd1, 0x15, 0xab,
-	0xdb, 0xb4, 0x09, 0x5d, 0x07, 0x2b, 0x56, 0x12, 0xf8, 0x9d, 0xb3, 0x5c, 0x67, 0xcc, 0x8a, 0x40,
-	0x79, 0x2e, 0x53, 0x2f, 0xa1, 0xb5, 0x88, 0xe2, 0x5c, 0xd9, 0x8d, 0xa1, 0x71, 0xf4, 0x2c, 0x6c,
-	0x2e, 0x46, 0xb9, 0x42, 0x9f, 0x41, 0x7b, 0x11, 0x09, 0x2e, 0x95, 0xdd, 0xd4, 0xd1, 0xd6, 0xe2,
-	0x94, 0x4b, 0x85, 0x2c, 0x68, 0x60, 0x22, 0xed, 0xd6, 0xd0, 0x38, 0xea, 0x86, 0xe5, 0x16, 0x3d,
-	0x07, 0x93, 0x48, 0xbb, 0xad, 0x8b, 0x4c, 0x22, 0xd1, 0x57, 0xd0, 0x51, 0x57, 0x11, 0xcb, 0x17,
-	0xdc, 0xee, 0xe8, 0x3f, 0x63, 0xb9, 0xc9, 0xc6, 0xad, 0x98, 0xce, 0x2e, 0x26, 0xf9, 0x82, 0x87,
-	0x6d, 0x75, 0x55, 0xae, 0x65, 0xa9, 0xac, 0x4b, 0xbb, 0xc3, 0xc6, 0xc7, 0xa5, 0x61, 0x5d, 0x2a,
-	0xab, 0x52, 0x04, 0x4d, 0x82, 0x15, 0xb6, 0xf7, 0x35, 0x75, 0xbd, 0x47, 0xe7, 0xf0, 0x8a, 0x68,
-	0xb9, 0x23, 0x7c, 0xa3, 0x77, 0x14, 0x57, 0x82, 0xdb, 0xa0, 0x7b, 0xbf, 0x76, 0xf1, 0xd2, 0x7d,
-	0xe4, 0x4d, 0xc2, 0x43, 0xf2, 0x70, 0xc2, 0xf9, 0xc7, 0x80, 0xcf, 0x2b, 0x81, 0x4f, 0x25, 0x17,
-	0x92, 0x51, 0x85, 0x65, 0x51, 0xd3, 0xaa, 0x75, 0xfe, 0x02, 0x7a, 0x19, 0x8e, 0x23, 0x81, 0x8b,
-	0x94, 0x63, 0x52, 0x6b, 0x0d, 0x19, 0x8e, 0x4f, 0xab, 0x48, 0x29, 0x54, 0xc6, 0xe2, 0x5a, 0xea,
-	0x72, 0x7b, 0x5b, 0x98, 0xc6, 0xff, 0x17, 0xa6, 0xf9, 0xb4, 0x30, 0xce, 0x9f, 0x80, 0x2a, 0xaa,
-	0x81, 0x94, 0x5c, 0xee, 0xb4, 0xc1, 0x97, 0xd0, 0x54, 0x85, 0xa0, 0x9a, 0xc1, 0xf3, 0xe3, 0x67,
-	0xa5, 0x3c, 0xfa, 0xe2, 0xac, 0x10, 0x34, 0xd4, 0x29, 0xf4, 0x29, 0xb4, 0x68, 0x19, 0xd2, 0x0f,
-	0xbf, 0x1f, 0x56, 0x87, 0xad, 0x49, 0x5a, 0x5b, 0x93, 0x38, 0x29, 0xd8, 0x55, 0xf3, 0x31, 0xdf,
-	0xe4, 0x25, 0x39, 0x7f, 0x74, 0xb2, 0x93, 0xc2, 0x0d, 0x92, 0x79, 0xcb, 0x6e, 0x0e, 0x1c, 0xe0,
-	0xf8, 0x32, 0xe7, 0x9b, 0x94, 0x92, 0x84, 0x12, 0xcd, 0xaf, 0x1b, 0x7e, 0x14, 0x73, 0x62, 0xe8,
-	0x4f, 0xa9, 0xaa, 0x5e, 0x73, 0xaa, 0xb0, 0x5a, 0x2d, 0x77, 0xf6, 0xb2, 0xa1, 0x33, 0xc7, 0x4a,
-	0x51, 0x59, 0xd4, 0xdd, 0xae, 0x8f, 0xa8, 0x0f, 0xed, 0x0c, 0xcb, 0x84, 0xe5, 0xba, 0x55, 0x2b,
-	0xac, 0x4f, 0x6f, 0xdf, 0x40, 0x37, 0xbc, 0x38, 0x67, 0x39, 0xe1, 0x1b, 0xd4, 0x81, 0x46, 0x78,
-	0xf1, 0x8d, 0xb5, 0x57, 0x6d, 0x8e, 0x2d, 0xe3, 0xed, 0x5f, 0xb0, 0x7f, 0x23, 0x17, 0xea, 0x41,
-	0xe7, 0x5d, 0xf0, 0x21, 0x08, 0x27, 0x23, 0x6b, 0x0f, 0x75, 0xa1, 0xf9, 0xd3, 0xcc, 0xf7, 0x2d,
-	0x03, 0x59, 0x70, 0x30, 0xf6, 0x67, 0x7e, 0x74, 0x76, 0x1a, 0xfd, 0x30, 0xfa, 0x30, 0xb3, 0x4c,
-	0xf4, 0x02, 0x7a, 0xd7, 0x91, 0xf7, 0x93, 0x91, 0xd5, 0x40, 0x03, 0xe8, 0x8f, 0x83, 0x5f, 0x26,
-	0xa3, 0x20, 0xfa, 0xf9, 0x2c, 0x38, 0x0b, 0xa2, 0xc9, 0x2c, 0x78, 0x1f, 0x4d, 0x27, 0xbf, 0x06,
-	0x56, 0xf3, 0xe1, 0x9c, 0x06, 0x6a, 0x1d, 0xff, 0xdd, 0x00, 0xdb, 0x17, 0x22, 0x65, 0xb1, 0xf6,
-	0xeb, 0x54, 0x8f, 0xa1, 0xf2, 0x97, 0xc5, 0x14, 0x4d, 0xc0, 0xba, 0x3b, 0x15, 0x90, 0xf6, 0xff,
-	0x23, 0xb3, 0x62, 0xd0, 0x77, 0xab, 0x91, 0xe9, 0x5e, 0x8f, 0x4c, 0x37, 0x28, 0x47, 0xa6, 0xb3,
-	0x87, 0xce, 0xaf, 0x07, 0xcc, 0x3d, 0xff, 0x23, 0x67, 0x8b, 0xf8, 0xd8, 0xc7, 0xf1, 0x04, 0xf0,
-	0xf7, 0xd0, 0xbb, 0xe5, 0x56, 0xd4, 0xdf, 0x82, 0xdd, 0xb6, 0xef, 0x13, 0x00, 0x27, 0xf0, 0xc9,
-	0x3d, 0xc7, 0xa1, 0x37, 0x5b, 0x98, 0xfb, 0x46, 0x7c, 0x02, 0xec, 0x1d, 0xbc, 0xb8, 0x63, 0x28,
-	0x34, 0x28, 0xa1, 0x1e, 0x76, 0xd9, 0xe3, 0x40, 0xf3, 0xb6, 0x8e, 0x7c, 0xfb, 0x5f, 0x00, 0x00,
-	0x00, 0xff, 0xff, 0x91, 0x4e, 0xdc, 0xf7, 0x92, 0x06, 0x00, 0x00,
+func init() { proto.RegisterFile("as.proto", fileDescriptor_as_895114a7ea19c9f3) }
+
+var fileDescriptor_as_895114a7ea19c9f3 = []byte{
+	// 1151 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x56, 0xdb, 0x72, 0xdb, 0x36,
+	0x13, 0x0e, 0x75, 0xf6, 0xea, 0x60, 0x1a, 0x49, 0x6c, 0xda, 0xc9, 0xff, 0xd7, 0x51, 0x2f, 0xea,
+	0x7a, 0x1a, 0x69, 0xea, 0x4e, 0x2f, 0x3a, 0xed, 0x4c, 0x47, 0x23, 0xb1, 0xaa, 0xc6, 0x89, 0xed,
+	0x42, 0x72, 0xec, 0xf4, 0x06, 0x03, 0x93, 0x10, 0x8b, 0x86, 0x22, 0x58, 0x08, 0x92, 0xac, 0xe9,
+	0x7d, 0xdf, 0xa0, 0x0f, 0xd0, 0xcb, 0x3e, 0x41, 0xaf, 0xfa, 0x08, 0x7d, 0xa7, 0x0e, 0x01, 0xea,
+	0xe0, 0xc4, 0x87, 0xde, 0x48, 0xc4, 0x7e, 0x8b, 0x6f, 0x97, 0xbb, 0x1f, 0x16, 0x84, 0x12, 0x1d,
+	0x37, 0x62, 0x29, 0x94, 0x40, 0x19, 0x3a, 0xde, 0x7b, 0x16, 0x08, 0x11, 0x84, 0xac, 0xa9, 0x2d,
+	0x57, 0x93, 0x61, 0x93, 0x8d, 0x62, 0x35, 0x37, 0x0e, 0x7b, 0x5f, 0x06, 0x5c, 0xfd, 0x34, 0xb9,
+	0x6a, 0x78, 0x62, 0xd4, 0xbc, 0x92, 0xc2, 0xa3, 0x54, 0x36, 0x43, 0x21, 0xe9, 0x98, 0xc9, 0x29,
+	0x93, 0x4d, 0x1a, 0xf3, 0xa6, 0x27, 0x46, 0x23, 0x11, 0xa5, 0x7f, 0xe9, 0xb6, 0x97, 0x0f, 0x6f,
+	0x0b, 0x66, 0xcd, 0x60, 0x66, 0xdc, 0xeb, 0x0c, 0x76, 0x3a, 0x6c, 0xca, 0x3d, 0xd6, 0xf2, 0x14,
+	0x9f, 0x52, 0xc5, 0x45, 0xd4, 0x16, 0x91, 0x62, 0xd7, 0x0a, 0xed, 0x42, 0xc9, 0x67, 0x53, 0x42,
+	0x7d, 0x5f, 0x3a, 0xd6, 0xbe, 0x75, 0x50, 0xc1, 0x45, 0x9f, 0x4d, 0x5b, 0xbe, 0x2f, 0x51, 0x13,
+	0x36, 0x68, 0x1c, 0x93, 0x31, 0x79, 0xc7, 0xe6, 0x4e, 0x66, 0xdf, 0x3a, 0x28, 0x1f, 0x3d, 0x6e,
+	0xa4, 0x69, 0x1c, 0xb3, 0xb9, 0x1b, 0x4d, 0x59, 0x28, 0x62, 0x86, 0x8b, 0x34, 0x8e, 0xfb, 0xc7,
+	0x6c, 0x5e, 0xff, 0xcb, 0x82, 0x9a, 0x89, 0xf3, 0x4a, 0x78, 0x3a, 0x0a, 0xda, 0x83, 0x52, 0x48,
+	0x15, 0x57, 0x13, 0x9f, 0x69, 0x7a, 0x0b, 0x2f, 0xd7, 0xe8, 0x39, 0x6c, 0x84, 0x22, 0x0a, 0x0c,
+	0x98, 0xd1, 0xe0, 0xca, 0x90, 0xec, 0xa4, 0x61, 0xba, 0x33, 0x6b, 0x76, 0x2e, 0xd6, 0xe8, 0x10,
+	0x0a, 0x63, 0x31, 0x91, 0x1e, 0x73, 0x72, 0xfb, 0xd6, 0x41, 0xed, 0x08, 0x35, 0xe8, 0xb8, 0xb1,
+	0x88, 0xd9, 0xd7, 0x08, 0x4e, 0x3d, 0xd0, 0xc7, 0x50, 0x0d, 0xa8, 0x62, 0x33, 0x3a, 0x27, 0x9e,
+	0x98, 0x44, 0xca, 0xc9, 0xef, 0x5b, 0x07, 0x55, 0x5c, 0x49, 0x8d, 0xed, 0xc4, 0x56, 0xff, 0x2d,
+	0x07, 0x3b, 0xdf, 0xd3, 0xc8, 0x0f, 0xd9, 0x79, 0x1c, 0xf2, 0xe8, 0x5d, 0x87, 0x2a, 0x8a, 0xd9,
+	0x2f, 0x13, 0x36, 0x56, 0x68, 0x07, 0x92, 0x8a, 0x10, 0x36, 0xe1, 0x69, 0x81, 0x0a, 0x3e, 0x9b,
+	0xba, 0x13, 0x9e, 0x94, 0xee, 0x67, 0xc1, 0x23, 0x8d, 0x64, 0x4c, 0xe9, 0x92, 0x75, 0x02, 0x3d,
+	0x86, 0xfc, 0x90, 0x78, 0x91, 0xd2, 0x99, 0x57, 0x71, 0x6e, 0xd8, 0x8e, 0x14, 0x7a, 0x0a, 0x85,
+	0x21, 0x89, 0x85, 0x54, 0x3a, 0xeb, 0x2a, 0xce, 0x0f, 0xcf, 0x84, 0x54, 0xc8, 0x86, 0x2c, 0xf5,
+	0xa5, 0x4e, 0xab, 0x84, 0x93, 0x47, 0x54, 0x83, 0x8c, 0x2f, 0x9d, 0x82, 0x76, 0xca, 0xf8, 0x12,
+	0x7d, 0x0a, 0x45, 0x75, 0x4d, 0x78, 0x34, 0x14, 0x4e, 0x51, 0xb7, 0xc1, 0x6e, 0x04, 0xb3, 0x86,
+	0xc9, 0x74, 0x70, 0xd9, 0x8b, 0x86, 0x02, 0x17, 0xd4, 0x75, 0xf2, 0x9f, 0xb8, 0xca, 0xd4, 0xb5,
+	0xb4, 0x9f, 0xbd, 0xe9, 0x8a, 0x53, 0x57, 0x69, 0x5c, 0x11, 0xe4, 0x7c, 0xaa, 0xa8, 0xb3, 0xa1,
+	0x53, 0xd7, 0xcf, 0xe8, 0x02, 0x76, 0x7d, 0xdd, 0x40, 0x42, 0x97, 0x4a, 0x21, 0x9e, 0x91, 0x8a,
+	0x03, 0x3a, 0xf6, 0xb3, 0xa4, 0xd6, 0x77, 0xa8, 0x09, 0xef, 0xf8, 0xb7, 0x03, 0xa8, 0x01, 0x8f,
+	0x25, 0x55, 0x8c, 0x84, 0x7c, 0xc4, 0x15, 0x61, 0xd7, 0x1e, 0x63, 0x3e, 0xf3, 0x9d, 0xb2, 0x7e,
+	0xe9, 0xad, 0x04, 0x7a, 0x95, 0x20, 0x6e, 0x0a, 0xa0, 0xaf, 0x61, 0x33, 0x4d, 0x24, 0x4c, 0xdb,
+	0xea, 0x54, 0x74, 0x78, 0xb4, 0x0a, 0xbf, 0x68, 0x38, 0xae, 0xf9, 0x37, 0xd6, 0xe8, 0x2b, 0xd8,
+	0x1d, 0xf1, 0x88, 0x04, 0x33, 0xe2, 0xf3, 0x29, 0x93, 0x63, 0xae, 0xe6, 0x64, 0xca, 0x45, 0x48,
+	0x15, 0xf3, 0x9d, 0xaa, 0x0e, 0xb9, 0x3d, 0xe2, 0x51, 0x77, 0xd6, 0x59, 0xc0, 0x6f, 0x52, 0xb4,
+	0xfe, 0xa7, 0x05, 0xff, 0x37, 0x42, 0x38, 0x93, 0x22, 0x96, 0x9c, 0x29, 0x2a, 0xe7, 0x69, 0xf9,
+	0x52, 0x3d, 0x7c, 0x04, 0xe5, 0x11, 0xf5, 0x48, 0x4c, 0xe7, 0xa1, 0xa0, 0x7e, 0xaa, 0x09, 0x18,
+	0x51, 0xef, 0xcc, 0x58, 0x92, 0x86, 0x8e, 0xb8, 0x97, 0x4a, 0x22, 0x79, 0x5c, 0x6f, 0x60, 0xf6,
+	0xbf, 0x37, 0x30, 0x77, 0x7f, 0x03, 0xeb, 0xbf, 0x02, 0x32, 0xa9, 0xba, 0x52, 0x0a, 0xf9, 0xa0,
+	0x5c, 0x5f, 0x40, 0x4e, 0xcd, 0x63, 0x73, 0x98, 0x6a, 0x47, 0xd5, 0xa4, 0x8e, 0x7a, 0xe3, 0x60,
+	0x1e, 0x33, 0xac, 0x21, 0xf4, 0x04, 0xf2, 0x2c, 0x31, 0x69, 0x81, 0x6e, 0x60, 0xb3, 0x58, 0x89,
+	0x39, 0xbf, 0x12, 0x73, 0x3d, 0x04, 0xc7, 0x04, 0xef, 0x88, 0x59, 0x94, 0x24, 0xd7, 0x6a, 0x1f,
+	0x3f, 0x98, 0xc2, 0x92, 0x29, 0xb3, 0x76, 0x2c, 0xea, 0x50, 0xa1, 0xde, 0xbb, 0x48, 0xcc, 0x42,
+	0xe6, 0x07, 0xcc, 0xd7, 0xf9, 0x95, 0xf0, 0x0d, 0x5b, 0xfd, 0x0f, 0x0b, 0xb6, 0xfb, 0x4c, 0x99,
+	0xbe, 0xf7, 0x15, 0x55, 0x93, 0xf1, 0x83, 0xc1, 0x1c, 0x28, 0x5e, 0x51, 0xa5, 0x98, 0x9c, 0xa7,
+	0xe1, 0x16, 0x4b, 0xb4, 0x0d, 0x85, 0x11, 0x95, 0x01, 0x8f, 0x74, 0xac, 0x3c, 0x4e, 0x57, 0xe8,
+	0x1b, 0xa8, 0xac, 0x6b, 0x46, 0x57, 0xa1, 0x7c, 0xb4, 0x9b, 0x54, 0xaa, 0x6b, 0xa6, 0xc5, 0x52,
+	0x30, 0x49, 0x0e, 0x63, 0x5c, 0x0e, 0x56, 0x12, 0xaa, 0xff, 0x6d, 0xc1, 0xd3, 0x5b, 0xdd, 0xd0,
+	0x0b, 0xa8, 0x4c, 0x74, 0x03, 0xd3, 0x09, 0x64, 0xe9, 0x74, 0xca, 0xc6, 0xa6, 0x07, 0x10, 0xfa,
+	0x04, 0x36, 0x8d, 0x42, 0xcd, 0x81, 0x9b, 0x2c, 0x6b, 0x54, 0x5b, 0x9a, 0x8d, 0xe3, 0x21, 0x6c,
+	0xd1, 0x69, 0x40, 0x6e, 0x8e, 0x34, 0x33, 0x1f, 0x37, 0xe9, 0x34, 0xe8, 0xae, 0x4d, 0x35, 0xf4,
+	0x19, 0xa0, 0x90, 0x8e, 0xd5, 0x7b, 0xce, 0x66, 0xf8, 0xd8, 0x09, 0xb2, 0xee, 0x7d, 0xf8, 0x1c,
+	0x4a, 0xf8, 0xf2, 0x82, 0x47, 0xbe, 0x98, 0xa1, 0x22, 0x64, 0xf1, 0xe5, 0xe7, 0xf6, 0x23, 0xf3,
+	0x70, 0x64, 0x5b, 0x87, 0xff, 0x58, 0xb0, 0xb1, 0x94, 0x0b, 0x2a, 0x43, 0xb1, 0xeb, 0x9e, 0xb8,
+	0xb8, 0xd7, 0xb6, 0x1f, 0xa1, 0x12, 0xe4, 0x4e, 0x07, 0xad, 0x96, 0x6d, 0x21, 0x1b, 0x2a, 0x9d,
+	0xd6, 0xa0, 0x45, 0xce, 0xcf, 0xc8, 0x77, 0xed, 0x93, 0x81, 0x9d, 0x41, 0x9b, 0x50, 0x5e, 0x58,
+	0x5e, 0xf7, 0xda, 0x76, 0x16, 0xed, 0xc1, 0x76, 0xc7, 0x7d, 0xd3, 0x6b, 0xbb, 0xe4, 0x87, 0x73,
+	0xf7, 0xdc, 0x25, 0xbd, 0x81, 0xfb, 0x9a, 0xf4, 0x7b, 0x3f, 0xba, 0x76, 0xee, 0x76, 0x4c, 0x13,
+	0xe5, 0xd1, 0x53, 0xd8, 0xd2, 0x44, 0x9d, 0xd3, 0x8b, 0x13, 0xd2, 0x6d, 0x0d, 0xdc, 0x8b, 0xd6,
+	0x5b, 0xbb, 0x80, 0x1c, 0x78, 0xb2, 0xe0, 0xef, 0x5e, 0x90, 0x4e, 0xef, 0x8d, 0x8b, 0xfb, 0xbd,
+	0xc1, 0x5b, 0xbb, 0x88, 0xfe, 0x07, 0xbb, 0x1f, 0x92, 0xb9, 0x97, 0x67, 0x3d, 0xec, 0x76, 0xec,
+	0xd2, 0xe1, 0x4b, 0xa8, 0xdd, 0xbc, 0x30, 0xd0, 0x16, 0x54, 0x71, 0xbf, 0xdf, 0x23, 0x6d, 0xf7,
+	0x64, 0x80, 0x4f, 0x7b, 0x1d, 0xf3, 0x66, 0x83, 0xce, 0x69, 0xcb, 0xb6, 0x8e, 0x7e, 0xcf, 0x82,
+	0xd3, 0x8a, 0xe3, 0x90, 0xa7, 0x5b, 0xf4, 0x3d, 0x9b, 0xfc, 0x72, 0x8f, 0xa1, 0x1e, 0xd8, 0xef,
+	0x5f, 0x1e, 0x48, 0x8f, 0xc9, 0x3b, 0xae, 0x94, 0xbd, 0xed, 0x86, 0xf9, 0x26, 0x68, 0x2c, 0xbe,
+	0x09, 0x1a, 0x6e, 0xf2, 0x4d, 0x50, 0x7f, 0x84, 0x2e, 0x60, 0xe7, 0x8e, 0xf1, 0x83, 0xea, 0x2b,
+	0xc6, 0xbb, 0x66, 0xd3, 0x3d, 0xc4, 0xdf, 0x42, 0x79, 0x6d, 0x58, 0xa0, 0xed, 0x15, 0xd9, 0xfa,
+	0xf4, 0xb8, 0x87, 0xe0, 0x18, 0xb6, 0x3e, 0x38, 0xf0, 0xe8, 0xf9, 0x8a, 0xe6, 0xc3, 0x39, 0x70,
+	0x0f, 0x59, 0x17, 0x36, 0xdf, 0x3b, 0xce, 0x68, 0x2f, 0xa1, 0xba, 0xfd, 0x8c, 0xdf, 0x4d, 0x74,
+	0x55, 0xd0, 0x96, 0x2f, 0xfe, 0x1d, 0x00, 0x45, 0xbe, 0x9e, 0x38, 0x73, 0x09, 0x00, 0x00,
 }
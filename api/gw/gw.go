@@ -24,21 +24,22 @@ type RXPacketBytes struct {
 
 // RXInfo contains the RX information.
 type RXInfo struct {
-	MAC               lorawan.EUI64 `json:"mac"`                         // MAC address of the gateway
-	Time              *time.Time    `json:"time,omitempty"`              // Receive timestamp (only set when the gateway has a GPS time-source)
-	TimeSinceGPSEpoch *Duration     `json:"timeSinceGPSEpoch,omitempty"` // Time since GPS epoch (1980-01-06, only set when the gateway has a GPS time source)
-	Timestamp         uint32        `json:"timestamp"`                   // gateway internal receive timestamp with microsecond precision, will rollover every ~ 72 minutes
-	Frequency         int           `json:"frequency"`                   // frequency in Hz
-	Channel           int           `json:"channel"`                     // concentrator IF channel used for RX
-	RFChain           int           `json:"rfChain"`                     // RF chain used for RX
-	CRCStatus         int           `json:"crcStatus"`                   // 1 = OK, -1 = fail, 0 = no CRC
-	CodeRate          string        `json:"codeRate"`                    // ECC code rate
-	RSSI              int           `json:"rssi"`                        // RSSI in dBm
-	LoRaSNR           float64       `json:"loRaSNR"`                     // LoRa signal-to-noise ratio in dB
-	Size              int           `json:"size"`                        // packet payload size
-	DataRate          band.DataRate `json:"dataRate"`                    // RX datarate (either LoRa or FSK)
-	Board             int           `json:"board"`                       // Concentrator board used for RX
-	Antenna           int           `json:"antenna"`                     // Antenna number on which signal has been received
+	MAC                   lorawan.EUI64 `json:"mac"`                             // MAC address of the gateway
+	Time                  *time.Time    `json:"time,omitempty"`                  // Receive timestamp (only set when the gateway has a GPS time-source)
+	TimeSinceGPSEpoch     *Duration     `json:"timeSinceGPSEpoch,omitempty"`     // Time since GPS epoch (1980-01-06, only set when the gateway has a GPS time source)
+	FineTimeSinceGPSEpoch *Duration     `json:"fineTimeSinceGPSEpoch,omitempty"` // Time since GPS epoch with nanosecond precision (only set when the gateway supports fine-timestamping)
+	Timestamp             uint32        `json:"timestamp"`                       // gateway internal receive timestamp with microsecond precision, will rollover every ~ 72 minutes
+	Frequency             int           `json:"frequency"`                       // frequency in Hz
+	Channel               int           `json:"channel"`                         // concentrator IF channel used for RX
+	RFChain               int           `json:"rfChain"`                         // RF chain used for RX
+	CRCStatus             int           `json:"crcStatus"`                       // 1 = OK, -1 = fail, 0 = no CRC
+	CodeRate              string        `json:"codeRate"`                        // ECC code rate
+	RSSI                  int           `json:"rssi"`                            // RSSI in dBm
+	LoRaSNR               float64       `json:"loRaSNR"`                         // LoRa signal-to-noise ratio in dB
+	Size                  int           `json:"size"`                            // packet payload size
+	DataRate              band.DataRate `json:"dataRate"`                        // RX datarate (either LoRa or FSK)
+	Board                 int           `json:"board"`                           // Concentrator board used for RX
+	Antenna               int           `json:"antenna"`                         // Antenna number on which signal has been received
 }
 
 // TXPacket contains the PHYPayload which should be send to the
@@ -47,6 +48,18 @@ type TXPacket struct {
 	Token      uint16             `json:"token"`
 	TXInfo     TXInfo             `json:"txInfo"`
 	PHYPayload lorawan.PHYPayload `json:"phyPayload"`
+
+	// Beacon contains the Class-B beacon frame. As a beacon does not use the
+	// PHYPayload format, it is sent instead of the PHYPayload when set.
+	Beacon []byte `json:"beacon,omitempty"`
+}
+
+// PayloadBytes returns the bytes to be transmitted by the gateway.
+func (p TXPacket) PayloadBytes() ([]byte, error) {
+	if p.Beacon != nil {
+		return p.Beacon, nil
+	}
+	return p.PHYPayload.MarshalBinary()
 }
 
 // TXPacketBytes contains the PHYPayload as []byte which should be send to the
@@ -94,6 +107,8 @@ type TXInfo struct {
 	IPol              *bool         `json:"iPol"`                        // when left nil, the gateway-bridge will use the default (true for LoRa modulation)
 	Board             int           `json:"board"`                       // Concentrator board used for RX
 	Antenna           int           `json:"antenna"`                     // Antenna number on which signal has been received
+	NoCRC             bool          `json:"noCRC,omitempty"`             // disable the CRC of the physical layer (e.g. for Class-B beacons)
+	NoHeader          bool          `json:"noHeader,omitempty"`          // disable the header of the physical layer (e.g. for Class-B beacons)
 }
 
 // GatewayStatsPacket contains the information of a gateway.
@@ -39,7 +39,7 @@ func (m *UplinkTXInfo) Reset()         { *m = UplinkTXInfo{} }
 func (m *UplinkTXInfo) String() string { return proto.CompactTextString(m) }
 func (*UplinkTXInfo) ProtoMessage()    {}
 func (*UplinkTXInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_gw_90ea3f7651ea710d, []int{0}
+	return fileDescriptor_gw_5636d0ce00653873, []int{0}
 }
 func (m *UplinkTXInfo) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UplinkTXInfo.Unmarshal(m, b)
@@ -200,7 +200,7 @@ func (m *LoRaModulationInfo) Reset()         { *m = LoRaModulationInfo{} }
 func (m *LoRaModulationInfo) String() string { return proto.CompactTextString(m) }
 func (*LoRaModulationInfo) ProtoMessage()    {}
 func (*LoRaModulationInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_gw_90ea3f7651ea710d, []int{1}
+	return fileDescriptor_gw_5636d0ce00653873, []int{1}
 }
 func (m *LoRaModulationInfo) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LoRaModulationInfo.Unmarshal(m, b)
@@ -262,7 +262,7 @@ func (m *FSKModulationInfo) Reset()         { *m = FSKModulationInfo{} }
 func (m *FSKModulationInfo) String() string { return proto.CompactTextString(m) }
 func (*FSKModulationInfo) ProtoMessage()    {}
 func (*FSKModulationInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_gw_90ea3f7651ea710d, []int{2}
+	return fileDescriptor_gw_5636d0ce00653873, []int{2}
 }
 func (m *FSKModulationInfo) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FSKModulationInfo.Unmarshal(m, b)
@@ -312,7 +312,7 @@ func (m *Location) Reset()         { *m = Location{} }
 func (m *Location) String() string { return proto.CompactTextString(m) }
 func (*Location) ProtoMessage()    {}
 func (*Location) Descriptor() ([]byte, []int) {
-	return fileDescriptor_gw_90ea3f7651ea710d, []int{3}
+	return fileDescriptor_gw_5636d0ce00653873, []int{3}
 }
 func (m *Location) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Location.Unmarshal(m, b)
@@ -375,17 +375,20 @@ type UplinkRXInfo struct {
 	// Antenna.
 	Antenna uint32 `protobuf:"varint,10,opt,name=antenna,proto3" json:"antenna,omitempty"`
 	// Location.
-	Location             *Location `protobuf:"bytes,11,opt,name=location,proto3" json:"location,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+	Location *Location `protobuf:"bytes,11,opt,name=location,proto3" json:"location,omitempty"`
+	// RX time since GPS epoch with nanosecond precision (only set when the
+	// gateway supports fine-timestamping).
+	FineTimeSinceGpsEpoch *duration.Duration `protobuf:"bytes,12,opt,name=fine_time_since_gps_epoch,json=fineTimeSinceGpsEpoch,proto3" json:"fine_time_since_gps_epoch,omitempty"`
+	XXX_NoUnkeyedLiteral  struct{}           `json:"-"`
+	XXX_unrecognized      []byte             `json:"-"`
+	XXX_sizecache         int32              `json:"-"`
 }
 
 func (m *UplinkRXInfo) Reset()         { *m = UplinkRXInfo{} }
 func (m *UplinkRXInfo) String() string { return proto.CompactTextString(m) }
 func (*UplinkRXInfo) ProtoMessage()    {}
 func (*UplinkRXInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_gw_90ea3f7651ea710d, []int{4}
+	return fileDescriptor_gw_5636d0ce00653873, []int{4}
 }
 func (m *UplinkRXInfo) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UplinkRXInfo.Unmarshal(m, b)
@@ -482,6 +485,13 @@ func (m *UplinkRXInfo) GetLocation() *Location {
 	return nil
 }
 
+func (m *UplinkRXInfo) GetFineTimeSinceGpsEpoch() *duration.Duration {
+	if m != nil {
+		return m.FineTimeSinceGpsEpoch
+	}
+	return nil
+}
+
 type DownlinkTXInfo struct {
 	// Gateway ID.
 	GatewayId []byte `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
@@ -504,7 +514,11 @@ type DownlinkTXInfo struct {
 	// The board identifier for emitting the frame.
 	Board uint32 `protobuf:"varint,10,opt,name=board,proto3" json:"board,omitempty"`
 	// The antenna identifier for emitting the frame.
-	Antenna              uint32   `protobuf:"varint,11,opt,name=antenna,proto3" json:"antenna,omitempty"`
+	Antenna uint32 `protobuf:"varint,11,opt,name=antenna,proto3" json:"antenna,omitempty"`
+	// Disable the CRC of the physical layer (e.g. for Class-B beacons).
+	NoCrc bool `protobuf:"varint,12,opt,name=no_crc,json=noCrc,proto3" json:"no_crc,omitempty"`
+	// Disable the header of the physical layer (e.g. for Class-B beacons).
+	NoHeader             bool     `protobuf:"varint,13,opt,name=no_header,json=noHeader,proto3" json:"no_header,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -514,7 +528,7 @@ func (m *DownlinkTXInfo) Reset()         { *m = DownlinkTXInfo{} }
 func (m *DownlinkTXInfo) String() string { return proto.CompactTextString(m) }
 func (*DownlinkTXInfo) ProtoMessage()    {}
 func (*DownlinkTXInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_gw_90ea3f7651ea710d, []int{5}
+	return fileDescriptor_gw_5636d0ce00653873, []int{5}
 }
 func (m *DownlinkTXInfo) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DownlinkTXInfo.Unmarshal(m, b)
@@ -632,6 +646,20 @@ func (m *DownlinkTXInfo) GetAntenna() uint32 {
 	return 0
 }
 
+func (m *DownlinkTXInfo) GetNoCrc() bool {
+	if m != nil {
+		return m.NoCrc
+	}
+	return false
+}
+
+func (m *DownlinkTXInfo) GetNoHeader() bool {
+	if m != nil {
+		return m.NoHeader
+	}
+	return false
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*DownlinkTXInfo) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _DownlinkTXInfo_OneofMarshaler, _DownlinkTXInfo_OneofUnmarshaler, _DownlinkTXInfo_OneofSizer, []interface{}{
@@ -722,7 +750,7 @@ func (m *UplinkFrame) Reset()         { *m = UplinkFrame{} }
 func (m *UplinkFrame) String() string { return proto.CompactTextString(m) }
 func (*UplinkFrame) ProtoMessage()    {}
 func (*UplinkFrame) Descriptor() ([]byte, []int) {
-	return fileDescriptor_gw_90ea3f7651ea710d, []int{6}
+	return fileDescriptor_gw_5636d0ce00653873, []int{6}
 }
 func (m *UplinkFrame) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UplinkFrame.Unmarshal(m, b)
@@ -779,7 +807,7 @@ func (m *UplinkFrameSet) Reset()         { *m = UplinkFrameSet{} }
 func (m *UplinkFrameSet) String() string { return proto.CompactTextString(m) }
 func (*UplinkFrameSet) ProtoMessage()    {}
 func (*UplinkFrameSet) Descriptor() ([]byte, []int) {
-	return fileDescriptor_gw_90ea3f7651ea710d, []int{7}
+	return fileDescriptor_gw_5636d0ce00653873, []int{7}
 }
 func (m *UplinkFrameSet) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UplinkFrameSet.Unmarshal(m, b)
@@ -836,7 +864,7 @@ func (m *DownlinkFrame) Reset()         { *m = DownlinkFrame{} }
 func (m *DownlinkFrame) String() string { return proto.CompactTextString(m) }
 func (*DownlinkFrame) ProtoMessage()    {}
 func (*DownlinkFrame) Descriptor() ([]byte, []int) {
-	return fileDescriptor_gw_90ea3f7651ea710d, []int{8}
+	return fileDescriptor_gw_5636d0ce00653873, []int{8}
 }
 func (m *DownlinkFrame) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DownlinkFrame.Unmarshal(m, b)
@@ -877,6 +905,480 @@ func (m *DownlinkFrame) GetToken() uint32 {
 	return 0
 }
 
+type GatewayStats struct {
+	// Gateway ID.
+	GatewayId []byte `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
+	// Gateway time.
+	Time *timestamp.Timestamp `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
+	// Gateway location.
+	Location *Location `protobuf:"bytes,3,opt,name=location,proto3" json:"location,omitempty"`
+	// Gateway configuration version (this maps to the config_version sent
+	// by LoRa Server to the gateway).
+	ConfigVersion string `protobuf:"bytes,4,opt,name=config_version,json=configVersion,proto3" json:"config_version,omitempty"`
+	// Number of radio packets received.
+	RxPacketsReceived uint32 `protobuf:"varint,5,opt,name=rx_packets_received,json=rxPacketsReceived,proto3" json:"rx_packets_received,omitempty"`
+	// Number of radio packets received with valid PHY CRC.
+	RxPacketsReceivedOk uint32 `protobuf:"varint,6,opt,name=rx_packets_received_ok,json=rxPacketsReceivedOk,proto3" json:"rx_packets_received_ok,omitempty"`
+	// Number of downlink packets received for transmission.
+	TxPacketsReceived uint32 `protobuf:"varint,7,opt,name=tx_packets_received,json=txPacketsReceived,proto3" json:"tx_packets_received,omitempty"`
+	// Number of downlink packets emitted.
+	TxPacketsEmitted     uint32   `protobuf:"varint,8,opt,name=tx_packets_emitted,json=txPacketsEmitted,proto3" json:"tx_packets_emitted,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GatewayStats) Reset()         { *m = GatewayStats{} }
+func (m *GatewayStats) String() string { return proto.CompactTextString(m) }
+func (*GatewayStats) ProtoMessage()    {}
+func (*GatewayStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_gw_5636d0ce00653873, []int{9}
+}
+func (m *GatewayStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GatewayStats.Unmarshal(m, b)
+}
+func (m *GatewayStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GatewayStats.Marshal(b, m, deterministic)
+}
+func (dst *GatewayStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GatewayStats.Merge(dst, src)
+}
+func (m *GatewayStats) XXX_Size() int {
+	return xxx_messageInfo_GatewayStats.Size(m)
+}
+func (m *GatewayStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_GatewayStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GatewayStats proto.InternalMessageInfo
+
+func (m *GatewayStats) GetGatewayId() []byte {
+	if m != nil {
+		return m.GatewayId
+	}
+	return nil
+}
+
+func (m *GatewayStats) GetTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+func (m *GatewayStats) GetLocation() *Location {
+	if m != nil {
+		return m.Location
+	}
+	return nil
+}
+
+func (m *GatewayStats) GetConfigVersion() string {
+	if m != nil {
+		return m.ConfigVersion
+	}
+	return ""
+}
+
+func (m *GatewayStats) GetRxPacketsReceived() uint32 {
+	if m != nil {
+		return m.RxPacketsReceived
+	}
+	return 0
+}
+
+func (m *GatewayStats) GetRxPacketsReceivedOk() uint32 {
+	if m != nil {
+		return m.RxPacketsReceivedOk
+	}
+	return 0
+}
+
+func (m *GatewayStats) GetTxPacketsReceived() uint32 {
+	if m != nil {
+		return m.TxPacketsReceived
+	}
+	return 0
+}
+
+func (m *GatewayStats) GetTxPacketsEmitted() uint32 {
+	if m != nil {
+		return m.TxPacketsEmitted
+	}
+	return 0
+}
+
+type DownlinkTXAck struct {
+	// Gateway ID.
+	GatewayId []byte `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
+	// Token (uint16 value).
+	Token uint32 `protobuf:"varint,2,opt,name=token,proto3" json:"token,omitempty"`
+	// Error.
+	Error                string   `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DownlinkTXAck) Reset()         { *m = DownlinkTXAck{} }
+func (m *DownlinkTXAck) String() string { return proto.CompactTextString(m) }
+func (*DownlinkTXAck) ProtoMessage()    {}
+func (*DownlinkTXAck) Descriptor() ([]byte, []int) {
+	return fileDescriptor_gw_5636d0ce00653873, []int{10}
+}
+func (m *DownlinkTXAck) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DownlinkTXAck.Unmarshal(m, b)
+}
+func (m *DownlinkTXAck) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DownlinkTXAck.Marshal(b, m, deterministic)
+}
+func (dst *DownlinkTXAck) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DownlinkTXAck.Merge(dst, src)
+}
+func (m *DownlinkTXAck) XXX_Size() int {
+	return xxx_messageInfo_DownlinkTXAck.Size(m)
+}
+func (m *DownlinkTXAck) XXX_DiscardUnknown() {
+	xxx_messageInfo_DownlinkTXAck.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DownlinkTXAck proto.InternalMessageInfo
+
+func (m *DownlinkTXAck) GetGatewayId() []byte {
+	if m != nil {
+		return m.GatewayId
+	}
+	return nil
+}
+
+func (m *DownlinkTXAck) GetToken() uint32 {
+	if m != nil {
+		return m.Token
+	}
+	return 0
+}
+
+func (m *DownlinkTXAck) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type GatewayConfiguration struct {
+	// Gateway ID.
+	GatewayId []byte `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
+	// Configuration version.
+	Version string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	// Channels.
+	Channels             []*ChannelConfiguration `protobuf:"bytes,3,rep,name=channels,proto3" json:"channels,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *GatewayConfiguration) Reset()         { *m = GatewayConfiguration{} }
+func (m *GatewayConfiguration) String() string { return proto.CompactTextString(m) }
+func (*GatewayConfiguration) ProtoMessage()    {}
+func (*GatewayConfiguration) Descriptor() ([]byte, []int) {
+	return fileDescriptor_gw_5636d0ce00653873, []int{11}
+}
+func (m *GatewayConfiguration) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GatewayConfiguration.Unmarshal(m, b)
+}
+func (m *GatewayConfiguration) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GatewayConfiguration.Marshal(b, m, deterministic)
+}
+func (dst *GatewayConfiguration) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GatewayConfiguration.Merge(dst, src)
+}
+func (m *GatewayConfiguration) XXX_Size() int {
+	return xxx_messageInfo_GatewayConfiguration.Size(m)
+}
+func (m *GatewayConfiguration) XXX_DiscardUnknown() {
+	xxx_messageInfo_GatewayConfiguration.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GatewayConfiguration proto.InternalMessageInfo
+
+func (m *GatewayConfiguration) GetGatewayId() []byte {
+	if m != nil {
+		return m.GatewayId
+	}
+	return nil
+}
+
+func (m *GatewayConfiguration) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *GatewayConfiguration) GetChannels() []*ChannelConfiguration {
+	if m != nil {
+		return m.Channels
+	}
+	return nil
+}
+
+type ChannelConfiguration struct {
+	// Frequency (Hz).
+	Frequency uint32 `protobuf:"varint,1,opt,name=frequency,proto3" json:"frequency,omitempty"`
+	// Channel modulation.
+	Modulation common.Modulation `protobuf:"varint,2,opt,name=modulation,proto3,enum=common.Modulation" json:"modulation,omitempty"`
+	// Types that are valid to be assigned to ModulationConfig:
+	//	*ChannelConfiguration_LoraModulationConfig
+	//	*ChannelConfiguration_FskModulationConfig
+	ModulationConfig     isChannelConfiguration_ModulationConfig `protobuf_oneof:"modulation_config"`
+	XXX_NoUnkeyedLiteral struct{}                                `json:"-"`
+	XXX_unrecognized     []byte                                  `json:"-"`
+	XXX_sizecache        int32                                   `json:"-"`
+}
+
+func (m *ChannelConfiguration) Reset()         { *m = ChannelConfiguration{} }
+func (m *ChannelConfiguration) String() string { return proto.CompactTextString(m) }
+func (*ChannelConfiguration) ProtoMessage()    {}
+func (*ChannelConfiguration) Descriptor() ([]byte, []int) {
+	return fileDescriptor_gw_5636d0ce00653873, []int{12}
+}
+func (m *ChannelConfiguration) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChannelConfiguration.Unmarshal(m, b)
+}
+func (m *ChannelConfiguration) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChannelConfiguration.Marshal(b, m, deterministic)
+}
+func (dst *ChannelConfiguration) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChannelConfiguration.Merge(dst, src)
+}
+func (m *ChannelConfiguration) XXX_Size() int {
+	return xxx_messageInfo_ChannelConfiguration.Size(m)
+}
+func (m *ChannelConfiguration) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChannelConfiguration.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChannelConfiguration proto.InternalMessageInfo
+
+type isChannelConfiguration_ModulationConfig interface {
+	isChannelConfiguration_ModulationConfig()
+}
+
+type ChannelConfiguration_LoraModulationConfig struct {
+	LoraModulationConfig *LoRaModulationConfig `protobuf:"bytes,3,opt,name=lora_modulation_config,json=loraModulationConfig,proto3,oneof"`
+}
+type ChannelConfiguration_FskModulationConfig struct {
+	FskModulationConfig *FSKModulationConfig `protobuf:"bytes,4,opt,name=fsk_modulation_config,json=fskModulationConfig,proto3,oneof"`
+}
+
+func (*ChannelConfiguration_LoraModulationConfig) isChannelConfiguration_ModulationConfig() {}
+func (*ChannelConfiguration_FskModulationConfig) isChannelConfiguration_ModulationConfig()  {}
+
+func (m *ChannelConfiguration) GetModulationConfig() isChannelConfiguration_ModulationConfig {
+	if m != nil {
+		return m.ModulationConfig
+	}
+	return nil
+}
+
+func (m *ChannelConfiguration) GetFrequency() uint32 {
+	if m != nil {
+		return m.Frequency
+	}
+	return 0
+}
+
+func (m *ChannelConfiguration) GetModulation() common.Modulation {
+	if m != nil {
+		return m.Modulation
+	}
+	return common.Modulation_LORA
+}
+
+func (m *ChannelConfiguration) GetLoraModulationConfig() *LoRaModulationConfig {
+	if x, ok := m.GetModulationConfig().(*ChannelConfiguration_LoraModulationConfig); ok {
+		return x.LoraModulationConfig
+	}
+	return nil
+}
+
+func (m *ChannelConfiguration) GetFskModulationConfig() *FSKModulationConfig {
+	if x, ok := m.GetModulationConfig().(*ChannelConfiguration_FskModulationConfig); ok {
+		return x.FskModulationConfig
+	}
+	return nil
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*ChannelConfiguration) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _ChannelConfiguration_OneofMarshaler, _ChannelConfiguration_OneofUnmarshaler, _ChannelConfiguration_OneofSizer, []interface{}{
+		(*ChannelConfiguration_LoraModulationConfig)(nil),
+		(*ChannelConfiguration_FskModulationConfig)(nil),
+	}
+}
+
+func _ChannelConfiguration_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*ChannelConfiguration)
+	// modulation_config
+	switch x := m.ModulationConfig.(type) {
+	case *ChannelConfiguration_LoraModulationConfig:
+		b.EncodeVarint(3<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.LoraModulationConfig); err != nil {
+			return err
+		}
+	case *ChannelConfiguration_FskModulationConfig:
+		b.EncodeVarint(4<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.FskModulationConfig); err != nil {
+			return err
+		}
+	case nil:
+	default:
+		return fmt.Errorf("ChannelConfiguration.ModulationConfig has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _ChannelConfiguration_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*ChannelConfiguration)
+	switch tag {
+	case 3: // modulation_config.lora_modulation_config
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(LoRaModulationConfig)
+		err := b.DecodeMessage(msg)
+		m.ModulationConfig = &ChannelConfiguration_LoraModulationConfig{msg}
+		return true, err
+	case 4: // modulation_config.fsk_modulation_config
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(FSKModulationConfig)
+		err := b.DecodeMessage(msg)
+		m.ModulationConfig = &ChannelConfiguration_FskModulationConfig{msg}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _ChannelConfiguration_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*ChannelConfiguration)
+	// modulation_config
+	switch x := m.ModulationConfig.(type) {
+	case *ChannelConfiguration_LoraModulationConfig:
+		s := proto.Size(x.LoraModulationConfig)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *ChannelConfiguration_FskModulationConfig:
+		s := proto.Size(x.FskModulationConfig)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+type LoRaModulationConfig struct {
+	// Bandwidth.
+	Bandwidth uint32 `protobuf:"varint,1,opt,name=bandwidth,proto3" json:"bandwidth,omitempty"`
+	// Spreading-factors.
+	SpreadingFactors     []uint32 `protobuf:"varint,2,rep,packed,name=spreading_factors,json=spreadingFactors,proto3" json:"spreading_factors,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LoRaModulationConfig) Reset()         { *m = LoRaModulationConfig{} }
+func (m *LoRaModulationConfig) String() string { return proto.CompactTextString(m) }
+func (*LoRaModulationConfig) ProtoMessage()    {}
+func (*LoRaModulationConfig) Descriptor() ([]byte, []int) {
+	return fileDescriptor_gw_5636d0ce00653873, []int{13}
+}
+func (m *LoRaModulationConfig) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LoRaModulationConfig.Unmarshal(m, b)
+}
+func (m *LoRaModulationConfig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LoRaModulationConfig.Marshal(b, m, deterministic)
+}
+func (dst *LoRaModulationConfig) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LoRaModulationConfig.Merge(dst, src)
+}
+func (m *LoRaModulationConfig) XXX_Size() int {
+	return xxx_messageInfo_LoRaModulationConfig.Size(m)
+}
+func (m *LoRaModulationConfig) XXX_DiscardUnknown() {
+	xxx_messageInfo_LoRaModulationConfig.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LoRaModulationConfig proto.InternalMessageInfo
+
+func (m *LoRaModulationConfig) GetBandwidth() uint32 {
+	if m != nil {
+		return m.Bandwidth
+	}
+	return 0
+}
+
+func (m *LoRaModulationConfig) GetSpreadingFactors() []uint32 {
+	if m != nil {
+		return m.SpreadingFactors
+	}
+	return nil
+}
+
+type FSKModulationConfig struct {
+	// Bandwidth.
+	Bandwidth uint32 `protobuf:"varint,1,opt,name=bandwidth,proto3" json:"bandwidth,omitempty"`
+	// Bitrate.
+	Bitrate              uint32   `protobuf:"varint,2,opt,name=bitrate,proto3" json:"bitrate,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FSKModulationConfig) Reset()         { *m = FSKModulationConfig{} }
+func (m *FSKModulationConfig) String() string { return proto.CompactTextString(m) }
+func (*FSKModulationConfig) ProtoMessage()    {}
+func (*FSKModulationConfig) Descriptor() ([]byte, []int) {
+	return fileDescriptor_gw_5636d0ce00653873, []int{14}
+}
+func (m *FSKModulationConfig) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FSKModulationConfig.Unmarshal(m, b)
+}
+func (m *FSKModulationConfig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FSKModulationConfig.Marshal(b, m, deterministic)
+}
+func (dst *FSKModulationConfig) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FSKModulationConfig.Merge(dst, src)
+}
+func (m *FSKModulationConfig) XXX_Size() int {
+	return xxx_messageInfo_FSKModulationConfig.Size(m)
+}
+func (m *FSKModulationConfig) XXX_DiscardUnknown() {
+	xxx_messageInfo_FSKModulationConfig.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FSKModulationConfig proto.InternalMessageInfo
+
+func (m *FSKModulationConfig) GetBandwidth() uint32 {
+	if m != nil {
+		return m.Bandwidth
+	}
+	return 0
+}
+
+func (m *FSKModulationConfig) GetBitrate() uint32 {
+	if m != nil {
+		return m.Bitrate
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*UplinkTXInfo)(nil), "gw.UplinkTXInfo")
 	proto.RegisterType((*LoRaModulationInfo)(nil), "gw.LoRaModulationInfo")
@@ -887,61 +1389,87 @@ func init() {
 	proto.RegisterType((*UplinkFrame)(nil), "gw.UplinkFrame")
 	proto.RegisterType((*UplinkFrameSet)(nil), "gw.UplinkFrameSet")
 	proto.RegisterType((*DownlinkFrame)(nil), "gw.DownlinkFrame")
-}
-
-func init() { proto.RegisterFile("gw.proto", fileDescriptor_gw_90ea3f7651ea710d) }
-
-var fileDescriptor_gw_90ea3f7651ea710d = []byte{
-	// 802 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x55, 0x5b, 0x8f, 0xdb, 0x44,
-	0x14, 0xc6, 0x9b, 0xcd, 0xc6, 0x39, 0xd9, 0x6c, 0xbb, 0x43, 0x5a, 0xb9, 0xe1, 0xd2, 0x28, 0x42,
-	0x28, 0x2b, 0x24, 0x47, 0x0a, 0xea, 0x1f, 0x28, 0x65, 0xcb, 0xd2, 0x22, 0xa1, 0x49, 0x91, 0x10,
-	0x2f, 0x66, 0x62, 0x8f, 0x9d, 0x51, 0xec, 0x19, 0x33, 0x9e, 0xac, 0x37, 0x3c, 0x83, 0xe0, 0xef,
-	0xf0, 0xfb, 0x78, 0x41, 0x33, 0x63, 0x27, 0x4e, 0x02, 0x2c, 0x88, 0xcb, 0x53, 0x72, 0xbe, 0x73,
-	0x99, 0xef, 0x5c, 0x0d, 0x6e, 0x52, 0xfa, 0xb9, 0x14, 0x4a, 0xa0, 0x93, 0xa4, 0x1c, 0x3e, 0x4b,
-	0x98, 0x5a, 0xae, 0x17, 0x7e, 0x28, 0xb2, 0xe9, 0x42, 0x8a, 0x90, 0x10, 0x39, 0x4d, 0x85, 0x24,
-	0x05, 0x95, 0xb7, 0x54, 0x4e, 0x49, 0xce, 0xa6, 0xa1, 0xc8, 0x32, 0xc1, 0xab, 0x1f, 0xeb, 0x3a,
-	0x7c, 0x9a, 0x08, 0x91, 0xa4, 0x74, 0x6a, 0xa4, 0xc5, 0x3a, 0x9e, 0x2a, 0x96, 0xd1, 0x42, 0x91,
-	0x2c, 0xaf, 0x0c, 0xde, 0x3f, 0x34, 0x88, 0xd6, 0x92, 0x28, 0x56, 0x07, 0x18, 0xff, 0x7c, 0x02,
-	0xe7, 0x5f, 0xe5, 0x29, 0xe3, 0xab, 0x37, 0x5f, 0xdf, 0xf0, 0x58, 0xa0, 0x77, 0xa1, 0x1b, 0x4b,
-	0xfa, 0xdd, 0x9a, 0xf2, 0x70, 0xe3, 0x39, 0x23, 0x67, 0xd2, 0xc7, 0x3b, 0x00, 0xcd, 0x00, 0x32,
-	0x11, 0xad, 0x53, 0x13, 0xc2, 0x3b, 0x19, 0x39, 0x93, 0x8b, 0x19, 0xf2, 0x2b, 0x4a, 0x5f, 0x6c,
-	0x35, 0xb8, 0x61, 0x85, 0x3e, 0x87, 0x81, 0xce, 0x24, 0xd8, 0x41, 0x01, 0xe3, 0xb1, 0xf0, 0x5a,
-	0x23, 0x67, 0xd2, 0x9b, 0x3d, 0xf6, 0x93, 0xd2, 0x7f, 0x2d, 0x30, 0xd9, 0x79, 0x6b, 0x1e, 0x9f,
-	0xbd, 0x85, 0x91, 0xf6, 0xda, 0x47, 0xd1, 0x4b, 0x78, 0x3b, 0x2e, 0x56, 0x47, 0xa1, 0x4e, 0x4d,
-	0xa8, 0x47, 0x3a, 0xd4, 0xf5, 0xfc, 0xd5, 0x51, 0xa4, 0xcb, 0xb8, 0x58, 0xed, 0x83, 0xcf, 0x2f,
-	0xe1, 0xc1, 0x41, 0x90, 0xf1, 0x2f, 0x0e, 0xa0, 0x63, 0x22, 0xba, 0x20, 0x0b, 0xc2, 0xa3, 0x92,
-	0x45, 0x6a, 0x59, 0x17, 0x64, 0x0b, 0xa0, 0x2b, 0x78, 0x58, 0xe4, 0x92, 0x92, 0x88, 0xf1, 0x24,
-	0x88, 0x49, 0xa8, 0x84, 0x34, 0x65, 0xe9, 0xe3, 0x07, 0x5b, 0xfc, 0xda, 0xc0, 0xe8, 0x1d, 0xe8,
-	0x86, 0x22, 0xa2, 0x81, 0x24, 0x8a, 0x9a, 0xe4, 0xbb, 0xd8, 0xd5, 0x00, 0x26, 0x8a, 0xa2, 0x67,
-	0xf0, 0x38, 0x17, 0x29, 0x91, 0xec, 0xfb, 0x9a, 0xd1, 0x2d, 0x95, 0x85, 0x2e, 0xb2, 0xce, 0xcd,
-	0xc5, 0x8f, 0x9a, 0xda, 0x9b, 0x5a, 0x39, 0x7e, 0x05, 0x97, 0x47, 0x09, 0xdf, 0xc3, 0xd8, 0x83,
-	0xce, 0x82, 0x29, 0x43, 0xc2, 0x12, 0xad, 0xc5, 0xf1, 0xb7, 0xe0, 0xbe, 0x16, 0xa1, 0x6d, 0xda,
-	0x10, 0x5c, 0x1d, 0x51, 0xad, 0x23, 0x6a, 0x42, 0x38, 0x78, 0x2b, 0xeb, 0xf8, 0xa9, 0xe0, 0x89,
-	0x55, 0x9e, 0x18, 0xe5, 0x0e, 0xd0, 0x9e, 0x24, 0xad, 0x3c, 0x5b, 0xd6, 0xb3, 0x96, 0xc7, 0x3f,
-	0xb6, 0xea, 0x69, 0xc3, 0x76, 0xda, 0xde, 0x03, 0x48, 0x88, 0xa2, 0x25, 0xd9, 0x04, 0x2c, 0x32,
-	0x0f, 0x9d, 0xe3, 0x6e, 0x85, 0xdc, 0x44, 0xc8, 0x87, 0x53, 0x3d, 0xd0, 0xe6, 0x91, 0xde, 0x6c,
-	0xe8, 0xdb, 0x61, 0xf6, 0xeb, 0x61, 0xf6, 0xdf, 0xd4, 0xd3, 0x8e, 0x8d, 0x9d, 0x1e, 0x35, 0xfd,
-	0x1b, 0x14, 0x8c, 0x87, 0x34, 0x48, 0xf2, 0x22, 0xa0, 0xb9, 0x08, 0x97, 0xd5, 0xa8, 0x3d, 0x39,
-	0xf2, 0x7f, 0x51, 0x2d, 0x03, 0xbe, 0xd4, 0x6e, 0x73, 0xed, 0xf5, 0x32, 0x2f, 0x3e, 0xd5, 0x3e,
-	0x3a, 0xcb, 0xed, 0x32, 0x99, 0x26, 0xf4, 0xf1, 0x0e, 0x40, 0x08, 0x4e, 0x65, 0x51, 0x30, 0xaf,
-	0x3d, 0x72, 0x26, 0x6d, 0x6c, 0xfe, 0xa3, 0x27, 0xe0, 0x9a, 0x41, 0x2f, 0xb8, 0xf4, 0xce, 0x4c,
-	0xe6, 0x1d, 0x2d, 0xcf, 0xb9, 0xd4, 0x45, 0x0f, 0x97, 0x84, 0x73, 0x9a, 0x7a, 0x1d, 0x5b, 0xf4,
-	0x4a, 0xd4, 0x4e, 0x32, 0x0e, 0xc2, 0x25, 0x61, 0xdc, 0x73, 0xad, 0x4a, 0xc6, 0x9f, 0x68, 0x11,
-	0x0d, 0xa0, 0xbd, 0x10, 0x44, 0x46, 0x5e, 0xd7, 0xe0, 0x56, 0xd0, 0xa1, 0x08, 0x57, 0x94, 0x73,
-	0xe2, 0x81, 0xb5, 0xaf, 0x44, 0x34, 0xd1, 0xef, 0xdb, 0xfe, 0x79, 0x3d, 0x93, 0xf1, 0xb9, 0x5d,
-	0x2e, 0x8b, 0xe1, 0xad, 0x76, 0xfc, 0x6b, 0x0b, 0x2e, 0x5e, 0x88, 0x92, 0x37, 0xf6, 0xfe, 0x9e,
-	0x4e, 0x8c, 0xa0, 0xc7, 0xb2, 0x8c, 0x46, 0x8c, 0x28, 0x9a, 0x6e, 0x4c, 0x43, 0x5c, 0xdc, 0x84,
-	0xfe, 0xc7, 0xda, 0xef, 0x9d, 0xa8, 0xf6, 0xe1, 0x89, 0x1a, 0x40, 0x3b, 0x17, 0x25, 0xb5, 0x2d,
-	0x68, 0x63, 0x2b, 0x1c, 0x1c, 0xae, 0xce, 0x3f, 0x3a, 0x5c, 0xee, 0xbf, 0x77, 0xb8, 0xba, 0x7f,
-	0xf7, 0x70, 0xed, 0x86, 0x02, 0xfe, 0x60, 0x28, 0x7a, 0x7b, 0x43, 0xf1, 0x7b, 0x87, 0xee, 0x07,
-	0x07, 0x7a, 0x76, 0x0b, 0xaf, 0x25, 0xc9, 0x28, 0x7a, 0x0a, 0xbd, 0x7c, 0xb9, 0x09, 0x72, 0xb2,
-	0x49, 0x05, 0xa9, 0x7b, 0x0f, 0xf9, 0x72, 0xf3, 0xa5, 0x45, 0xd0, 0x15, 0x74, 0xd4, 0x9d, 0x25,
-	0x6c, 0x37, 0xf1, 0xa1, 0x26, 0xdc, 0xfc, 0x6c, 0xe0, 0x33, 0x75, 0x67, 0xe8, 0x5d, 0x41, 0x47,
-	0xde, 0x35, 0xef, 0x7b, 0xc3, 0x14, 0x57, 0xa6, 0xd2, 0x98, 0x8e, 0x7f, 0x72, 0xe0, 0xa2, 0x41,
-	0x63, 0x4e, 0xd5, 0x7f, 0xc7, 0xa4, 0xf5, 0xa7, 0x4c, 0x0a, 0xe8, 0xd7, 0xdb, 0xf0, 0x17, 0x2b,
-	0xf2, 0xd1, 0x21, 0x0f, 0xa4, 0x83, 0xef, 0xaf, 0xd4, 0x96, 0xc9, 0x00, 0xda, 0x4a, 0xac, 0x28,
-	0x37, 0x15, 0xe9, 0x63, 0x2b, 0x3c, 0xff, 0xf0, 0x9b, 0x0f, 0xee, 0xff, 0xe6, 0x27, 0xe5, 0xe2,
-	0xcc, 0x6c, 0xcc, 0xc7, 0xbf, 0x05, 0x00, 0x00, 0xff, 0xff, 0x96, 0xa2, 0x8d, 0x51, 0x30, 0x08,
-	0x00, 0x00,
+	proto.RegisterType((*GatewayStats)(nil), "gw.GatewayStats")
+	proto.RegisterType((*DownlinkTXAck)(nil), "gw.DownlinkTXAck")
+	proto.RegisterType((*GatewayConfiguration)(nil), "gw.GatewayConfiguration")
+	proto.RegisterType((*ChannelConfiguration)(nil), "gw.ChannelConfiguration")
+	proto.RegisterType((*LoRaModulationConfig)(nil), "gw.LoRaModulationConfig")
+	proto.RegisterType((*FSKModulationConfig)(nil), "gw.FSKModulationConfig")
+}
+
+func init() { proto.RegisterFile("gw.proto", fileDescriptor_gw_5636d0ce00653873) }
+
+var fileDescriptor_gw_5636d0ce00653873 = []byte{
+	// 1126 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x57, 0xeb, 0x6e, 0x1a, 0x47,
+	0x14, 0x0e, 0x60, 0xcc, 0x72, 0x30, 0x4e, 0x18, 0xb0, 0xbb, 0x71, 0x2f, 0x41, 0xab, 0xb6, 0xb2,
+	0x95, 0x0a, 0x24, 0xa7, 0x79, 0x80, 0xda, 0x89, 0x2f, 0x4d, 0xac, 0x5a, 0x83, 0x5b, 0x45, 0xf9,
+	0xb3, 0x1d, 0x76, 0x87, 0x65, 0x05, 0xcc, 0x6c, 0x67, 0x07, 0x63, 0xfa, 0xbb, 0x55, 0xfb, 0x14,
+	0x7d, 0x85, 0xaa, 0xaf, 0xd3, 0xb7, 0xe8, 0x1b, 0x54, 0x33, 0xb3, 0x0b, 0xcb, 0xa5, 0xa1, 0x97,
+	0xa4, 0xbf, 0xf0, 0xf9, 0xce, 0x65, 0xce, 0x6d, 0xbe, 0x1d, 0x83, 0x15, 0x4c, 0x5a, 0x91, 0xe0,
+	0x92, 0xa3, 0x7c, 0x30, 0x39, 0x78, 0x1a, 0x84, 0xb2, 0x3f, 0xee, 0xb6, 0x3c, 0x3e, 0x6a, 0x77,
+	0x05, 0xf7, 0x08, 0x11, 0xed, 0x21, 0x17, 0x24, 0xa6, 0xe2, 0x96, 0x8a, 0x36, 0x89, 0xc2, 0xb6,
+	0xc7, 0x47, 0x23, 0xce, 0x92, 0x1f, 0xe3, 0x7a, 0xf0, 0x28, 0xe0, 0x3c, 0x18, 0xd2, 0xb6, 0x96,
+	0xba, 0xe3, 0x5e, 0x5b, 0x86, 0x23, 0x1a, 0x4b, 0x32, 0x8a, 0x12, 0x83, 0x8f, 0x96, 0x0d, 0xfc,
+	0xb1, 0x20, 0x32, 0x4c, 0x03, 0x38, 0x3f, 0xe7, 0x61, 0xe7, 0xeb, 0x68, 0x18, 0xb2, 0xc1, 0xcd,
+	0xab, 0x4b, 0xd6, 0xe3, 0xe8, 0x03, 0x28, 0xf7, 0x04, 0xfd, 0x6e, 0x4c, 0x99, 0x37, 0xb5, 0x73,
+	0xcd, 0xdc, 0x61, 0x15, 0xcf, 0x01, 0x74, 0x0c, 0x30, 0xe2, 0xfe, 0x78, 0xa8, 0x43, 0xd8, 0xf9,
+	0x66, 0xee, 0x70, 0xf7, 0x18, 0xb5, 0x92, 0x94, 0xae, 0x66, 0x1a, 0x9c, 0xb1, 0x42, 0x5f, 0x42,
+	0x43, 0x55, 0xe2, 0xce, 0x21, 0x37, 0x64, 0x3d, 0x6e, 0x17, 0x9a, 0xb9, 0xc3, 0xca, 0xf1, 0x7e,
+	0x2b, 0x98, 0xb4, 0x5e, 0x72, 0x4c, 0xe6, 0xde, 0x2a, 0x8f, 0x8b, 0x7b, 0x18, 0x29, 0xaf, 0x45,
+	0x14, 0x9d, 0x43, 0xbd, 0x17, 0x0f, 0x56, 0x42, 0x6d, 0xe9, 0x50, 0x7b, 0x2a, 0xd4, 0x59, 0xe7,
+	0xc5, 0x4a, 0xa4, 0x5a, 0x2f, 0x1e, 0x2c, 0x82, 0x27, 0x35, 0xb8, 0xbf, 0x14, 0xc4, 0xf9, 0x2d,
+	0x07, 0x68, 0x35, 0x11, 0xd5, 0x90, 0x2e, 0x61, 0xfe, 0x24, 0xf4, 0x65, 0x3f, 0x6d, 0xc8, 0x0c,
+	0x40, 0x47, 0xf0, 0x20, 0x8e, 0x04, 0x25, 0x7e, 0xc8, 0x02, 0xb7, 0x47, 0x3c, 0xc9, 0x85, 0x6e,
+	0x4b, 0x15, 0xdf, 0x9f, 0xe1, 0x67, 0x1a, 0x46, 0xef, 0x43, 0xd9, 0xe3, 0x3e, 0x75, 0x05, 0x91,
+	0x54, 0x17, 0x5f, 0xc6, 0x96, 0x02, 0x30, 0x91, 0x14, 0x3d, 0x85, 0xfd, 0x88, 0x0f, 0x89, 0x08,
+	0xbf, 0x4f, 0x33, 0xba, 0xa5, 0x22, 0x56, 0x4d, 0x56, 0xb5, 0x59, 0x78, 0x2f, 0xab, 0xbd, 0x4c,
+	0x95, 0xce, 0x0b, 0xa8, 0xad, 0x14, 0xbc, 0x21, 0x63, 0x1b, 0x4a, 0xdd, 0x50, 0xea, 0x24, 0x4c,
+	0xa2, 0xa9, 0xe8, 0x7c, 0x0b, 0xd6, 0x4b, 0xee, 0x99, 0xa1, 0x1d, 0x80, 0xa5, 0x22, 0xca, 0xb1,
+	0x4f, 0x75, 0x88, 0x1c, 0x9e, 0xc9, 0x2a, 0xfe, 0x90, 0xb3, 0xc0, 0x28, 0xf3, 0x5a, 0x39, 0x07,
+	0x94, 0x27, 0x19, 0x26, 0x9e, 0x05, 0xe3, 0x99, 0xca, 0xce, 0xef, 0x85, 0x74, 0xdb, 0xb0, 0xd9,
+	0xb6, 0x0f, 0x01, 0x02, 0x22, 0xe9, 0x84, 0x4c, 0xdd, 0xd0, 0xd7, 0x07, 0xed, 0xe0, 0x72, 0x82,
+	0x5c, 0xfa, 0xa8, 0x05, 0x5b, 0x6a, 0xa1, 0xf5, 0x21, 0x95, 0xe3, 0x83, 0x96, 0x59, 0xe6, 0x56,
+	0xba, 0xcc, 0xad, 0x9b, 0x74, 0xdb, 0xb1, 0xb6, 0x53, 0xab, 0xa6, 0x7e, 0xdd, 0x38, 0x64, 0x1e,
+	0x75, 0x83, 0x28, 0x76, 0x69, 0xc4, 0xbd, 0x7e, 0xb2, 0x6a, 0x0f, 0x57, 0xfc, 0x9f, 0x25, 0x97,
+	0x01, 0xd7, 0x94, 0x5b, 0x47, 0x79, 0x9d, 0x47, 0xf1, 0x73, 0xe5, 0xa3, 0xaa, 0x9c, 0x5d, 0x26,
+	0x3d, 0x84, 0x2a, 0x9e, 0x03, 0x08, 0xc1, 0x96, 0x88, 0xe3, 0xd0, 0x2e, 0x36, 0x73, 0x87, 0x45,
+	0xac, 0xff, 0x46, 0x0f, 0xc1, 0xd2, 0x8b, 0x1e, 0x33, 0x61, 0x6f, 0xeb, 0xca, 0x4b, 0x4a, 0xee,
+	0x30, 0xa1, 0x9a, 0xee, 0xf5, 0x09, 0x63, 0x74, 0x68, 0x97, 0x4c, 0xd3, 0x13, 0x51, 0x39, 0x89,
+	0x9e, 0xeb, 0xf5, 0x49, 0xc8, 0x6c, 0xcb, 0xa8, 0x44, 0xef, 0x54, 0x89, 0xa8, 0x01, 0xc5, 0x2e,
+	0x27, 0xc2, 0xb7, 0xcb, 0x1a, 0x37, 0x82, 0x0a, 0x45, 0x98, 0xa4, 0x8c, 0x11, 0x1b, 0x8c, 0x7d,
+	0x22, 0xa2, 0x43, 0x75, 0xbe, 0x99, 0x9f, 0x5d, 0xd1, 0x15, 0xef, 0x98, 0xcb, 0x65, 0x30, 0x3c,
+	0xd3, 0xa2, 0x0e, 0x3c, 0xec, 0x85, 0x8c, 0xba, 0x6b, 0x9b, 0xb5, 0xb3, 0xa9, 0x59, 0x7b, 0xca,
+	0xf7, 0x66, 0xb9, 0x61, 0xce, 0xaf, 0x5b, 0xb0, 0xfb, 0x8c, 0x4f, 0x58, 0x86, 0x4c, 0x36, 0x8c,
+	0xb7, 0x09, 0x95, 0x70, 0x34, 0xa2, 0x7e, 0x48, 0x24, 0x1d, 0x4e, 0xf5, 0x94, 0x2d, 0x9c, 0x85,
+	0xfe, 0xc7, 0x81, 0x2e, 0xf0, 0x5e, 0x71, 0x99, 0xf7, 0x1a, 0x50, 0x8c, 0xf8, 0x84, 0x9a, 0xb9,
+	0x16, 0xb1, 0x11, 0x96, 0xd8, 0xb0, 0xf4, 0x9f, 0xd8, 0xd0, 0x7a, 0x7b, 0x6c, 0x58, 0xfe, 0xa7,
+	0x6c, 0x38, 0xdf, 0x34, 0xf8, 0x8b, 0x4d, 0xab, 0x2c, 0x6e, 0xda, 0x1e, 0x6c, 0x33, 0xee, 0x7a,
+	0xc2, 0xd3, 0xcb, 0x62, 0xe1, 0x22, 0xe3, 0xa7, 0xc2, 0x53, 0x0c, 0xc7, 0xb8, 0xdb, 0xa7, 0xc4,
+	0xa7, 0xc2, 0xae, 0x6a, 0x8d, 0xc5, 0xf8, 0x85, 0x96, 0xd7, 0x31, 0xee, 0x0f, 0x39, 0xa8, 0x18,
+	0x3a, 0x38, 0x13, 0x64, 0x44, 0xd1, 0x23, 0xa8, 0x44, 0xfd, 0xa9, 0x1b, 0x91, 0xe9, 0x90, 0x93,
+	0x74, 0x5f, 0x20, 0xea, 0x4f, 0xaf, 0x0d, 0x82, 0x8e, 0xa0, 0x24, 0xef, 0x4c, 0x91, 0x86, 0x12,
+	0x1e, 0xa8, 0x22, 0xb3, 0xdf, 0x2f, 0xbc, 0x2d, 0xef, 0x74, 0x49, 0x47, 0x50, 0x12, 0x77, 0xd9,
+	0x0f, 0x4d, 0xc6, 0x14, 0x27, 0xa6, 0x42, 0x9b, 0x3a, 0x3f, 0xe5, 0x60, 0x37, 0x93, 0x46, 0x87,
+	0xca, 0x77, 0x97, 0x49, 0xe1, 0x8d, 0x99, 0xc4, 0x50, 0x4d, 0x6f, 0xd0, 0xdf, 0xec, 0xc8, 0xe3,
+	0xe5, 0x3c, 0x90, 0x0a, 0xbe, 0x78, 0x0d, 0x67, 0x99, 0x34, 0xa0, 0x28, 0xf9, 0x80, 0x32, 0xdd,
+	0x91, 0x2a, 0x36, 0x82, 0xf3, 0x47, 0x1e, 0x76, 0xce, 0xcd, 0x9d, 0xec, 0x48, 0x22, 0xe3, 0xb7,
+	0x4d, 0xca, 0x59, 0x5a, 0x2a, 0xbc, 0x91, 0x96, 0x3e, 0x81, 0x5d, 0x8f, 0xb3, 0x5e, 0x18, 0xb8,
+	0xd9, 0x8f, 0x5f, 0x19, 0x57, 0x0d, 0xfa, 0x8d, 0x01, 0x51, 0x0b, 0xea, 0xe2, 0xce, 0x8d, 0x88,
+	0x37, 0xa0, 0x32, 0x76, 0x05, 0xf5, 0x68, 0x78, 0x4b, 0xfd, 0xe4, 0xd2, 0xd6, 0xc4, 0xdd, 0xb5,
+	0xd1, 0xe0, 0x44, 0x81, 0x9e, 0xc0, 0xfe, 0x1a, 0x7b, 0x97, 0x0f, 0xf4, 0x6d, 0xae, 0xe2, 0xfa,
+	0x8a, 0xcb, 0x57, 0x03, 0x75, 0x88, 0x5c, 0x73, 0x88, 0x61, 0xef, 0x9a, 0x5c, 0x39, 0xe4, 0x33,
+	0x40, 0x19, 0x7b, 0x3a, 0x0a, 0xa5, 0xa4, 0x7e, 0xc2, 0xe8, 0x0f, 0x66, 0xe6, 0xcf, 0x0d, 0xee,
+	0xbc, 0x9e, 0x0f, 0xfa, 0xe6, 0xd5, 0x17, 0xde, 0x60, 0x53, 0xcf, 0x67, 0x93, 0xcb, 0x67, 0x26,
+	0xa7, 0x50, 0x2a, 0x04, 0x17, 0xc9, 0x6b, 0xc2, 0x08, 0xce, 0x8f, 0x39, 0x68, 0x24, 0xf3, 0x3c,
+	0xd5, 0x7d, 0x4b, 0x38, 0x71, 0xd3, 0x19, 0x36, 0x94, 0xd2, 0xb6, 0xe7, 0x75, 0xbc, 0x54, 0x44,
+	0x9f, 0x83, 0x95, 0x7c, 0xae, 0xe2, 0x64, 0x85, 0x6d, 0x35, 0xc1, 0x53, 0x83, 0x2d, 0x1c, 0x82,
+	0x67, 0x96, 0xce, 0x2f, 0x79, 0x68, 0xac, 0x33, 0x79, 0x07, 0x4f, 0xcc, 0x6b, 0xd8, 0x5f, 0x26,
+	0x55, 0xb3, 0x32, 0xc9, 0xc2, 0xd9, 0xab, 0xb4, 0x6a, 0x52, 0xba, 0xb8, 0x87, 0x1b, 0x8b, 0xc4,
+	0x6a, 0x70, 0x74, 0x05, 0x7b, 0x4b, 0xd4, 0x9a, 0x04, 0x34, 0x4f, 0xcd, 0xf7, 0x56, 0xc8, 0x75,
+	0x16, 0xaf, 0xbe, 0x40, 0xaf, 0x06, 0x3e, 0xa9, 0x43, 0x6d, 0x25, 0x94, 0x43, 0xa0, 0xb1, 0x2e,
+	0xa7, 0x0d, 0xef, 0xb7, 0xc7, 0x50, 0x5b, 0x7e, 0x71, 0xc6, 0x76, 0xbe, 0x59, 0x50, 0x7b, 0xb6,
+	0xf4, 0xe4, 0x8c, 0x9d, 0x2b, 0xa8, 0xaf, 0xc9, 0xf2, 0xdf, 0xbe, 0x10, 0x4f, 0x3e, 0x7d, 0xfd,
+	0xf1, 0xe6, 0xff, 0x53, 0x82, 0x49, 0x77, 0x5b, 0x93, 0xc1, 0x93, 0x3f, 0x07, 0x00, 0xc0, 0x7d,
+	0x52, 0x8f, 0xe4, 0x0c, 0x00, 0x00,
 }
@@ -8,6 +8,7 @@ import fmt "fmt"
 import math "math"
 import common "github.com/brocaar/loraserver/api/common"
 import gw "github.com/brocaar/loraserver/api/gw"
+import duration "github.com/golang/protobuf/ptypes/duration"
 import empty "github.com/golang/protobuf/ptypes/empty"
 import timestamp "github.com/golang/protobuf/ptypes/timestamp"
 
@@ -49,7 +50,7 @@ func (x RXWindow) String() string {
 	return proto.EnumName(RXWindow_name, int32(x))
 }
 func (RXWindow) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_ns_d187786e40ecf1f8, []int{0}
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{0}
 }
 
 type AggregationInterval int32
@@ -90,7 +91,32 @@ func (x AggregationInterval) String() string {
 	return proto.EnumName(AggregationInterval_name, int32(x))
 }
 func (AggregationInterval) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_ns_d187786e40ecf1f8, []int{1}
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{1}
+}
+
+type MulticastGroupType int32
+
+const (
+	// Class-B.
+	MulticastGroupType_CLASS_B MulticastGroupType = 0
+	// Class-C.
+	MulticastGroupType_CLASS_C MulticastGroupType = 1
+)
+
+var MulticastGroupType_name = map[int32]string{
+	0: "CLASS_B",
+	1: "CLASS_C",
+}
+var MulticastGroupType_value = map[string]int32{
+	"CLASS_B": 0,
+	"CLASS_C": 1,
+}
+
+func (x MulticastGroupType) String() string {
+	return proto.EnumName(MulticastGroupType_name, int32(x))
+}
+func (MulticastGroupType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{2}
 }
 
 type CreateServiceProfileRequest struct {
@@ -105,7 +131,7 @@ func (m *CreateServiceProfileRequest) Reset()         { *m = CreateServiceProfil
 func (m *CreateServiceProfileRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateServiceProfileRequest) ProtoMessage()    {}
 func (*CreateServiceProfileRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ns_d187786e40ecf1f8, []int{0}
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{0}
 }
 func (m *CreateServiceProfileRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateServiceProfileRequest.Unmarshal(m, b)
@@ -144,7 +170,7 @@ func (m *CreateServiceProfileResponse) Reset()         { *m = CreateServiceProfi
 func (m *CreateServiceProfileResponse) String() string { return proto.CompactTextString(m) }
 func (*CreateServiceProfileResponse) ProtoMessage()    {}
 func (*CreateServiceProfileResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ns_d187786e40ecf1f8, []int{1}
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{1}
 }
 func (m *CreateServiceProfileResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateServiceProfileResponse.Unmarshal(m, b)
@@ -183,7 +209,7 @@ func (m *GetServiceProfileRequest) Reset()         { *m = GetServiceProfileReque
 func (m *GetServiceProfileRequest) String() string { return proto.CompactTextString(m) }
 func (*GetServiceProfileRequest) ProtoMessage()    {}
 func (*GetServiceProfileRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ns_d187786e40ecf1f8, []int{2}
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{2}
 }
 func (m *GetServiceProfileRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetServiceProfileRequest.Unmarshal(m, b)
@@ -226,7 +252,7 @@ func (m *GetServiceProfileResponse) Reset()         { *m = GetServiceProfileResp
 func (m *GetServiceProfileResponse) String() string { return proto.CompactTextString(m) }
 func (*GetServiceProfileResponse) ProtoMessage()    {}
 func (*GetServiceProfileResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ns_d187786e40ecf1f8, []int{3}
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{3}
 }
 func (m *GetServiceProfileResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetServiceProfileResponse.Unmarshal(m, b)
@@ -279,7 +305,7 @@ func (m *UpdateServiceProfileRequest) Reset()         { *m = UpdateServiceProfil
 func (m *UpdateServiceProfileRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateServiceProfileRequest) ProtoMessage()    {}
 func (*UpdateServiceProfileRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ns_d187786e40ecf1f8, []int{4}
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{4}
 }
 func (m *UpdateServiceProfileRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UpdateServiceProfileRequest.Unmarshal(m, b)
@@ -318,7 +344,7 @@ func (m *DeleteServiceProfileRequest) Reset()         { *m = DeleteServiceProfil
 func (m *DeleteServiceProfileRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteServiceProfileRequest) ProtoMessage()    {}
 func (*DeleteServiceProfileRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ns_d187786e40ecf1f8, []int{5}
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{5}
 }
 func (m *DeleteServiceProfileRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeleteServiceProfileRequest.Unmarshal(m, b)
@@ -357,7 +383,7 @@ func (m *CreateRoutingProfileRequest) Reset()         { *m = CreateRoutingProfil
 func (m *CreateRoutingProfileRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateRoutingProfileRequest) ProtoMessage()    {}
 func (*CreateRoutingProfileRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ns_d187786e40ecf1f8, []int{6}
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{6}
 }
 func (m *CreateRoutingProfileRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateRoutingProfileRequest.Unmarshal(m, b)
@@ -396,7 +422,7 @@ func (m *CreateRoutingProfileResponse) Reset()         { *m = CreateRoutingProfi
 func (m *CreateRoutingProfileResponse) String() string { return proto.CompactTextString(m) }
 func (*CreateRoutingProfileResponse) ProtoMessage()    {}
 func (*CreateRoutingProfileResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ns_d187786e40ecf1f8, []int{7}
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{7}
 }
 func (m *CreateRoutingProfileResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateRoutingProfileResponse.Unmarshal(m, b)
@@ -435,7 +461,7 @@ func (m *GetRoutingProfileRequest) Reset()         { *m = GetRoutingProfileReque
 func (m *GetRoutingProfileRequest) String() string { return proto.CompactTextString(m) }
 func (*GetRoutingProfileRequest) ProtoMessage()    {}
 func (*GetRoutingProfileRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ns_d187786e40ecf1f8, []int{8}
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{8}
 }
 func (m *GetRoutingProfileRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetRoutingProfileRequest.Unmarshal(m, b)
@@ -478,7 +504,7 @@ func (m *GetRoutingProfileResponse) Reset()         { *m = GetRoutingProfileResp
 func (m *GetRoutingProfileResponse) String() string { return proto.CompactTextString(m) }
 func (*GetRoutingProfileResponse) ProtoMessage()    {}
 func (*GetRoutingProfileResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ns_d187786e40ecf1f8, []int{9}
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{9}
 }
 func (m *GetRoutingProfileResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetRoutingProfileResponse.Unmarshal(m, b)
@@ -531,7 +557,7 @@ func (m *UpdateRoutingProfileRequest) Reset()         { *m = UpdateRoutingProfil
 func (m *UpdateRoutingProfileRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateRoutingProfileRequest) ProtoMessage()    {}
 func (*UpdateRoutingProfileRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ns_d187786e40ecf1f8, []int{10}
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{10}
 }
 func (m *UpdateRoutingProfileRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UpdateRoutingProfileRequest.Unmarshal(m, b)
@@ -570,7 +596,7 @@ func (m *DeleteRoutingProfileRequest) Reset()         { *m = DeleteRoutingProfil
 func (m *DeleteRoutingProfileRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteRoutingProfileRequest) ProtoMessage()    {}
 func (*DeleteRoutingProfileRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ns_d187786e40ecf1f8, []int{11}
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{11}
 }
 func (m *DeleteRoutingProfileRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeleteRoutingProfileRequest.Unmarshal(m, b)
@@ -609,7 +635,7 @@ func (m *CreateDeviceProfileRequest) Reset()         { *m = CreateDeviceProfileR
 func (m *CreateDeviceProfileRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateDeviceProfileRequest) ProtoMessage()    {}
 func (*CreateDeviceProfileRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ns_d187786e40ecf1f8, []int{12}
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{12}
 }
 func (m *CreateDeviceProfileRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateDeviceProfileRequest.Unmarshal(m, b)
@@ -648,7 +674,7 @@ func (m *CreateDeviceProfileResponse) Reset()         { *m = CreateDeviceProfile
 func (m *CreateDeviceProfileResponse) String() string { return proto.CompactTextString(m) }
 func (*CreateDeviceProfileResponse) ProtoMessage()    {}
 func (*CreateDeviceProfileResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ns_d187786e40ecf1f8, []int{13}
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{13}
 }
 func (m *CreateDeviceProfileResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateDeviceProfileResponse.Unmarshal(m, b)
@@ -687,7 +713,7 @@ func (m *GetDeviceProfileRequest) Reset()         { *m = GetDeviceProfileRequest
 func (m *GetDeviceProfileRequest) String() string { return proto.CompactTextString(m) }
 func (*GetDeviceProfileRequest) ProtoMessage()    {}
 func (*GetDeviceProfileRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ns_d187786e40ecf1f8, []int{14}
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{14}
 }
 func (m *GetDeviceProfileRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetDeviceProfileRequest.Unmarshal(m, b)
@@ -730,7 +756,7 @@ func (m *GetDeviceProfileResponse) Reset()         { *m = GetDeviceProfileRespon
 func (m *GetDeviceProfileResponse) String() string { return proto.CompactTextString(m) }
 func (*GetDeviceProfileResponse) ProtoMessage()    {}
 func (*GetDeviceProfileResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ns_d187786e40ecf1f8, []int{15}
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{15}
 }
 func (m *GetDeviceProfileResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetDeviceProfileResponse.Unmarshal(m, b)
@@ -783,7 +809,7 @@ func (m *UpdateDeviceProfileRequest) Reset()         { *m = UpdateDeviceProfileR
 func (m *UpdateDeviceProfileRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateDeviceProfileRequest) ProtoMessage()    {}
 func (*UpdateDeviceProfileRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ns_d187786e40ecf1f8, []int{16}
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{16}
 }
 func (m *UpdateDeviceProfileRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UpdateDeviceProfileRequest.Unmarshal(m, b)
@@ -822,7 +848,7 @@ func (m *DeleteDeviceProfileRequest) Reset()         { *m = DeleteDeviceProfileR
 func (m *DeleteDeviceProfileRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteDeviceProfileRequest) ProtoMessage()    {}
 func (*DeleteDeviceProfileRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ns_d187786e40ecf1f8, []int{17}
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{17}
 }
 func (m *DeleteDeviceProfileRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeleteDeviceProfileRequest.Unmarshal(m, b)
@@ -870,7 +896,7 @@ func (m *Device) Reset()         { *m = Device{} }
 func (m *Device) String() string { return proto.CompactTextString(m) }
 func (*Device) ProtoMessage()    {}
 func (*Device) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ns_d187786e40ecf1f8, []int{18}
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{18}
 }
 func (m *Device) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Device.Unmarshal(m, b)
@@ -937,7 +963,7 @@ func (m *CreateDeviceRequest) Reset()         { *m = CreateDeviceRequest{} }
 func (m *CreateDeviceRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateDeviceRequest) ProtoMessage()    {}
 func (*CreateDeviceRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ns_d187786e40ecf1f8, []int{19}
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{19}
 }
 func (m *CreateDeviceRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateDeviceRequest.Unmarshal(m, b)
@@ -976,7 +1002,7 @@ func (m *GetDeviceRequest) Reset()         { *m = GetDeviceRequest{} }
 func (m *GetDeviceRequest) String() string { return proto.CompactTextString(m) }
 func (*GetDeviceRequest) ProtoMessage()    {}
 func (*GetDeviceRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ns_d187786e40ecf1f8, []int{20}
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{20}
 }
 func (m *GetDeviceRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetDeviceRequest.Unmarshal(m, b)
@@ -1019,7 +1045,7 @@ func (m *GetDeviceResponse) Reset()         { *m = GetDeviceResponse{} }
 func (m *GetDeviceResponse) String() string { return proto.CompactTextString(m) }
 func (*GetDeviceResponse) ProtoMessage()    {}
 func (*GetDeviceResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ns_d187786e40ecf1f8, []int{21}
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{21}
 }
 func (m *GetDeviceResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetDeviceResponse.Unmarshal(m, b)
@@ -1072,7 +1098,7 @@ func (m *UpdateDeviceRequest) Reset()         { *m = UpdateDeviceRequest{} }
 func (m *UpdateDeviceRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateDeviceRequest) ProtoMessage()    {}
 func (*UpdateDeviceRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ns_d187786e40ecf1f8, []int{22}
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{22}
 }
 func (m *UpdateDeviceRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UpdateDeviceRequest.Unmarshal(m, b)
@@ -1111,7 +1137,7 @@ func (m *DeleteDeviceRequest) Reset()         { *m = DeleteDeviceRequest{} }
 func (m *DeleteDeviceRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteDeviceRequest) ProtoMessage()    {}
 func (*DeleteDeviceRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ns_d187786e40ecf1f8, []int{23}
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{23}
 }
 func (m *DeleteDeviceRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeleteDeviceRequest.Unmarshal(m, b)
@@ -1166,7 +1192,7 @@ func (m *DeviceActivation) Reset()         { *m = DeviceActivation{} }
 func (m *DeviceActivation) String() string { return proto.CompactTextString(m) }
 func (*DeviceActivation) ProtoMessage()    {}
 func (*DeviceActivation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ns_d187786e40ecf1f8, []int{24}
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{24}
 }
 func (m *DeviceActivation) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeviceActivation.Unmarshal(m, b)
@@ -1261,7 +1287,7 @@ func (m *ActivateDeviceRequest) Reset()         { *m = ActivateDeviceRequest{} }
 func (m *ActivateDeviceRequest) String() string { return proto.CompactTextString(m) }
 func (*ActivateDeviceRequest) ProtoMessage()    {}
 func (*ActivateDeviceRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ns_d187786e40ecf1f8, []int{25}
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{25}
 }
 func (m *ActivateDeviceRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ActivateDeviceRequest.Unmarshal(m, b)
@@ -1300,7 +1326,7 @@ func (m *DeactivateDeviceRequest) Reset()         { *m = DeactivateDeviceRequest
 func (m *DeactivateDeviceRequest) String() string { return proto.CompactTextString(m) }
 func (*DeactivateDeviceRequest) ProtoMessage()    {}
 func (*DeactivateDeviceRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ns_d187786e40ecf1f8, []int{26}
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{26}
 }
 func (m *DeactivateDeviceRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeactivateDeviceRequest.Unmarshal(m, b)
@@ -1339,7 +1365,7 @@ func (m *GetDeviceActivationRequest) Reset()         { *m = GetDeviceActivationR
 func (m *GetDeviceActivationRequest) String() string { return proto.CompactTextString(m) }
 func (*GetDeviceActivationRequest) ProtoMessage()    {}
 func (*GetDeviceActivationRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ns_d187786e40ecf1f8, []int{27}
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{27}
 }
 func (m *GetDeviceActivationRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetDeviceActivationRequest.Unmarshal(m, b)
@@ -1368,17 +1394,20 @@ func (m *GetDeviceActivationRequest) GetDevEui() []byte {
 
 type GetDeviceActivationResponse struct {
 	// Device-activation object.
-	DeviceActivation     *DeviceActivation `protobuf:"bytes,1,opt,name=device_activation,json=deviceActivation,proto3" json:"device_activation,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+	DeviceActivation *DeviceActivation `protobuf:"bytes,1,opt,name=device_activation,json=deviceActivation,proto3" json:"device_activation,omitempty"`
+	// Outcome of the last ForceRejoinReq (if any). This is set when the
+	// last (re)activation of the device was triggered by a ForceRejoinReq.
+	ForceRejoinOutcome   *ForceRejoinOutcome `protobuf:"bytes,2,opt,name=force_rejoin_outcome,json=forceRejoinOutcome,proto3" json:"force_rejoin_outcome,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
 }
 
 func (m *GetDeviceActivationResponse) Reset()         { *m = GetDeviceActivationResponse{} }
 func (m *GetDeviceActivationResponse) String() string { return proto.CompactTextString(m) }
 func (*GetDeviceActivationResponse) ProtoMessage()    {}
 func (*GetDeviceActivationResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ns_d187786e40ecf1f8, []int{28}
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{28}
 }
 func (m *GetDeviceActivationResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetDeviceActivationResponse.Unmarshal(m, b)
@@ -1405,6 +1434,182 @@ func (m *GetDeviceActivationResponse) GetDeviceActivation() *DeviceActivation {
 	return nil
 }
 
+func (m *GetDeviceActivationResponse) GetForceRejoinOutcome() *ForceRejoinOutcome {
+	if m != nil {
+		return m.ForceRejoinOutcome
+	}
+	return nil
+}
+
+type ForceRejoinOutcome struct {
+	// Rejoin-request type requested by the ForceRejoinReq.
+	RequestedRejoinType uint32 `protobuf:"varint,1,opt,name=requested_rejoin_type,json=requestedRejoinType,proto3" json:"requested_rejoin_type,omitempty"`
+	// Rejoin-request type sent by the device.
+	RejoinType uint32 `protobuf:"varint,2,opt,name=rejoin_type,json=rejoinType,proto3" json:"rejoin_type,omitempty"`
+	// Timestamp of the rejoin.
+	RejoinedAt           *timestamp.Timestamp `protobuf:"bytes,3,opt,name=rejoined_at,json=rejoinedAt,proto3" json:"rejoined_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ForceRejoinOutcome) Reset()         { *m = ForceRejoinOutcome{} }
+func (m *ForceRejoinOutcome) String() string { return proto.CompactTextString(m) }
+func (*ForceRejoinOutcome) ProtoMessage()    {}
+func (*ForceRejoinOutcome) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{29}
+}
+func (m *ForceRejoinOutcome) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ForceRejoinOutcome.Unmarshal(m, b)
+}
+func (m *ForceRejoinOutcome) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ForceRejoinOutcome.Marshal(b, m, deterministic)
+}
+func (dst *ForceRejoinOutcome) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ForceRejoinOutcome.Merge(dst, src)
+}
+func (m *ForceRejoinOutcome) XXX_Size() int {
+	return xxx_messageInfo_ForceRejoinOutcome.Size(m)
+}
+func (m *ForceRejoinOutcome) XXX_DiscardUnknown() {
+	xxx_messageInfo_ForceRejoinOutcome.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ForceRejoinOutcome proto.InternalMessageInfo
+
+func (m *ForceRejoinOutcome) GetRequestedRejoinType() uint32 {
+	if m != nil {
+		return m.RequestedRejoinType
+	}
+	return 0
+}
+
+func (m *ForceRejoinOutcome) GetRejoinType() uint32 {
+	if m != nil {
+		return m.RejoinType
+	}
+	return 0
+}
+
+func (m *ForceRejoinOutcome) GetRejoinedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.RejoinedAt
+	}
+	return nil
+}
+
+type GetGatewayDiversityStatsForDevEUIRequest struct {
+	// Device EUI (8 bytes).
+	DevEui               []byte   `protobuf:"bytes,1,opt,name=dev_eui,json=devEui,proto3" json:"dev_eui,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetGatewayDiversityStatsForDevEUIRequest) Reset() {
+	*m = GetGatewayDiversityStatsForDevEUIRequest{}
+}
+func (m *GetGatewayDiversityStatsForDevEUIRequest) String() string { return proto.CompactTextString(m) }
+func (*GetGatewayDiversityStatsForDevEUIRequest) ProtoMessage()    {}
+func (*GetGatewayDiversityStatsForDevEUIRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{30}
+}
+func (m *GetGatewayDiversityStatsForDevEUIRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetGatewayDiversityStatsForDevEUIRequest.Unmarshal(m, b)
+}
+func (m *GetGatewayDiversityStatsForDevEUIRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetGatewayDiversityStatsForDevEUIRequest.Marshal(b, m, deterministic)
+}
+func (dst *GetGatewayDiversityStatsForDevEUIRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetGatewayDiversityStatsForDevEUIRequest.Merge(dst, src)
+}
+func (m *GetGatewayDiversityStatsForDevEUIRequest) XXX_Size() int {
+	return xxx_messageInfo_GetGatewayDiversityStatsForDevEUIRequest.Size(m)
+}
+func (m *GetGatewayDiversityStatsForDevEUIRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetGatewayDiversityStatsForDevEUIRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetGatewayDiversityStatsForDevEUIRequest proto.InternalMessageInfo
+
+func (m *GetGatewayDiversityStatsForDevEUIRequest) GetDevEui() []byte {
+	if m != nil {
+		return m.DevEui
+	}
+	return nil
+}
+
+type GetGatewayDiversityStatsForDevEUIResponse struct {
+	// Number of uplinks.
+	UplinkCount uint32 `protobuf:"varint,1,opt,name=uplink_count,json=uplinkCount,proto3" json:"uplink_count,omitempty"`
+	// Number of uplinks received by fewer gateways than the service-profile
+	// MinGWDiversity.
+	ViolationCount uint32 `protobuf:"varint,2,opt,name=violation_count,json=violationCount,proto3" json:"violation_count,omitempty"`
+	// Average number of receiving gateways.
+	AvgGatewayCount float64 `protobuf:"fixed64,3,opt,name=avg_gateway_count,json=avgGatewayCount,proto3" json:"avg_gateway_count,omitempty"`
+	// Number of gateways that received the last uplink.
+	LastGatewayCount     uint32   `protobuf:"varint,4,opt,name=last_gateway_count,json=lastGatewayCount,proto3" json:"last_gateway_count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetGatewayDiversityStatsForDevEUIResponse) Reset() {
+	*m = GetGatewayDiversityStatsForDevEUIResponse{}
+}
+func (m *GetGatewayDiversityStatsForDevEUIResponse) String() string {
+	return proto.CompactTextString(m)
+}
+func (*GetGatewayDiversityStatsForDevEUIResponse) ProtoMessage() {}
+func (*GetGatewayDiversityStatsForDevEUIResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{31}
+}
+func (m *GetGatewayDiversityStatsForDevEUIResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetGatewayDiversityStatsForDevEUIResponse.Unmarshal(m, b)
+}
+func (m *GetGatewayDiversityStatsForDevEUIResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetGatewayDiversityStatsForDevEUIResponse.Marshal(b, m, deterministic)
+}
+func (dst *GetGatewayDiversityStatsForDevEUIResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetGatewayDiversityStatsForDevEUIResponse.Merge(dst, src)
+}
+func (m *GetGatewayDiversityStatsForDevEUIResponse) XXX_Size() int {
+	return xxx_messageInfo_GetGatewayDiversityStatsForDevEUIResponse.Size(m)
+}
+func (m *GetGatewayDiversityStatsForDevEUIResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetGatewayDiversityStatsForDevEUIResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetGatewayDiversityStatsForDevEUIResponse proto.InternalMessageInfo
+
+func (m *GetGatewayDiversityStatsForDevEUIResponse) GetUplinkCount() uint32 {
+	if m != nil {
+		return m.UplinkCount
+	}
+	return 0
+}
+
+func (m *GetGatewayDiversityStatsForDevEUIResponse) GetViolationCount() uint32 {
+	if m != nil {
+		return m.ViolationCount
+	}
+	return 0
+}
+
+func (m *GetGatewayDiversityStatsForDevEUIResponse) GetAvgGatewayCount() float64 {
+	if m != nil {
+		return m.AvgGatewayCount
+	}
+	return 0
+}
+
+func (m *GetGatewayDiversityStatsForDevEUIResponse) GetLastGatewayCount() uint32 {
+	if m != nil {
+		return m.LastGatewayCount
+	}
+	return 0
+}
+
 type GetRandomDevAddrResponse struct {
 	// Random device address (DevAddr).
 	// Note that this includes the NetID prefix of the network-server.
@@ -1418,7 +1623,7 @@ func (m *GetRandomDevAddrResponse) Reset()         { *m = GetRandomDevAddrRespon
 func (m *GetRandomDevAddrResponse) String() string { return proto.CompactTextString(m) }
 func (*GetRandomDevAddrResponse) ProtoMessage()    {}
 func (*GetRandomDevAddrResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ns_d187786e40ecf1f8, []int{29}
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{32}
 }
 func (m *GetRandomDevAddrResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetRandomDevAddrResponse.Unmarshal(m, b)
@@ -1462,7 +1667,7 @@ func (m *CreateMACCommandQueueItemRequest) Reset()         { *m = CreateMACComma
 func (m *CreateMACCommandQueueItemRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateMACCommandQueueItemRequest) ProtoMessage()    {}
 func (*CreateMACCommandQueueItemRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ns_d187786e40ecf1f8, []int{30}
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{33}
 }
 func (m *CreateMACCommandQueueItemRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateMACCommandQueueItemRequest.Unmarshal(m, b)
@@ -1503,6 +1708,121 @@ func (m *CreateMACCommandQueueItemRequest) GetCommands() [][]byte {
 	return nil
 }
 
+type ForceDutyCycleReconfigurationRequest struct {
+	// DevEUI EUI (8 bytes).
+	DevEui               []byte   `protobuf:"bytes,1,opt,name=dev_eui,json=devEui,proto3" json:"dev_eui,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ForceDutyCycleReconfigurationRequest) Reset()         { *m = ForceDutyCycleReconfigurationRequest{} }
+func (m *ForceDutyCycleReconfigurationRequest) String() string { return proto.CompactTextString(m) }
+func (*ForceDutyCycleReconfigurationRequest) ProtoMessage()    {}
+func (*ForceDutyCycleReconfigurationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{34}
+}
+func (m *ForceDutyCycleReconfigurationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ForceDutyCycleReconfigurationRequest.Unmarshal(m, b)
+}
+func (m *ForceDutyCycleReconfigurationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ForceDutyCycleReconfigurationRequest.Marshal(b, m, deterministic)
+}
+func (dst *ForceDutyCycleReconfigurationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ForceDutyCycleReconfigurationRequest.Merge(dst, src)
+}
+func (m *ForceDutyCycleReconfigurationRequest) XXX_Size() int {
+	return xxx_messageInfo_ForceDutyCycleReconfigurationRequest.Size(m)
+}
+func (m *ForceDutyCycleReconfigurationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ForceDutyCycleReconfigurationRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ForceDutyCycleReconfigurationRequest proto.InternalMessageInfo
+
+func (m *ForceDutyCycleReconfigurationRequest) GetDevEui() []byte {
+	if m != nil {
+		return m.DevEui
+	}
+	return nil
+}
+
+type ForceRejoinRequest struct {
+	// DevEUI EUI (8 bytes).
+	DevEui []byte `protobuf:"bytes,1,opt,name=dev_eui,json=devEui,proto3" json:"dev_eui,omitempty"`
+	// Rejoin-request type (0 or 2).
+	RejoinType uint32 `protobuf:"varint,2,opt,name=rejoin_type,json=rejoinType,proto3" json:"rejoin_type,omitempty"`
+	// Data-rate used by the device for the rejoin-request.
+	Dr uint32 `protobuf:"varint,3,opt,name=dr,proto3" json:"dr,omitempty"`
+	// Max number of retransmissions of the rejoin-request (0 - 7).
+	MaxRetries uint32 `protobuf:"varint,4,opt,name=max_retries,json=maxRetries,proto3" json:"max_retries,omitempty"`
+	// Delay between the retransmissions of the rejoin-request
+	// (32 seconds * 2^period, 0 - 7).
+	Period               uint32   `protobuf:"varint,5,opt,name=period,proto3" json:"period,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ForceRejoinRequest) Reset()         { *m = ForceRejoinRequest{} }
+func (m *ForceRejoinRequest) String() string { return proto.CompactTextString(m) }
+func (*ForceRejoinRequest) ProtoMessage()    {}
+func (*ForceRejoinRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{35}
+}
+func (m *ForceRejoinRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ForceRejoinRequest.Unmarshal(m, b)
+}
+func (m *ForceRejoinRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ForceRejoinRequest.Marshal(b, m, deterministic)
+}
+func (dst *ForceRejoinRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ForceRejoinRequest.Merge(dst, src)
+}
+func (m *ForceRejoinRequest) XXX_Size() int {
+	return xxx_messageInfo_ForceRejoinRequest.Size(m)
+}
+func (m *ForceRejoinRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ForceRejoinRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ForceRejoinRequest proto.InternalMessageInfo
+
+func (m *ForceRejoinRequest) GetDevEui() []byte {
+	if m != nil {
+		return m.DevEui
+	}
+	return nil
+}
+
+func (m *ForceRejoinRequest) GetRejoinType() uint32 {
+	if m != nil {
+		return m.RejoinType
+	}
+	return 0
+}
+
+func (m *ForceRejoinRequest) GetDr() uint32 {
+	if m != nil {
+		return m.Dr
+	}
+	return 0
+}
+
+func (m *ForceRejoinRequest) GetMaxRetries() uint32 {
+	if m != nil {
+		return m.MaxRetries
+	}
+	return 0
+}
+
+func (m *ForceRejoinRequest) GetPeriod() uint32 {
+	if m != nil {
+		return m.Period
+	}
+	return 0
+}
+
 type SendProprietaryPayloadRequest struct {
 	// MACPayload of the proprietary LoRaWAN frame.
 	MacPayload []byte `protobuf:"bytes,1,opt,name=mac_payload,json=macPayload,proto3" json:"mac_payload,omitempty"`
@@ -1526,7 +1846,7 @@ func (m *SendProprietaryPayloadRequest) Reset()         { *m = SendProprietaryPa
 func (m *SendProprietaryPayloadRequest) String() string { return proto.CompactTextString(m) }
 func (*SendProprietaryPayloadRequest) ProtoMessage()    {}
 func (*SendProprietaryPayloadRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ns_d187786e40ecf1f8, []int{31}
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{36}
 }
 func (m *SendProprietaryPayloadRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SendProprietaryPayloadRequest.Unmarshal(m, b)
@@ -1604,7 +1924,7 @@ func (m *Gateway) Reset()         { *m = Gateway{} }
 func (m *Gateway) String() string { return proto.CompactTextString(m) }
 func (*Gateway) ProtoMessage()    {}
 func (*Gateway) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ns_d187786e40ecf1f8, []int{32}
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{37}
 }
 func (m *Gateway) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Gateway.Unmarshal(m, b)
@@ -1657,7 +1977,7 @@ func (m *CreateGatewayRequest) Reset()         { *m = CreateGatewayRequest{} }
 func (m *CreateGatewayRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateGatewayRequest) ProtoMessage()    {}
 func (*CreateGatewayRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ns_d187786e40ecf1f8, []int{33}
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{38}
 }
 func (m *CreateGatewayRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateGatewayRequest.Unmarshal(m, b)
@@ -1696,7 +2016,7 @@ func (m *GetGatewayRequest) Reset()         { *m = GetGatewayRequest{} }
 func (m *GetGatewayRequest) String() string { return proto.CompactTextString(m) }
 func (*GetGatewayRequest) ProtoMessage()    {}
 func (*GetGatewayRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ns_d187786e40ecf1f8, []int{34}
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{39}
 }
 func (m *GetGatewayRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetGatewayRequest.Unmarshal(m, b)
@@ -1743,7 +2063,7 @@ func (m *GetGatewayResponse) Reset()         { *m = GetGatewayResponse{} }
 func (m *GetGatewayResponse) String() string { return proto.CompactTextString(m) }
 func (*GetGatewayResponse) ProtoMessage()    {}
 func (*GetGatewayResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ns_d187786e40ecf1f8, []int{35}
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{40}
 }
 func (m *GetGatewayResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetGatewayResponse.Unmarshal(m, b)
@@ -1810,7 +2130,7 @@ func (m *UpdateGatewayRequest) Reset()         { *m = UpdateGatewayRequest{} }
 func (m *UpdateGatewayRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateGatewayRequest) ProtoMessage()    {}
 func (*UpdateGatewayRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ns_d187786e40ecf1f8, []int{36}
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{41}
 }
 func (m *UpdateGatewayRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UpdateGatewayRequest.Unmarshal(m, b)
@@ -1849,7 +2169,7 @@ func (m *DeleteGatewayRequest) Reset()         { *m = DeleteGatewayRequest{} }
 func (m *DeleteGatewayRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteGatewayRequest) ProtoMessage()    {}
 func (*DeleteGatewayRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ns_d187786e40ecf1f8, []int{37}
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{42}
 }
 func (m *DeleteGatewayRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeleteGatewayRequest.Unmarshal(m, b)
@@ -1896,7 +2216,7 @@ func (m *GatewayStats) Reset()         { *m = GatewayStats{} }
 func (m *GatewayStats) String() string { return proto.CompactTextString(m) }
 func (*GatewayStats) ProtoMessage()    {}
 func (*GatewayStats) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ns_d187786e40ecf1f8, []int{38}
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{43}
 }
 func (m *GatewayStats) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GatewayStats.Unmarshal(m, b)
@@ -1969,7 +2289,7 @@ func (m *GetGatewayStatsRequest) Reset()         { *m = GetGatewayStatsRequest{}
 func (m *GetGatewayStatsRequest) String() string { return proto.CompactTextString(m) }
 func (*GetGatewayStatsRequest) ProtoMessage()    {}
 func (*GetGatewayStatsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ns_d187786e40ecf1f8, []int{39}
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{44}
 }
 func (m *GetGatewayStatsRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetGatewayStatsRequest.Unmarshal(m, b)
@@ -2017,18 +2337,96 @@ func (m *GetGatewayStatsRequest) GetEndTimestamp() *timestamp.Timestamp {
 	return nil
 }
 
+type GatewayDutyCycleUsage struct {
+	// Min. frequency of the sub-band (Hz).
+	MinFrequency uint32 `protobuf:"varint,1,opt,name=min_frequency,json=minFrequency,proto3" json:"min_frequency,omitempty"`
+	// Max. frequency (exclusive) of the sub-band (Hz).
+	MaxFrequency uint32 `protobuf:"varint,2,opt,name=max_frequency,json=maxFrequency,proto3" json:"max_frequency,omitempty"`
+	// Max. duty-cycle of the sub-band (percent).
+	MaxDutyCycle float32 `protobuf:"fixed32,3,opt,name=max_duty_cycle,json=maxDutyCycle,proto3" json:"max_duty_cycle,omitempty"`
+	// Downlink airtime used within the duty-cycle window.
+	Airtime *duration.Duration `protobuf:"bytes,4,opt,name=airtime,proto3" json:"airtime,omitempty"`
+	// Max. downlink airtime within the duty-cycle window.
+	Budget               *duration.Duration `protobuf:"bytes,5,opt,name=budget,proto3" json:"budget,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *GatewayDutyCycleUsage) Reset()         { *m = GatewayDutyCycleUsage{} }
+func (m *GatewayDutyCycleUsage) String() string { return proto.CompactTextString(m) }
+func (*GatewayDutyCycleUsage) ProtoMessage()    {}
+func (*GatewayDutyCycleUsage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{45}
+}
+func (m *GatewayDutyCycleUsage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GatewayDutyCycleUsage.Unmarshal(m, b)
+}
+func (m *GatewayDutyCycleUsage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GatewayDutyCycleUsage.Marshal(b, m, deterministic)
+}
+func (dst *GatewayDutyCycleUsage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GatewayDutyCycleUsage.Merge(dst, src)
+}
+func (m *GatewayDutyCycleUsage) XXX_Size() int {
+	return xxx_messageInfo_GatewayDutyCycleUsage.Size(m)
+}
+func (m *GatewayDutyCycleUsage) XXX_DiscardUnknown() {
+	xxx_messageInfo_GatewayDutyCycleUsage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GatewayDutyCycleUsage proto.InternalMessageInfo
+
+func (m *GatewayDutyCycleUsage) GetMinFrequency() uint32 {
+	if m != nil {
+		return m.MinFrequency
+	}
+	return 0
+}
+
+func (m *GatewayDutyCycleUsage) GetMaxFrequency() uint32 {
+	if m != nil {
+		return m.MaxFrequency
+	}
+	return 0
+}
+
+func (m *GatewayDutyCycleUsage) GetMaxDutyCycle() float32 {
+	if m != nil {
+		return m.MaxDutyCycle
+	}
+	return 0
+}
+
+func (m *GatewayDutyCycleUsage) GetAirtime() *duration.Duration {
+	if m != nil {
+		return m.Airtime
+	}
+	return nil
+}
+
+func (m *GatewayDutyCycleUsage) GetBudget() *duration.Duration {
+	if m != nil {
+		return m.Budget
+	}
+	return nil
+}
+
 type GetGatewayStatsResponse struct {
-	Result               []*GatewayStats `protobuf:"bytes,1,rep,name=result,proto3" json:"result,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+	Result []*GatewayStats `protobuf:"bytes,1,rep,name=result,proto3" json:"result,omitempty"`
+	// Downlink duty-cycle usage per sub-band (only set when duty-cycle
+	// accounting is enabled).
+	DutyCycleUsage       []*GatewayDutyCycleUsage `protobuf:"bytes,2,rep,name=duty_cycle_usage,json=dutyCycleUsage,proto3" json:"duty_cycle_usage,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
 }
 
 func (m *GetGatewayStatsResponse) Reset()         { *m = GetGatewayStatsResponse{} }
 func (m *GetGatewayStatsResponse) String() string { return proto.CompactTextString(m) }
 func (*GetGatewayStatsResponse) ProtoMessage()    {}
 func (*GetGatewayStatsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ns_d187786e40ecf1f8, []int{40}
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{46}
 }
 func (m *GetGatewayStatsResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetGatewayStatsResponse.Unmarshal(m, b)
@@ -2055,6 +2453,13 @@ func (m *GetGatewayStatsResponse) GetResult() []*GatewayStats {
 	return nil
 }
 
+func (m *GetGatewayStatsResponse) GetDutyCycleUsage() []*GatewayDutyCycleUsage {
+	if m != nil {
+		return m.DutyCycleUsage
+	}
+	return nil
+}
+
 type DeviceQueueItem struct {
 	// DevEUI of the device.
 	DevEui []byte `protobuf:"bytes,1,opt,name=dev_eui,json=devEui,proto3" json:"dev_eui,omitempty"`
@@ -2066,17 +2471,26 @@ type DeviceQueueItem struct {
 	FPort uint32 `protobuf:"varint,4,opt,name=f_port,json=fPort,proto3" json:"f_port,omitempty"`
 	// When set to true, LoRa Server will wait for the device to ack the
 	// received frame.
-	Confirmed            bool     `protobuf:"varint,5,opt,name=confirmed,proto3" json:"confirmed,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Confirmed bool `protobuf:"varint,5,opt,name=confirmed,proto3" json:"confirmed,omitempty"`
+	// The item was enqueued while the downlink rate of the service-profile
+	// was exceeded (set by LoRa Server when the policy is MARK).
+	RateLimitExceeded bool `protobuf:"varint,6,opt,name=rate_limit_exceeded,json=rateLimitExceeded,proto3" json:"rate_limit_exceeded,omitempty"`
+	// ID of the item (set by LoRa Server).
+	Id int64 `protobuf:"varint,7,opt,name=id,proto3" json:"id,omitempty"`
+	// When set, the item is removed from the queue when it has not been
+	// transmitted before this time. The application-server will be notified
+	// (DEVICE_QUEUE_ITEM_EXPIRED error).
+	ExpiresAt            *timestamp.Timestamp `protobuf:"bytes,8,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
 func (m *DeviceQueueItem) Reset()         { *m = DeviceQueueItem{} }
 func (m *DeviceQueueItem) String() string { return proto.CompactTextString(m) }
 func (*DeviceQueueItem) ProtoMessage()    {}
 func (*DeviceQueueItem) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ns_d187786e40ecf1f8, []int{41}
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{47}
 }
 func (m *DeviceQueueItem) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeviceQueueItem.Unmarshal(m, b)
@@ -2131,6 +2545,27 @@ func (m *DeviceQueueItem) GetConfirmed() bool {
 	return false
 }
 
+func (m *DeviceQueueItem) GetRateLimitExceeded() bool {
+	if m != nil {
+		return m.RateLimitExceeded
+	}
+	return false
+}
+
+func (m *DeviceQueueItem) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *DeviceQueueItem) GetExpiresAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return nil
+}
+
 type CreateDeviceQueueItemRequest struct {
 	Item                 *DeviceQueueItem `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
@@ -2142,7 +2577,7 @@ func (m *CreateDeviceQueueItemRequest) Reset()         { *m = CreateDeviceQueueI
 func (m *CreateDeviceQueueItemRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateDeviceQueueItemRequest) ProtoMessage()    {}
 func (*CreateDeviceQueueItemRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ns_d187786e40ecf1f8, []int{42}
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{48}
 }
 func (m *CreateDeviceQueueItemRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateDeviceQueueItemRequest.Unmarshal(m, b)
@@ -2181,7 +2616,7 @@ func (m *FlushDeviceQueueForDevEUIRequest) Reset()         { *m = FlushDeviceQue
 func (m *FlushDeviceQueueForDevEUIRequest) String() string { return proto.CompactTextString(m) }
 func (*FlushDeviceQueueForDevEUIRequest) ProtoMessage()    {}
 func (*FlushDeviceQueueForDevEUIRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ns_d187786e40ecf1f8, []int{43}
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{49}
 }
 func (m *FlushDeviceQueueForDevEUIRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FlushDeviceQueueForDevEUIRequest.Unmarshal(m, b)
@@ -2208,47 +2643,105 @@ func (m *FlushDeviceQueueForDevEUIRequest) GetDevEui() []byte {
 	return nil
 }
 
-type GetDeviceQueueItemsForDevEUIRequest struct {
+type DeleteDeviceQueueItemRequest struct {
 	// DevEUI of the device.
-	DevEui               []byte   `protobuf:"bytes,1,opt,name=dev_eui,json=devEui,proto3" json:"dev_eui,omitempty"`
+	DevEui []byte `protobuf:"bytes,1,opt,name=dev_eui,json=devEui,proto3" json:"dev_eui,omitempty"`
+	// ID of the device-queue item. When not set, the item is identified by
+	// its frame-counter.
+	Id int64 `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	// Frame-counter of the device-queue item.
+	FCnt                 uint32   `protobuf:"varint,3,opt,name=f_cnt,json=fCnt,proto3" json:"f_cnt,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetDeviceQueueItemsForDevEUIRequest) Reset()         { *m = GetDeviceQueueItemsForDevEUIRequest{} }
-func (m *GetDeviceQueueItemsForDevEUIRequest) String() string { return proto.CompactTextString(m) }
-func (*GetDeviceQueueItemsForDevEUIRequest) ProtoMessage()    {}
-func (*GetDeviceQueueItemsForDevEUIRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ns_d187786e40ecf1f8, []int{44}
+func (m *DeleteDeviceQueueItemRequest) Reset()         { *m = DeleteDeviceQueueItemRequest{} }
+func (m *DeleteDeviceQueueItemRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteDeviceQueueItemRequest) ProtoMessage()    {}
+func (*DeleteDeviceQueueItemRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{50}
 }
-func (m *GetDeviceQueueItemsForDevEUIRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetDeviceQueueItemsForDevEUIRequest.Unmarshal(m, b)
+func (m *DeleteDeviceQueueItemRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteDeviceQueueItemRequest.Unmarshal(m, b)
 }
-func (m *GetDeviceQueueItemsForDevEUIRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetDeviceQueueItemsForDevEUIRequest.Marshal(b, m, deterministic)
+func (m *DeleteDeviceQueueItemRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteDeviceQueueItemRequest.Marshal(b, m, deterministic)
 }
-func (dst *GetDeviceQueueItemsForDevEUIRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetDeviceQueueItemsForDevEUIRequest.Merge(dst, src)
+func (dst *DeleteDeviceQueueItemRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteDeviceQueueItemRequest.Merge(dst, src)
 }
-func (m *GetDeviceQueueItemsForDevEUIRequest) XXX_Size() int {
-	return xxx_messageInfo_GetDeviceQueueItemsForDevEUIRequest.Size(m)
+func (m *DeleteDeviceQueueItemRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteDeviceQueueItemRequest.Size(m)
 }
-func (m *GetDeviceQueueItemsForDevEUIRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetDeviceQueueItemsForDevEUIRequest.DiscardUnknown(m)
+func (m *DeleteDeviceQueueItemRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteDeviceQueueItemRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetDeviceQueueItemsForDevEUIRequest proto.InternalMessageInfo
+var xxx_messageInfo_DeleteDeviceQueueItemRequest proto.InternalMessageInfo
 
-func (m *GetDeviceQueueItemsForDevEUIRequest) GetDevEui() []byte {
+func (m *DeleteDeviceQueueItemRequest) GetDevEui() []byte {
 	if m != nil {
 		return m.DevEui
 	}
 	return nil
 }
 
-type GetDeviceQueueItemsForDevEUIResponse struct {
-	Items                []*DeviceQueueItem `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+func (m *DeleteDeviceQueueItemRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *DeleteDeviceQueueItemRequest) GetFCnt() uint32 {
+	if m != nil {
+		return m.FCnt
+	}
+	return 0
+}
+
+type GetDeviceQueueItemsForDevEUIRequest struct {
+	// DevEUI of the device.
+	DevEui               []byte   `protobuf:"bytes,1,opt,name=dev_eui,json=devEui,proto3" json:"dev_eui,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetDeviceQueueItemsForDevEUIRequest) Reset()         { *m = GetDeviceQueueItemsForDevEUIRequest{} }
+func (m *GetDeviceQueueItemsForDevEUIRequest) String() string { return proto.CompactTextString(m) }
+func (*GetDeviceQueueItemsForDevEUIRequest) ProtoMessage()    {}
+func (*GetDeviceQueueItemsForDevEUIRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{51}
+}
+func (m *GetDeviceQueueItemsForDevEUIRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDeviceQueueItemsForDevEUIRequest.Unmarshal(m, b)
+}
+func (m *GetDeviceQueueItemsForDevEUIRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDeviceQueueItemsForDevEUIRequest.Marshal(b, m, deterministic)
+}
+func (dst *GetDeviceQueueItemsForDevEUIRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDeviceQueueItemsForDevEUIRequest.Merge(dst, src)
+}
+func (m *GetDeviceQueueItemsForDevEUIRequest) XXX_Size() int {
+	return xxx_messageInfo_GetDeviceQueueItemsForDevEUIRequest.Size(m)
+}
+func (m *GetDeviceQueueItemsForDevEUIRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDeviceQueueItemsForDevEUIRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetDeviceQueueItemsForDevEUIRequest proto.InternalMessageInfo
+
+func (m *GetDeviceQueueItemsForDevEUIRequest) GetDevEui() []byte {
+	if m != nil {
+		return m.DevEui
+	}
+	return nil
+}
+
+type GetDeviceQueueItemsForDevEUIResponse struct {
+	Items                []*DeviceQueueItem `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
 	XXX_unrecognized     []byte             `json:"-"`
 	XXX_sizecache        int32              `json:"-"`
@@ -2258,7 +2751,7 @@ func (m *GetDeviceQueueItemsForDevEUIResponse) Reset()         { *m = GetDeviceQ
 func (m *GetDeviceQueueItemsForDevEUIResponse) String() string { return proto.CompactTextString(m) }
 func (*GetDeviceQueueItemsForDevEUIResponse) ProtoMessage()    {}
 func (*GetDeviceQueueItemsForDevEUIResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ns_d187786e40ecf1f8, []int{45}
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{52}
 }
 func (m *GetDeviceQueueItemsForDevEUIResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetDeviceQueueItemsForDevEUIResponse.Unmarshal(m, b)
@@ -2297,7 +2790,7 @@ func (m *GetNextDownlinkFCntForDevEUIRequest) Reset()         { *m = GetNextDown
 func (m *GetNextDownlinkFCntForDevEUIRequest) String() string { return proto.CompactTextString(m) }
 func (*GetNextDownlinkFCntForDevEUIRequest) ProtoMessage()    {}
 func (*GetNextDownlinkFCntForDevEUIRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ns_d187786e40ecf1f8, []int{46}
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{53}
 }
 func (m *GetNextDownlinkFCntForDevEUIRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetNextDownlinkFCntForDevEUIRequest.Unmarshal(m, b)
@@ -2336,7 +2829,7 @@ func (m *GetNextDownlinkFCntForDevEUIResponse) Reset()         { *m = GetNextDow
 func (m *GetNextDownlinkFCntForDevEUIResponse) String() string { return proto.CompactTextString(m) }
 func (*GetNextDownlinkFCntForDevEUIResponse) ProtoMessage()    {}
 func (*GetNextDownlinkFCntForDevEUIResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ns_d187786e40ecf1f8, []int{47}
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{54}
 }
 func (m *GetNextDownlinkFCntForDevEUIResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetNextDownlinkFCntForDevEUIResponse.Unmarshal(m, b)
@@ -2375,7 +2868,7 @@ func (m *StreamFrameLogsForGatewayRequest) Reset()         { *m = StreamFrameLog
 func (m *StreamFrameLogsForGatewayRequest) String() string { return proto.CompactTextString(m) }
 func (*StreamFrameLogsForGatewayRequest) ProtoMessage()    {}
 func (*StreamFrameLogsForGatewayRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ns_d187786e40ecf1f8, []int{48}
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{55}
 }
 func (m *StreamFrameLogsForGatewayRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StreamFrameLogsForGatewayRequest.Unmarshal(m, b)
@@ -2416,7 +2909,7 @@ func (m *StreamFrameLogsForGatewayResponse) Reset()         { *m = StreamFrameLo
 func (m *StreamFrameLogsForGatewayResponse) String() string { return proto.CompactTextString(m) }
 func (*StreamFrameLogsForGatewayResponse) ProtoMessage()    {}
 func (*StreamFrameLogsForGatewayResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ns_d187786e40ecf1f8, []int{49}
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{56}
 }
 func (m *StreamFrameLogsForGatewayResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StreamFrameLogsForGatewayResponse.Unmarshal(m, b)
@@ -2448,6 +2941,7 @@ type StreamFrameLogsForGatewayResponse_DownlinkFrame struct {
 }
 
 func (*StreamFrameLogsForGatewayResponse_UplinkFrameSet) isStreamFrameLogsForGatewayResponse_Frame() {}
+
 func (*StreamFrameLogsForGatewayResponse_DownlinkFrame) isStreamFrameLogsForGatewayResponse_Frame()  {}
 
 func (m *StreamFrameLogsForGatewayResponse) GetFrame() isStreamFrameLogsForGatewayResponse_Frame {
@@ -2557,7 +3051,7 @@ func (m *StreamFrameLogsForDeviceRequest) Reset()         { *m = StreamFrameLogs
 func (m *StreamFrameLogsForDeviceRequest) String() string { return proto.CompactTextString(m) }
 func (*StreamFrameLogsForDeviceRequest) ProtoMessage()    {}
 func (*StreamFrameLogsForDeviceRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ns_d187786e40ecf1f8, []int{50}
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{57}
 }
 func (m *StreamFrameLogsForDeviceRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StreamFrameLogsForDeviceRequest.Unmarshal(m, b)
@@ -2598,7 +3092,7 @@ func (m *StreamFrameLogsForDeviceResponse) Reset()         { *m = StreamFrameLog
 func (m *StreamFrameLogsForDeviceResponse) String() string { return proto.CompactTextString(m) }
 func (*StreamFrameLogsForDeviceResponse) ProtoMessage()    {}
 func (*StreamFrameLogsForDeviceResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ns_d187786e40ecf1f8, []int{51}
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{58}
 }
 func (m *StreamFrameLogsForDeviceResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StreamFrameLogsForDeviceResponse.Unmarshal(m, b)
@@ -2741,7 +3235,7 @@ func (m *GetVersionResponse) Reset()         { *m = GetVersionResponse{} }
 func (m *GetVersionResponse) String() string { return proto.CompactTextString(m) }
 func (*GetVersionResponse) ProtoMessage()    {}
 func (*GetVersionResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ns_d187786e40ecf1f8, []int{52}
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{59}
 }
 func (m *GetVersionResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetVersionResponse.Unmarshal(m, b)
@@ -2783,17 +3277,20 @@ type GatewayProfile struct {
 	Channels []uint32 `protobuf:"varint,2,rep,packed,name=channels,proto3" json:"channels,omitempty"`
 	// Extra channels added to the channel-configuration (in case the LoRaWAN
 	// region supports adding custom channels).
-	ExtraChannels        []*GatewayProfileExtraChannel `protobuf:"bytes,3,rep,name=extra_channels,json=extraChannels,proto3" json:"extra_channels,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                      `json:"-"`
-	XXX_unrecognized     []byte                        `json:"-"`
-	XXX_sizecache        int32                         `json:"-"`
+	ExtraChannels []*GatewayProfileExtraChannel `protobuf:"bytes,3,rep,name=extra_channels,json=extraChannels,proto3" json:"extra_channels,omitempty"`
+	// Class-B beacons are sent by the network-server through the gateways
+	// using this gateway-profile.
+	BeaconEnabled        bool     `protobuf:"varint,4,opt,name=beacon_enabled,json=beaconEnabled,proto3" json:"beacon_enabled,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *GatewayProfile) Reset()         { *m = GatewayProfile{} }
 func (m *GatewayProfile) String() string { return proto.CompactTextString(m) }
 func (*GatewayProfile) ProtoMessage()    {}
 func (*GatewayProfile) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ns_d187786e40ecf1f8, []int{53}
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{60}
 }
 func (m *GatewayProfile) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GatewayProfile.Unmarshal(m, b)
@@ -2834,6 +3331,13 @@ func (m *GatewayProfile) GetExtraChannels() []*GatewayProfileExtraChannel {
 	return nil
 }
 
+func (m *GatewayProfile) GetBeaconEnabled() bool {
+	if m != nil {
+		return m.BeaconEnabled
+	}
+	return false
+}
+
 type GatewayProfileExtraChannel struct {
 	// Modulation.
 	Modulation common.Modulation `protobuf:"varint,1,opt,name=modulation,proto3,enum=common.Modulation" json:"modulation,omitempty"`
@@ -2854,7 +3358,7 @@ func (m *GatewayProfileExtraChannel) Reset()         { *m = GatewayProfileExtraC
 func (m *GatewayProfileExtraChannel) String() string { return proto.CompactTextString(m) }
 func (*GatewayProfileExtraChannel) ProtoMessage()    {}
 func (*GatewayProfileExtraChannel) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ns_d187786e40ecf1f8, []int{54}
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{61}
 }
 func (m *GatewayProfileExtraChannel) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GatewayProfileExtraChannel.Unmarshal(m, b)
@@ -2921,7 +3425,7 @@ func (m *CreateGatewayProfileRequest) Reset()         { *m = CreateGatewayProfil
 func (m *CreateGatewayProfileRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateGatewayProfileRequest) ProtoMessage()    {}
 func (*CreateGatewayProfileRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ns_d187786e40ecf1f8, []int{55}
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{62}
 }
 func (m *CreateGatewayProfileRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateGatewayProfileRequest.Unmarshal(m, b)
@@ -2960,7 +3464,7 @@ func (m *CreateGatewayProfileResponse) Reset()         { *m = CreateGatewayProfi
 func (m *CreateGatewayProfileResponse) String() string { return proto.CompactTextString(m) }
 func (*CreateGatewayProfileResponse) ProtoMessage()    {}
 func (*CreateGatewayProfileResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ns_d187786e40ecf1f8, []int{56}
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{63}
 }
 func (m *CreateGatewayProfileResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateGatewayProfileResponse.Unmarshal(m, b)
@@ -2999,7 +3503,7 @@ func (m *GetGatewayProfileRequest) Reset()         { *m = GetGatewayProfileReque
 func (m *GetGatewayProfileRequest) String() string { return proto.CompactTextString(m) }
 func (*GetGatewayProfileRequest) ProtoMessage()    {}
 func (*GetGatewayProfileRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ns_d187786e40ecf1f8, []int{57}
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{64}
 }
 func (m *GetGatewayProfileRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetGatewayProfileRequest.Unmarshal(m, b)
@@ -3042,7 +3546,7 @@ func (m *GetGatewayProfileResponse) Reset()         { *m = GetGatewayProfileResp
 func (m *GetGatewayProfileResponse) String() string { return proto.CompactTextString(m) }
 func (*GetGatewayProfileResponse) ProtoMessage()    {}
 func (*GetGatewayProfileResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ns_d187786e40ecf1f8, []int{58}
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{65}
 }
 func (m *GetGatewayProfileResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetGatewayProfileResponse.Unmarshal(m, b)
@@ -3095,7 +3599,7 @@ func (m *UpdateGatewayProfileRequest) Reset()         { *m = UpdateGatewayProfil
 func (m *UpdateGatewayProfileRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateGatewayProfileRequest) ProtoMessage()    {}
 func (*UpdateGatewayProfileRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ns_d187786e40ecf1f8, []int{59}
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{66}
 }
 func (m *UpdateGatewayProfileRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UpdateGatewayProfileRequest.Unmarshal(m, b)
@@ -3134,7 +3638,7 @@ func (m *DeleteGatewayProfileRequest) Reset()         { *m = DeleteGatewayProfil
 func (m *DeleteGatewayProfileRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteGatewayProfileRequest) ProtoMessage()    {}
 func (*DeleteGatewayProfileRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ns_d187786e40ecf1f8, []int{60}
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{67}
 }
 func (m *DeleteGatewayProfileRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeleteGatewayProfileRequest.Unmarshal(m, b)
@@ -3161,6 +3665,689 @@ func (m *DeleteGatewayProfileRequest) GetId() []byte {
 	return nil
 }
 
+type MulticastGroup struct {
+	// ID of the multicast-group.
+	Id []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Multicast address.
+	McAddr []byte `protobuf:"bytes,2,opt,name=mc_addr,json=mcAddr,proto3" json:"mc_addr,omitempty"`
+	// Multicast network session key.
+	McNwkSKey []byte `protobuf:"bytes,3,opt,name=mc_nwk_s_key,json=mcNwkSKey,proto3" json:"mc_nwk_s_key,omitempty"`
+	// Frame-counter.
+	FCnt uint32 `protobuf:"varint,4,opt,name=f_cnt,json=fCnt,proto3" json:"f_cnt,omitempty"`
+	// Multicast type.
+	GroupType MulticastGroupType `protobuf:"varint,5,opt,name=group_type,json=groupType,proto3,enum=ns.MulticastGroupType" json:"group_type,omitempty"`
+	// Data-rate.
+	Dr uint32 `protobuf:"varint,6,opt,name=dr,proto3" json:"dr,omitempty"`
+	// Frequency (Hz).
+	Frequency uint32 `protobuf:"varint,7,opt,name=frequency,proto3" json:"frequency,omitempty"`
+	// Ping-slot period (Class-B).
+	PingSlotPeriod       uint32   `protobuf:"varint,8,opt,name=ping_slot_period,json=pingSlotPeriod,proto3" json:"ping_slot_period,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MulticastGroup) Reset()         { *m = MulticastGroup{} }
+func (m *MulticastGroup) String() string { return proto.CompactTextString(m) }
+func (*MulticastGroup) ProtoMessage()    {}
+func (*MulticastGroup) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{68}
+}
+func (m *MulticastGroup) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MulticastGroup.Unmarshal(m, b)
+}
+func (m *MulticastGroup) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MulticastGroup.Marshal(b, m, deterministic)
+}
+func (dst *MulticastGroup) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MulticastGroup.Merge(dst, src)
+}
+func (m *MulticastGroup) XXX_Size() int {
+	return xxx_messageInfo_MulticastGroup.Size(m)
+}
+func (m *MulticastGroup) XXX_DiscardUnknown() {
+	xxx_messageInfo_MulticastGroup.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MulticastGroup proto.InternalMessageInfo
+
+func (m *MulticastGroup) GetId() []byte {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *MulticastGroup) GetMcAddr() []byte {
+	if m != nil {
+		return m.McAddr
+	}
+	return nil
+}
+
+func (m *MulticastGroup) GetMcNwkSKey() []byte {
+	if m != nil {
+		return m.McNwkSKey
+	}
+	return nil
+}
+
+func (m *MulticastGroup) GetFCnt() uint32 {
+	if m != nil {
+		return m.FCnt
+	}
+	return 0
+}
+
+func (m *MulticastGroup) GetGroupType() MulticastGroupType {
+	if m != nil {
+		return m.GroupType
+	}
+	return MulticastGroupType_CLASS_B
+}
+
+func (m *MulticastGroup) GetDr() uint32 {
+	if m != nil {
+		return m.Dr
+	}
+	return 0
+}
+
+func (m *MulticastGroup) GetFrequency() uint32 {
+	if m != nil {
+		return m.Frequency
+	}
+	return 0
+}
+
+func (m *MulticastGroup) GetPingSlotPeriod() uint32 {
+	if m != nil {
+		return m.PingSlotPeriod
+	}
+	return 0
+}
+
+type CreateMulticastGroupRequest struct {
+	// Multicast-group object to create.
+	MulticastGroup       *MulticastGroup `protobuf:"bytes,1,opt,name=multicast_group,json=multicastGroup,proto3" json:"multicast_group,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *CreateMulticastGroupRequest) Reset()         { *m = CreateMulticastGroupRequest{} }
+func (m *CreateMulticastGroupRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateMulticastGroupRequest) ProtoMessage()    {}
+func (*CreateMulticastGroupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{69}
+}
+func (m *CreateMulticastGroupRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateMulticastGroupRequest.Unmarshal(m, b)
+}
+func (m *CreateMulticastGroupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateMulticastGroupRequest.Marshal(b, m, deterministic)
+}
+func (dst *CreateMulticastGroupRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateMulticastGroupRequest.Merge(dst, src)
+}
+func (m *CreateMulticastGroupRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateMulticastGroupRequest.Size(m)
+}
+func (m *CreateMulticastGroupRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateMulticastGroupRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateMulticastGroupRequest proto.InternalMessageInfo
+
+func (m *CreateMulticastGroupRequest) GetMulticastGroup() *MulticastGroup {
+	if m != nil {
+		return m.MulticastGroup
+	}
+	return nil
+}
+
+type CreateMulticastGroupResponse struct {
+	// ID of the multicast-group.
+	Id                   []byte   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateMulticastGroupResponse) Reset()         { *m = CreateMulticastGroupResponse{} }
+func (m *CreateMulticastGroupResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateMulticastGroupResponse) ProtoMessage()    {}
+func (*CreateMulticastGroupResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{70}
+}
+func (m *CreateMulticastGroupResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateMulticastGroupResponse.Unmarshal(m, b)
+}
+func (m *CreateMulticastGroupResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateMulticastGroupResponse.Marshal(b, m, deterministic)
+}
+func (dst *CreateMulticastGroupResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateMulticastGroupResponse.Merge(dst, src)
+}
+func (m *CreateMulticastGroupResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateMulticastGroupResponse.Size(m)
+}
+func (m *CreateMulticastGroupResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateMulticastGroupResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateMulticastGroupResponse proto.InternalMessageInfo
+
+func (m *CreateMulticastGroupResponse) GetId() []byte {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+type GetMulticastGroupRequest struct {
+	// ID of the multicast-group.
+	Id                   []byte   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetMulticastGroupRequest) Reset()         { *m = GetMulticastGroupRequest{} }
+func (m *GetMulticastGroupRequest) String() string { return proto.CompactTextString(m) }
+func (*GetMulticastGroupRequest) ProtoMessage()    {}
+func (*GetMulticastGroupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{71}
+}
+func (m *GetMulticastGroupRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetMulticastGroupRequest.Unmarshal(m, b)
+}
+func (m *GetMulticastGroupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetMulticastGroupRequest.Marshal(b, m, deterministic)
+}
+func (dst *GetMulticastGroupRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetMulticastGroupRequest.Merge(dst, src)
+}
+func (m *GetMulticastGroupRequest) XXX_Size() int {
+	return xxx_messageInfo_GetMulticastGroupRequest.Size(m)
+}
+func (m *GetMulticastGroupRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetMulticastGroupRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetMulticastGroupRequest proto.InternalMessageInfo
+
+func (m *GetMulticastGroupRequest) GetId() []byte {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+type GetMulticastGroupResponse struct {
+	// Multicast-group object.
+	MulticastGroup *MulticastGroup `protobuf:"bytes,1,opt,name=multicast_group,json=multicastGroup,proto3" json:"multicast_group,omitempty"`
+	// Created at timestamp.
+	CreatedAt *timestamp.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Last update timestamp.
+	UpdatedAt            *timestamp.Timestamp `protobuf:"bytes,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *GetMulticastGroupResponse) Reset()         { *m = GetMulticastGroupResponse{} }
+func (m *GetMulticastGroupResponse) String() string { return proto.CompactTextString(m) }
+func (*GetMulticastGroupResponse) ProtoMessage()    {}
+func (*GetMulticastGroupResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{72}
+}
+func (m *GetMulticastGroupResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetMulticastGroupResponse.Unmarshal(m, b)
+}
+func (m *GetMulticastGroupResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetMulticastGroupResponse.Marshal(b, m, deterministic)
+}
+func (dst *GetMulticastGroupResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetMulticastGroupResponse.Merge(dst, src)
+}
+func (m *GetMulticastGroupResponse) XXX_Size() int {
+	return xxx_messageInfo_GetMulticastGroupResponse.Size(m)
+}
+func (m *GetMulticastGroupResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetMulticastGroupResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetMulticastGroupResponse proto.InternalMessageInfo
+
+func (m *GetMulticastGroupResponse) GetMulticastGroup() *MulticastGroup {
+	if m != nil {
+		return m.MulticastGroup
+	}
+	return nil
+}
+
+func (m *GetMulticastGroupResponse) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+func (m *GetMulticastGroupResponse) GetUpdatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return nil
+}
+
+type UpdateMulticastGroupRequest struct {
+	// Multicast-group object to update.
+	MulticastGroup       *MulticastGroup `protobuf:"bytes,1,opt,name=multicast_group,json=multicastGroup,proto3" json:"multicast_group,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *UpdateMulticastGroupRequest) Reset()         { *m = UpdateMulticastGroupRequest{} }
+func (m *UpdateMulticastGroupRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateMulticastGroupRequest) ProtoMessage()    {}
+func (*UpdateMulticastGroupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{73}
+}
+func (m *UpdateMulticastGroupRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateMulticastGroupRequest.Unmarshal(m, b)
+}
+func (m *UpdateMulticastGroupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateMulticastGroupRequest.Marshal(b, m, deterministic)
+}
+func (dst *UpdateMulticastGroupRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateMulticastGroupRequest.Merge(dst, src)
+}
+func (m *UpdateMulticastGroupRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateMulticastGroupRequest.Size(m)
+}
+func (m *UpdateMulticastGroupRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateMulticastGroupRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateMulticastGroupRequest proto.InternalMessageInfo
+
+func (m *UpdateMulticastGroupRequest) GetMulticastGroup() *MulticastGroup {
+	if m != nil {
+		return m.MulticastGroup
+	}
+	return nil
+}
+
+type DeleteMulticastGroupRequest struct {
+	// ID of the multicast-group.
+	Id                   []byte   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteMulticastGroupRequest) Reset()         { *m = DeleteMulticastGroupRequest{} }
+func (m *DeleteMulticastGroupRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteMulticastGroupRequest) ProtoMessage()    {}
+func (*DeleteMulticastGroupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{74}
+}
+func (m *DeleteMulticastGroupRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteMulticastGroupRequest.Unmarshal(m, b)
+}
+func (m *DeleteMulticastGroupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteMulticastGroupRequest.Marshal(b, m, deterministic)
+}
+func (dst *DeleteMulticastGroupRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteMulticastGroupRequest.Merge(dst, src)
+}
+func (m *DeleteMulticastGroupRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteMulticastGroupRequest.Size(m)
+}
+func (m *DeleteMulticastGroupRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteMulticastGroupRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteMulticastGroupRequest proto.InternalMessageInfo
+
+func (m *DeleteMulticastGroupRequest) GetId() []byte {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+type AddDeviceToMulticastGroupRequest struct {
+	// DevEUI of the device.
+	DevEui []byte `protobuf:"bytes,1,opt,name=dev_eui,json=devEui,proto3" json:"dev_eui,omitempty"`
+	// Multicast-group ID.
+	MulticastGroupId     []byte   `protobuf:"bytes,2,opt,name=multicast_group_id,json=multicastGroupId,proto3" json:"multicast_group_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AddDeviceToMulticastGroupRequest) Reset()         { *m = AddDeviceToMulticastGroupRequest{} }
+func (m *AddDeviceToMulticastGroupRequest) String() string { return proto.CompactTextString(m) }
+func (*AddDeviceToMulticastGroupRequest) ProtoMessage()    {}
+func (*AddDeviceToMulticastGroupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{75}
+}
+func (m *AddDeviceToMulticastGroupRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddDeviceToMulticastGroupRequest.Unmarshal(m, b)
+}
+func (m *AddDeviceToMulticastGroupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddDeviceToMulticastGroupRequest.Marshal(b, m, deterministic)
+}
+func (dst *AddDeviceToMulticastGroupRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddDeviceToMulticastGroupRequest.Merge(dst, src)
+}
+func (m *AddDeviceToMulticastGroupRequest) XXX_Size() int {
+	return xxx_messageInfo_AddDeviceToMulticastGroupRequest.Size(m)
+}
+func (m *AddDeviceToMulticastGroupRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddDeviceToMulticastGroupRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddDeviceToMulticastGroupRequest proto.InternalMessageInfo
+
+func (m *AddDeviceToMulticastGroupRequest) GetDevEui() []byte {
+	if m != nil {
+		return m.DevEui
+	}
+	return nil
+}
+
+func (m *AddDeviceToMulticastGroupRequest) GetMulticastGroupId() []byte {
+	if m != nil {
+		return m.MulticastGroupId
+	}
+	return nil
+}
+
+type RemoveDeviceFromMulticastGroupRequest struct {
+	// DevEUI of the device.
+	DevEui []byte `protobuf:"bytes,1,opt,name=dev_eui,json=devEui,proto3" json:"dev_eui,omitempty"`
+	// Multicast-group ID.
+	MulticastGroupId     []byte   `protobuf:"bytes,2,opt,name=multicast_group_id,json=multicastGroupId,proto3" json:"multicast_group_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RemoveDeviceFromMulticastGroupRequest) Reset()         { *m = RemoveDeviceFromMulticastGroupRequest{} }
+func (m *RemoveDeviceFromMulticastGroupRequest) String() string { return proto.CompactTextString(m) }
+func (*RemoveDeviceFromMulticastGroupRequest) ProtoMessage()    {}
+func (*RemoveDeviceFromMulticastGroupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{76}
+}
+func (m *RemoveDeviceFromMulticastGroupRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemoveDeviceFromMulticastGroupRequest.Unmarshal(m, b)
+}
+func (m *RemoveDeviceFromMulticastGroupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemoveDeviceFromMulticastGroupRequest.Marshal(b, m, deterministic)
+}
+func (dst *RemoveDeviceFromMulticastGroupRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoveDeviceFromMulticastGroupRequest.Merge(dst, src)
+}
+func (m *RemoveDeviceFromMulticastGroupRequest) XXX_Size() int {
+	return xxx_messageInfo_RemoveDeviceFromMulticastGroupRequest.Size(m)
+}
+func (m *RemoveDeviceFromMulticastGroupRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoveDeviceFromMulticastGroupRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RemoveDeviceFromMulticastGroupRequest proto.InternalMessageInfo
+
+func (m *RemoveDeviceFromMulticastGroupRequest) GetDevEui() []byte {
+	if m != nil {
+		return m.DevEui
+	}
+	return nil
+}
+
+func (m *RemoveDeviceFromMulticastGroupRequest) GetMulticastGroupId() []byte {
+	if m != nil {
+		return m.MulticastGroupId
+	}
+	return nil
+}
+
+type MulticastQueueItem struct {
+	// Multicast-group ID.
+	MulticastGroupId []byte `protobuf:"bytes,1,opt,name=multicast_group_id,json=multicastGroupId,proto3" json:"multicast_group_id,omitempty"`
+	// The encrypted FRMPayload bytes.
+	FrmPayload []byte `protobuf:"bytes,2,opt,name=frm_payload,json=frmPayload,proto3" json:"frm_payload,omitempty"`
+	// The FCnt of the payload.
+	FCnt uint32 `protobuf:"varint,3,opt,name=f_cnt,json=fCnt,proto3" json:"f_cnt,omitempty"`
+	// The FPort of the payload.
+	FPort                uint32   `protobuf:"varint,4,opt,name=f_port,json=fPort,proto3" json:"f_port,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MulticastQueueItem) Reset()         { *m = MulticastQueueItem{} }
+func (m *MulticastQueueItem) String() string { return proto.CompactTextString(m) }
+func (*MulticastQueueItem) ProtoMessage()    {}
+func (*MulticastQueueItem) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{77}
+}
+func (m *MulticastQueueItem) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MulticastQueueItem.Unmarshal(m, b)
+}
+func (m *MulticastQueueItem) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MulticastQueueItem.Marshal(b, m, deterministic)
+}
+func (dst *MulticastQueueItem) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MulticastQueueItem.Merge(dst, src)
+}
+func (m *MulticastQueueItem) XXX_Size() int {
+	return xxx_messageInfo_MulticastQueueItem.Size(m)
+}
+func (m *MulticastQueueItem) XXX_DiscardUnknown() {
+	xxx_messageInfo_MulticastQueueItem.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MulticastQueueItem proto.InternalMessageInfo
+
+func (m *MulticastQueueItem) GetMulticastGroupId() []byte {
+	if m != nil {
+		return m.MulticastGroupId
+	}
+	return nil
+}
+
+func (m *MulticastQueueItem) GetFrmPayload() []byte {
+	if m != nil {
+		return m.FrmPayload
+	}
+	return nil
+}
+
+func (m *MulticastQueueItem) GetFCnt() uint32 {
+	if m != nil {
+		return m.FCnt
+	}
+	return 0
+}
+
+func (m *MulticastQueueItem) GetFPort() uint32 {
+	if m != nil {
+		return m.FPort
+	}
+	return 0
+}
+
+type EnqueueMulticastQueueItemRequest struct {
+	// Multicast queue-item object to enqueue.
+	MulticastQueueItem   *MulticastQueueItem `protobuf:"bytes,1,opt,name=multicast_queue_item,json=multicastQueueItem,proto3" json:"multicast_queue_item,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *EnqueueMulticastQueueItemRequest) Reset()         { *m = EnqueueMulticastQueueItemRequest{} }
+func (m *EnqueueMulticastQueueItemRequest) String() string { return proto.CompactTextString(m) }
+func (*EnqueueMulticastQueueItemRequest) ProtoMessage()    {}
+func (*EnqueueMulticastQueueItemRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{78}
+}
+func (m *EnqueueMulticastQueueItemRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EnqueueMulticastQueueItemRequest.Unmarshal(m, b)
+}
+func (m *EnqueueMulticastQueueItemRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EnqueueMulticastQueueItemRequest.Marshal(b, m, deterministic)
+}
+func (dst *EnqueueMulticastQueueItemRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EnqueueMulticastQueueItemRequest.Merge(dst, src)
+}
+func (m *EnqueueMulticastQueueItemRequest) XXX_Size() int {
+	return xxx_messageInfo_EnqueueMulticastQueueItemRequest.Size(m)
+}
+func (m *EnqueueMulticastQueueItemRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_EnqueueMulticastQueueItemRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EnqueueMulticastQueueItemRequest proto.InternalMessageInfo
+
+func (m *EnqueueMulticastQueueItemRequest) GetMulticastQueueItem() *MulticastQueueItem {
+	if m != nil {
+		return m.MulticastQueueItem
+	}
+	return nil
+}
+
+type FlushMulticastQueueForMulticastGroupRequest struct {
+	// Multicast-group ID.
+	MulticastGroupId     []byte   `protobuf:"bytes,1,opt,name=multicast_group_id,json=multicastGroupId,proto3" json:"multicast_group_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FlushMulticastQueueForMulticastGroupRequest) Reset() {
+	*m = FlushMulticastQueueForMulticastGroupRequest{}
+}
+func (m *FlushMulticastQueueForMulticastGroupRequest) String() string {
+	return proto.CompactTextString(m)
+}
+func (*FlushMulticastQueueForMulticastGroupRequest) ProtoMessage() {}
+func (*FlushMulticastQueueForMulticastGroupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{79}
+}
+func (m *FlushMulticastQueueForMulticastGroupRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FlushMulticastQueueForMulticastGroupRequest.Unmarshal(m, b)
+}
+func (m *FlushMulticastQueueForMulticastGroupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FlushMulticastQueueForMulticastGroupRequest.Marshal(b, m, deterministic)
+}
+func (dst *FlushMulticastQueueForMulticastGroupRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FlushMulticastQueueForMulticastGroupRequest.Merge(dst, src)
+}
+func (m *FlushMulticastQueueForMulticastGroupRequest) XXX_Size() int {
+	return xxx_messageInfo_FlushMulticastQueueForMulticastGroupRequest.Size(m)
+}
+func (m *FlushMulticastQueueForMulticastGroupRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FlushMulticastQueueForMulticastGroupRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FlushMulticastQueueForMulticastGroupRequest proto.InternalMessageInfo
+
+func (m *FlushMulticastQueueForMulticastGroupRequest) GetMulticastGroupId() []byte {
+	if m != nil {
+		return m.MulticastGroupId
+	}
+	return nil
+}
+
+type GetMulticastQueueItemsForMulticastGroupRequest struct {
+	// Multicast-group ID.
+	MulticastGroupId     []byte   `protobuf:"bytes,1,opt,name=multicast_group_id,json=multicastGroupId,proto3" json:"multicast_group_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetMulticastQueueItemsForMulticastGroupRequest) Reset() {
+	*m = GetMulticastQueueItemsForMulticastGroupRequest{}
+}
+func (m *GetMulticastQueueItemsForMulticastGroupRequest) String() string {
+	return proto.CompactTextString(m)
+}
+func (*GetMulticastQueueItemsForMulticastGroupRequest) ProtoMessage() {}
+func (*GetMulticastQueueItemsForMulticastGroupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{80}
+}
+func (m *GetMulticastQueueItemsForMulticastGroupRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetMulticastQueueItemsForMulticastGroupRequest.Unmarshal(m, b)
+}
+func (m *GetMulticastQueueItemsForMulticastGroupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetMulticastQueueItemsForMulticastGroupRequest.Marshal(b, m, deterministic)
+}
+func (dst *GetMulticastQueueItemsForMulticastGroupRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetMulticastQueueItemsForMulticastGroupRequest.Merge(dst, src)
+}
+func (m *GetMulticastQueueItemsForMulticastGroupRequest) XXX_Size() int {
+	return xxx_messageInfo_GetMulticastQueueItemsForMulticastGroupRequest.Size(m)
+}
+func (m *GetMulticastQueueItemsForMulticastGroupRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetMulticastQueueItemsForMulticastGroupRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetMulticastQueueItemsForMulticastGroupRequest proto.InternalMessageInfo
+
+func (m *GetMulticastQueueItemsForMulticastGroupRequest) GetMulticastGroupId() []byte {
+	if m != nil {
+		return m.MulticastGroupId
+	}
+	return nil
+}
+
+type GetMulticastQueueItemsForMulticastGroupResponse struct {
+	MulticastQueueItems  []*MulticastQueueItem `protobuf:"bytes,1,rep,name=multicast_queue_items,json=multicastQueueItems,proto3" json:"multicast_queue_items,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *GetMulticastQueueItemsForMulticastGroupResponse) Reset() {
+	*m = GetMulticastQueueItemsForMulticastGroupResponse{}
+}
+func (m *GetMulticastQueueItemsForMulticastGroupResponse) String() string {
+	return proto.CompactTextString(m)
+}
+func (*GetMulticastQueueItemsForMulticastGroupResponse) ProtoMessage() {}
+func (*GetMulticastQueueItemsForMulticastGroupResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ns_fcf95a0cc2fb7a5f, []int{81}
+}
+func (m *GetMulticastQueueItemsForMulticastGroupResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetMulticastQueueItemsForMulticastGroupResponse.Unmarshal(m, b)
+}
+func (m *GetMulticastQueueItemsForMulticastGroupResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetMulticastQueueItemsForMulticastGroupResponse.Marshal(b, m, deterministic)
+}
+func (dst *GetMulticastQueueItemsForMulticastGroupResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetMulticastQueueItemsForMulticastGroupResponse.Merge(dst, src)
+}
+func (m *GetMulticastQueueItemsForMulticastGroupResponse) XXX_Size() int {
+	return xxx_messageInfo_GetMulticastQueueItemsForMulticastGroupResponse.Size(m)
+}
+func (m *GetMulticastQueueItemsForMulticastGroupResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetMulticastQueueItemsForMulticastGroupResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetMulticastQueueItemsForMulticastGroupResponse proto.InternalMessageInfo
+
+func (m *GetMulticastQueueItemsForMulticastGroupResponse) GetMulticastQueueItems() []*MulticastQueueItem {
+	if m != nil {
+		return m.MulticastQueueItems
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*CreateServiceProfileRequest)(nil), "ns.CreateServiceProfileRequest")
 	proto.RegisterType((*CreateServiceProfileResponse)(nil), "ns.CreateServiceProfileResponse")
@@ -3191,8 +4378,13 @@ func init() {
 	proto.RegisterType((*DeactivateDeviceRequest)(nil), "ns.DeactivateDeviceRequest")
 	proto.RegisterType((*GetDeviceActivationRequest)(nil), "ns.GetDeviceActivationRequest")
 	proto.RegisterType((*GetDeviceActivationResponse)(nil), "ns.GetDeviceActivationResponse")
+	proto.RegisterType((*ForceRejoinOutcome)(nil), "ns.ForceRejoinOutcome")
+	proto.RegisterType((*GetGatewayDiversityStatsForDevEUIRequest)(nil), "ns.GetGatewayDiversityStatsForDevEUIRequest")
+	proto.RegisterType((*GetGatewayDiversityStatsForDevEUIResponse)(nil), "ns.GetGatewayDiversityStatsForDevEUIResponse")
 	proto.RegisterType((*GetRandomDevAddrResponse)(nil), "ns.GetRandomDevAddrResponse")
 	proto.RegisterType((*CreateMACCommandQueueItemRequest)(nil), "ns.CreateMACCommandQueueItemRequest")
+	proto.RegisterType((*ForceDutyCycleReconfigurationRequest)(nil), "ns.ForceDutyCycleReconfigurationRequest")
+	proto.RegisterType((*ForceRejoinRequest)(nil), "ns.ForceRejoinRequest")
 	proto.RegisterType((*SendProprietaryPayloadRequest)(nil), "ns.SendProprietaryPayloadRequest")
 	proto.RegisterType((*Gateway)(nil), "ns.Gateway")
 	proto.RegisterType((*CreateGatewayRequest)(nil), "ns.CreateGatewayRequest")
@@ -3202,10 +4394,12 @@ func init() {
 	proto.RegisterType((*DeleteGatewayRequest)(nil), "ns.DeleteGatewayRequest")
 	proto.RegisterType((*GatewayStats)(nil), "ns.GatewayStats")
 	proto.RegisterType((*GetGatewayStatsRequest)(nil), "ns.GetGatewayStatsRequest")
+	proto.RegisterType((*GatewayDutyCycleUsage)(nil), "ns.GatewayDutyCycleUsage")
 	proto.RegisterType((*GetGatewayStatsResponse)(nil), "ns.GetGatewayStatsResponse")
 	proto.RegisterType((*DeviceQueueItem)(nil), "ns.DeviceQueueItem")
 	proto.RegisterType((*CreateDeviceQueueItemRequest)(nil), "ns.CreateDeviceQueueItemRequest")
 	proto.RegisterType((*FlushDeviceQueueForDevEUIRequest)(nil), "ns.FlushDeviceQueueForDevEUIRequest")
+	proto.RegisterType((*DeleteDeviceQueueItemRequest)(nil), "ns.DeleteDeviceQueueItemRequest")
 	proto.RegisterType((*GetDeviceQueueItemsForDevEUIRequest)(nil), "ns.GetDeviceQueueItemsForDevEUIRequest")
 	proto.RegisterType((*GetDeviceQueueItemsForDevEUIResponse)(nil), "ns.GetDeviceQueueItemsForDevEUIResponse")
 	proto.RegisterType((*GetNextDownlinkFCntForDevEUIRequest)(nil), "ns.GetNextDownlinkFCntForDevEUIRequest")
@@ -3223,8 +4417,23 @@ func init() {
 	proto.RegisterType((*GetGatewayProfileResponse)(nil), "ns.GetGatewayProfileResponse")
 	proto.RegisterType((*UpdateGatewayProfileRequest)(nil), "ns.UpdateGatewayProfileRequest")
 	proto.RegisterType((*DeleteGatewayProfileRequest)(nil), "ns.DeleteGatewayProfileRequest")
+	proto.RegisterType((*MulticastGroup)(nil), "ns.MulticastGroup")
+	proto.RegisterType((*CreateMulticastGroupRequest)(nil), "ns.CreateMulticastGroupRequest")
+	proto.RegisterType((*CreateMulticastGroupResponse)(nil), "ns.CreateMulticastGroupResponse")
+	proto.RegisterType((*GetMulticastGroupRequest)(nil), "ns.GetMulticastGroupRequest")
+	proto.RegisterType((*GetMulticastGroupResponse)(nil), "ns.GetMulticastGroupResponse")
+	proto.RegisterType((*UpdateMulticastGroupRequest)(nil), "ns.UpdateMulticastGroupRequest")
+	proto.RegisterType((*DeleteMulticastGroupRequest)(nil), "ns.DeleteMulticastGroupRequest")
+	proto.RegisterType((*AddDeviceToMulticastGroupRequest)(nil), "ns.AddDeviceToMulticastGroupRequest")
+	proto.RegisterType((*RemoveDeviceFromMulticastGroupRequest)(nil), "ns.RemoveDeviceFromMulticastGroupRequest")
+	proto.RegisterType((*MulticastQueueItem)(nil), "ns.MulticastQueueItem")
+	proto.RegisterType((*EnqueueMulticastQueueItemRequest)(nil), "ns.EnqueueMulticastQueueItemRequest")
+	proto.RegisterType((*FlushMulticastQueueForMulticastGroupRequest)(nil), "ns.FlushMulticastQueueForMulticastGroupRequest")
+	proto.RegisterType((*GetMulticastQueueItemsForMulticastGroupRequest)(nil), "ns.GetMulticastQueueItemsForMulticastGroupRequest")
+	proto.RegisterType((*GetMulticastQueueItemsForMulticastGroupResponse)(nil), "ns.GetMulticastQueueItemsForMulticastGroupResponse")
 	proto.RegisterEnum("ns.RXWindow", RXWindow_name, RXWindow_value)
 	proto.RegisterEnum("ns.AggregationInterval", AggregationInterval_name, AggregationInterval_value)
+	proto.RegisterEnum("ns.MulticastGroupType", MulticastGroupType_name, MulticastGroupType_value)
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -3277,12 +4486,17 @@ type NetworkServerServiceClient interface {
 	DeactivateDevice(ctx context.Context, in *DeactivateDeviceRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 	// GetDeviceActivation returns the device activation details.
 	GetDeviceActivation(ctx context.Context, in *GetDeviceActivationRequest, opts ...grpc.CallOption) (*GetDeviceActivationResponse, error)
+	// GetGatewayDiversityStatsForDevEUI returns the gateway diversity statistics of the given device.
+	GetGatewayDiversityStatsForDevEUI(ctx context.Context, in *GetGatewayDiversityStatsForDevEUIRequest, opts ...grpc.CallOption) (*GetGatewayDiversityStatsForDevEUIResponse, error)
 	// CreateDeviceQueueItem creates the given device-queue item.
 	CreateDeviceQueueItem(ctx context.Context, in *CreateDeviceQueueItemRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 	// FlushDeviceQueueForDevEUI flushes the device-queue for the given DevEUI.
 	FlushDeviceQueueForDevEUI(ctx context.Context, in *FlushDeviceQueueForDevEUIRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 	// GetDeviceQueueItemsForDevEUI returns all device-queue items for the given DevEUI.
 	GetDeviceQueueItemsForDevEUI(ctx context.Context, in *GetDeviceQueueItemsForDevEUIRequest, opts ...grpc.CallOption) (*GetDeviceQueueItemsForDevEUIResponse, error)
+	// DeleteDeviceQueueItem deletes a single device-queue item, identified
+	// by its ID or frame-counter.
+	DeleteDeviceQueueItem(ctx context.Context, in *DeleteDeviceQueueItemRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 	// GetNextDownlinkFCntForDevEUI returns the next FCnt that must be used.
 	// This also takes device-queue items for the given DevEUI into consideration.
 	GetNextDownlinkFCntForDevEUI(ctx context.Context, in *GetNextDownlinkFCntForDevEUIRequest, opts ...grpc.CallOption) (*GetNextDownlinkFCntForDevEUIResponse, error)
@@ -3290,6 +4504,13 @@ type NetworkServerServiceClient interface {
 	GetRandomDevAddr(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*GetRandomDevAddrResponse, error)
 	// CreateMACCommandQueueItem adds the downlink mac-command to the queue.
 	CreateMACCommandQueueItem(ctx context.Context, in *CreateMACCommandQueueItemRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// ForceDutyCycleReconfiguration adds a DutyCycleReq mac-command, using
+	// the max duty-cycle of the device-profile, to the queue of the device.
+	ForceDutyCycleReconfiguration(ctx context.Context, in *ForceDutyCycleReconfigurationRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// ForceRejoin adds a ForceRejoinReq mac-command to the queue of the
+	// device (LoRaWAN 1.1+ only). The device will respond with a
+	// rejoin-request of the requested type.
+	ForceRejoin(ctx context.Context, in *ForceRejoinRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 	// SendProprietaryPayload send a payload using the 'Proprietary' LoRaWAN message-type.
 	SendProprietaryPayload(ctx context.Context, in *SendProprietaryPayloadRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 	// CreateGateway creates the given gateway.
@@ -3316,6 +4537,24 @@ type NetworkServerServiceClient interface {
 	StreamFrameLogsForDevice(ctx context.Context, in *StreamFrameLogsForDeviceRequest, opts ...grpc.CallOption) (NetworkServerService_StreamFrameLogsForDeviceClient, error)
 	// GetVersion returns the LoRa Server version.
 	GetVersion(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*GetVersionResponse, error)
+	// CreateMulticastGroup creates the given multicast-group.
+	CreateMulticastGroup(ctx context.Context, in *CreateMulticastGroupRequest, opts ...grpc.CallOption) (*CreateMulticastGroupResponse, error)
+	// GetMulticastGroup returns the multicast-group given an id.
+	GetMulticastGroup(ctx context.Context, in *GetMulticastGroupRequest, opts ...grpc.CallOption) (*GetMulticastGroupResponse, error)
+	// UpdateMulticastGroup updates the given multicast-group.
+	UpdateMulticastGroup(ctx context.Context, in *UpdateMulticastGroupRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// DeleteMulticastGroup deletes a multicast-group given an id.
+	DeleteMulticastGroup(ctx context.Context, in *DeleteMulticastGroupRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// AddDeviceToMulticastGroup adds the given device to the given multicast-group.
+	AddDeviceToMulticastGroup(ctx context.Context, in *AddDeviceToMulticastGroupRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// RemoveDeviceFromMulticastGroup removes the given device from the given multicast-group.
+	RemoveDeviceFromMulticastGroup(ctx context.Context, in *RemoveDeviceFromMulticastGroupRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// EnqueueMulticastQueueItem creates the given multicast queue-item.
+	EnqueueMulticastQueueItem(ctx context.Context, in *EnqueueMulticastQueueItemRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// FlushMulticastQueueForMulticastGroup flushes the multicast device-queue given a multicast-group id.
+	FlushMulticastQueueForMulticastGroup(ctx context.Context, in *FlushMulticastQueueForMulticastGroupRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// GetMulticastQueueItemsForMulticastGroup returns the queue-items given a multicast-group id.
+	GetMulticastQueueItemsForMulticastGroup(ctx context.Context, in *GetMulticastQueueItemsForMulticastGroupRequest, opts ...grpc.CallOption) (*GetMulticastQueueItemsForMulticastGroupResponse, error)
 }
 
 type networkServerServiceClient struct {
@@ -3497,6 +4736,15 @@ func (c *networkServerServiceClient) GetDeviceActivation(ctx context.Context, in
 	return out, nil
 }
 
+func (c *networkServerServiceClient) GetGatewayDiversityStatsForDevEUI(ctx context.Context, in *GetGatewayDiversityStatsForDevEUIRequest, opts ...grpc.CallOption) (*GetGatewayDiversityStatsForDevEUIResponse, error) {
+	out := new(GetGatewayDiversityStatsForDevEUIResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetGatewayDiversityStatsForDevEUI", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *networkServerServiceClient) CreateDeviceQueueItem(ctx context.Context, in *CreateDeviceQueueItemRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
 	out := new(empty.Empty)
 	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/CreateDeviceQueueItem", in, out, opts...)
@@ -3524,6 +4772,15 @@ func (c *networkServerServiceClient) GetDeviceQueueItemsForDevEUI(ctx context.Co
 	return out, nil
 }
 
+func (c *networkServerServiceClient) DeleteDeviceQueueItem(ctx context.Context, in *DeleteDeviceQueueItemRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/DeleteDeviceQueueItem", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *networkServerServiceClient) GetNextDownlinkFCntForDevEUI(ctx context.Context, in *GetNextDownlinkFCntForDevEUIRequest, opts ...grpc.CallOption) (*GetNextDownlinkFCntForDevEUIResponse, error) {
 	out := new(GetNextDownlinkFCntForDevEUIResponse)
 	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetNextDownlinkFCntForDevEUI", in, out, opts...)
@@ -3544,7 +4801,25 @@ func (c *networkServerServiceClient) GetRandomDevAddr(ctx context.Context, in *e
 
 func (c *networkServerServiceClient) CreateMACCommandQueueItem(ctx context.Context, in *CreateMACCommandQueueItemRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
 	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/CreateMACCommandQueueItem", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/CreateMACCommandQueueItem", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) ForceDutyCycleReconfiguration(ctx context.Context, in *ForceDutyCycleReconfigurationRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/ForceDutyCycleReconfiguration", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) ForceRejoin(ctx context.Context, in *ForceRejoinRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/ForceRejoin", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -3714,6 +4989,87 @@ func (c *networkServerServiceClient) GetVersion(ctx context.Context, in *empty.E
 	return out, nil
 }
 
+func (c *networkServerServiceClient) CreateMulticastGroup(ctx context.Context, in *CreateMulticastGroupRequest, opts ...grpc.CallOption) (*CreateMulticastGroupResponse, error) {
+	out := new(CreateMulticastGroupResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/CreateMulticastGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) GetMulticastGroup(ctx context.Context, in *GetMulticastGroupRequest, opts ...grpc.CallOption) (*GetMulticastGroupResponse, error) {
+	out := new(GetMulticastGroupResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetMulticastGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) UpdateMulticastGroup(ctx context.Context, in *UpdateMulticastGroupRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/UpdateMulticastGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) DeleteMulticastGroup(ctx context.Context, in *DeleteMulticastGroupRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/DeleteMulticastGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) AddDeviceToMulticastGroup(ctx context.Context, in *AddDeviceToMulticastGroupRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/AddDeviceToMulticastGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) RemoveDeviceFromMulticastGroup(ctx context.Context, in *RemoveDeviceFromMulticastGroupRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/RemoveDeviceFromMulticastGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) EnqueueMulticastQueueItem(ctx context.Context, in *EnqueueMulticastQueueItemRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/EnqueueMulticastQueueItem", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) FlushMulticastQueueForMulticastGroup(ctx context.Context, in *FlushMulticastQueueForMulticastGroupRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/FlushMulticastQueueForMulticastGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) GetMulticastQueueItemsForMulticastGroup(ctx context.Context, in *GetMulticastQueueItemsForMulticastGroupRequest, opts ...grpc.CallOption) (*GetMulticastQueueItemsForMulticastGroupResponse, error) {
+	out := new(GetMulticastQueueItemsForMulticastGroupResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetMulticastQueueItemsForMulticastGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // NetworkServerServiceServer is the server API for NetworkServerService service.
 type NetworkServerServiceServer interface {
 	// CreateServiceProfile creates the given service-profile.
@@ -3754,12 +5110,17 @@ type NetworkServerServiceServer interface {
 	DeactivateDevice(context.Context, *DeactivateDeviceRequest) (*empty.Empty, error)
 	// GetDeviceActivation returns the device activation details.
 	GetDeviceActivation(context.Context, *GetDeviceActivationRequest) (*GetDeviceActivationResponse, error)
+	// GetGatewayDiversityStatsForDevEUI returns the gateway diversity statistics of the given device.
+	GetGatewayDiversityStatsForDevEUI(context.Context, *GetGatewayDiversityStatsForDevEUIRequest) (*GetGatewayDiversityStatsForDevEUIResponse, error)
 	// CreateDeviceQueueItem creates the given device-queue item.
 	CreateDeviceQueueItem(context.Context, *CreateDeviceQueueItemRequest) (*empty.Empty, error)
 	// FlushDeviceQueueForDevEUI flushes the device-queue for the given DevEUI.
 	FlushDeviceQueueForDevEUI(context.Context, *FlushDeviceQueueForDevEUIRequest) (*empty.Empty, error)
 	// GetDeviceQueueItemsForDevEUI returns all device-queue items for the given DevEUI.
 	GetDeviceQueueItemsForDevEUI(context.Context, *GetDeviceQueueItemsForDevEUIRequest) (*GetDeviceQueueItemsForDevEUIResponse, error)
+	// DeleteDeviceQueueItem deletes a single device-queue item, identified
+	// by its ID or frame-counter.
+	DeleteDeviceQueueItem(context.Context, *DeleteDeviceQueueItemRequest) (*empty.Empty, error)
 	// GetNextDownlinkFCntForDevEUI returns the next FCnt that must be used.
 	// This also takes device-queue items for the given DevEUI into consideration.
 	GetNextDownlinkFCntForDevEUI(context.Context, *GetNextDownlinkFCntForDevEUIRequest) (*GetNextDownlinkFCntForDevEUIResponse, error)
@@ -3767,6 +5128,13 @@ type NetworkServerServiceServer interface {
 	GetRandomDevAddr(context.Context, *empty.Empty) (*GetRandomDevAddrResponse, error)
 	// CreateMACCommandQueueItem adds the downlink mac-command to the queue.
 	CreateMACCommandQueueItem(context.Context, *CreateMACCommandQueueItemRequest) (*empty.Empty, error)
+	// ForceDutyCycleReconfiguration adds a DutyCycleReq mac-command, using
+	// the max duty-cycle of the device-profile, to the queue of the device.
+	ForceDutyCycleReconfiguration(context.Context, *ForceDutyCycleReconfigurationRequest) (*empty.Empty, error)
+	// ForceRejoin adds a ForceRejoinReq mac-command to the queue of the
+	// device (LoRaWAN 1.1+ only). The device will respond with a
+	// rejoin-request of the requested type.
+	ForceRejoin(context.Context, *ForceRejoinRequest) (*empty.Empty, error)
 	// SendProprietaryPayload send a payload using the 'Proprietary' LoRaWAN message-type.
 	SendProprietaryPayload(context.Context, *SendProprietaryPayloadRequest) (*empty.Empty, error)
 	// CreateGateway creates the given gateway.
@@ -3793,6 +5161,24 @@ type NetworkServerServiceServer interface {
 	StreamFrameLogsForDevice(*StreamFrameLogsForDeviceRequest, NetworkServerService_StreamFrameLogsForDeviceServer) error
 	// GetVersion returns the LoRa Server version.
 	GetVersion(context.Context, *empty.Empty) (*GetVersionResponse, error)
+	// CreateMulticastGroup creates the given multicast-group.
+	CreateMulticastGroup(context.Context, *CreateMulticastGroupRequest) (*CreateMulticastGroupResponse, error)
+	// GetMulticastGroup returns the multicast-group given an id.
+	GetMulticastGroup(context.Context, *GetMulticastGroupRequest) (*GetMulticastGroupResponse, error)
+	// UpdateMulticastGroup updates the given multicast-group.
+	UpdateMulticastGroup(context.Context, *UpdateMulticastGroupRequest) (*empty.Empty, error)
+	// DeleteMulticastGroup deletes a multicast-group given an id.
+	DeleteMulticastGroup(context.Context, *DeleteMulticastGroupRequest) (*empty.Empty, error)
+	// AddDeviceToMulticastGroup adds the given device to the given multicast-group.
+	AddDeviceToMulticastGroup(context.Context, *AddDeviceToMulticastGroupRequest) (*empty.Empty, error)
+	// RemoveDeviceFromMulticastGroup removes the given device from the given multicast-group.
+	RemoveDeviceFromMulticastGroup(context.Context, *RemoveDeviceFromMulticastGroupRequest) (*empty.Empty, error)
+	// EnqueueMulticastQueueItem creates the given multicast queue-item.
+	EnqueueMulticastQueueItem(context.Context, *EnqueueMulticastQueueItemRequest) (*empty.Empty, error)
+	// FlushMulticastQueueForMulticastGroup flushes the multicast device-queue given a multicast-group id.
+	FlushMulticastQueueForMulticastGroup(context.Context, *FlushMulticastQueueForMulticastGroupRequest) (*empty.Empty, error)
+	// GetMulticastQueueItemsForMulticastGroup returns the queue-items given a multicast-group id.
+	GetMulticastQueueItemsForMulticastGroup(context.Context, *GetMulticastQueueItemsForMulticastGroupRequest) (*GetMulticastQueueItemsForMulticastGroupResponse, error)
 }
 
 func RegisterNetworkServerServiceServer(s *grpc.Server, srv NetworkServerServiceServer) {
@@ -4141,6 +5527,24 @@ func _NetworkServerService_GetDeviceActivation_Handler(srv interface{}, ctx cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NetworkServerService_GetGatewayDiversityStatsForDevEUI_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGatewayDiversityStatsForDevEUIRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).GetGatewayDiversityStatsForDevEUI(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/GetGatewayDiversityStatsForDevEUI",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).GetGatewayDiversityStatsForDevEUI(ctx, req.(*GetGatewayDiversityStatsForDevEUIRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _NetworkServerService_CreateDeviceQueueItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateDeviceQueueItemRequest)
 	if err := dec(in); err != nil {
@@ -4195,6 +5599,24 @@ func _NetworkServerService_GetDeviceQueueItemsForDevEUI_Handler(srv interface{},
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NetworkServerService_DeleteDeviceQueueItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteDeviceQueueItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).DeleteDeviceQueueItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/DeleteDeviceQueueItem",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).DeleteDeviceQueueItem(ctx, req.(*DeleteDeviceQueueItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _NetworkServerService_GetNextDownlinkFCntForDevEUI_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetNextDownlinkFCntForDevEUIRequest)
 	if err := dec(in); err != nil {
@@ -4249,6 +5671,42 @@ func _NetworkServerService_CreateMACCommandQueueItem_Handler(srv interface{}, ct
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NetworkServerService_ForceDutyCycleReconfiguration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ForceDutyCycleReconfigurationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).ForceDutyCycleReconfiguration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/ForceDutyCycleReconfiguration",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).ForceDutyCycleReconfiguration(ctx, req.(*ForceDutyCycleReconfigurationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServerService_ForceRejoin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ForceRejoinRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).ForceRejoin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/ForceRejoin",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).ForceRejoin(ctx, req.(*ForceRejoinRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _NetworkServerService_SendProprietaryPayload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SendProprietaryPayloadRequest)
 	if err := dec(in); err != nil {
@@ -4489,6 +5947,168 @@ func _NetworkServerService_GetVersion_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NetworkServerService_CreateMulticastGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateMulticastGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).CreateMulticastGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/CreateMulticastGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).CreateMulticastGroup(ctx, req.(*CreateMulticastGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServerService_GetMulticastGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMulticastGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).GetMulticastGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/GetMulticastGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).GetMulticastGroup(ctx, req.(*GetMulticastGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServerService_UpdateMulticastGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateMulticastGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).UpdateMulticastGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/UpdateMulticastGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).UpdateMulticastGroup(ctx, req.(*UpdateMulticastGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServerService_DeleteMulticastGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteMulticastGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).DeleteMulticastGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/DeleteMulticastGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).DeleteMulticastGroup(ctx, req.(*DeleteMulticastGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServerService_AddDeviceToMulticastGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddDeviceToMulticastGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).AddDeviceToMulticastGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/AddDeviceToMulticastGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).AddDeviceToMulticastGroup(ctx, req.(*AddDeviceToMulticastGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServerService_RemoveDeviceFromMulticastGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveDeviceFromMulticastGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).RemoveDeviceFromMulticastGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/RemoveDeviceFromMulticastGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).RemoveDeviceFromMulticastGroup(ctx, req.(*RemoveDeviceFromMulticastGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServerService_EnqueueMulticastQueueItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnqueueMulticastQueueItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).EnqueueMulticastQueueItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/EnqueueMulticastQueueItem",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).EnqueueMulticastQueueItem(ctx, req.(*EnqueueMulticastQueueItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServerService_FlushMulticastQueueForMulticastGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FlushMulticastQueueForMulticastGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).FlushMulticastQueueForMulticastGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/FlushMulticastQueueForMulticastGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).FlushMulticastQueueForMulticastGroup(ctx, req.(*FlushMulticastQueueForMulticastGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServerService_GetMulticastQueueItemsForMulticastGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMulticastQueueItemsForMulticastGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).GetMulticastQueueItemsForMulticastGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/GetMulticastQueueItemsForMulticastGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).GetMulticastQueueItemsForMulticastGroup(ctx, req.(*GetMulticastQueueItemsForMulticastGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _NetworkServerService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "ns.NetworkServerService",
 	HandlerType: (*NetworkServerServiceServer)(nil),
@@ -4569,6 +6189,10 @@ var _NetworkServerService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetDeviceActivation",
 			Handler:    _NetworkServerService_GetDeviceActivation_Handler,
 		},
+		{
+			MethodName: "GetGatewayDiversityStatsForDevEUI",
+			Handler:    _NetworkServerService_GetGatewayDiversityStatsForDevEUI_Handler,
+		},
 		{
 			MethodName: "CreateDeviceQueueItem",
 			Handler:    _NetworkServerService_CreateDeviceQueueItem_Handler,
@@ -4581,6 +6205,10 @@ var _NetworkServerService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetDeviceQueueItemsForDevEUI",
 			Handler:    _NetworkServerService_GetDeviceQueueItemsForDevEUI_Handler,
 		},
+		{
+			MethodName: "DeleteDeviceQueueItem",
+			Handler:    _NetworkServerService_DeleteDeviceQueueItem_Handler,
+		},
 		{
 			MethodName: "GetNextDownlinkFCntForDevEUI",
 			Handler:    _NetworkServerService_GetNextDownlinkFCntForDevEUI_Handler,
@@ -4593,6 +6221,14 @@ var _NetworkServerService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "CreateMACCommandQueueItem",
 			Handler:    _NetworkServerService_CreateMACCommandQueueItem_Handler,
 		},
+		{
+			MethodName: "ForceDutyCycleReconfiguration",
+			Handler:    _NetworkServerService_ForceDutyCycleReconfiguration_Handler,
+		},
+		{
+			MethodName: "ForceRejoin",
+			Handler:    _NetworkServerService_ForceRejoin_Handler,
+		},
 		{
 			MethodName: "SendProprietaryPayload",
 			Handler:    _NetworkServerService_SendProprietaryPayload_Handler,
@@ -4637,6 +6273,42 @@ var _NetworkServerService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetVersion",
 			Handler:    _NetworkServerService_GetVersion_Handler,
 		},
+		{
+			MethodName: "CreateMulticastGroup",
+			Handler:    _NetworkServerService_CreateMulticastGroup_Handler,
+		},
+		{
+			MethodName: "GetMulticastGroup",
+			Handler:    _NetworkServerService_GetMulticastGroup_Handler,
+		},
+		{
+			MethodName: "UpdateMulticastGroup",
+			Handler:    _NetworkServerService_UpdateMulticastGroup_Handler,
+		},
+		{
+			MethodName: "DeleteMulticastGroup",
+			Handler:    _NetworkServerService_DeleteMulticastGroup_Handler,
+		},
+		{
+			MethodName: "AddDeviceToMulticastGroup",
+			Handler:    _NetworkServerService_AddDeviceToMulticastGroup_Handler,
+		},
+		{
+			MethodName: "RemoveDeviceFromMulticastGroup",
+			Handler:    _NetworkServerService_RemoveDeviceFromMulticastGroup_Handler,
+		},
+		{
+			MethodName: "EnqueueMulticastQueueItem",
+			Handler:    _NetworkServerService_EnqueueMulticastQueueItem_Handler,
+		},
+		{
+			MethodName: "FlushMulticastQueueForMulticastGroup",
+			Handler:    _NetworkServerService_FlushMulticastQueueForMulticastGroup_Handler,
+		},
+		{
+			MethodName: "GetMulticastQueueItemsForMulticastGroup",
+			Handler:    _NetworkServerService_GetMulticastQueueItemsForMulticastGroup_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -4653,163 +6325,222 @@ var _NetworkServerService_serviceDesc = grpc.ServiceDesc{
 	Metadata: "ns.proto",
 }
 
-func init() { proto.RegisterFile("ns.proto", fileDescriptor_ns_d187786e40ecf1f8) }
-
-var fileDescriptor_ns_d187786e40ecf1f8 = []byte{
-	// 2469 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x5a, 0xcf, 0x76, 0xdb, 0xc6,
-	0xd5, 0x17, 0x29, 0x51, 0xa2, 0xae, 0x48, 0x8a, 0x1a, 0xf9, 0x0f, 0x4d, 0xc9, 0x11, 0x8d, 0x38,
-	0xb1, 0xe2, 0x38, 0xd4, 0xf7, 0x29, 0xc7, 0xe7, 0xc4, 0x76, 0xe3, 0x1e, 0x96, 0xa2, 0x6d, 0x35,
-	0xb6, 0x64, 0x83, 0x96, 0xe3, 0xa4, 0x0b, 0x14, 0x02, 0x86, 0x34, 0x0e, 0x09, 0x80, 0x01, 0x86,
-	0xa2, 0xd5, 0x6d, 0x97, 0x5d, 0xf7, 0x19, 0xda, 0x4d, 0x4f, 0xf7, 0x7d, 0x84, 0x2e, 0xba, 0x68,
-	0x77, 0x7d, 0x84, 0x2e, 0xfb, 0x04, 0x3d, 0x83, 0x19, 0x0c, 0x01, 0x68, 0x00, 0xb2, 0x71, 0x7c,
-	0xdc, 0x95, 0x88, 0xb9, 0xbf, 0xfb, 0x9b, 0x3b, 0xf7, 0x5e, 0xcc, 0xdc, 0xb9, 0x10, 0x14, 0x1d,
-	0xbf, 0x39, 0xf2, 0x5c, 0xe2, 0xa2, 0xbc, 0xe3, 0xd7, 0x77, 0xfa, 0xae, 0xdb, 0x1f, 0xe2, 0xbd,
-	0x60, 0xe4, 0x74, 0xdc, 0xdb, 0x23, 0x96, 0x8d, 0x7d, 0xa2, 0xdb, 0x23, 0x06, 0xaa, 0x6f, 0x25,
-	0x01, 0xd8, 0x1e, 0x91, 0x73, 0x2e, 0xbc, 0xdb, 0xb7, 0xc8, 0x9b, 0xf1, 0x69, 0xd3, 0x70, 0xed,
-	0xbd, 0x53, 0xcf, 0x35, 0x74, 0xdd, 0xdb, 0x1b, 0xba, 0x9e, 0xee, 0x63, 0xef, 0x0c, 0x7b, 0x7b,
-	0xfa, 0xc8, 0xda, 0x33, 0x5c, 0xdb, 0x76, 0x1d, 0xfe, 0x87, 0xab, 0x7d, 0x31, 0x5b, 0xad, 0x3f,
-	0xd9, 0xeb, 0x4f, 0x38, 0xbc, 0x32, 0xf2, 0xdc, 0x9e, 0x35, 0xc4, 0xdc, 0x6e, 0xe5, 0x7b, 0xd8,
-	0x6a, 0x7b, 0x58, 0x27, 0xb8, 0x8b, 0xbd, 0x33, 0xcb, 0xc0, 0xcf, 0x99, 0x58, 0xc5, 0x3f, 0x8c,
-	0xb1, 0x4f, 0xd0, 0x03, 0x58, 0xf7, 0x99, 0x40, 0xe3, 0x8a, 0xb5, 0x5c, 0x23, 0xb7, 0xbb, 0xb6,
-	0x8f, 0x9a, 0x8e, 0xdf, 0x4c, 0xe8, 0x54, 0xfc, 0xd8, 0xb3, 0xd2, 0x84, 0x6d, 0x39, 0xb7, 0x3f,
-	0x72, 0x1d, 0x1f, 0xa3, 0x0a, 0xe4, 0x2d, 0x33, 0xe0, 0x2b, 0xa9, 0x79, 0xcb, 0x54, 0x6e, 0x43,
-	0xed, 0x31, 0x26, 0x72, 0x43, 0x92, 0xd8, 0xbf, 0xe5, 0xe0, 0x9a, 0x04, 0xcc, 0x99, 0xdf, 0xc5,
-	0x6c, 0x74, 0x0f, 0xc0, 0x08, 0xcc, 0x36, 0x35, 0x9d, 0xd4, 0xf2, 0x81, 0x5e, 0xbd, 0xc9, 0x42,
-	0xd7, 0x0c, 0x43, 0xd7, 0x7c, 0x19, 0xc6, 0x56, 0x5d, 0xe5, 0xe8, 0x16, 0xa1, 0xaa, 0xe3, 0x91,
-	0x19, 0xaa, 0x2e, 0xce, 0x56, 0xe5, 0xe8, 0x16, 0xa1, 0x81, 0x38, 0x09, 0x1e, 0xde, 0x43, 0x20,
-	0xbe, 0x80, 0xad, 0x03, 0x3c, 0xc4, 0x69, 0xdc, 0x49, 0xdf, 0x8a, 0x9c, 0x50, 0xdd, 0x31, 0xb1,
-	0x9c, 0xfe, 0x45, 0x53, 0x3c, 0x26, 0x90, 0x99, 0x92, 0xd0, 0xa9, 0x78, 0xb1, 0xe7, 0x69, 0x4e,
-	0x24, 0xb9, 0x33, 0x73, 0x42, 0x6e, 0x48, 0x4a, 0x4e, 0xa4, 0x30, 0xbf, 0x8b, 0xd9, 0x1f, 0x3a,
-	0x27, 0xde, 0x43, 0x20, 0x44, 0x4e, 0xcc, 0xe7, 0xdb, 0x57, 0x50, 0x67, 0x71, 0x3b, 0xc0, 0x92,
-	0x0c, 0xfa, 0x0a, 0x2a, 0x26, 0x96, 0x24, 0xe7, 0x06, 0x35, 0x24, 0xae, 0x51, 0x36, 0x71, 0x22,
-	0x35, 0xa5, 0xbc, 0x29, 0xe9, 0xf0, 0x19, 0x5c, 0x7d, 0x8c, 0x89, 0xd4, 0x86, 0x24, 0xf4, 0xaf,
-	0xb9, 0x20, 0x75, 0xe4, 0xbc, 0x3f, 0xda, 0xe0, 0x0f, 0x94, 0x09, 0xaf, 0xa0, 0xce, 0x32, 0xe1,
-	0x27, 0x76, 0xff, 0x1d, 0xa8, 0xb3, 0x2c, 0x98, 0xcb, 0xa5, 0x7f, 0xcf, 0xc1, 0x32, 0x03, 0xa2,
-	0xab, 0xb0, 0x62, 0xe2, 0x33, 0x0d, 0x8f, 0x2d, 0x2e, 0x5f, 0x36, 0xf1, 0x59, 0x67, 0x6c, 0xa1,
-	0xdb, 0xb0, 0x11, 0xb7, 0x45, 0xb3, 0xcc, 0xc0, 0x4d, 0x25, 0x75, 0x3d, 0x36, 0xf7, 0xa1, 0x89,
-	0xee, 0x00, 0x4a, 0x6c, 0x6a, 0x14, 0xbc, 0x18, 0x80, 0xab, 0xf1, 0x3d, 0x8c, 0xa1, 0x13, 0xe9,
-	0x4e, 0xd1, 0x4b, 0x0c, 0x1d, 0xcf, 0xee, 0x43, 0x13, 0xdd, 0x82, 0xaa, 0x3f, 0xb0, 0x46, 0x5a,
-	0x4f, 0x33, 0x1c, 0xa2, 0x19, 0x6f, 0xb0, 0x31, 0xa8, 0x15, 0x1a, 0xb9, 0xdd, 0xa2, 0x5a, 0xa6,
-	0xe3, 0x8f, 0xda, 0x0e, 0x69, 0xd3, 0x41, 0xe5, 0x1e, 0x6c, 0x46, 0x33, 0x30, 0x5c, 0xbb, 0x02,
-	0xcb, 0xcc, 0x5c, 0xee, 0x4b, 0x98, 0xfa, 0x52, 0xe5, 0x12, 0xe5, 0x73, 0xa8, 0x8a, 0x0c, 0x0b,
-	0xf5, 0xd2, 0x1c, 0xa3, 0xfc, 0x29, 0x07, 0x1b, 0x11, 0x34, 0x4f, 0xc4, 0x39, 0xa6, 0xf9, 0x40,
-	0x29, 0x77, 0x0f, 0x36, 0xa3, 0x29, 0xf7, 0xdf, 0xf8, 0xa5, 0x09, 0x9b, 0xd1, 0xac, 0x9a, 0xe9,
-	0x9a, 0xbf, 0xe4, 0xa1, 0xca, 0xa0, 0x2d, 0x83, 0x58, 0x67, 0x3a, 0xb1, 0x5c, 0x27, 0x3d, 0xc3,
-	0xae, 0x41, 0x91, 0x0a, 0x74, 0xd3, 0xf4, 0x78, 0x62, 0x51, 0x60, 0xcb, 0x34, 0x3d, 0x74, 0x13,
-	0xd6, 0x7d, 0xcd, 0x99, 0x0c, 0x34, 0x5f, 0xb3, 0x1c, 0xa2, 0x0d, 0xf0, 0x39, 0xcf, 0xa6, 0x35,
-	0xff, 0x68, 0x32, 0xe8, 0x1e, 0x3a, 0xe4, 0x1b, 0x7c, 0x4e, 0x51, 0xbd, 0x04, 0x8a, 0x65, 0xd1,
-	0x5a, 0x2f, 0x82, 0xba, 0x01, 0x65, 0x86, 0xc1, 0x8e, 0x11, 0x60, 0x0a, 0x01, 0x06, 0x9c, 0xc9,
-	0xa0, 0xdb, 0x71, 0x0c, 0x0a, 0xa9, 0x41, 0x91, 0xa5, 0xd7, 0x78, 0x54, 0x5b, 0x6e, 0xe4, 0x76,
-	0xcb, 0xea, 0x72, 0xaf, 0xed, 0x90, 0x93, 0x11, 0xda, 0x81, 0x92, 0xc3, 0x53, 0xcf, 0x74, 0x27,
-	0x4e, 0x6d, 0x25, 0x90, 0xae, 0x3a, 0x34, 0xed, 0x0e, 0xdc, 0x89, 0x43, 0x01, 0x7a, 0x14, 0x50,
-	0x64, 0x00, 0x5d, 0x00, 0x64, 0xf9, 0xbb, 0x2a, 0xcb, 0xdf, 0xef, 0xe1, 0x32, 0xf7, 0x5a, 0xc2,
-	0xdd, 0x2d, 0xf1, 0x26, 0xea, 0xc2, 0xab, 0x3c, 0x68, 0x97, 0xa6, 0x41, 0x9b, 0x7a, 0x5c, 0xad,
-	0x9a, 0x89, 0x11, 0x65, 0x1f, 0xae, 0x1e, 0x60, 0x5d, 0xca, 0x9e, 0x1a, 0xcc, 0xbb, 0x50, 0x17,
-	0x69, 0x1e, 0x21, 0x9f, 0xa5, 0xf6, 0x6b, 0xd8, 0x92, 0xaa, 0xf1, 0xf7, 0xe4, 0x27, 0x58, 0xcc,
-	0x5d, 0x56, 0x4a, 0xe8, 0x8e, 0xe9, 0xda, 0x07, 0x2c, 0x61, 0x04, 0x7d, 0x34, 0xa7, 0x72, 0xb1,
-	0x9c, 0x52, 0x2c, 0x68, 0xb0, 0xfd, 0xe1, 0x59, 0xab, 0xdd, 0x76, 0x6d, 0x5b, 0x77, 0xcc, 0x17,
-	0x63, 0x3c, 0xc6, 0x87, 0x04, 0xdb, 0xb3, 0x56, 0x85, 0xaa, 0xb0, 0x68, 0xf0, 0x4d, 0xaa, 0xac,
-	0xd2, 0x9f, 0xa8, 0x0e, 0x45, 0x83, 0xb1, 0xf8, 0xb5, 0x42, 0x63, 0x71, 0xb7, 0xa4, 0x8a, 0x67,
-	0xe5, 0x9f, 0x39, 0xb8, 0xde, 0xc5, 0x8e, 0xf9, 0xdc, 0x73, 0x47, 0x9e, 0x85, 0x89, 0xee, 0x9d,
-	0x3f, 0xd7, 0xcf, 0x87, 0xae, 0x6e, 0x86, 0x13, 0xed, 0xc0, 0x9a, 0xad, 0x1b, 0xda, 0x88, 0x8d,
-	0xf2, 0xc9, 0xc0, 0xd6, 0x0d, 0x8e, 0xa3, 0x13, 0xda, 0x96, 0xc1, 0xdf, 0x0b, 0xfa, 0x13, 0xdd,
-	0x80, 0x52, 0x5f, 0x27, 0x78, 0xa2, 0x9f, 0x6b, 0xb6, 0x6e, 0xf8, 0xb5, 0xc5, 0x60, 0xd2, 0x35,
-	0x3e, 0xf6, 0x4c, 0x37, 0x7c, 0x74, 0x17, 0xae, 0x8c, 0xdc, 0xa1, 0xee, 0x59, 0xbf, 0x09, 0x3c,
-	0xa5, 0x59, 0xce, 0x19, 0xf6, 0x7c, 0xea, 0xe1, 0xa5, 0x20, 0xe3, 0x2e, 0x47, 0xa5, 0x87, 0xa1,
-	0x10, 0x6d, 0xc3, 0x6a, 0xcf, 0xa3, 0x86, 0x39, 0x06, 0x7b, 0x3b, 0xca, 0xea, 0x74, 0x80, 0x1e,
-	0x1e, 0xa6, 0xc7, 0x5f, 0x8b, 0xbc, 0xe9, 0x29, 0x3f, 0xc0, 0xca, 0x63, 0x36, 0x67, 0xf2, 0x5c,
-	0x41, 0xbb, 0x50, 0x1c, 0xba, 0x06, 0x8b, 0x29, 0xdb, 0xde, 0x4a, 0xcd, 0xfe, 0xa4, 0xf9, 0x94,
-	0x8f, 0xa9, 0x42, 0x4a, 0xcf, 0x80, 0x70, 0x31, 0x17, 0x4f, 0x0c, 0x2e, 0x11, 0x67, 0x80, 0xf2,
-	0x35, 0x5c, 0x62, 0xa1, 0xe3, 0x13, 0x87, 0x5e, 0xfc, 0x04, 0x56, 0x38, 0x96, 0xa7, 0xd0, 0x1a,
-	0x4d, 0xa1, 0x10, 0x14, 0xca, 0x94, 0x8f, 0x83, 0x0d, 0x3b, 0xa1, 0x9b, 0x3c, 0x13, 0xff, 0x9c,
-	0x07, 0x14, 0x45, 0xf1, 0x84, 0x9a, 0x6f, 0x8a, 0x0f, 0xb3, 0xb5, 0xa3, 0x87, 0x50, 0xee, 0x59,
-	0x9e, 0x4f, 0x34, 0x1f, 0x63, 0x87, 0x6a, 0x2f, 0xcd, 0xd4, 0x5e, 0x0b, 0x14, 0xba, 0x18, 0x3b,
-	0x2d, 0x82, 0x7e, 0x06, 0xa5, 0xa1, 0x1e, 0x51, 0x2f, 0xcc, 0x54, 0x07, 0x8a, 0x67, 0xda, 0x34,
-	0x2a, 0xec, 0x60, 0xf9, 0x71, 0x51, 0xf9, 0x14, 0x2e, 0xb1, 0xc3, 0x65, 0x46, 0x60, 0x7e, 0x97,
-	0x87, 0x12, 0x87, 0x74, 0x89, 0x4e, 0x7c, 0xf4, 0x15, 0xac, 0x8a, 0x0b, 0x39, 0x9f, 0x21, 0xd3,
-	0x5f, 0x02, 0x8c, 0x9a, 0xb0, 0xe9, 0xbd, 0xd5, 0x46, 0xba, 0x31, 0xc0, 0xc4, 0xd7, 0x3c, 0x6c,
-	0x60, 0xeb, 0x0c, 0xb3, 0xaa, 0xa6, 0xa0, 0x6e, 0x78, 0x6f, 0x9f, 0x33, 0x89, 0xca, 0x05, 0xe8,
-	0x4b, 0xb8, 0x22, 0xc1, 0x6b, 0xee, 0x20, 0x08, 0x53, 0x41, 0xdd, 0xbc, 0xa0, 0x72, 0x3c, 0xa0,
-	0x93, 0x10, 0xc9, 0x24, 0x4b, 0x6c, 0x12, 0x72, 0x61, 0x92, 0x3b, 0x80, 0x22, 0x78, 0x6c, 0x5b,
-	0x84, 0x60, 0x33, 0x08, 0x45, 0x41, 0xad, 0x0a, 0x78, 0x87, 0x8d, 0x2b, 0xff, 0xce, 0xc1, 0x95,
-	0x69, 0x9a, 0x06, 0x0e, 0x09, 0x1d, 0x77, 0x1d, 0x20, 0x7c, 0xa7, 0x84, 0x03, 0x57, 0xf9, 0xc8,
-	0x21, 0x5d, 0x4c, 0xd1, 0x72, 0x08, 0xf6, 0xce, 0xf4, 0x61, 0xb0, 0xe2, 0xca, 0xfe, 0x55, 0x1a,
-	0x97, 0x56, 0xbf, 0xef, 0xe1, 0x3e, 0xdf, 0x11, 0x98, 0x58, 0x15, 0x40, 0xd4, 0x86, 0x75, 0x9f,
-	0xe8, 0x1e, 0xd1, 0xa6, 0x1e, 0x9f, 0x9d, 0xa1, 0x95, 0x40, 0x45, 0x3c, 0xa3, 0x9f, 0x43, 0x19,
-	0x3b, 0x66, 0x84, 0x62, 0x76, 0x9a, 0x96, 0xb0, 0x63, 0x8a, 0x27, 0xa5, 0x1d, 0xdc, 0x16, 0xe2,
-	0x6b, 0xe6, 0xef, 0xe7, 0x2e, 0x2c, 0x7b, 0xd8, 0x1f, 0x0f, 0x49, 0x2d, 0xd7, 0x58, 0xdc, 0x5d,
-	0xdb, 0xaf, 0x46, 0x72, 0x8d, 0x21, 0xb9, 0x5c, 0xf9, 0x7d, 0x0e, 0xd6, 0xd9, 0xe9, 0x22, 0xb6,
-	0xfd, 0xf4, 0xfd, 0x7e, 0x07, 0xd6, 0x7a, 0x9e, 0x2d, 0xf6, 0x67, 0xb6, 0x0d, 0x43, 0xcf, 0xb3,
-	0xc3, 0xfd, 0x79, 0x13, 0x0a, 0xc1, 0x89, 0x1e, 0xb8, 0xa3, 0xac, 0x2e, 0xd1, 0x7a, 0x01, 0x5d,
-	0x86, 0xe5, 0x9e, 0x36, 0x72, 0x3d, 0xc2, 0x0f, 0x8a, 0x42, 0xef, 0xb9, 0xeb, 0x11, 0xba, 0xbf,
-	0x1a, 0xae, 0xd3, 0xb3, 0x3c, 0x9b, 0x07, 0xb6, 0xa8, 0x4e, 0x07, 0x94, 0xc7, 0xe1, 0x4d, 0x3a,
-	0x61, 0x5c, 0x18, 0xd6, 0x5b, 0xb0, 0x64, 0x11, 0x6c, 0xf3, 0x4c, 0xdf, 0x9c, 0x1e, 0x92, 0x53,
-	0x64, 0x00, 0x50, 0x1e, 0x40, 0xe3, 0xd1, 0x70, 0xec, 0xbf, 0x89, 0x48, 0x1f, 0xb9, 0xde, 0x01,
-	0x3e, 0xeb, 0x9c, 0x1c, 0xce, 0x3c, 0xb6, 0x1f, 0xc2, 0xc7, 0xe2, 0xd8, 0x16, 0xc4, 0xfe, 0xfc,
-	0xfa, 0x2f, 0xe0, 0x66, 0xb6, 0x3e, 0x8f, 0xd7, 0x67, 0x50, 0xa0, 0xc6, 0xfa, 0x3c, 0x5c, 0xd2,
-	0xe5, 0x30, 0x04, 0x37, 0xe9, 0x08, 0xbf, 0x0d, 0x0a, 0xa9, 0xa1, 0xe5, 0x0c, 0x68, 0xb1, 0x34,
-	0xbf, 0x49, 0x0f, 0x02, 0x93, 0x32, 0xf4, 0xb9, 0x49, 0x22, 0x94, 0xb9, 0x69, 0x28, 0x95, 0x16,
-	0x34, 0xba, 0xc4, 0xc3, 0xba, 0xfd, 0xc8, 0xd3, 0x6d, 0xfc, 0xd4, 0xed, 0xd3, 0xb5, 0x24, 0x76,
-	0xaa, 0xec, 0x17, 0x4e, 0xf9, 0x63, 0x0e, 0x6e, 0x64, 0x70, 0xf0, 0xd9, 0x1f, 0x42, 0x75, 0x3c,
-	0xa2, 0xc6, 0x69, 0x3d, 0x8a, 0xd2, 0x7c, 0x4c, 0xc4, 0xed, 0xbf, 0x3f, 0x69, 0x9e, 0x04, 0xb2,
-	0x80, 0xa0, 0x8b, 0xc9, 0x93, 0x05, 0xb5, 0x32, 0x8e, 0x8d, 0xa0, 0xfb, 0x50, 0x31, 0xf9, 0xf2,
-	0x18, 0x03, 0x3f, 0x7d, 0x36, 0xa8, 0xb6, 0x58, 0x38, 0x15, 0x3c, 0x59, 0x50, 0xcb, 0x66, 0x74,
-	0xe0, 0x17, 0x2b, 0x50, 0x08, 0x54, 0x94, 0xfb, 0xb0, 0x73, 0xd1, 0xd2, 0x39, 0xeb, 0xc4, 0x3f,
-	0xe4, 0x64, 0xae, 0x4a, 0x5c, 0x8f, 0xfe, 0x27, 0x56, 0xf9, 0x2a, 0x38, 0xe1, 0x5f, 0xb1, 0xaa,
-	0x47, 0x98, 0x56, 0x83, 0x95, 0xb0, 0x4a, 0xa2, 0x16, 0xad, 0xaa, 0xe1, 0x23, 0xfa, 0x94, 0xee,
-	0x2d, 0xfd, 0xb0, 0x98, 0xa9, 0xec, 0x57, 0x9a, 0xbc, 0x63, 0xab, 0x06, 0xa3, 0x2a, 0x97, 0x2a,
-	0xbf, 0xcd, 0x41, 0xe5, 0x71, 0xac, 0x66, 0xb9, 0x50, 0x19, 0xd1, 0x6a, 0xf1, 0x8d, 0xee, 0x38,
-	0x78, 0xe8, 0xd7, 0xf2, 0x8d, 0xc5, 0xdd, 0xb2, 0x2a, 0x9e, 0x51, 0x07, 0x2a, 0xf8, 0x2d, 0xf1,
-	0x74, 0x4d, 0x20, 0x16, 0x83, 0x77, 0xe3, 0xa3, 0xc8, 0x56, 0xc6, 0x79, 0x3b, 0x14, 0xd7, 0x66,
-	0x30, 0xb5, 0x8c, 0x23, 0x4f, 0xbe, 0xf2, 0x8f, 0x1c, 0xd4, 0xd3, 0xd1, 0x68, 0x1f, 0xc0, 0x76,
-	0xcd, 0xf1, 0x70, 0x5a, 0x71, 0x57, 0xf6, 0x51, 0xb8, 0xa0, 0x67, 0x42, 0xa2, 0x46, 0x50, 0xf1,
-	0xc2, 0x30, 0x9f, 0x2c, 0x0c, 0xb7, 0x61, 0xf5, 0x54, 0x77, 0xcc, 0x89, 0x65, 0x92, 0x37, 0x7c,
-	0x1b, 0x9c, 0x0e, 0x50, 0xb7, 0x9e, 0x5a, 0xc4, 0xd3, 0x09, 0xe6, 0x9b, 0x61, 0xf8, 0x88, 0x3e,
-	0x87, 0x0d, 0x7f, 0xe4, 0x61, 0xdd, 0xb4, 0x9c, 0xbe, 0xd6, 0xd3, 0x0d, 0xe2, 0x7a, 0xac, 0x84,
-	0x2e, 0xab, 0x55, 0x21, 0x78, 0xc4, 0xc6, 0xa7, 0x3d, 0xcc, 0xf8, 0xd2, 0x22, 0xad, 0xb3, 0x44,
-	0x1d, 0x19, 0x6d, 0x9d, 0x25, 0x74, 0x2a, 0xf1, 0xc2, 0x72, 0xda, 0xc3, 0x4c, 0x72, 0x67, 0xf6,
-	0x30, 0xe5, 0x86, 0xa4, 0xf4, 0x30, 0x53, 0x98, 0xdf, 0xc5, 0xec, 0x0f, 0xdd, 0xc3, 0x7c, 0x0f,
-	0x81, 0x10, 0x3d, 0xcc, 0xb9, 0x7c, 0x7b, 0x7b, 0x1b, 0x8a, 0xea, 0xeb, 0x6f, 0x2d, 0xc7, 0x74,
-	0x27, 0x68, 0x05, 0x16, 0xd5, 0xd7, 0xff, 0x5f, 0x5d, 0x60, 0x3f, 0xf6, 0xab, 0xb9, 0xdb, 0x43,
-	0xd8, 0x94, 0xd4, 0x34, 0x08, 0x60, 0xb9, 0xdb, 0x69, 0x1f, 0x1f, 0x1d, 0x54, 0x17, 0xe8, 0xef,
-	0x67, 0x87, 0x47, 0x27, 0x2f, 0x3b, 0xd5, 0x1c, 0x2a, 0xc2, 0xd2, 0x93, 0xe3, 0x13, 0xb5, 0x9a,
-	0xa7, 0x0c, 0x07, 0xad, 0xef, 0xaa, 0x8b, 0x74, 0xe8, 0xdb, 0x4e, 0xe7, 0x9b, 0xea, 0x12, 0x5a,
-	0x85, 0xc2, 0xb3, 0xe3, 0xa3, 0x97, 0x4f, 0xaa, 0x05, 0xb4, 0x06, 0x2b, 0x2f, 0x4e, 0x5a, 0xea,
-	0xcb, 0x8e, 0x5a, 0x5d, 0xa6, 0x88, 0xef, 0x3a, 0x2d, 0xb5, 0xba, 0xb2, 0xff, 0xaf, 0x1a, 0x5c,
-	0x3a, 0xc2, 0x64, 0xe2, 0x7a, 0x83, 0x6e, 0xf0, 0xa5, 0x86, 0xb7, 0xe6, 0xd1, 0xaf, 0xc2, 0x3b,
-	0x4b, 0xbc, 0x57, 0x8f, 0x76, 0xa8, 0x3f, 0x32, 0x3e, 0xd5, 0xd4, 0x1b, 0xe9, 0x00, 0x96, 0x3d,
-	0xca, 0x02, 0x52, 0x83, 0x1b, 0x4d, 0x82, 0x79, 0x3b, 0xf0, 0x74, 0xca, 0x87, 0x97, 0xfa, 0xf5,
-	0x14, 0xa9, 0xe0, 0x7c, 0x11, 0x96, 0xf3, 0x32, 0x83, 0x33, 0x3e, 0x69, 0xd4, 0xaf, 0x5c, 0x48,
-	0xa0, 0x8e, 0x3d, 0x22, 0xe7, 0x8c, 0x52, 0xf6, 0xbd, 0x82, 0x51, 0x66, 0x7c, 0xc9, 0xc8, 0xa0,
-	0x14, 0x6e, 0x8d, 0xb7, 0xbb, 0xa3, 0x6e, 0x95, 0x36, 0xc2, 0xa3, 0x6e, 0x95, 0x7f, 0x58, 0x10,
-	0x6e, 0x4d, 0x30, 0x87, 0x6e, 0x95, 0xd3, 0x5e, 0x4f, 0x91, 0x5e, 0x74, 0xab, 0xcc, 0xe0, 0x8c,
-	0xaf, 0x02, 0xf3, 0xb8, 0x55, 0x46, 0x99, 0xf1, 0x31, 0x20, 0x83, 0xf2, 0x75, 0xbc, 0x79, 0x1a,
-	0x32, 0x7e, 0x34, 0x75, 0x9a, 0xac, 0xb1, 0x5c, 0xdf, 0x49, 0x95, 0x8b, 0xf5, 0x1f, 0x47, 0x7a,
-	0xab, 0x21, 0xed, 0x16, 0x77, 0x9a, 0x94, 0x73, 0x5b, 0x2e, 0x8c, 0x10, 0x6e, 0x4a, 0x5a, 0xe8,
-	0xcc, 0xd4, 0xf4, 0xde, 0x7a, 0xc6, 0xda, 0x8f, 0xe3, 0x5d, 0xce, 0x18, 0x61, 0x7a, 0x53, 0x3d,
-	0x83, 0xb0, 0x05, 0xa5, 0xa8, 0x4f, 0xd0, 0xd5, 0xa4, 0x97, 0x66, 0x53, 0xdc, 0x87, 0x55, 0xe1,
-	0x02, 0x74, 0x29, 0xe6, 0x91, 0x50, 0xf9, 0x72, 0x62, 0x54, 0x38, 0xa8, 0x05, 0xa5, 0xa8, 0x1f,
-	0xd8, 0xf4, 0x92, 0x16, 0x70, 0xf6, 0x0a, 0xa2, 0x2b, 0x67, 0x14, 0x92, 0x56, 0x70, 0x06, 0x45,
-	0x07, 0x2a, 0xf1, 0x76, 0x26, 0xba, 0x16, 0x5c, 0x37, 0x65, 0x4d, 0xc8, 0x0c, 0x9a, 0x43, 0xa8,
-	0x26, 0x3b, 0x97, 0x2c, 0x7d, 0x52, 0xfa, 0x99, 0xd9, 0x39, 0x2e, 0xe9, 0x4c, 0xb2, 0x38, 0xa7,
-	0x77, 0x3a, 0x59, 0x8e, 0x67, 0xb4, 0x34, 0x95, 0x05, 0xd4, 0x85, 0xcb, 0xd2, 0x2b, 0x1c, 0x6a,
-	0x24, 0x23, 0x9f, 0xbc, 0xdd, 0x65, 0xee, 0x74, 0xd7, 0x52, 0xaf, 0x73, 0xe8, 0x26, 0x25, 0x9e,
-	0x75, 0xdb, 0xcb, 0x20, 0xf7, 0x61, 0x3b, 0xeb, 0xba, 0x86, 0x6e, 0xc5, 0x16, 0x9d, 0x7e, 0x21,
-	0xac, 0xef, 0xce, 0x06, 0x0a, 0x37, 0xb1, 0x49, 0x53, 0x2f, 0x64, 0x62, 0xd2, 0x59, 0x57, 0x3e,
-	0x31, 0xe9, 0xcc, 0xbb, 0x9d, 0xb2, 0x80, 0x7e, 0x19, 0xec, 0x3f, 0xb1, 0x6e, 0x31, 0x4a, 0xf1,
-	0x8b, 0xd8, 0x7a, 0xa4, 0xbd, 0x65, 0x16, 0x92, 0xd4, 0x16, 0x32, 0x0b, 0xc9, 0xac, 0x0e, 0x73,
-	0x46, 0x48, 0x4e, 0xe0, 0x8a, 0xbc, 0x67, 0x8c, 0x6e, 0xb0, 0x7f, 0x0d, 0xc8, 0xe8, 0x27, 0x67,
-	0xd0, 0xb6, 0xa1, 0x1c, 0x2b, 0x72, 0x51, 0x6d, 0x6a, 0x67, 0xfc, 0x3e, 0x9b, 0x41, 0xf2, 0x35,
-	0xc0, 0xb4, 0x98, 0x45, 0xe1, 0xce, 0x73, 0x41, 0x3d, 0x31, 0x2c, 0xfc, 0xd6, 0x86, 0x72, 0xac,
-	0x76, 0x64, 0x36, 0xc8, 0x9a, 0x87, 0xd9, 0x0b, 0x89, 0x15, 0x89, 0x8c, 0x44, 0xd6, 0x42, 0x9c,
-	0xa7, 0x7c, 0x48, 0xdc, 0xd7, 0x76, 0x2e, 0x38, 0x25, 0xbd, 0x7c, 0x90, 0xd7, 0xf4, 0xa2, 0x7c,
-	0x48, 0x30, 0x6f, 0xc7, 0xbd, 0x92, 0x52, 0x3e, 0xa4, 0x72, 0xbe, 0x48, 0x34, 0x59, 0x25, 0xe5,
-	0x83, 0x9c, 0x79, 0x8e, 0xf2, 0x41, 0x46, 0x99, 0x51, 0x87, 0x67, 0x50, 0x3e, 0x85, 0xf5, 0x44,
-	0x83, 0x0e, 0xd5, 0xe3, 0x2b, 0x8b, 0x76, 0x2a, 0xeb, 0x5b, 0x52, 0x99, 0x58, 0xf3, 0x10, 0xae,
-	0xa5, 0xf6, 0x4d, 0xd8, 0x6b, 0x36, 0xab, 0x35, 0x53, 0xff, 0x64, 0x06, 0x2a, 0x9c, 0xeb, 0xff,
-	0x72, 0xc8, 0x82, 0x5a, 0x5a, 0xfb, 0x02, 0x7d, 0x2c, 0xa7, 0x89, 0x9f, 0x38, 0x37, 0xb3, 0x41,
-	0x91, 0xa9, 0x1e, 0x06, 0xaf, 0x11, 0x6f, 0x40, 0xa4, 0xee, 0x42, 0xe1, 0x7b, 0x94, 0x68, 0x54,
-	0x28, 0x0b, 0xa7, 0xcb, 0x01, 0xf2, 0xcb, 0xff, 0x04, 0x00, 0x00, 0xff, 0xff, 0x46, 0x29, 0xb2,
-	0x4e, 0xaf, 0x26, 0x00, 0x00,
+func init() { proto.RegisterFile("ns.proto", fileDescriptor_ns_fcf95a0cc2fb7a5f) }
+
+var fileDescriptor_ns_fcf95a0cc2fb7a5f = []byte{
+	// 3414 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x5a, 0x5b, 0x6f, 0x1b, 0xc9,
+	0x95, 0x76, 0x53, 0x37, 0xea, 0x48, 0xa4, 0xa8, 0x92, 0x65, 0xd3, 0xb4, 0x6c, 0xc9, 0x6d, 0x79,
+	0x2c, 0x7b, 0x3c, 0xd4, 0x8e, 0x0c, 0x03, 0xe3, 0xf1, 0x8e, 0x07, 0x1c, 0x8a, 0xb2, 0x35, 0xe3,
+	0x6b, 0xcb, 0xf2, 0xdc, 0x16, 0xdb, 0x68, 0x75, 0x17, 0xe9, 0x5e, 0xb1, 0xbb, 0xe9, 0xee, 0xa2,
+	0x2e, 0x0b, 0xec, 0xcb, 0x3e, 0x2d, 0xb0, 0x0f, 0xc9, 0x43, 0x80, 0xfc, 0x83, 0x24, 0x08, 0x10,
+	0xe4, 0x3d, 0x3f, 0x21, 0x0f, 0x01, 0x92, 0xbc, 0xe5, 0x37, 0x04, 0x08, 0x90, 0x1f, 0x10, 0x04,
+	0x75, 0xe9, 0x2b, 0xfb, 0x42, 0x8f, 0xc7, 0x70, 0x9e, 0xc8, 0xae, 0xf3, 0x9d, 0x53, 0xa7, 0x4e,
+	0x9d, 0xaa, 0x3a, 0x75, 0xea, 0x40, 0xd9, 0xf6, 0x9a, 0x03, 0xd7, 0x21, 0x0e, 0x2a, 0xd9, 0x5e,
+	0x63, 0xb5, 0xe7, 0x38, 0xbd, 0x3e, 0xde, 0x64, 0x2d, 0x07, 0xc3, 0xee, 0x26, 0x31, 0x2d, 0xec,
+	0x11, 0xcd, 0x1a, 0x70, 0x50, 0xe3, 0x72, 0x12, 0x60, 0x0c, 0x5d, 0x8d, 0x98, 0x8e, 0x2d, 0xe8,
+	0x17, 0x93, 0x74, 0x6c, 0x0d, 0xc8, 0xa9, 0x20, 0xde, 0xe9, 0x99, 0xe4, 0xd5, 0xf0, 0xa0, 0xa9,
+	0x3b, 0xd6, 0xe6, 0x81, 0xeb, 0xe8, 0x9a, 0xe6, 0x6e, 0xf6, 0x1d, 0x57, 0xf3, 0xb0, 0x7b, 0x84,
+	0xdd, 0x4d, 0x6d, 0x60, 0x6e, 0xea, 0x8e, 0x65, 0x39, 0xb6, 0xf8, 0x11, 0x6c, 0x1f, 0x15, 0xb3,
+	0xf5, 0x8e, 0x37, 0x7b, 0xc7, 0x02, 0x5e, 0x1d, 0xb8, 0x4e, 0xd7, 0xec, 0x63, 0x31, 0x2e, 0xf9,
+	0x3b, 0xb8, 0xd8, 0x76, 0xb1, 0x46, 0xf0, 0x1e, 0x76, 0x8f, 0x4c, 0x1d, 0x3f, 0xe3, 0x64, 0x05,
+	0xbf, 0x1e, 0x62, 0x8f, 0xa0, 0x7b, 0xb0, 0xe0, 0x71, 0x82, 0x2a, 0x18, 0xeb, 0xd2, 0x9a, 0xb4,
+	0x31, 0xb7, 0x85, 0x9a, 0xb6, 0xd7, 0x4c, 0xf0, 0x54, 0xbd, 0xd8, 0xb7, 0xdc, 0x84, 0x95, 0x74,
+	0xd9, 0xde, 0xc0, 0xb1, 0x3d, 0x8c, 0xaa, 0x50, 0x32, 0x0d, 0x26, 0x6f, 0x5e, 0x29, 0x99, 0x86,
+	0x7c, 0x13, 0xea, 0x0f, 0x30, 0x49, 0x57, 0x24, 0x89, 0xfd, 0x83, 0x04, 0x17, 0x52, 0xc0, 0x42,
+	0xf2, 0xdb, 0xa8, 0x8d, 0xee, 0x02, 0xe8, 0x4c, 0x6d, 0x43, 0xd5, 0x48, 0xbd, 0xc4, 0xf8, 0x1a,
+	0x4d, 0x3e, 0x75, 0x4d, 0x7f, 0xea, 0x9a, 0x2f, 0xfc, 0xb9, 0x57, 0x66, 0x05, 0xba, 0x45, 0x28,
+	0xeb, 0x70, 0x60, 0xf8, 0xac, 0x13, 0xc5, 0xac, 0x02, 0xdd, 0x22, 0x74, 0x22, 0xf6, 0xd9, 0xc7,
+	0x3b, 0x98, 0x88, 0x8f, 0xe0, 0xe2, 0x36, 0xee, 0x63, 0x82, 0xc7, 0xb3, 0x6d, 0xe0, 0x13, 0x8a,
+	0x33, 0x24, 0xa6, 0xdd, 0x1b, 0x55, 0xc5, 0xe5, 0x84, 0x34, 0x55, 0x12, 0x3c, 0x55, 0x37, 0xf6,
+	0x1d, 0xfa, 0x44, 0x52, 0x76, 0xae, 0x4f, 0xa4, 0x2b, 0x92, 0xe1, 0x13, 0x19, 0x92, 0xdf, 0x46,
+	0xed, 0xf7, 0xed, 0x13, 0xef, 0x60, 0x22, 0x02, 0x9f, 0x18, 0xcf, 0xb6, 0x2f, 0xa1, 0xc1, 0xe7,
+	0x6d, 0x1b, 0xa7, 0x78, 0xd0, 0x27, 0x50, 0x35, 0x70, 0x8a, 0x73, 0x2e, 0x52, 0x45, 0xe2, 0x1c,
+	0x15, 0x03, 0x27, 0x5c, 0x33, 0x55, 0x6e, 0x86, 0x3b, 0xdc, 0x80, 0xf3, 0x0f, 0x30, 0x49, 0xd5,
+	0x21, 0x09, 0xfd, 0xbd, 0x04, 0xf5, 0x51, 0xac, 0x90, 0xfb, 0x83, 0x15, 0x7e, 0x4f, 0x9e, 0xf0,
+	0x12, 0x1a, 0xdc, 0x13, 0x7e, 0x64, 0xf3, 0xdf, 0x82, 0x06, 0xf7, 0x82, 0xb1, 0x4c, 0xfa, 0x27,
+	0x09, 0xa6, 0x39, 0x10, 0x9d, 0x87, 0x19, 0x03, 0x1f, 0xa9, 0x78, 0x68, 0x0a, 0xfa, 0xb4, 0x81,
+	0x8f, 0x3a, 0x43, 0x13, 0xdd, 0x84, 0xc5, 0xb8, 0x2e, 0xaa, 0x69, 0x30, 0x33, 0xcd, 0x2b, 0x0b,
+	0xb1, 0xbe, 0x77, 0x0d, 0x74, 0x0b, 0x50, 0x62, 0x53, 0xa3, 0xe0, 0x09, 0x06, 0xae, 0xc5, 0xf7,
+	0x30, 0x8e, 0x4e, 0xb8, 0x3b, 0x45, 0x4f, 0x72, 0x74, 0xdc, 0xbb, 0x77, 0x0d, 0x74, 0x1d, 0x6a,
+	0xde, 0xa1, 0x39, 0x50, 0xbb, 0xaa, 0x6e, 0x13, 0x55, 0x7f, 0x85, 0xf5, 0xc3, 0xfa, 0xd4, 0x9a,
+	0xb4, 0x51, 0x56, 0x2a, 0xb4, 0x7d, 0xa7, 0x6d, 0x93, 0x36, 0x6d, 0x94, 0xef, 0xc2, 0x52, 0xd4,
+	0x03, 0xfd, 0xb1, 0xcb, 0x30, 0xcd, 0xd5, 0x15, 0xb6, 0x84, 0xd0, 0x96, 0x8a, 0xa0, 0xc8, 0x1f,
+	0x42, 0x2d, 0xf0, 0x30, 0x9f, 0x2f, 0xcb, 0x30, 0xf2, 0x6f, 0x24, 0x58, 0x8c, 0xa0, 0x85, 0x23,
+	0x8e, 0xd1, 0xcd, 0x7b, 0x72, 0xb9, 0xbb, 0xb0, 0x14, 0x75, 0xb9, 0x37, 0xb1, 0x4b, 0x13, 0x96,
+	0xa2, 0x5e, 0x55, 0x68, 0x9a, 0xdf, 0x95, 0xa0, 0xc6, 0xa1, 0x2d, 0x9d, 0x98, 0x47, 0x2c, 0x64,
+	0xca, 0xf6, 0xb0, 0x0b, 0x50, 0xa6, 0x04, 0xcd, 0x30, 0x5c, 0xe1, 0x58, 0x14, 0xd8, 0x32, 0x0c,
+	0x17, 0xad, 0xc3, 0x82, 0xa7, 0xda, 0xc7, 0x87, 0xaa, 0xa7, 0x9a, 0x36, 0x51, 0x0f, 0xf1, 0xa9,
+	0xf0, 0xa6, 0x39, 0xef, 0xc9, 0xf1, 0xe1, 0xde, 0xae, 0x4d, 0xbe, 0xc2, 0xa7, 0x14, 0xd5, 0x4d,
+	0xa0, 0xb8, 0x17, 0xcd, 0x75, 0x23, 0xa8, 0x2b, 0x50, 0xe1, 0x18, 0x6c, 0xeb, 0x0c, 0x33, 0xc5,
+	0x30, 0x60, 0x1f, 0x1f, 0xee, 0x75, 0x6c, 0x9d, 0x42, 0xea, 0x50, 0xe6, 0xee, 0x35, 0x1c, 0xd4,
+	0xa7, 0xd7, 0xa4, 0x8d, 0x8a, 0x32, 0xdd, 0x6d, 0xdb, 0x64, 0x7f, 0x80, 0x56, 0x61, 0xde, 0x16,
+	0xae, 0x67, 0x38, 0xc7, 0x76, 0x7d, 0x86, 0x51, 0x67, 0x6d, 0xea, 0x76, 0xdb, 0xce, 0xb1, 0x4d,
+	0x01, 0x5a, 0x14, 0x50, 0xe6, 0x00, 0x2d, 0x00, 0xa4, 0xf9, 0xef, 0x6c, 0x9a, 0xff, 0x7e, 0x07,
+	0xcb, 0xc2, 0x6a, 0x09, 0x73, 0xb7, 0x82, 0x95, 0xa8, 0x05, 0x56, 0x15, 0x93, 0x76, 0x36, 0x9c,
+	0xb4, 0xd0, 0xe2, 0x4a, 0xcd, 0x48, 0xb4, 0xc8, 0x5b, 0x70, 0x7e, 0x1b, 0x6b, 0xa9, 0xd2, 0x33,
+	0x27, 0xf3, 0x0e, 0x34, 0x02, 0x37, 0x8f, 0x08, 0x2f, 0x62, 0xfb, 0xb5, 0x04, 0x17, 0x53, 0xf9,
+	0xc4, 0x42, 0x79, 0xfb, 0xd1, 0xa0, 0x87, 0x70, 0xb6, 0xeb, 0xb8, 0x3a, 0x56, 0x5d, 0xfc, 0x5f,
+	0x8e, 0x69, 0xab, 0xce, 0x90, 0xe8, 0x8e, 0x85, 0xc5, 0x8a, 0x3a, 0x47, 0xa5, 0xec, 0x50, 0xba,
+	0xc2, 0xc8, 0x4f, 0x39, 0x55, 0x41, 0xdd, 0x91, 0x36, 0xf9, 0x17, 0x12, 0xa0, 0x51, 0x28, 0xda,
+	0x82, 0x65, 0x97, 0x8f, 0x13, 0x1b, 0x7e, 0x27, 0xe4, 0x74, 0xc0, 0x97, 0x4a, 0x45, 0x59, 0x0a,
+	0x88, 0x9c, 0xed, 0xc5, 0xe9, 0x00, 0xa3, 0x55, 0x98, 0x8b, 0x22, 0x4b, 0x0c, 0x09, 0x6e, 0x08,
+	0xb8, 0xe7, 0x03, 0xc6, 0x5d, 0xc3, 0xe0, 0xc3, 0x5b, 0x44, 0x6e, 0xc3, 0xc6, 0x03, 0x4c, 0x1e,
+	0x68, 0x04, 0x1f, 0x6b, 0xa7, 0xdb, 0xe6, 0x11, 0x76, 0x3d, 0x93, 0x9c, 0xee, 0x11, 0x8d, 0x78,
+	0x3b, 0x8e, 0xbb, 0x8d, 0x8f, 0x3a, 0xfb, 0xbb, 0x85, 0x53, 0xf3, 0x47, 0x09, 0x6e, 0x8c, 0x21,
+	0x45, 0x4c, 0xd4, 0x15, 0x98, 0x1f, 0x0e, 0xfa, 0xa6, 0x7d, 0xa8, 0xea, 0xce, 0xd0, 0x26, 0x62,
+	0xec, 0x73, 0xbc, 0xad, 0x4d, 0x9b, 0xd0, 0x75, 0x58, 0x38, 0x32, 0x9d, 0x3e, 0x9b, 0x15, 0x81,
+	0xe2, 0xe3, 0xae, 0x06, 0xcd, 0x1c, 0x78, 0x13, 0x16, 0xb5, 0xa3, 0x9e, 0xda, 0xe3, 0x3d, 0x0b,
+	0x28, 0xb5, 0x80, 0xa4, 0x2c, 0x68, 0x47, 0x3d, 0xa1, 0x11, 0xc7, 0xde, 0x02, 0xd4, 0xd7, 0x3c,
+	0x92, 0x00, 0x4f, 0x32, 0xb9, 0x35, 0x4a, 0x89, 0xa2, 0xe5, 0x3b, 0x3c, 0xae, 0xd4, 0x6c, 0xc3,
+	0xb1, 0xb6, 0xf9, 0xee, 0x11, 0x8c, 0x20, 0xba, 0xc1, 0x48, 0xb1, 0x0d, 0x46, 0x36, 0x61, 0x8d,
+	0x1f, 0x16, 0x8f, 0x5b, 0xed, 0xb6, 0x63, 0x59, 0x9a, 0x6d, 0x3c, 0x1f, 0xe2, 0x21, 0xde, 0x25,
+	0xd8, 0x2a, 0xb2, 0x23, 0xaa, 0xc1, 0x84, 0x2e, 0x4e, 0xac, 0x8a, 0x42, 0xff, 0xa2, 0x06, 0x94,
+	0x75, 0x2e, 0xc5, 0xab, 0x4f, 0xad, 0x4d, 0x6c, 0xcc, 0x2b, 0xc1, 0xb7, 0xfc, 0x39, 0xac, 0x33,
+	0x17, 0xdb, 0x1e, 0x92, 0xd3, 0xf6, 0xa9, 0xde, 0xc7, 0x0a, 0xd6, 0x1d, 0xbb, 0x6b, 0xf6, 0xc4,
+	0x9d, 0xb2, 0x70, 0xda, 0x7e, 0x1e, 0x77, 0xd2, 0x42, 0xf5, 0x0a, 0x3d, 0xb1, 0x0a, 0x25, 0xc3,
+	0x65, 0xe6, 0xaf, 0x28, 0x25, 0xc3, 0xa5, 0x0c, 0x96, 0x76, 0xa2, 0xba, 0x98, 0xb8, 0x26, 0xf6,
+	0xc4, 0xb8, 0xc0, 0xd2, 0x4e, 0x14, 0xde, 0x82, 0xce, 0xc1, 0xf4, 0x00, 0xbb, 0xa6, 0x63, 0xb0,
+	0xbd, 0xb3, 0xa2, 0x88, 0x2f, 0xf9, 0x2f, 0x12, 0x5c, 0xda, 0xc3, 0xb6, 0xf1, 0xcc, 0x75, 0x06,
+	0xae, 0x89, 0x89, 0xe6, 0x9e, 0x3e, 0xd3, 0x4e, 0xfb, 0x8e, 0x66, 0xf8, 0x4a, 0x32, 0xd1, 0xba,
+	0x3a, 0xe0, 0xad, 0x42, 0x51, 0xb0, 0x34, 0x5d, 0xe0, 0xa8, 0x2d, 0x2d, 0x53, 0x17, 0xfb, 0x3f,
+	0xfd, 0x4b, 0xfd, 0xce, 0x9f, 0x7a, 0x4b, 0xd3, 0xbd, 0xfa, 0x04, 0xb3, 0xe7, 0x9c, 0x68, 0x7b,
+	0xac, 0xe9, 0x1e, 0xba, 0x03, 0xe7, 0x06, 0x4e, 0x5f, 0x73, 0xcd, 0xff, 0xe6, 0xae, 0x67, 0xda,
+	0xcc, 0x95, 0x1d, 0x9b, 0xe9, 0x5e, 0x56, 0x96, 0xa3, 0xd4, 0x5d, 0x9f, 0x88, 0x56, 0x60, 0xb6,
+	0xcb, 0x96, 0xae, 0xad, 0x9f, 0x8a, 0x91, 0x84, 0x0d, 0xc2, 0x2a, 0xd3, 0xbe, 0x55, 0xe4, 0xd7,
+	0x30, 0x23, 0x3c, 0x2d, 0x19, 0x3f, 0xa1, 0x0d, 0x28, 0xf7, 0x1d, 0x9d, 0x6f, 0x5d, 0x7c, 0xd3,
+	0x99, 0x6f, 0xf6, 0x8e, 0x9b, 0x8f, 0x44, 0x9b, 0x12, 0x50, 0xa9, 0x33, 0xfb, 0x83, 0x19, 0x8d,
+	0x8c, 0x04, 0x25, 0x88, 0x75, 0xe4, 0xcf, 0xe0, 0x2c, 0xf7, 0x4a, 0xd1, 0xb1, 0x6f, 0xc5, 0x6b,
+	0x30, 0x23, 0xb0, 0x62, 0xa7, 0x9c, 0xa3, 0x7b, 0x9c, 0x0f, 0xf2, 0x69, 0xf2, 0x55, 0x16, 0x98,
+	0x24, 0x78, 0x93, 0xb1, 0xdf, 0x6f, 0x4b, 0x80, 0xa2, 0x28, 0xb1, 0x56, 0xc6, 0xeb, 0xe2, 0xfd,
+	0x84, 0x30, 0xe8, 0x3e, 0x54, 0xba, 0xa6, 0xeb, 0x11, 0xd5, 0xc3, 0xd8, 0xa6, 0xdc, 0x93, 0x85,
+	0xdc, 0x73, 0x8c, 0x61, 0x0f, 0x63, 0xbb, 0x45, 0xd0, 0xbf, 0xc3, 0x7c, 0x5f, 0x8b, 0xb0, 0x4f,
+	0x15, 0xb2, 0x43, 0x5f, 0xf3, 0xb9, 0xe9, 0xac, 0xf0, 0x00, 0xea, 0x87, 0xcd, 0xca, 0x07, 0x70,
+	0x96, 0x07, 0x51, 0x05, 0x13, 0xf3, 0xff, 0x25, 0x98, 0x17, 0x10, 0xb6, 0x23, 0xa3, 0x4f, 0x60,
+	0x36, 0x48, 0x4c, 0xd5, 0xa5, 0x42, 0x95, 0x43, 0x30, 0x6a, 0xc2, 0x92, 0x7b, 0xa2, 0x0e, 0x34,
+	0xfd, 0x10, 0x13, 0x4f, 0x75, 0xb1, 0x8e, 0xcd, 0x23, 0xcc, 0xa3, 0xf7, 0x29, 0x65, 0xd1, 0x3d,
+	0x79, 0xc6, 0x29, 0x8a, 0x20, 0xa0, 0xdb, 0x70, 0x2e, 0x05, 0xaf, 0x3a, 0x87, 0x6c, 0x9a, 0xa6,
+	0x94, 0xa5, 0x11, 0x96, 0xa7, 0x87, 0xb4, 0x13, 0x92, 0xd2, 0xc9, 0x24, 0xef, 0x84, 0x8c, 0x74,
+	0x72, 0x0b, 0x50, 0x04, 0x8f, 0x2d, 0x93, 0x10, 0xcc, 0x37, 0x94, 0x29, 0xa5, 0x16, 0xc0, 0x3b,
+	0xbc, 0x5d, 0xfe, 0xbb, 0x04, 0xe7, 0x42, 0x37, 0x65, 0x06, 0xf1, 0x0d, 0x77, 0x09, 0xc0, 0x5f,
+	0x53, 0x81, 0x01, 0x67, 0x45, 0xcb, 0x2e, 0x1d, 0x4c, 0xd9, 0xb4, 0x09, 0x76, 0x8f, 0xb4, 0x3e,
+	0x1b, 0x71, 0x75, 0xeb, 0x3c, 0x9d, 0x97, 0x56, 0xaf, 0xe7, 0xe2, 0x9e, 0xd8, 0x11, 0x38, 0x59,
+	0x09, 0x80, 0xa8, 0x0d, 0x0b, 0x1e, 0xd1, 0x5c, 0xa2, 0x86, 0x16, 0x2f, 0xf6, 0xd0, 0x2a, 0x63,
+	0x09, 0xbe, 0xd1, 0xe7, 0x50, 0xc1, 0xb6, 0x11, 0x11, 0x51, 0xec, 0xa6, 0xf3, 0xd8, 0x36, 0x82,
+	0x2f, 0xf9, 0x6f, 0x12, 0x2c, 0xfb, 0xa7, 0xb3, 0x7f, 0x5a, 0xec, 0x7b, 0x5a, 0x0f, 0xa3, 0xab,
+	0x50, 0xb1, 0x4c, 0x5b, 0x0d, 0xb7, 0x2f, 0x7e, 0x1a, 0xcf, 0x5b, 0xa6, 0xbd, 0xe3, 0xb7, 0x31,
+	0x90, 0x76, 0x12, 0x01, 0x95, 0x04, 0x48, 0x3b, 0x09, 0x41, 0xeb, 0x50, 0xa5, 0x20, 0x63, 0x48,
+	0x4e, 0x55, 0x9d, 0x76, 0xc0, 0x06, 0x5a, 0x62, 0xa8, 0xa0, 0x53, 0x74, 0x1b, 0x66, 0x34, 0xd3,
+	0xa5, 0x23, 0x11, 0x83, 0xb8, 0x30, 0x32, 0x88, 0x6d, 0xff, 0xfc, 0xf2, 0x91, 0xe8, 0x63, 0x98,
+	0x3e, 0x18, 0x1a, 0x3d, 0xec, 0x2f, 0xb0, 0x1c, 0x1e, 0x01, 0x94, 0xff, 0x4f, 0x62, 0x89, 0x80,
+	0xf8, 0x34, 0x8b, 0x2d, 0x69, 0x03, 0xa6, 0x5d, 0xec, 0x0d, 0xfb, 0x34, 0xf4, 0x98, 0xd8, 0x98,
+	0xdb, 0xaa, 0x45, 0x96, 0x17, 0x47, 0x0a, 0x3a, 0x6a, 0x43, 0x2d, 0x1c, 0x8f, 0x3a, 0xa4, 0x16,
+	0xab, 0x97, 0x18, 0xcf, 0x85, 0x08, 0x4f, 0xdc, 0xa4, 0x4a, 0xd5, 0x88, 0x7d, 0xcb, 0x3f, 0x2d,
+	0xc1, 0x02, 0x0f, 0x3e, 0x83, 0x48, 0x20, 0xf7, 0x8c, 0xed, 0xba, 0x56, 0x70, 0xae, 0xf1, 0xe3,
+	0x0b, 0xba, 0xae, 0xe5, 0x9f, 0x6b, 0x4b, 0x30, 0xc5, 0x22, 0x7e, 0x71, 0xcc, 0x4e, 0xd2, 0xfb,
+	0x04, 0x5a, 0x86, 0xe9, 0xae, 0x3a, 0x70, 0x5c, 0x3f, 0x9c, 0x99, 0xea, 0x3e, 0x73, 0x5c, 0x42,
+	0xcf, 0x25, 0x16, 0x11, 0xb8, 0x96, 0x58, 0x10, 0x65, 0x25, 0x6c, 0x60, 0x8b, 0x59, 0x23, 0x58,
+	0xed, 0x9b, 0x96, 0x49, 0x54, 0x7c, 0xa2, 0x63, 0x6c, 0x60, 0x83, 0x1d, 0x54, 0x65, 0x65, 0x91,
+	0x92, 0x1e, 0x51, 0x4a, 0x47, 0x10, 0xc4, 0xbe, 0x42, 0x2f, 0x2a, 0x13, 0xec, 0xb0, 0xba, 0x0b,
+	0x80, 0x4f, 0x06, 0xa6, 0x8b, 0x3d, 0xba, 0xf5, 0x95, 0x8b, 0xf7, 0x11, 0x81, 0x6e, 0x11, 0xf9,
+	0x81, 0x9f, 0xe4, 0x4b, 0xd8, 0xc5, 0x5f, 0x89, 0xd7, 0x61, 0xd2, 0x24, 0xd8, 0x12, 0x9b, 0xd3,
+	0x52, 0x18, 0xbe, 0x87, 0x48, 0x06, 0x90, 0xef, 0xc1, 0xda, 0x4e, 0x7f, 0xe8, 0xbd, 0x8a, 0x50,
+	0xc7, 0x0f, 0x5b, 0xff, 0x03, 0x56, 0xa2, 0xb7, 0xd0, 0xf1, 0xe3, 0x34, 0x6e, 0x89, 0x52, 0x60,
+	0x89, 0xb4, 0x39, 0x91, 0xef, 0xc3, 0xd5, 0xe0, 0xba, 0x12, 0x88, 0x7e, 0x83, 0xa0, 0xfa, 0x39,
+	0xac, 0xe7, 0xf3, 0x0b, 0x6f, 0xbe, 0x01, 0x53, 0xd4, 0x14, 0x9e, 0x70, 0xe6, 0x54, 0x63, 0x71,
+	0x84, 0x50, 0xe9, 0x09, 0x3e, 0x61, 0x37, 0x48, 0x1a, 0x6e, 0xd3, 0x5b, 0xe2, 0xf8, 0x2a, 0xdd,
+	0x83, 0xf5, 0x7c, 0x7e, 0xa1, 0x52, 0x60, 0x0f, 0x29, 0x62, 0x8f, 0x16, 0xac, 0xed, 0x11, 0x17,
+	0x6b, 0xd6, 0x8e, 0xab, 0x59, 0xf8, 0x91, 0xd3, 0xa3, 0x63, 0x49, 0x1c, 0x5d, 0xf9, 0x3b, 0xb0,
+	0xfc, 0x2b, 0x09, 0xae, 0xe4, 0xc8, 0x10, 0xbd, 0xdf, 0x87, 0x9a, 0xb8, 0x5f, 0x74, 0x29, 0x4a,
+	0xf5, 0x30, 0x09, 0xd2, 0x9e, 0xbd, 0xe3, 0xe6, 0x3e, 0xa3, 0x31, 0x01, 0x7b, 0x98, 0x3c, 0x3c,
+	0xa3, 0x54, 0x87, 0xb1, 0x16, 0xf4, 0x29, 0x54, 0x0d, 0x31, 0x3c, 0x2e, 0x41, 0x84, 0x23, 0x8b,
+	0x94, 0x3b, 0x18, 0x38, 0x25, 0x3c, 0x3c, 0xa3, 0x54, 0x8c, 0x68, 0xc3, 0x17, 0x33, 0x30, 0xc5,
+	0x58, 0xe4, 0x4f, 0x61, 0x75, 0x54, 0xd3, 0x31, 0x2f, 0xc8, 0xbf, 0x94, 0x60, 0x2d, 0x9b, 0xf9,
+	0x5f, 0x69, 0x94, 0x2f, 0x59, 0xc8, 0xf7, 0x92, 0x87, 0xc1, 0x81, 0x6a, 0x75, 0x98, 0xf1, 0xc3,
+	0x66, 0xaa, 0xd1, 0xac, 0xe2, 0x7f, 0xa2, 0x0f, 0xe8, 0xce, 0xdb, 0xf3, 0xa3, 0xdb, 0xea, 0x56,
+	0xb5, 0x29, 0x9e, 0xaa, 0x14, 0xd6, 0xaa, 0x08, 0x2a, 0xbd, 0xeb, 0x57, 0x1f, 0xc4, 0x82, 0xd8,
+	0x91, 0x50, 0x99, 0xde, 0x8c, 0x5e, 0x69, 0xb6, 0x8d, 0xfb, 0x1e, 0xdb, 0x92, 0x2b, 0x4a, 0xf0,
+	0x8d, 0x3a, 0x50, 0xc5, 0x27, 0xc4, 0xd5, 0xd4, 0x00, 0x31, 0xc1, 0xd6, 0xc6, 0xe5, 0xc8, 0xa6,
+	0x2d, 0xe4, 0x76, 0x28, 0xae, 0xcd, 0x61, 0x4a, 0x05, 0x47, 0xbe, 0x3c, 0x74, 0x0d, 0xaa, 0x07,
+	0x58, 0xd3, 0x1d, 0x5b, 0xc5, 0xb6, 0x76, 0xd0, 0x17, 0x31, 0x48, 0x59, 0xa9, 0xf0, 0xd6, 0x0e,
+	0x6f, 0x94, 0xff, 0x2c, 0x41, 0x23, 0x5b, 0x28, 0xda, 0x02, 0xb0, 0x1c, 0x63, 0xd8, 0x0f, 0x13,
+	0x12, 0xd5, 0x2d, 0xe4, 0x8f, 0xfb, 0x71, 0x40, 0x51, 0x22, 0xa8, 0xf8, 0x85, 0xa2, 0x94, 0xbc,
+	0x50, 0xac, 0xc0, 0xec, 0x81, 0x66, 0x1b, 0xc7, 0xa6, 0x41, 0x5e, 0x89, 0x2d, 0x27, 0x6c, 0xa0,
+	0xd6, 0x3f, 0x30, 0x09, 0xdd, 0xbe, 0xc5, 0x61, 0xe0, 0x7f, 0xa2, 0x0f, 0x61, 0xd1, 0x1b, 0xb8,
+	0x58, 0x33, 0x68, 0x86, 0xb4, 0xab, 0xe9, 0xc4, 0x71, 0xf9, 0xad, 0xb2, 0xa2, 0xd4, 0x02, 0xc2,
+	0x0e, 0x6f, 0x0f, 0xdf, 0x78, 0xe2, 0x43, 0x8b, 0x3c, 0x2d, 0x24, 0xee, 0x1f, 0xd1, 0xa7, 0x85,
+	0x04, 0x4f, 0x35, 0x7e, 0x21, 0x09, 0xdf, 0x78, 0x92, 0xb2, 0x73, 0xdf, 0x78, 0xd2, 0x15, 0xc9,
+	0x78, 0xe3, 0xc9, 0x90, 0xfc, 0x36, 0x6a, 0xbf, 0xef, 0x37, 0x9e, 0x77, 0x30, 0x11, 0xc1, 0x1b,
+	0xcf, 0x78, 0xb6, 0xfd, 0x87, 0x04, 0xd5, 0xc7, 0xc3, 0x3e, 0x31, 0x75, 0x9a, 0x2d, 0x71, 0x9d,
+	0xe1, 0x60, 0x64, 0x59, 0x9e, 0x87, 0x19, 0x4b, 0x8f, 0xa6, 0x5e, 0xa7, 0x2d, 0x9d, 0x65, 0x5e,
+	0x57, 0x61, 0xde, 0xd2, 0x45, 0x52, 0x35, 0x4c, 0xbb, 0xce, 0x5a, 0x3a, 0xcd, 0xa8, 0xd2, 0x5c,
+	0x69, 0x70, 0x68, 0x4c, 0x46, 0x02, 0x9b, 0x3b, 0x00, 0x3d, 0xda, 0x0f, 0xcf, 0x38, 0x4c, 0xb1,
+	0xc5, 0xc3, 0xf2, 0x70, 0x71, 0x35, 0x68, 0xf6, 0x41, 0x99, 0xed, 0xf9, 0x7f, 0x93, 0x57, 0xee,
+	0xf8, 0x7a, 0x9a, 0x49, 0xae, 0xa7, 0x0d, 0xa8, 0x0d, 0xe8, 0x92, 0xf0, 0xfa, 0x0e, 0x51, 0x45,
+	0x3e, 0x82, 0xa7, 0x5b, 0xab, 0xb4, 0x7d, 0xaf, 0xef, 0x90, 0x67, 0xac, 0x35, 0x5c, 0x14, 0xf1,
+	0xee, 0x23, 0x73, 0x61, 0xf9, 0x04, 0x95, 0x69, 0x13, 0x9d, 0x8b, 0x04, 0x4f, 0xd5, 0x8a, 0x7d,
+	0x87, 0x8b, 0x22, 0x29, 0x3b, 0x77, 0x51, 0xa4, 0x2b, 0x92, 0xb1, 0x28, 0x32, 0x24, 0xbf, 0x8d,
+	0xda, 0xef, 0x7b, 0x51, 0xbc, 0x83, 0x89, 0x08, 0x16, 0xc5, 0x78, 0xb6, 0x35, 0x61, 0xad, 0x65,
+	0x18, 0xfc, 0x74, 0x7e, 0xe1, 0xa4, 0xf3, 0x64, 0x46, 0x92, 0xb7, 0x00, 0x25, 0x14, 0x0d, 0x5f,
+	0xc3, 0x6a, 0x71, 0xbd, 0x76, 0x0d, 0xd9, 0x86, 0x6b, 0x0a, 0xb6, 0x9c, 0x23, 0x11, 0xb0, 0xee,
+	0xb8, 0x8e, 0xf5, 0x4e, 0xfb, 0xfb, 0x89, 0x04, 0x28, 0xe8, 0x20, 0xbc, 0xbc, 0xa4, 0x0b, 0x91,
+	0xd2, 0x85, 0xfc, 0xa8, 0x37, 0x1a, 0xb9, 0x0f, 0x6b, 0x1d, 0xfb, 0x35, 0xd5, 0x64, 0x54, 0x2f,
+	0x7f, 0xf0, 0x0f, 0xe1, 0x6c, 0xa8, 0x1e, 0xc3, 0xaa, 0x91, 0xcb, 0x44, 0x7c, 0xf7, 0x08, 0x99,
+	0x91, 0x35, 0xd2, 0x26, 0x7f, 0x0f, 0x1f, 0xb2, 0xdb, 0x45, 0x1c, 0xbe, 0xe3, 0xb8, 0xe9, 0x56,
+	0x7f, 0x23, 0xbb, 0xc8, 0xff, 0x09, 0xcd, 0xe8, 0x92, 0x8c, 0x85, 0xf8, 0x3f, 0x86, 0xfc, 0xff,
+	0x81, 0xcd, 0xb1, 0xe5, 0x8b, 0x8d, 0xe0, 0x4b, 0x58, 0x4e, 0xb3, 0x9c, 0x7f, 0xb5, 0xc8, 0x32,
+	0xdd, 0xd2, 0xa8, 0xe9, 0xbc, 0x9b, 0x2b, 0x50, 0x56, 0xbe, 0xf9, 0xda, 0xb4, 0x0d, 0xe7, 0x18,
+	0xcd, 0xc0, 0x84, 0xf2, 0xcd, 0xc7, 0xb5, 0x33, 0xfc, 0xcf, 0x56, 0x4d, 0xba, 0xd9, 0x87, 0xa5,
+	0x94, 0xbc, 0x09, 0x02, 0x98, 0xde, 0xeb, 0xb4, 0x9f, 0x3e, 0xd9, 0xae, 0x9d, 0xa1, 0xff, 0x1f,
+	0xef, 0x3e, 0xd9, 0x7f, 0xd1, 0xa9, 0x49, 0xa8, 0x0c, 0x93, 0x0f, 0x9f, 0xee, 0x2b, 0xb5, 0x12,
+	0x95, 0xb0, 0xdd, 0xfa, 0xb6, 0x36, 0x41, 0x9b, 0xbe, 0xee, 0x74, 0xbe, 0xaa, 0x4d, 0xa2, 0x59,
+	0x98, 0x7a, 0xfc, 0xf4, 0xc9, 0x8b, 0x87, 0xb5, 0x29, 0x34, 0x07, 0x33, 0xcf, 0xf7, 0x5b, 0xca,
+	0x8b, 0x8e, 0x52, 0x9b, 0xa6, 0x88, 0x6f, 0x3b, 0x2d, 0xa5, 0x36, 0x73, 0xb3, 0x09, 0x28, 0x3e,
+	0x62, 0x76, 0x48, 0xcc, 0xc1, 0x4c, 0xfb, 0x51, 0x6b, 0x6f, 0x4f, 0xfd, 0xa2, 0x76, 0x26, 0xfc,
+	0x68, 0xd7, 0xa4, 0xad, 0xbf, 0xca, 0x70, 0xf6, 0x09, 0x26, 0xc7, 0x8e, 0x7b, 0xb8, 0xc7, 0xaa,
+	0xa4, 0x44, 0x59, 0x0c, 0xfa, 0xde, 0xcf, 0xa3, 0xc6, 0xeb, 0x64, 0xd0, 0x2a, 0xb5, 0x4c, 0x4e,
+	0x99, 0x54, 0x63, 0x2d, 0x1b, 0xc0, 0x6d, 0x2f, 0x9f, 0x41, 0x0a, 0xcb, 0xb2, 0x26, 0x24, 0xaf,
+	0xb0, 0x53, 0x3c, 0xa3, 0xe8, 0xa9, 0x71, 0x29, 0x83, 0x1a, 0xc8, 0x7c, 0xee, 0xa7, 0x18, 0xd3,
+	0x14, 0xce, 0x29, 0x27, 0x6a, 0x9c, 0x1b, 0xd9, 0x87, 0x3b, 0xb4, 0x14, 0x8d, 0x8b, 0x4c, 0xab,
+	0x15, 0xe2, 0x22, 0x73, 0xaa, 0x88, 0x72, 0x44, 0x06, 0x66, 0x8d, 0x97, 0x9a, 0x44, 0xcd, 0x9a,
+	0x5a, 0x84, 0xd2, 0x58, 0xcb, 0x06, 0x24, 0xcc, 0x9a, 0x90, 0xec, 0x9b, 0x35, 0x5d, 0xec, 0xa5,
+	0x0c, 0xea, 0xa8, 0x59, 0xd3, 0x14, 0xce, 0xa9, 0xc8, 0x19, 0xc7, 0xac, 0x69, 0x22, 0x73, 0x0a,
+	0x71, 0x72, 0x44, 0x7e, 0x13, 0x2f, 0x5c, 0xf0, 0x25, 0x5e, 0x0e, 0x8d, 0x96, 0x56, 0xd4, 0xd1,
+	0x58, 0xcd, 0xa4, 0x07, 0xe3, 0x7f, 0x1a, 0xa9, 0x6b, 0xf0, 0xc5, 0x5e, 0x14, 0x46, 0x4b, 0x95,
+	0xb9, 0x92, 0x4e, 0x8c, 0x08, 0x5c, 0x4a, 0x29, 0x5f, 0xe1, 0xaa, 0x66, 0xd7, 0xb5, 0xe4, 0x8c,
+	0xfd, 0x69, 0xbc, 0xc2, 0x20, 0x26, 0x30, 0xbb, 0xa0, 0x25, 0x47, 0x60, 0x0b, 0xe6, 0xa3, 0x36,
+	0x41, 0xe7, 0x93, 0x56, 0x2a, 0x16, 0xf1, 0x29, 0xcc, 0x06, 0x26, 0x40, 0x67, 0x63, 0x16, 0xf1,
+	0x99, 0x97, 0x13, 0xad, 0x81, 0x81, 0x5a, 0x30, 0x1f, 0xb5, 0x03, 0xef, 0x3e, 0xa5, 0xfc, 0x22,
+	0x7f, 0x04, 0xd1, 0x91, 0x73, 0x11, 0x29, 0x65, 0x18, 0x39, 0x22, 0x3a, 0x50, 0x8d, 0x97, 0x12,
+	0x20, 0x96, 0x07, 0x4d, 0x2d, 0x2f, 0xc8, 0x11, 0xb3, 0x4b, 0xab, 0x39, 0xe2, 0x55, 0x03, 0xdc,
+	0x7d, 0x32, 0x6a, 0x09, 0xf2, 0x7d, 0x3c, 0xa5, 0x28, 0x80, 0xcf, 0x73, 0x76, 0x95, 0x41, 0x63,
+	0x35, 0x93, 0x1e, 0x58, 0xfc, 0x7f, 0x25, 0xb8, 0x52, 0xf8, 0xa8, 0x8d, 0x6e, 0x09, 0x41, 0x63,
+	0xbd, 0xa0, 0x37, 0x3e, 0x1a, 0x13, 0x1d, 0x28, 0xb1, 0x07, 0xcb, 0xa9, 0x89, 0x52, 0xb4, 0x96,
+	0x74, 0xbf, 0x64, 0x18, 0x94, 0xbb, 0xdd, 0x5e, 0xc8, 0x4c, 0x9a, 0xa2, 0x75, 0x56, 0xe5, 0x50,
+	0x90, 0x53, 0xcd, 0x11, 0xee, 0xc1, 0x4a, 0x5e, 0xda, 0x12, 0x5d, 0x8f, 0x59, 0x3e, 0x3b, 0x31,
+	0xda, 0xd8, 0x28, 0x06, 0x46, 0xcd, 0x94, 0x9a, 0xc9, 0xe5, 0x66, 0xca, 0x4b, 0xf2, 0x16, 0x8e,
+	0x24, 0x33, 0xdb, 0x19, 0x8c, 0xa4, 0x28, 0x9f, 0xda, 0xd8, 0x28, 0x06, 0x06, 0x23, 0xf9, 0x12,
+	0x6a, 0xc9, 0xb2, 0x03, 0x94, 0xa1, 0x62, 0xb0, 0xa9, 0xa6, 0x16, 0x29, 0xf0, 0x79, 0xce, 0xac,
+	0x45, 0xe0, 0xf3, 0x5c, 0x54, 0xaa, 0x90, 0x63, 0x1d, 0x1d, 0x2e, 0xe5, 0x56, 0x1f, 0xa0, 0x8d,
+	0xa0, 0x5c, 0xa6, 0xa0, 0x40, 0x21, 0xa7, 0x93, 0xcf, 0x61, 0x2e, 0x52, 0xa0, 0x80, 0x92, 0x15,
+	0x38, 0xc5, 0x02, 0xf6, 0xe1, 0x5c, 0x7a, 0x1d, 0x01, 0xba, 0xc2, 0xcb, 0xa2, 0x73, 0x6a, 0x0c,
+	0x72, 0xc4, 0xb6, 0xa1, 0x12, 0x4b, 0x60, 0xa1, 0x7a, 0x68, 0xcd, 0x78, 0x4a, 0x3b, 0x47, 0xc8,
+	0x67, 0x00, 0xe1, 0x56, 0x80, 0x96, 0xe3, 0x5b, 0x43, 0xc8, 0x9e, 0x68, 0x0e, 0x66, 0xb7, 0x0d,
+	0x95, 0x58, 0x5e, 0x88, 0xeb, 0x90, 0xf6, 0xa0, 0x9c, 0x3f, 0x90, 0x58, 0x02, 0x88, 0x0b, 0x49,
+	0x7b, 0x56, 0x1e, 0x27, 0x7c, 0x4b, 0xa4, 0x6c, 0x57, 0x47, 0x8c, 0x92, 0x1d, 0xbe, 0xa5, 0xe7,
+	0xeb, 0x82, 0xf0, 0x2d, 0x21, 0x79, 0x25, 0x6e, 0x95, 0x8c, 0xf0, 0x2d, 0x53, 0xe6, 0xf3, 0xc4,
+	0xc3, 0x7b, 0x4a, 0xf8, 0x96, 0x2e, 0x79, 0x8c, 0xf0, 0x2d, 0x4d, 0x64, 0x4e, 0x8e, 0x2d, 0x47,
+	0xe4, 0x23, 0x58, 0x48, 0xbc, 0x60, 0xa2, 0x46, 0x7c, 0x64, 0xd1, 0xd7, 0xeb, 0xc6, 0xc5, 0x54,
+	0x5a, 0x30, 0xe6, 0x3e, 0x5c, 0xc8, 0x7c, 0x3a, 0xe1, 0x9b, 0x41, 0xd1, 0xeb, 0x4c, 0xe3, 0x5a,
+	0x01, 0xca, 0xef, 0xeb, 0xdf, 0x24, 0x64, 0x42, 0x3d, 0xeb, 0x05, 0x03, 0x5d, 0x4d, 0x17, 0x13,
+	0x3f, 0xf1, 0xd7, 0xf3, 0x41, 0x91, 0xae, 0xee, 0x03, 0x84, 0x6f, 0x10, 0x99, 0x7b, 0xa5, 0xbf,
+	0x8e, 0x12, 0x6f, 0x15, 0x51, 0xef, 0x4d, 0x64, 0x36, 0x23, 0xde, 0x9b, 0x7a, 0x1d, 0x6f, 0xac,
+	0x65, 0x03, 0x12, 0xde, 0x9b, 0x90, 0xec, 0x7b, 0x6f, 0xba, 0xd8, 0x4b, 0x19, 0xd4, 0x51, 0xef,
+	0x4d, 0x53, 0x38, 0x27, 0x2b, 0x36, 0x8e, 0xf7, 0xa6, 0x89, 0xcc, 0x49, 0x86, 0xe5, 0x07, 0x19,
+	0x99, 0x69, 0x31, 0xee, 0x6f, 0x45, 0x59, 0xb3, 0x1c, 0xe1, 0x18, 0x2e, 0xe7, 0x27, 0xc2, 0xd0,
+	0x0d, 0xda, 0xc3, 0x58, 0xc9, 0xb2, 0xfc, 0x31, 0x64, 0x66, 0x9b, 0xf8, 0x18, 0x8a, 0x92, 0x51,
+	0x39, 0xc2, 0x5f, 0xc3, 0xfa, 0x38, 0xc9, 0x25, 0xb4, 0x19, 0x04, 0x64, 0xe3, 0xa5, 0xa1, 0x72,
+	0xba, 0xfc, 0x99, 0x04, 0xd7, 0xc7, 0xcc, 0x09, 0xa1, 0xad, 0xa4, 0x1b, 0x16, 0x27, 0xa8, 0x1a,
+	0xb7, 0xdf, 0x88, 0xc7, 0x77, 0xe8, 0x83, 0x69, 0xa6, 0xe8, 0xed, 0x7f, 0x0e, 0x00, 0x7a, 0xb0,
+	0x43, 0x72, 0x4d, 0x37, 0x00, 0x00,
 }
@@ -40,7 +40,37 @@ func (x RatePolicy) String() string {
 	return proto.EnumName(RatePolicy_name, int32(x))
 }
 func (RatePolicy) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_profiles_9be6d47fc7143253, []int{0}
+	return fileDescriptor_profiles_bf03657f3dcfe4f2, []int{0}
+}
+
+type GatewayDiversityPolicy int32
+
+const (
+	// Forward the uplink with the min_gw_diversity_violated flag set.
+	GatewayDiversityPolicy_DIVERSITY_MARK GatewayDiversityPolicy = 0
+	// Drop the uplink.
+	GatewayDiversityPolicy_DIVERSITY_DROP GatewayDiversityPolicy = 1
+	// Forward the uplink with the min_gw_diversity_violated flag set and
+	// report the violation as error to the application-server.
+	GatewayDiversityPolicy_DIVERSITY_REPORT GatewayDiversityPolicy = 2
+)
+
+var GatewayDiversityPolicy_name = map[int32]string{
+	0: "DIVERSITY_MARK",
+	1: "DIVERSITY_DROP",
+	2: "DIVERSITY_REPORT",
+}
+var GatewayDiversityPolicy_value = map[string]int32{
+	"DIVERSITY_MARK":   0,
+	"DIVERSITY_DROP":   1,
+	"DIVERSITY_REPORT": 2,
+}
+
+func (x GatewayDiversityPolicy) String() string {
+	return proto.EnumName(GatewayDiversityPolicy_name, int32(x))
+}
+func (GatewayDiversityPolicy) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_profiles_bf03657f3dcfe4f2, []int{1}
 }
 
 type ServiceProfile struct {
@@ -83,7 +113,15 @@ type ServiceProfile struct {
 	// Target Packet Error Rate.
 	TargetPer uint32 `protobuf:"varint,19,opt,name=target_per,json=targetPer,proto3" json:"target_per,omitempty"`
 	// Minimum number of receiving GWs (informative).
-	MinGwDiversity       uint32   `protobuf:"varint,20,opt,name=min_gw_diversity,json=minGwDiversity,proto3" json:"min_gw_diversity,omitempty"`
+	MinGwDiversity uint32 `protobuf:"varint,20,opt,name=min_gw_diversity,json=minGwDiversity,proto3" json:"min_gw_diversity,omitempty"`
+	// Policy applied to uplinks received by fewer than MinGWDiversity gateways.
+	MinGwDiversityPolicy GatewayDiversityPolicy `protobuf:"varint,21,opt,name=min_gw_diversity_policy,json=minGwDiversityPolicy,proto3,enum=ns.GatewayDiversityPolicy" json:"min_gw_diversity_policy,omitempty"`
+	// RX1 downlink frequency (Hz) for each uplink channel (by index).
+	// A frequency of 0 uses the band default (DlChannelReq).
+	DlChannelFrequencies []uint32 `protobuf:"varint,22,rep,packed,name=dl_channel_frequencies,json=dlChannelFrequencies,proto3" json:"dl_channel_frequencies,omitempty"`
+	// Number of retransmissions of an unacknowledged confirmed downlink.
+	// Each retransmission uses the same frame-counter.
+	DlConfirmedRetries   uint32   `protobuf:"varint,23,opt,name=dl_confirmed_retries,json=dlConfirmedRetries,proto3" json:"dl_confirmed_retries,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -93,7 +131,7 @@ func (m *ServiceProfile) Reset()         { *m = ServiceProfile{} }
 func (m *ServiceProfile) String() string { return proto.CompactTextString(m) }
 func (*ServiceProfile) ProtoMessage()    {}
 func (*ServiceProfile) Descriptor() ([]byte, []int) {
-	return fileDescriptor_profiles_9be6d47fc7143253, []int{0}
+	return fileDescriptor_profiles_bf03657f3dcfe4f2, []int{0}
 }
 func (m *ServiceProfile) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ServiceProfile.Unmarshal(m, b)
@@ -253,6 +291,27 @@ func (m *ServiceProfile) GetMinGwDiversity() uint32 {
 	return 0
 }
 
+func (m *ServiceProfile) GetMinGwDiversityPolicy() GatewayDiversityPolicy {
+	if m != nil {
+		return m.MinGwDiversityPolicy
+	}
+	return GatewayDiversityPolicy_DIVERSITY_MARK
+}
+
+func (m *ServiceProfile) GetDlChannelFrequencies() []uint32 {
+	if m != nil {
+		return m.DlChannelFrequencies
+	}
+	return nil
+}
+
+func (m *ServiceProfile) GetDlConfirmedRetries() uint32 {
+	if m != nil {
+		return m.DlConfirmedRetries
+	}
+	return 0
+}
+
 type DeviceProfile struct {
 	// Device-profile ID.
 	Id []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -293,17 +352,38 @@ type DeviceProfile struct {
 	// RF region name.
 	RfRegion string `protobuf:"bytes,19,opt,name=rf_region,json=rfRegion,proto3" json:"rf_region,omitempty"`
 	// End-Device uses 32bit FCnt (mandatory for LoRaWAN 1.0 End-Device).
-	Supports_32BitFCnt   bool     `protobuf:"varint,20,opt,name=supports_32bit_f_cnt,json=supports32bitFCnt,proto3" json:"supports_32bit_f_cnt,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Supports_32BitFCnt bool `protobuf:"varint,20,opt,name=supports_32bit_f_cnt,json=supports32bitFCnt,proto3" json:"supports_32bit_f_cnt,omitempty"`
+	// ADR algorithm ID.
+	// When left blank, the default ADR algorithm will be used.
+	AdrAlgorithmId string `protobuf:"bytes,21,opt,name=adr_algorithm_id,json=adrAlgorithmId,proto3" json:"adr_algorithm_id,omitempty"`
+	// Uplink dwell-time is limited to 400ms (TxParamSetupReq).
+	UplinkDwellTime_400Ms bool `protobuf:"varint,22,opt,name=uplink_dwell_time_400ms,json=uplinkDwellTime400ms,proto3" json:"uplink_dwell_time_400ms,omitempty"`
+	// Downlink dwell-time is limited to 400ms (TxParamSetupReq).
+	DownlinkDwellTime_400Ms bool `protobuf:"varint,23,opt,name=downlink_dwell_time_400ms,json=downlinkDwellTime400ms,proto3" json:"downlink_dwell_time_400ms,omitempty"`
+	// ADR_ACK_LIMIT exponent (ADRParamSetupReq, LoRaWAN 1.1+).
+	// When both exponents are 0, the LoRaWAN defaults are used.
+	AdrAckLimitExp uint32 `protobuf:"varint,24,opt,name=adr_ack_limit_exp,json=adrAckLimitExp,proto3" json:"adr_ack_limit_exp,omitempty"`
+	// ADR_ACK_DELAY exponent (ADRParamSetupReq, LoRaWAN 1.1+).
+	AdrAckDelayExp uint32 `protobuf:"varint,25,opt,name=adr_ack_delay_exp,json=adrAckDelayExp,proto3" json:"adr_ack_delay_exp,omitempty"`
+	// Class-B beacon frequency (Hz, BeaconFreqReq).
+	// When 0, the default beacon frequency of the band is used.
+	BeaconFreq uint32 `protobuf:"varint,26,opt,name=beacon_freq,json=beaconFreq,proto3" json:"beacon_freq,omitempty"`
+	// The RX parameters (rx_delay_1, rx_dr_offset_1, rx_datarate_2 and
+	// rx_freq_2) are set. When false, the network-server settings are used.
+	RxParametersSet bool `protobuf:"varint,27,opt,name=rx_parameters_set,json=rxParametersSet,proto3" json:"rx_parameters_set,omitempty"`
+	// The ping-slot parameters (ping_slot_dr and ping_slot_freq) are set.
+	// When false, the network-server Class-B settings are used.
+	PingSlotParametersSet bool     `protobuf:"varint,28,opt,name=ping_slot_parameters_set,json=pingSlotParametersSet,proto3" json:"ping_slot_parameters_set,omitempty"`
+	XXX_NoUnkeyedLiteral  struct{} `json:"-"`
+	XXX_unrecognized      []byte   `json:"-"`
+	XXX_sizecache         int32    `json:"-"`
 }
 
 func (m *DeviceProfile) Reset()         { *m = DeviceProfile{} }
 func (m *DeviceProfile) String() string { return proto.CompactTextString(m) }
 func (*DeviceProfile) ProtoMessage()    {}
 func (*DeviceProfile) Descriptor() ([]byte, []int) {
-	return fileDescriptor_profiles_9be6d47fc7143253, []int{1}
+	return fileDescriptor_profiles_bf03657f3dcfe4f2, []int{1}
 }
 func (m *DeviceProfile) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeviceProfile.Unmarshal(m, b)
@@ -463,6 +543,62 @@ func (m *DeviceProfile) GetSupports_32BitFCnt() bool {
 	return false
 }
 
+func (m *DeviceProfile) GetAdrAlgorithmId() string {
+	if m != nil {
+		return m.AdrAlgorithmId
+	}
+	return ""
+}
+
+func (m *DeviceProfile) GetUplinkDwellTime_400Ms() bool {
+	if m != nil {
+		return m.UplinkDwellTime_400Ms
+	}
+	return false
+}
+
+func (m *DeviceProfile) GetDownlinkDwellTime_400Ms() bool {
+	if m != nil {
+		return m.DownlinkDwellTime_400Ms
+	}
+	return false
+}
+
+func (m *DeviceProfile) GetAdrAckLimitExp() uint32 {
+	if m != nil {
+		return m.AdrAckLimitExp
+	}
+	return 0
+}
+
+func (m *DeviceProfile) GetAdrAckDelayExp() uint32 {
+	if m != nil {
+		return m.AdrAckDelayExp
+	}
+	return 0
+}
+
+func (m *DeviceProfile) GetBeaconFreq() uint32 {
+	if m != nil {
+		return m.BeaconFreq
+	}
+	return 0
+}
+
+func (m *DeviceProfile) GetRxParametersSet() bool {
+	if m != nil {
+		return m.RxParametersSet
+	}
+	return false
+}
+
+func (m *DeviceProfile) GetPingSlotParametersSet() bool {
+	if m != nil {
+		return m.PingSlotParametersSet
+	}
+	return false
+}
+
 type RoutingProfile struct {
 	// ID of the routing profile.
 	Id []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -486,7 +622,7 @@ func (m *RoutingProfile) Reset()         { *m = RoutingProfile{} }
 func (m *RoutingProfile) String() string { return proto.CompactTextString(m) }
 func (*RoutingProfile) ProtoMessage()    {}
 func (*RoutingProfile) Descriptor() ([]byte, []int) {
-	return fileDescriptor_profiles_9be6d47fc7143253, []int{2}
+	return fileDescriptor_profiles_bf03657f3dcfe4f2, []int{2}
 }
 func (m *RoutingProfile) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RoutingProfile.Unmarshal(m, b)
@@ -546,68 +682,86 @@ func init() {
 	proto.RegisterType((*DeviceProfile)(nil), "ns.DeviceProfile")
 	proto.RegisterType((*RoutingProfile)(nil), "ns.RoutingProfile")
 	proto.RegisterEnum("ns.RatePolicy", RatePolicy_name, RatePolicy_value)
-}
-
-func init() { proto.RegisterFile("profiles.proto", fileDescriptor_profiles_9be6d47fc7143253) }
-
-var fileDescriptor_profiles_9be6d47fc7143253 = []byte{
-	// 913 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x55, 0x5d, 0x6f, 0xdb, 0x36,
-	0x14, 0x9d, 0xd3, 0xc4, 0xb1, 0x19, 0x4b, 0x75, 0x98, 0x74, 0x55, 0xf7, 0xe9, 0xa5, 0xc3, 0x60,
-	0x0c, 0x58, 0xb6, 0xb8, 0x03, 0x86, 0x3d, 0x36, 0xf6, 0x1a, 0x6c, 0x5d, 0x50, 0x83, 0x19, 0xf6,
-	0x4a, 0x30, 0x22, 0xed, 0x70, 0x96, 0x44, 0xe5, 0x92, 0xb2, 0xa5, 0x3e, 0xee, 0xf7, 0x0e, 0xd8,
-	0x5f, 0x18, 0x78, 0x25, 0x7f, 0x34, 0xdd, 0xf6, 0x26, 0x9d, 0x73, 0x2e, 0x0f, 0xef, 0xe5, 0xa1,
-	0x44, 0xc2, 0x1c, 0xcc, 0x4c, 0x27, 0xca, 0x9e, 0xe7, 0x60, 0x9c, 0xa1, 0x7b, 0x99, 0x3d, 0xfb,
-	0xeb, 0x80, 0x84, 0x37, 0x0a, 0x96, 0x3a, 0x56, 0xd3, 0x9a, 0xa5, 0x21, 0xd9, 0xd3, 0x32, 0x6a,
-	0x0d, 0x5a, 0xc3, 0x1e, 0xdb, 0xd3, 0x92, 0x3e, 0x25, 0x87, 0x45, 0xc2, 0x41, 0x38, 0x15, 0xed,
-	0x0d, 0x5a, 0xc3, 0x80, 0xb5, 0x8b, 0x84, 0x09, 0xa7, 0xe8, 0x97, 0x24, 0x2c, 0x12, 0x7e, 0x5b,
-	0xc4, 0x0b, 0xe5, 0xb8, 0xd5, 0x6f, 0x55, 0xf4, 0x08, 0xf9, 0x5e, 0x91, 0x5c, 0x22, 0x78, 0xa3,
-	0xdf, 0x2a, 0xfa, 0x3d, 0xaa, 0x7c, 0x39, 0xcf, 0x4d, 0xa2, 0xe3, 0x2a, 0xda, 0x1f, 0xb4, 0x86,
-	0xe1, 0x28, 0x3c, 0xcf, 0xec, 0xb9, 0x5f, 0x67, 0x8a, 0xa8, 0xaf, 0xda, 0xbe, 0x79, 0x53, 0xd9,
-	0x98, 0x1e, 0xd4, 0xa6, 0x72, 0x63, 0x2a, 0xdf, 0x35, 0x6d, 0xd7, 0xa6, 0xf2, 0x81, 0xa9, 0x7c,
-	0xd7, 0xf4, 0xf0, 0xdf, 0x4d, 0xe5, 0xae, 0xe9, 0x57, 0xe4, 0xb1, 0x90, 0x92, 0xcf, 0x57, 0x3c,
-	0x55, 0x4e, 0x48, 0xe1, 0x44, 0xd4, 0x19, 0xb4, 0x86, 0x1d, 0x16, 0x08, 0x29, 0xaf, 0x56, 0xd7,
-	0x0d, 0x48, 0xbf, 0x21, 0x27, 0x52, 0x2d, 0xb9, 0x75, 0xc2, 0x15, 0x96, 0x83, 0xba, 0xe7, 0x33,
-	0x50, 0xf7, 0x51, 0x17, 0x37, 0xd2, 0x97, 0x6a, 0x79, 0x83, 0x0c, 0x53, 0xf7, 0xaf, 0x40, 0xdd,
-	0xd3, 0x1f, 0xc9, 0x33, 0x50, 0xb9, 0x01, 0xc7, 0x77, 0xaa, 0x6e, 0x85, 0x73, 0x0a, 0xaa, 0x88,
-	0xa0, 0xc1, 0x87, 0xb5, 0x60, 0xb2, 0x2e, 0xbd, 0xac, 0x59, 0xfa, 0x03, 0x89, 0xde, 0x2f, 0x4d,
-	0x05, 0xcc, 0x75, 0x16, 0x1d, 0x61, 0xe5, 0x93, 0x07, 0x95, 0xd7, 0x48, 0xd2, 0x27, 0xa4, 0x2d,
-	0x81, 0xa7, 0x3a, 0x8b, 0x7a, 0xb8, 0xab, 0x03, 0x09, 0xd7, 0x5b, 0x58, 0x94, 0x51, 0xb0, 0x81,
-	0x45, 0x49, 0xbf, 0x20, 0xbd, 0xf8, 0x4e, 0x64, 0x99, 0x4a, 0x78, 0x2a, 0xec, 0x22, 0x0a, 0xf1,
-	0xf0, 0x8f, 0x1a, 0xec, 0x5a, 0xd8, 0x05, 0xfd, 0x94, 0x90, 0x1c, 0xb8, 0x48, 0x12, 0xb3, 0x52,
-	0x32, 0x7a, 0x8c, 0xde, 0xdd, 0x1c, 0x5e, 0xd6, 0x80, 0xa7, 0xef, 0xb6, 0x74, 0xbf, 0xa6, 0xef,
-	0x76, 0x69, 0x10, 0x1b, 0xfa, 0xb8, 0xa6, 0x41, 0xac, 0xe9, 0xcf, 0xc8, 0x51, 0xb6, 0x5a, 0xf0,
-	0xb9, 0x32, 0x3c, 0x31, 0x71, 0x44, 0x6b, 0x3e, 0x5b, 0x2d, 0xae, 0x94, 0xf9, 0xd5, 0xc4, 0xbe,
-	0xdc, 0x09, 0x98, 0x2b, 0xc7, 0x73, 0x05, 0xd1, 0x09, 0x6e, 0xbd, 0x5b, 0x23, 0x53, 0x05, 0x74,
-	0x48, 0xfa, 0xa9, 0xce, 0xfc, 0xb9, 0x49, 0xbd, 0x54, 0x60, 0xb5, 0xab, 0xa2, 0x53, 0x14, 0x85,
-	0xa9, 0xce, 0xae, 0x56, 0x93, 0x35, 0x7a, 0xf6, 0xf7, 0x01, 0x09, 0x26, 0xea, 0xff, 0xd2, 0x3e,
-	0x24, 0x7d, 0x5b, 0xe4, 0x7e, 0xa4, 0x96, 0xc7, 0x89, 0xb0, 0x96, 0xdf, 0x62, 0xec, 0x3b, 0x2c,
-	0x5c, 0xe3, 0x63, 0x0f, 0x5f, 0xfa, 0xb4, 0x34, 0x02, 0xee, 0x74, 0xaa, 0x4c, 0xe1, 0x9a, 0xfc,
-	0x07, 0x08, 0x5f, 0xfe, 0x56, 0x83, 0x7e, 0xc5, 0x5c, 0x67, 0x73, 0x6e, 0x13, 0x83, 0xfb, 0xd7,
-	0x46, 0xe2, 0x15, 0x08, 0x58, 0xe8, 0xf1, 0x9b, 0xc4, 0xf8, 0x26, 0xb4, 0x91, 0x74, 0x40, 0x7a,
-	0x5b, 0xa5, 0x84, 0x26, 0xf9, 0x64, 0xad, 0x9a, 0x80, 0x4f, 0xff, 0x56, 0x81, 0xa1, 0x6b, 0xd2,
-	0xbf, 0xd6, 0x60, 0xe0, 0xde, 0xef, 0x21, 0xc6, 0xfc, 0x3f, 0xec, 0x61, 0xbc, 0xed, 0x21, 0xde,
-	0xf4, 0xd0, 0xd9, 0xe9, 0x61, 0xbc, 0xee, 0xe1, 0x73, 0x72, 0x94, 0x8a, 0x98, 0xe3, 0x18, 0x4d,
-	0x86, 0x49, 0xef, 0x32, 0x92, 0x8a, 0xf8, 0xf7, 0x1a, 0xa1, 0xe7, 0xe4, 0x04, 0xd4, 0x9c, 0xe7,
-	0x02, 0x44, 0xea, 0xaf, 0xc4, 0x52, 0xa3, 0x90, 0xa0, 0xf0, 0x18, 0xd4, 0x7c, 0x8a, 0x0c, 0x6b,
-	0x08, 0xfa, 0x09, 0x21, 0x50, 0x72, 0xa9, 0x12, 0x51, 0xf1, 0x0b, 0x8c, 0x72, 0xc0, 0x3a, 0x50,
-	0x4e, 0x3c, 0x70, 0x41, 0x9f, 0x93, 0xd0, 0xb3, 0xc0, 0xcd, 0x6c, 0x66, 0x95, 0xe3, 0x17, 0x4d,
-	0x8a, 0x8f, 0xa0, 0x9c, 0xc0, 0x1b, 0xc4, 0x2e, 0xe8, 0x19, 0x09, 0xbc, 0x48, 0x38, 0x81, 0xf7,
-	0x7c, 0xd4, 0x44, 0xda, 0x6b, 0x1a, 0x6c, 0x44, 0x3f, 0x22, 0x5d, 0x28, 0x71, 0x50, 0x7c, 0x84,
-	0xa9, 0x0e, 0xd8, 0x21, 0x94, 0x7e, 0x48, 0x23, 0xfa, 0x1d, 0x39, 0x9d, 0x89, 0xd8, 0x19, 0xa8,
-	0x78, 0x0e, 0xca, 0xdb, 0x78, 0x9d, 0x8d, 0x1e, 0x0f, 0x1e, 0x0d, 0x03, 0x46, 0x1b, 0x6e, 0x8a,
-	0x94, 0xaf, 0xb0, 0xf4, 0x19, 0xe9, 0xa4, 0xa2, 0xe4, 0x4a, 0x43, 0x8e, 0x11, 0x0f, 0xd8, 0x61,
-	0x2a, 0xca, 0x9f, 0x34, 0xe4, 0xfe, 0x60, 0x3c, 0x25, 0x0b, 0x57, 0xf1, 0xb8, 0x8a, 0x13, 0x85,
-	0x21, 0x0f, 0x58, 0x2f, 0x15, 0xe5, 0xa4, 0x70, 0xd5, 0xd8, 0x63, 0xf4, 0x39, 0x09, 0x36, 0x07,
-	0xf3, 0x87, 0xd1, 0x59, 0x93, 0xf4, 0xde, 0x1a, 0xfc, 0xc5, 0xe8, 0x8c, 0x7e, 0x4c, 0xba, 0x30,
-	0xe3, 0xa0, 0xe6, 0x7e, 0x80, 0x27, 0x38, 0xc0, 0x0e, 0xcc, 0x18, 0xbe, 0xd3, 0x6f, 0xc9, 0xe9,
-	0x66, 0x85, 0x17, 0xa3, 0x5b, 0xed, 0xf8, 0x8c, 0xc7, 0x99, 0xc3, 0xb8, 0x77, 0xd8, 0xf1, 0x9a,
-	0x43, 0xea, 0xd5, 0x38, 0x73, 0x67, 0x7f, 0xb6, 0x48, 0xc8, 0x4c, 0xe1, 0x74, 0x36, 0xff, 0xaf,
-	0xc8, 0x9f, 0x90, 0x03, 0x61, 0xb9, 0x96, 0x98, 0xf3, 0x2e, 0xdb, 0x17, 0xf6, 0x67, 0xfc, 0xea,
-	0xc7, 0x82, 0xc7, 0x0a, 0xea, 0x54, 0x77, 0x59, 0x3b, 0x16, 0x63, 0x05, 0xce, 0x0f, 0xc1, 0x25,
-	0xb6, 0x66, 0xf6, 0x91, 0x39, 0x74, 0x89, 0x45, 0xea, 0x29, 0xf1, 0x8f, 0x7c, 0xa1, 0x2a, 0x8c,
-	0x6e, 0x97, 0xb5, 0x5d, 0x62, 0x5f, 0xab, 0xea, 0xeb, 0x01, 0x21, 0x3b, 0x9f, 0xd9, 0x0e, 0xd9,
-	0x9f, 0xb0, 0x37, 0xd3, 0xfe, 0x07, 0xfe, 0xe9, 0xfa, 0x25, 0x7b, 0xdd, 0x6f, 0xdd, 0xb6, 0xf1,
-	0x97, 0xf4, 0xe2, 0x9f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x54, 0x69, 0x9d, 0x8e, 0xa4, 0x06, 0x00,
-	0x00,
+	proto.RegisterEnum("ns.GatewayDiversityPolicy", GatewayDiversityPolicy_name, GatewayDiversityPolicy_value)
+}
+
+func init() { proto.RegisterFile("profiles.proto", fileDescriptor_profiles_bf03657f3dcfe4f2) }
+
+var fileDescriptor_profiles_bf03657f3dcfe4f2 = []byte{
+	// 1193 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x56, 0x5d, 0x73, 0xdb, 0x36,
+	0x16, 0x8d, 0x1c, 0xc7, 0x92, 0x60, 0x91, 0x96, 0x61, 0xc5, 0x66, 0x3e, 0x76, 0xa3, 0x75, 0x76,
+	0x76, 0xb4, 0x99, 0xa9, 0x1b, 0x3b, 0xe9, 0x74, 0xfa, 0x18, 0x4b, 0x8e, 0xc7, 0x4d, 0x3c, 0x51,
+	0xe1, 0x4c, 0x66, 0xfa, 0x84, 0x81, 0x89, 0x2b, 0x19, 0x15, 0xbf, 0x0c, 0x42, 0x16, 0x95, 0xc7,
+	0xfe, 0x99, 0xfe, 0xb4, 0xfe, 0x8d, 0x0e, 0x2e, 0x48, 0x49, 0x76, 0xdc, 0xbe, 0x91, 0xe7, 0x9c,
+	0x8b, 0x8b, 0x8f, 0x73, 0x40, 0x12, 0x3f, 0xd3, 0xe9, 0x48, 0x45, 0x90, 0x1f, 0x64, 0x3a, 0x35,
+	0x29, 0x5d, 0x4b, 0xf2, 0xfd, 0x3f, 0xea, 0xc4, 0xbf, 0x00, 0x7d, 0xa3, 0x42, 0x18, 0x3a, 0x96,
+	0xfa, 0x64, 0x4d, 0xc9, 0xa0, 0xd6, 0xad, 0xf5, 0x5a, 0x6c, 0x4d, 0x49, 0xba, 0x47, 0xea, 0xd3,
+	0x88, 0x6b, 0x61, 0x20, 0x58, 0xeb, 0xd6, 0x7a, 0x1e, 0xdb, 0x98, 0x46, 0x4c, 0x18, 0xa0, 0xff,
+	0x25, 0xfe, 0x34, 0xe2, 0x97, 0xd3, 0x70, 0x02, 0x86, 0xe7, 0xea, 0x2b, 0x04, 0x0f, 0x91, 0x6f,
+	0x4d, 0xa3, 0x63, 0x04, 0x2f, 0xd4, 0x57, 0xa0, 0x6f, 0x89, 0x5f, 0x96, 0xf3, 0x2c, 0x8d, 0x54,
+	0x38, 0x0f, 0xd6, 0xbb, 0xb5, 0x9e, 0x7f, 0xe4, 0x1f, 0x24, 0xf9, 0x81, 0x1d, 0x67, 0x88, 0xa8,
+	0xad, 0x5a, 0xbe, 0xd9, 0xa6, 0xb2, 0x6c, 0xfa, 0xc8, 0x35, 0x95, 0x8b, 0xa6, 0xf2, 0x76, 0xd3,
+	0x0d, 0xd7, 0x54, 0xde, 0x69, 0x2a, 0x6f, 0x37, 0xad, 0xdf, 0xdf, 0x54, 0xae, 0x36, 0xfd, 0x1f,
+	0xd9, 0x12, 0x52, 0xf2, 0xf1, 0x8c, 0xc7, 0x60, 0x84, 0x14, 0x46, 0x04, 0x8d, 0x6e, 0xad, 0xd7,
+	0x60, 0x9e, 0x90, 0xf2, 0x74, 0x76, 0x5e, 0x82, 0xf4, 0x3b, 0xb2, 0x23, 0xe1, 0x86, 0xe7, 0x46,
+	0x98, 0x69, 0xce, 0x35, 0x5c, 0xf3, 0x91, 0x86, 0xeb, 0xa0, 0x89, 0x13, 0x69, 0x4b, 0xb8, 0xb9,
+	0x40, 0x86, 0xc1, 0xf5, 0x7b, 0x0d, 0xd7, 0xf4, 0x27, 0xf2, 0x44, 0x43, 0x96, 0x6a, 0xc3, 0x57,
+	0xaa, 0x2e, 0x85, 0x31, 0xa0, 0xe7, 0x01, 0xc1, 0x06, 0xbb, 0x4e, 0x30, 0xa8, 0x4a, 0x8f, 0x1d,
+	0x4b, 0x7f, 0x24, 0xc1, 0xb7, 0xa5, 0xb1, 0xd0, 0x63, 0x95, 0x04, 0x9b, 0x58, 0xf9, 0xf8, 0x4e,
+	0xe5, 0x39, 0x92, 0xf4, 0x31, 0xd9, 0x90, 0x9a, 0xc7, 0x2a, 0x09, 0x5a, 0x38, 0xab, 0x47, 0x52,
+	0x9f, 0x2f, 0x61, 0x51, 0x04, 0xde, 0x02, 0x16, 0x05, 0xfd, 0x0f, 0x69, 0x85, 0x57, 0x22, 0x49,
+	0x20, 0xe2, 0xb1, 0xc8, 0x27, 0x81, 0x8f, 0x87, 0xbf, 0x59, 0x62, 0xe7, 0x22, 0x9f, 0xd0, 0x7f,
+	0x11, 0x92, 0x69, 0x2e, 0xa2, 0x28, 0x9d, 0x81, 0x0c, 0xb6, 0xb0, 0x77, 0x33, 0xd3, 0xef, 0x1c,
+	0x60, 0xe9, 0xab, 0x25, 0xdd, 0x76, 0xf4, 0xd5, 0x2a, 0xad, 0xc5, 0x82, 0xde, 0x76, 0xb4, 0x16,
+	0x15, 0xfd, 0x6f, 0xb2, 0x99, 0xcc, 0x26, 0x7c, 0x0c, 0x29, 0x8f, 0xd2, 0x30, 0xa0, 0x8e, 0x4f,
+	0x66, 0x93, 0x53, 0x48, 0x3f, 0xa6, 0xa1, 0x2d, 0x37, 0x42, 0x8f, 0xc1, 0xf0, 0x0c, 0x74, 0xb0,
+	0x83, 0x53, 0x6f, 0x3a, 0x64, 0x08, 0x9a, 0xf6, 0x48, 0x3b, 0x56, 0x89, 0x3d, 0x37, 0xa9, 0x6e,
+	0x40, 0xe7, 0xca, 0xcc, 0x83, 0x0e, 0x8a, 0xfc, 0x58, 0x25, 0xa7, 0xb3, 0x41, 0x85, 0xd2, 0x5f,
+	0xc8, 0xde, 0x5d, 0x65, 0x65, 0x90, 0xc7, 0x68, 0x90, 0xa7, 0xd6, 0x20, 0xa7, 0xc2, 0xc0, 0x4c,
+	0xcc, 0x17, 0x65, 0xa5, 0x59, 0x3a, 0xb7, 0x07, 0x73, 0x28, 0x7d, 0x4b, 0x76, 0x65, 0xc4, 0xab,
+	0xed, 0xb3, 0x46, 0x98, 0x42, 0x12, 0x2a, 0xc8, 0x83, 0xdd, 0xee, 0xc3, 0x9e, 0xc7, 0x3a, 0x32,
+	0xea, 0x3b, 0xf2, 0xfd, 0x92, 0xa3, 0xaf, 0x49, 0xc7, 0x56, 0xa5, 0xc9, 0x48, 0xe9, 0x18, 0x24,
+	0xd7, 0x60, 0xb4, 0xad, 0xd9, 0xc3, 0x69, 0x53, 0x19, 0xf5, 0x2b, 0x8a, 0x39, 0x66, 0xff, 0xcf,
+	0x06, 0xf1, 0x06, 0xf0, 0x4f, 0x41, 0xed, 0x91, 0x76, 0x3e, 0xcd, 0xac, 0x1b, 0x72, 0x1e, 0x46,
+	0x22, 0xcf, 0xf9, 0x25, 0x26, 0xb6, 0xc1, 0xfc, 0x0a, 0xef, 0x5b, 0xf8, 0xd8, 0x1a, 0xbd, 0x14,
+	0x70, 0xa3, 0x62, 0x48, 0xa7, 0xa6, 0x8c, 0xae, 0x87, 0xf0, 0xf1, 0x67, 0x07, 0xda, 0x11, 0x33,
+	0x95, 0x8c, 0x79, 0x1e, 0xa5, 0xb8, 0xf5, 0x2a, 0x95, 0x98, 0x5e, 0x8f, 0xf9, 0x16, 0xbf, 0x88,
+	0x52, 0xbb, 0xff, 0x2a, 0x95, 0xb4, 0x4b, 0x5a, 0x4b, 0xa5, 0xd4, 0x65, 0x68, 0x49, 0xa5, 0x1a,
+	0x68, 0x1b, 0xdc, 0xa5, 0x02, 0xf3, 0x52, 0x06, 0xb7, 0xd2, 0x60, 0x56, 0xbe, 0x5d, 0x43, 0x18,
+	0xd4, 0xef, 0x59, 0x43, 0x7f, 0xb9, 0x86, 0x70, 0xb1, 0x86, 0xc6, 0xca, 0x1a, 0xfa, 0xd5, 0x1a,
+	0x5e, 0x90, 0xcd, 0x58, 0x84, 0x1c, 0x0f, 0x2d, 0x4d, 0x30, 0xa4, 0x4d, 0x46, 0x62, 0x11, 0x7e,
+	0x71, 0x08, 0x3d, 0x20, 0x3b, 0x1a, 0xc6, 0x3c, 0x13, 0x5a, 0xc4, 0x36, 0xcd, 0x37, 0x0a, 0x85,
+	0x04, 0x85, 0xdb, 0x1a, 0xc6, 0x43, 0x64, 0x58, 0x49, 0xd0, 0xe7, 0x84, 0xe8, 0x82, 0x4b, 0x88,
+	0xc4, 0x9c, 0x1f, 0x62, 0x0a, 0x3d, 0xd6, 0xd0, 0xc5, 0xc0, 0x02, 0x87, 0xf4, 0x25, 0xf1, 0x2d,
+	0xab, 0x79, 0x3a, 0x1a, 0xe5, 0x60, 0xf8, 0x61, 0x19, 0xc0, 0x4d, 0x5d, 0x0c, 0xf4, 0x27, 0xc4,
+	0x0e, 0xe9, 0x3e, 0xf1, 0xac, 0x48, 0x18, 0x81, 0x57, 0xd4, 0x51, 0xe0, 0x2d, 0x34, 0x25, 0x76,
+	0x44, 0x9f, 0x92, 0xa6, 0x2e, 0x70, 0xa3, 0xf8, 0x11, 0x06, 0xd2, 0x63, 0x75, 0x5d, 0xd8, 0x4d,
+	0x3a, 0xb2, 0xee, 0x19, 0x89, 0xd0, 0xa4, 0x7a, 0xce, 0x33, 0x0d, 0xb6, 0x8d, 0xd5, 0xe5, 0xc1,
+	0x16, 0x3a, 0x8e, 0x96, 0xdc, 0x10, 0x29, 0x5b, 0x91, 0xd3, 0x27, 0xa4, 0x11, 0x8b, 0x82, 0x83,
+	0xd2, 0x19, 0xa6, 0xd3, 0x63, 0xf5, 0x58, 0x14, 0x27, 0x4a, 0x67, 0xf6, 0x60, 0x2c, 0x25, 0xa7,
+	0x66, 0xce, 0xc3, 0x79, 0x18, 0x01, 0xe6, 0xd3, 0x63, 0xad, 0x58, 0x14, 0x83, 0xa9, 0x99, 0xf7,
+	0x2d, 0x46, 0x5f, 0x12, 0x6f, 0x71, 0x30, 0xbf, 0xa5, 0x2a, 0x29, 0x43, 0xda, 0xaa, 0xc0, 0x9f,
+	0x53, 0x95, 0xd0, 0x67, 0xa4, 0xa9, 0x47, 0x5c, 0xc3, 0xd8, 0x6e, 0xe0, 0x0e, 0x6e, 0x60, 0x43,
+	0x8f, 0x18, 0xbe, 0xd3, 0xef, 0x49, 0x67, 0x31, 0xc2, 0x9b, 0xa3, 0x4b, 0x65, 0xf8, 0x88, 0x87,
+	0x89, 0xc1, 0xa4, 0x36, 0xd8, 0x76, 0xc5, 0x21, 0xf5, 0xbe, 0x9f, 0xa0, 0xfb, 0x84, 0xb4, 0x97,
+	0xca, 0x38, 0xd5, 0xca, 0x5c, 0xc5, 0x5c, 0x49, 0x4c, 0x69, 0x93, 0xf9, 0x42, 0xea, 0x77, 0x15,
+	0x7c, 0x26, 0xe9, 0x0f, 0x64, 0x6f, 0x9a, 0x45, 0x2a, 0x99, 0x70, 0x39, 0x83, 0x28, 0x42, 0x43,
+	0xf0, 0xb7, 0xaf, 0x5f, 0xc7, 0x36, 0x84, 0x76, 0xf4, 0x8e, 0xa3, 0x07, 0x96, 0xb5, 0xc6, 0x40,
+	0xce, 0x5e, 0xcc, 0x32, 0x9d, 0x25, 0xf7, 0x17, 0xee, 0xb9, 0x8b, 0xb9, 0x12, 0xdc, 0x29, 0xfd,
+	0x3f, 0xd9, 0xc6, 0xb9, 0x85, 0x13, 0x1e, 0xa9, 0x58, 0x19, 0x0e, 0x45, 0x16, 0x04, 0x2e, 0x1a,
+	0x76, 0x72, 0xe1, 0xe4, 0xa3, 0x85, 0x4f, 0x8a, 0x6c, 0x55, 0xea, 0x4c, 0x63, 0xa5, 0x4f, 0x56,
+	0xa5, 0x68, 0x1d, 0x2b, 0x7d, 0x41, 0x36, 0x2f, 0x41, 0x84, 0x69, 0xe2, 0x02, 0xf2, 0xd4, 0x85,
+	0xc8, 0x41, 0x18, 0x8f, 0x57, 0x64, 0x5b, 0x17, 0xce, 0xaa, 0x60, 0x40, 0xe7, 0x3c, 0x07, 0x13,
+	0x3c, 0xc3, 0x99, 0x6e, 0xe9, 0x62, 0xb8, 0xc0, 0x2f, 0xc0, 0xd8, 0x6f, 0xc7, 0x4a, 0x78, 0x6f,
+	0x97, 0x3c, 0x77, 0xdf, 0x8e, 0x45, 0x88, 0x57, 0x0b, 0xf7, 0x7f, 0xaf, 0x11, 0x9f, 0xa5, 0x53,
+	0xa3, 0x92, 0xf1, 0xdf, 0x5d, 0x35, 0x3b, 0xe4, 0x91, 0xc8, 0xed, 0x79, 0xac, 0xe1, 0x79, 0xac,
+	0x8b, 0xfc, 0x0c, 0x7f, 0x14, 0x42, 0xc1, 0x43, 0xd0, 0xee, 0x36, 0x69, 0xb2, 0x8d, 0x50, 0xf4,
+	0x41, 0x1b, 0x6b, 0x3e, 0x13, 0xe5, 0x8e, 0x59, 0x47, 0xa6, 0x6e, 0xa2, 0x1c, 0xa9, 0x3d, 0x62,
+	0x1f, 0xf9, 0x04, 0xe6, 0x78, 0x65, 0x34, 0xd9, 0x86, 0x89, 0xf2, 0x0f, 0x30, 0x7f, 0xd5, 0x25,
+	0x64, 0xe5, 0xcb, 0xdc, 0x20, 0xeb, 0x03, 0xf6, 0x69, 0xd8, 0x7e, 0x60, 0x9f, 0xce, 0xdf, 0xb1,
+	0x0f, 0xed, 0xda, 0xab, 0x2f, 0x64, 0xf7, 0xfe, 0x8b, 0x9a, 0x52, 0xe2, 0x0f, 0xce, 0xbe, 0x9c,
+	0xb0, 0x8b, 0xb3, 0xcf, 0xbf, 0x72, 0x54, 0x3f, 0xb8, 0x8d, 0xe1, 0x58, 0x35, 0xda, 0x21, 0xed,
+	0x25, 0xc6, 0x4e, 0x86, 0x9f, 0xd8, 0xe7, 0xf6, 0xda, 0xe5, 0x06, 0xfe, 0x1d, 0xbd, 0xf9, 0x6b,
+	0x00, 0xc5, 0xba, 0xea, 0x63, 0x2f, 0x09, 0x00, 0x00,
 }
@@ -133,6 +133,8 @@ get_downlink_data_delay="{{ .NetworkServer.GetDownlinkDataDelay }}"
   # dwell time setting must be set to enforce the max payload size
   # given the dwell-time limitation. For band configuration where the dwell-time is
   # always enforced, setting this flag is not required.
+  # When not set, the dwell-time can be enforced per device-profile
+  # (using the TxParamSetupReq mac-command).
   dwell_time_400ms={{ .NetworkServer.Band.DwellTime400ms }}
 
   # Enforce repeater compatibility
@@ -257,6 +259,9 @@ get_downlink_data_delay="{{ .NetworkServer.GetDownlinkDataDelay }}"
 {{ end }}
 
   # Class B settings
+  #
+  # These ping-slot settings are used when they are not set by the
+  # device-profile.
   [network_server.network_settings.class_b]
   # Ping-slot data-rate.
   ping_slot_dr={{ .NetworkServer.NetworkSettings.ClassB.PingSlotDR }}
@@ -288,6 +293,28 @@ get_downlink_data_delay="{{ .NetworkServer.GetDownlinkDataDelay }}"
   max_time_n={{ .NetworkServer.NetworkSettings.RejoinRequest.MaxTimeN }}
 
 
+  # Class-B and Class-C downlink scheduler settings.
+  #
+  # Enqueueing a downlink for a Class-B or Class-C device triggers the
+  # scheduler immediately. Besides this, the scheduler polls the device-queue
+  # every second for items which are due (e.g. Class-B ping-slots).
+  [network_server.scheduler]
+  # Number of partitions
+  #
+  # Devices are divided over this number of partitions (by DevEUI). Each
+  # LoRa Server instance leases an equal share of the partitions and only
+  # schedules the devices within its own partitions. This value must be
+  # equal for all instances. Set to 0 to disable partitioning (e.g. when
+  # running a single instance).
+  partitions={{ .NetworkServer.Scheduler.Partitions }}
+
+  # Lease duration
+  #
+  # When an instance stops, its partitions are taken over by the other
+  # instances after this duration.
+  lease_duration="{{ .NetworkServer.Scheduler.LeaseDuration }}"
+
+
   # Network-server API
   #
   # This is the network-server API that is used by LoRa App Server or other
@@ -332,6 +359,79 @@ get_downlink_data_delay="{{ .NetworkServer.GetDownlinkDataDelay }}"
   aggregation_intervals=[{{ if .NetworkServer.Gateway.Stats.AggregationIntervals|len }}"{{ end }}{{ range $index, $element := .NetworkServer.Gateway.Stats.AggregationIntervals }}{{ if $index }}", "{{ end }}{{ $element }}{{ end }}{{ if .NetworkServer.Gateway.Stats.AggregationIntervals|len }}"{{ end }}]
 
 
+  # Downlink gateway selection settings.
+  #
+  # For each downlink, the gateways that received the device are ranked.
+  # Gateways that have been seen recently (health) are preferred, then the
+  # gateways within the link-budget margin of the best gateway are
+  # considered, of which the gateway with the lowest tx load is selected.
+  [network_server.gateway.downlink_gateway_selection]
+  # Tie-breaker
+  #
+  # The tie-breaker is used when multiple gateways remain. Valid options are:
+  #   * snr    - highest SNR (then RSSI) of the last uplink
+  #   * rssi   - highest RSSI of the last uplink
+  #   * recent - most recent reception of an uplink
+  #   * mac    - lowest gateway MAC
+  tie_breaker="{{ .NetworkServer.Gateway.DownlinkGatewaySelection.TieBreaker }}"
+
+  # Link-budget margin (dB)
+  #
+  # Gateways with a link-budget within this margin of the best gateway
+  # are considered equal, so that the tx load can be spread.
+  link_budget_margin={{ .NetworkServer.Gateway.DownlinkGatewaySelection.LinkBudgetMargin }}
+
+  # Health timeout
+  #
+  # A gateway is considered healthy when it has sent its statistics within
+  # this duration. Set this to 0 to disable the health check.
+  health_timeout="{{ .NetworkServer.Gateway.DownlinkGatewaySelection.HealthTimeout }}"
+
+
+  # Downlink duty-cycle settings.
+  #
+  # When enabled, LoRa Server keeps track of the downlink airtime per gateway
+  # and sub-band over a rolling window. A downlink is not sent through a
+  # gateway when it would exceed the max. duty-cycle of the sub-band. In that
+  # case an other gateway or RX2 is used, or the Class-B / Class-C downlink
+  # is deferred.
+  [network_server.gateway.duty_cycle]
+  # Enable duty-cycle accounting.
+  enabled={{ .NetworkServer.Gateway.DutyCycle.Enabled }}
+
+  # Window over which the duty-cycle is calculated.
+  window="{{ .NetworkServer.Gateway.DutyCycle.Window }}"
+
+  # Sub-bands and their max. duty-cycle (in percent).
+  #
+  # Frequencies are in Hz, the max. frequency is exclusive. When no sub-bands
+  # are configured and the band is EU_863_870, the ETSI EN 300 220 sub-bands
+  # are used. Frequencies outside the sub-bands are not limited.
+  #
+  # Example:
+  # [[network_server.gateway.duty_cycle.sub_bands]]
+  # min_frequency=869400000
+  # max_frequency=869650000
+  # max_duty_cycle=10
+{{ range $index, $element := .NetworkServer.Gateway.DutyCycle.SubBands }}
+  [[network_server.gateway.duty_cycle.sub_bands]]
+  min_frequency={{ $element.MinFrequency }}
+  max_frequency={{ $element.MaxFrequency }}
+  max_duty_cycle={{ $element.MaxDutyCycle }}
+{{ end }}
+
+  # Gateway backend settings.
+  [network_server.gateway.backend]
+  # Gateway backend type.
+  #
+  # Valid options are:
+  #   * mqtt         - gateways connect through a MQTT broker (e.g. using the
+  #                    LoRa Gateway Bridge)
+  #   * semtech_udp  - gateways running the Semtech UDP packet-forwarder
+  #                    connect directly to LoRa Server
+  type="{{ .NetworkServer.Gateway.Backend.Type }}"
+
+
   # MQTT gateway backend settings.
   #
   # This is the backend communicating with the LoRa gateways over a MQTT broker.
@@ -351,6 +451,18 @@ get_downlink_data_delay="{{ .NetworkServer.GetDownlinkDataDelay }}"
   ack_topic_template="{{ .NetworkServer.Gateway.Backend.MQTT.AckTopicTemplate }}"
   config_topic_template="{{ .NetworkServer.Gateway.Backend.MQTT.ConfigTopicTemplate }}"
 
+  # Payload marshaler.
+  #
+  # This defines how the MQTT payloads are encoded. Valid options are:
+  # * json:     JSON encoding (the LoRa Gateway Bridge JSON format)
+  # * protobuf: Protobuf encoding (more compact, which reduces the backhaul
+  #             traffic)
+  #
+  # When set to protobuf, the encoding of the messages received from the
+  # gateways (uplink, stats and ack) is detected automatically, so that
+  # gateways still sending JSON keep working.
+  marshaler="{{ .NetworkServer.Gateway.Backend.MQTT.Marshaler }}"
+
   # MQTT server (e.g. scheme://host:port where scheme is tcp, ssl or ws)
   server="{{ .NetworkServer.Gateway.Backend.MQTT.Server }}"
 
@@ -398,6 +510,21 @@ get_downlink_data_delay="{{ .NetworkServer.GetDownlinkDataDelay }}"
   tls_key="{{ .NetworkServer.Gateway.Backend.MQTT.TLSKey }}"
 
 
+  # Semtech UDP gateway backend settings.
+  #
+  # This is the backend communicating directly with LoRa gateways running
+  # the Semtech UDP packet-forwarder. Only used when type is set to
+  # semtech_udp.
+  [network_server.gateway.backend.semtech_udp]
+  # ip:port to bind the UDP listener to
+  #
+  # Example: 0.0.0.0:1700 to listen on port 1700 for all network interfaces.
+  # This is the listener to which the packet-forwarder forwards its data
+  # so make sure the 'serv_port_up' and 'serv_port_down' from your
+  # packet-forwarder matches this port.
+  bind="{{ .NetworkServer.Gateway.Backend.SemtechUDP.Bind }}"
+
+
 # Default join-server settings.
 [join_server.default]
 # hostname:port of the default join-server
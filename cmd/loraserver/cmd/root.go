@@ -60,7 +60,14 @@ func init() {
 	viper.SetDefault("network_server.get_downlink_data_delay", 100*time.Millisecond)
 	viper.SetDefault("network_server.gateway.stats.aggregation_intervals", []string{"minute", "hour", "day"})
 	viper.SetDefault("network_server.gateway.stats.create_gateway_on_stats", true)
+	viper.SetDefault("network_server.gateway.downlink_gateway_selection.tie_breaker", "snr")
+	viper.SetDefault("network_server.gateway.downlink_gateway_selection.link_budget_margin", 3)
+	viper.SetDefault("network_server.gateway.downlink_gateway_selection.health_timeout", 2*time.Minute)
+	viper.SetDefault("network_server.gateway.duty_cycle.enabled", true)
+	viper.SetDefault("network_server.gateway.duty_cycle.window", time.Hour)
 	viper.SetDefault("network_server.device_session_ttl", time.Hour*24*31)
+	viper.SetDefault("network_server.scheduler.partitions", 32)
+	viper.SetDefault("network_server.scheduler.lease_duration", 10*time.Second)
 	viper.SetDefault("join_server.default.server", "http://localhost:8003")
 	viper.SetDefault("network_server.network_settings.installation_margin", 10)
 	viper.SetDefault("network_server.network_settings.rx1_delay", 1)
@@ -74,6 +81,9 @@ func init() {
 	viper.SetDefault("network_server.gateway.backend.mqtt.ack_topic_template", "gateway/+/ack")
 	viper.SetDefault("network_server.gateway.backend.mqtt.config_topic_template", "gateway/{{ .MAC }}/config")
 	viper.SetDefault("network_server.gateway.backend.mqtt.clean_session", true)
+	viper.SetDefault("network_server.gateway.backend.mqtt.marshaler", "json")
+	viper.SetDefault("network_server.gateway.backend.type", "mqtt")
+	viper.SetDefault("network_server.gateway.backend.semtech_udp.bind", "0.0.0.0:1700")
 
 	rootCmd.AddCommand(versionCmd)
 	rootCmd.AddCommand(configCmd)
@@ -27,11 +27,14 @@ import (
 	"github.com/brocaar/loraserver/internal/api"
 	"github.com/brocaar/loraserver/internal/api/client/asclient"
 	"github.com/brocaar/loraserver/internal/api/client/jsclient"
+	"github.com/brocaar/loraserver/internal/backend"
 	"github.com/brocaar/loraserver/internal/backend/controller"
 	gwBackend "github.com/brocaar/loraserver/internal/backend/gateway"
+	"github.com/brocaar/loraserver/internal/backend/gateway/semtechudp"
 	"github.com/brocaar/loraserver/internal/common"
 	"github.com/brocaar/loraserver/internal/config"
 	"github.com/brocaar/loraserver/internal/downlink"
+	"github.com/brocaar/loraserver/internal/downlink/beacon"
 	"github.com/brocaar/loraserver/internal/gateway"
 	"github.com/brocaar/loraserver/internal/migrations"
 	"github.com/brocaar/loraserver/internal/migrations/code"
@@ -65,6 +68,7 @@ func run(cmd *cobra.Command, args []string) error {
 		startLoRaServer(server),
 		startStatsServer(gwStats),
 		startQueueScheduler,
+		startBeaconScheduler,
 	}
 
 	for _, t := range tasks {
@@ -205,10 +209,20 @@ func setPostgreSQLConnection() error {
 }
 
 func setGatewayBackend() error {
-	gw, err := gwBackend.NewMQTTBackend(
-		config.C.Redis.Pool,
-		config.C.NetworkServer.Gateway.Backend.MQTT,
-	)
+	var gw backend.Gateway
+	var err error
+
+	switch config.C.NetworkServer.Gateway.Backend.Type {
+	case "mqtt":
+		gw, err = gwBackend.NewMQTTBackend(
+			config.C.Redis.Pool,
+			config.C.NetworkServer.Gateway.Backend.MQTT,
+		)
+	case "semtech_udp":
+		gw, err = semtechudp.NewBackend(config.C.NetworkServer.Gateway.Backend.SemtechUDP)
+	default:
+		return fmt.Errorf("unknown gateway backend type: %s", config.C.NetworkServer.Gateway.Backend.Type)
+	}
 	if err != nil {
 		return errors.Wrap(err, "gateway-backend setup failed")
 	}
@@ -350,6 +364,12 @@ func startQueueScheduler() error {
 	return nil
 }
 
+func startBeaconScheduler() error {
+	log.Info("starting class-b beacon scheduler")
+	go beacon.SchedulerLoop()
+	return nil
+}
+
 func mustGetTransportCredentials(tlsCert, tlsKey, caCert string, verifyClientCert bool) credentials.TransportCredentials {
 	cert, err := tls.LoadX509KeyPair(tlsCert, tlsKey)
 	if err != nil {
@@ -2,15 +2,20 @@ package adr
 
 import (
 	"fmt"
+	"math"
 
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 
+	"github.com/brocaar/loraserver/internal/channels"
 	"github.com/brocaar/loraserver/internal/config"
 	"github.com/brocaar/loraserver/internal/storage"
 	"github.com/brocaar/lorawan"
 )
 
+// maxNbTrans defines the max number of transmissions set by ADR.
+const maxNbTrans = 3
+
 var pktLossRateTable = [][3]uint8{
 	{1, 1, 2},
 	{1, 2, 3},
@@ -19,8 +24,9 @@ var pktLossRateTable = [][3]uint8{
 }
 
 // HandleADR handles ADR in case requested by the node and configured
-// in the device-session.
-func HandleADR(ds storage.DeviceSession, linkADRReqBlock *storage.MACCommandBlock) ([]storage.MACCommandBlock, error) {
+// in the device-session. The ADR algorithm is selected by the
+// device-profile.
+func HandleADR(sp storage.ServiceProfile, dp storage.DeviceProfile, ds storage.DeviceSession, linkADRReqBlock *storage.MACCommandBlock) ([]storage.MACCommandBlock, error) {
 
 	// if the node has ADR disabled or it's disabled gloablly
 	if !ds.ADR || config.C.NetworkServer.NetworkSettings.DisableADR {
@@ -30,19 +36,6 @@ func HandleADR(ds storage.DeviceSession, linkADRReqBlock *storage.MACCommandBloc
 		return []storage.MACCommandBlock{*linkADRReqBlock}, nil
 	}
 
-	// get the max SNR from the UplinkHistory
-	var snrM float64 = -999
-	var historyCount int
-	for _, uh := range ds.UplinkHistory {
-		if uh.TXPowerIndex == ds.TXPowerIndex {
-			historyCount++
-
-			if uh.MaxSNR > snrM {
-				snrM = uh.MaxSNR
-			}
-		}
-	}
-
 	if ds.DR > getMaxAllowedDR() {
 		log.WithFields(log.Fields{
 			"dr":      ds.DR,
@@ -51,6 +44,11 @@ func HandleADR(ds storage.DeviceSession, linkADRReqBlock *storage.MACCommandBloc
 		return nil, nil
 	}
 
+	algorithm, err := GetAlgorithm(dp.ADRAlgorithmID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "get adr algorithm error (id: %s)", dp.ADRAlgorithmID)
+	}
+
 	dr, err := config.C.NetworkServer.Band.Band.GetDataRate(ds.DR)
 	if err != nil {
 		return nil, errors.Wrap(err, "get data-rate error")
@@ -61,21 +59,37 @@ func HandleADR(ds storage.DeviceSession, linkADRReqBlock *storage.MACCommandBloc
 		return nil, err
 	}
 
-	snrMargin := snrM - requiredSNR - config.C.NetworkServer.NetworkSettings.InstallationMargin
-	nStep := int(snrMargin / 3)
-
-	// In case of negative steps the ADR algorithm will increase the TXPower
-	// if possible. To avoid up / down / up / down TXPower changes, wait until
-	// we have a full history table before making adjustments.
-	if nStep < 0 && historyCount != storage.UplinkHistorySize {
-		return nil, nil
+	// the service-profile is read on every decision, so that changes to
+	// the DRMin / DRMax take effect without a rejoin of the device
+	maxDR := minInt(getMaxAllowedDR(), getMaxSupportedDRForNode(ds))
+	if sp.DRMax != 0 {
+		maxDR = minInt(maxDR, sp.DRMax)
+	}
+	minAllowedDR, err := getMinAllowedDRForNode(dp, ds)
+	if err != nil {
+		return nil, err
+	}
+	minDR := minInt(maxInt(sp.DRMin, minAllowedDR), maxDR)
+
+	resp, err := algorithm.Handle(Request{
+		DeviceSession:      ds,
+		ServiceProfile:     sp,
+		DeviceProfile:      dp,
+		RequiredSNRForDR:   requiredSNR,
+		InstallationMargin: config.C.NetworkServer.NetworkSettings.InstallationMargin,
+		MinDR:              minDR,
+		MaxDR:              maxDR,
+		MaxTXPowerIndex:    minInt(getMaxTXPowerOffsetIndex(), getMaxSupportedTXPowerOffsetIndexForNode(ds)),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "handle adr error (algorithm: %s)", algorithm.ID())
 	}
 
-	maxSupportedDR := getMaxSupportedDRForNode(ds)
-	maxSupportedTXPowerOffsetIndex := getMaxSupportedTXPowerOffsetIndexForNode(ds)
-
-	idealTXPowerIndex, idealDR := getIdealTXPowerOffsetAndDR(nStep, ds.TXPowerIndex, ds.DR, ds.MinSupportedTXPowerIndex, maxSupportedTXPowerOffsetIndex, maxSupportedDR)
-	idealNbRep := getNbRep(ds.NbTrans, ds.GetPacketLossPercentage())
+	// clamp the data-rate to the allowed range, as a registered algorithm
+	// might return a data-rate outside this range
+	idealDR := clampDR(resp.DR, minDR, maxDR)
+	idealTXPowerIndex := resp.TXPowerIndex
+	idealNbRep := resp.NbTrans
 
 	// there is nothing to adjust
 	if ds.TXPowerIndex == idealTXPowerIndex && ds.DR == idealDR && ds.NbTrans == idealNbRep {
@@ -83,38 +97,28 @@ func HandleADR(ds storage.DeviceSession, linkADRReqBlock *storage.MACCommandBloc
 	}
 
 	if linkADRReqBlock == nil || len(linkADRReqBlock.MACCommands) == 0 {
-		// nothing is pending
-		var chMask lorawan.ChMask
-		chMaskCntl := -1
-		for _, c := range ds.EnabledUplinkChannels {
-			if chMaskCntl != c/16 {
-				if chMaskCntl == -1 {
-					// set the chMaskCntl
-					chMaskCntl = c / 16
-				} else {
-					// break the loop as we only need to send one block of channels
-					break
-				}
-			}
-			chMask[c%16] = true
+		// nothing is pending, as each LinkADRReq contains a channel-mask
+		// the enabled channels of the device must be expressed exactly
+		payloads := channels.GetLinkADRReqPayloadsForEnabledUplinkChannels(ds.EnabledUplinkChannels)
+		if len(payloads) == 0 {
+			return nil, errors.New("no link_adr_req payloads for enabled uplink channels")
 		}
 
+		// the device uses the data-rate, tx-power and nb-rep of the last
+		// payload of the block
+		last := &payloads[len(payloads)-1]
+		last.DataRate = uint8(idealDR)
+		last.TXPower = uint8(idealTXPowerIndex)
+		last.Redundancy.NbRep = uint8(idealNbRep)
+
 		linkADRReqBlock = &storage.MACCommandBlock{
 			CID: lorawan.LinkADRReq,
-			MACCommands: []lorawan.MACCommand{
-				{
-					CID: lorawan.LinkADRReq,
-					Payload: &lorawan.LinkADRReqPayload{
-						DataRate: uint8(idealDR),
-						TXPower:  uint8(idealTXPowerIndex),
-						ChMask:   chMask,
-						Redundancy: lorawan.Redundancy{
-							ChMaskCntl: uint8(chMaskCntl),
-							NbRep:      uint8(idealNbRep),
-						},
-					},
-				},
-			},
+		}
+		for i := range payloads {
+			linkADRReqBlock.MACCommands = append(linkADRReqBlock.MACCommands, lorawan.MACCommand{
+				CID:     lorawan.LinkADRReq,
+				Payload: &payloads[i],
+			})
 		}
 	} else {
 		// there is a pending block of commands in the queue, add the adr parameters
@@ -139,6 +143,7 @@ func HandleADR(ds storage.DeviceSession, linkADRReqBlock *storage.MACCommandBloc
 		"req_tx_power_idx": idealTXPowerIndex,
 		"nb_trans":         ds.NbTrans,
 		"req_nb_trans":     idealNbRep,
+		"adr_algorithm":    algorithm.ID(),
 	}).Info("adr request added to mac-command queue")
 
 	return []storage.MACCommandBlock{*linkADRReqBlock}, nil
@@ -162,6 +167,44 @@ func getNbRep(currentNbRep uint8, pktLossRate float64) uint8 {
 	return pktLossRateTable[3][currentNbRep-1]
 }
 
+// getNbTrans returns the number of transmissions needed to meet the
+// target packet-error rate (%). Each transmission is assumed to fail
+// independently, with an error rate derived from the packet-loss (%) over
+// the current number of transmissions. When no target is set, the
+// pktLossRateTable is used.
+func getNbTrans(currentNbTrans uint8, pktLossRate float64, targetPER int) uint8 {
+	if targetPER <= 0 {
+		return getNbRep(currentNbTrans, pktLossRate)
+	}
+
+	if currentNbTrans < 1 {
+		currentNbTrans = 1
+	}
+
+	frameErrorRate := pktLossRate / 100
+	target := float64(targetPER) / 100
+
+	if frameErrorRate <= 0 || target >= 1 {
+		return 1
+	}
+	if frameErrorRate >= 1 {
+		return maxNbTrans
+	}
+
+	// error rate of a single transmission
+	txErrorRate := math.Pow(frameErrorRate, 1/float64(currentNbTrans))
+
+	// a small tolerance avoids an extra transmission due to rounding
+	nbTrans := math.Ceil(math.Log(target)/math.Log(txErrorRate) - 1e-9)
+	if nbTrans < 1 {
+		return 1
+	}
+	if nbTrans > maxNbTrans {
+		return maxNbTrans
+	}
+	return uint8(nbTrans)
+}
+
 func getMaxTXPowerOffsetIndex() int {
 	var idx int
 	for i := 0; ; i++ {
@@ -184,42 +227,37 @@ func getMaxSupportedTXPowerOffsetIndexForNode(ds storage.DeviceSession) int {
 	return getMaxTXPowerOffsetIndex()
 }
 
-func getIdealTXPowerOffsetAndDR(nStep, txPowerOffsetIndex, dr, minSupportedTXPowerOffsetIndex, maxSupportedTXPowerOffsetIndex, maxSupportedDR int) (int, int) {
+// getIdealTXPowerOffsetAndDR returns the ideal tx-power offset index and
+// data-rate for the given number of (3dB) steps. The data-rate is never
+// decreased. maxTXPowerOffsetIndex and maxDR must be within the limits of
+// both the network and the node.
+func getIdealTXPowerOffsetAndDR(nStep, txPowerOffsetIndex, dr, minTXPowerOffsetIndex, maxTXPowerOffsetIndex, maxDR int) (int, int) {
 	if nStep == 0 {
 		return txPowerOffsetIndex, dr
 	}
 
 	if nStep > 0 {
-		if dr < getMaxAllowedDR() && dr < maxSupportedDR {
-			// maxSupportedDR is the max supported DR by the node. Depending the
-			// Regional Parameters specification the node is implementing, this
-			// might not be equal to the getMaxAllowedDR value.
+		if dr < maxDR {
 			dr++
-
-		} else if txPowerOffsetIndex < getMaxTXPowerOffsetIndex() && txPowerOffsetIndex < maxSupportedTXPowerOffsetIndex {
-			// maxSupportedTXPowerOffsetIndex is the max supported TXPower
-			// index by the node. Depending the Regional Parameters
-			// specification the node is implementing, this might not be
-			// equal to the getMaxTXPowerOffsetIndex value.
+		} else if txPowerOffsetIndex < maxTXPowerOffsetIndex {
 			txPowerOffsetIndex++
-
 		}
 
 		nStep--
-		if txPowerOffsetIndex >= getMaxTXPowerOffsetIndex() {
-			return getMaxTXPowerOffsetIndex(), dr
+		if txPowerOffsetIndex >= maxTXPowerOffsetIndex {
+			return maxTXPowerOffsetIndex, dr
 		}
 
 	} else {
-		if txPowerOffsetIndex > minSupportedTXPowerOffsetIndex {
+		if txPowerOffsetIndex > minTXPowerOffsetIndex {
 			txPowerOffsetIndex--
 			nStep++
-		} else if txPowerOffsetIndex <= minSupportedTXPowerOffsetIndex {
-			return minSupportedTXPowerOffsetIndex, dr
+		} else if txPowerOffsetIndex <= minTXPowerOffsetIndex {
+			return minTXPowerOffsetIndex, dr
 		}
 	}
 
-	return getIdealTXPowerOffsetAndDR(nStep, txPowerOffsetIndex, dr, minSupportedTXPowerOffsetIndex, maxSupportedTXPowerOffsetIndex, maxSupportedDR)
+	return getIdealTXPowerOffsetAndDR(nStep, txPowerOffsetIndex, dr, minTXPowerOffsetIndex, maxTXPowerOffsetIndex, maxDR)
 }
 
 func getRequiredSNRForSF(sf int) (float64, error) {
@@ -248,9 +286,50 @@ func getMaxAllowedDR() int {
 	return maxDR
 }
 
+// getMinAllowedDRForNode returns the lowest data-rate that can be used by
+// the node, given its uplink dwell-time. Data-rates exceeding the dwell-time
+// limitation have a max payload-size of 0.
+func getMinAllowedDRForNode(dp storage.DeviceProfile, ds storage.DeviceSession) (int, error) {
+	b, err := config.GetBandForDwellTime(ds.GetUplinkDwellTime())
+	if err != nil {
+		return 0, errors.Wrap(err, "get band for dwell-time error")
+	}
+
+	for dr := 0; ; dr++ {
+		plSize, err := b.GetMaxPayloadSizeForDataRateIndex(dp.MACVersion, dp.RegParamsRevision, dr)
+		if err != nil {
+			// there is no data-rate with a max payload-size
+			return 0, nil
+		}
+		if plSize.N > 0 {
+			return dr, nil
+		}
+	}
+}
+
 func getMaxSupportedDRForNode(ds storage.DeviceSession) int {
 	if ds.MaxSupportedDR != 0 {
 		return ds.MaxSupportedDR
 	}
 	return getMaxAllowedDR()
 }
+
+// clampDR returns the given data-rate, limited to the given min and max
+// data-rate.
+func clampDR(dr, minDR, maxDR int) int {
+	return maxInt(minDR, minInt(dr, maxDR))
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -547,7 +547,7 @@ func TestADR(t *testing.T) {
 
 				for i, tst := range testTable {
 					Convey(fmt.Sprintf("Test: %s [%d]", tst.Name, i), func() {
-						blocks, err := HandleADR(tst.DeviceSession, tst.LinkADRReqBlock)
+						blocks, err := HandleADR(storage.ServiceProfile{}, storage.DeviceProfile{}, tst.DeviceSession, tst.LinkADRReqBlock)
 						if tst.ExpectedError != nil {
 							So(err, ShouldNotBeNil)
 							So(err, ShouldResemble, tst.ExpectedError)
@@ -605,7 +605,7 @@ func TestADR(t *testing.T) {
 					},
 				}
 
-				blocks, err := HandleADR(ds, larb)
+				blocks, err := HandleADR(storage.ServiceProfile{}, storage.DeviceProfile{}, ds, larb)
 
 				So(err, ShouldBeNil)
 				So(blocks, ShouldResemble, []storage.MACCommandBlock{macBlock})
@@ -614,3 +614,194 @@ func TestADR(t *testing.T) {
 		})
 	})
 }
+
+func TestGetNbTrans(t *testing.T) {
+	Convey("Given a testtable for getNbTrans", t, func() {
+		testTable := []struct {
+			PktLossRate     float64
+			CurrentNbTrans  uint8
+			TargetPER       int
+			ExpectedNbTrans uint8
+		}{
+			{0, 2, 5, 1},
+			{4, 1, 10, 1},
+			{15, 1, 5, 2},
+			{2.25, 2, 5, 2},
+			{50, 1, 1, 3},
+			{100, 1, 5, 3},
+			{30, 3, 0, 3}, // no target, pktLossRateTable is used
+		}
+
+		for i, tst := range testTable {
+			Convey(fmt.Sprintf("Given PktLossRate: %f, Current NbTrans: %d, TargetPER: %d [%d]", tst.PktLossRate, tst.CurrentNbTrans, tst.TargetPER, i), func() {
+				So(getNbTrans(tst.CurrentNbTrans, tst.PktLossRate, tst.TargetPER), ShouldEqual, tst.ExpectedNbTrans)
+			})
+		}
+	})
+}
+
+func TestHandleADRServiceProfileDataRates(t *testing.T) {
+	test.GetConfig()
+
+	Convey("Given a device-session with ADR enabled", t, func() {
+		config.C.NetworkServer.NetworkSettings.DisableADR = false
+
+		ds := storage.DeviceSession{
+			DevEUI:  lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			ADR:     true,
+			NbTrans: 1,
+		}
+		ds.EnabledUplinkChannels = []int{0, 1, 2}
+
+		testTable := []struct {
+			Name           string
+			DR             int
+			ServiceProfile storage.ServiceProfile
+			ExpectedDR     int
+		}{
+			{"data-rate below DRMin", 1, storage.ServiceProfile{DRMin: 3, DRMax: 5}, 3},
+			{"data-rate above DRMax", 5, storage.ServiceProfile{DRMin: 0, DRMax: 2}, 2},
+		}
+
+		for i, tst := range testTable {
+			Convey(fmt.Sprintf("Test: %s [%d]", tst.Name, i), func() {
+				ds.DR = tst.DR
+
+				blocks, err := HandleADR(tst.ServiceProfile, storage.DeviceProfile{}, ds, nil)
+				So(err, ShouldBeNil)
+				So(blocks, ShouldHaveLength, 1)
+				So(blocks[0].MACCommands, ShouldHaveLength, 1)
+
+				pl := blocks[0].MACCommands[0].Payload.(*lorawan.LinkADRReqPayload)
+				So(pl.DataRate, ShouldEqual, tst.ExpectedDR)
+			})
+		}
+
+		Convey("When the data-rate is within the service-profile range", func() {
+			ds.DR = 3
+
+			Convey("Then HandleADR does not return a LinkADRReq", func() {
+				blocks, err := HandleADR(storage.ServiceProfile{DRMin: 2, DRMax: 4}, storage.DeviceProfile{}, ds, nil)
+				So(err, ShouldBeNil)
+				So(blocks, ShouldHaveLength, 0)
+			})
+		})
+	})
+}
+
+func TestAlgorithms(t *testing.T) {
+	Convey("Testing the ADR algorithm registry", t, func() {
+		Convey("Then GetAlgorithm returns the default algorithm for an empty ID", func() {
+			a, err := GetAlgorithm("")
+			So(err, ShouldBeNil)
+			So(a.ID(), ShouldEqual, DefaultAlgorithmID)
+		})
+
+		Convey("Then GetAlgorithm returns the loss-aware algorithm", func() {
+			a, err := GetAlgorithm(LossAwareAlgorithmID)
+			So(err, ShouldBeNil)
+			So(a.ID(), ShouldEqual, LossAwareAlgorithmID)
+		})
+
+		Convey("Then GetAlgorithm returns an error for an unknown ID", func() {
+			_, err := GetAlgorithm("unknown")
+			So(err, ShouldEqual, ErrUnknownAlgorithm)
+		})
+
+		Convey("Then an algorithm can not be registered twice", func() {
+			So(Register(defaultAlgorithm{}), ShouldNotBeNil)
+		})
+	})
+
+	Convey("Given a device with a full uplink history with good SNR", t, func() {
+		// lostEvery defines after how many uplinks an uplink is lost
+		uplinkHistory := func(lostEvery int) []storage.UplinkHistory {
+			var out []storage.UplinkHistory
+			var fCnt uint32
+			for i := 0; i < storage.UplinkHistorySize; i++ {
+				if lostEvery != 0 && i != 0 && i%lostEvery == 0 {
+					fCnt++
+				}
+				out = append(out, storage.UplinkHistory{FCnt: fCnt, MaxSNR: 0})
+				fCnt++
+			}
+			return out
+		}
+
+		req := Request{
+			DeviceSession: storage.DeviceSession{
+				DR:            2,
+				NbTrans:       1,
+				UplinkHistory: uplinkHistory(0),
+			},
+			RequiredSNRForDR:   -15,
+			InstallationMargin: 5,
+			MaxDR:              5,
+			MaxTXPowerIndex:    7,
+		}
+
+		Convey("Then both algorithms increase the data-rate", func() {
+			for _, a := range []Algorithm{defaultAlgorithm{}, lossAwareAlgorithm{}} {
+				resp, err := a.Handle(req)
+				So(err, ShouldBeNil)
+				So(resp, ShouldResemble, Response{DR: 5, TXPowerIndex: 0, NbTrans: 1})
+			}
+		})
+
+		Convey("Given the device lost 15% of its uplinks", func() {
+			req.DeviceSession.UplinkHistory = uplinkHistory(5)
+			So(req.DeviceSession.GetPacketLossPercentage(), ShouldEqual, 15)
+
+			Convey("Then the default algorithm increases the data-rate and the number of transmissions", func() {
+				resp, err := defaultAlgorithm{}.Handle(req)
+				So(err, ShouldBeNil)
+				So(resp, ShouldResemble, Response{DR: 5, TXPowerIndex: 0, NbTrans: 2})
+			})
+
+			Convey("Then the loss-aware algorithm only increases the number of transmissions", func() {
+				resp, err := lossAwareAlgorithm{}.Handle(req)
+				So(err, ShouldBeNil)
+				So(resp, ShouldResemble, Response{DR: 2, TXPowerIndex: 0, NbTrans: 2})
+			})
+		})
+
+		Convey("Given the uplink history is not complete", func() {
+			req.DeviceSession.UplinkHistory = req.DeviceSession.UplinkHistory[:5]
+
+			Convey("Then the loss-aware algorithm does not make any adjustments", func() {
+				resp, err := lossAwareAlgorithm{}.Handle(req)
+				So(err, ShouldBeNil)
+				So(resp, ShouldResemble, Response{DR: 2, TXPowerIndex: 0, NbTrans: 1})
+			})
+		})
+
+		Convey("Given the uplink history is not complete and the data-rate is below the min data-rate", func() {
+			req.DeviceSession.UplinkHistory = req.DeviceSession.UplinkHistory[:5]
+			for i := range req.DeviceSession.UplinkHistory {
+				req.DeviceSession.UplinkHistory[i].MaxSNR = -20
+			}
+			req.MinDR = 3
+
+			Convey("Then both algorithms raise the data-rate to the min data-rate", func() {
+				for _, a := range []Algorithm{defaultAlgorithm{}, lossAwareAlgorithm{}} {
+					resp, err := a.Handle(req)
+					So(err, ShouldBeNil)
+					So(resp.DR, ShouldEqual, 3)
+				}
+			})
+		})
+
+		Convey("Given the data-rate is above the max data-rate", func() {
+			req.DeviceSession.DR = 5
+			req.MaxDR = 4
+
+			Convey("Then both algorithms lower the data-rate to the max data-rate", func() {
+				for _, a := range []Algorithm{defaultAlgorithm{}, lossAwareAlgorithm{}} {
+					resp, err := a.Handle(req)
+					So(err, ShouldBeNil)
+					So(resp.DR, ShouldEqual, 4)
+				}
+			})
+		})
+	})
+}
@@ -0,0 +1,102 @@
+package adr
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/loraserver/internal/storage"
+)
+
+// Built-in ADR algorithm IDs.
+const (
+	DefaultAlgorithmID   = "default"
+	LossAwareAlgorithmID = "lora-loss-aware"
+)
+
+// ErrUnknownAlgorithm is returned when the requested ADR algorithm has not
+// been registered.
+var ErrUnknownAlgorithm = errors.New("unknown adr algorithm")
+
+// Algorithm defines the interface of an ADR algorithm.
+type Algorithm interface {
+	// ID returns the identifier of the algorithm, this is the value stored
+	// in the device-profile.
+	ID() string
+
+	// Name returns a human-readable name of the algorithm.
+	Name() string
+
+	// Handle returns the data-rate, tx-power index and number of
+	// transmissions that the device must use.
+	Handle(req Request) (Response, error)
+}
+
+// Request contains the input of an ADR algorithm.
+type Request struct {
+	// DeviceSession of the device, including the UplinkHistory.
+	DeviceSession storage.DeviceSession
+
+	// ServiceProfile of the device.
+	ServiceProfile storage.ServiceProfile
+
+	// DeviceProfile of the device.
+	DeviceProfile storage.DeviceProfile
+
+	// RequiredSNRForDR holds the SNR (dB) required to demodulate the current
+	// data-rate of the device.
+	RequiredSNRForDR float64
+
+	// InstallationMargin (dB) of the network.
+	InstallationMargin float64
+
+	// MinDR holds the min data-rate that can be used by the device. This
+	// takes the service-profile and the max payload-size of the data-rates
+	// into account.
+	MinDR int
+
+	// MaxDR holds the max data-rate that can be used by the device. This
+	// takes the network, device and service-profile limits into account.
+	MaxDR int
+
+	// MaxTXPowerIndex holds the max tx-power index (min tx-power) that can
+	// be used by the device.
+	MaxTXPowerIndex int
+}
+
+// Response contains the output of an ADR algorithm.
+type Response struct {
+	DR           int
+	TXPowerIndex int
+	NbTrans      uint8
+}
+
+// algorithms contains the registered ADR algorithms. Algorithms must be
+// registered on init, as access to this map is not synchronized.
+var algorithms = map[string]Algorithm{
+	DefaultAlgorithmID:   defaultAlgorithm{},
+	LossAwareAlgorithmID: lossAwareAlgorithm{},
+}
+
+// Register registers the given ADR algorithm.
+func Register(a Algorithm) error {
+	if _, ok := algorithms[a.ID()]; ok {
+		return fmt.Errorf("adr algorithm %s is already registered", a.ID())
+	}
+	algorithms[a.ID()] = a
+	return nil
+}
+
+// GetAlgorithm returns the ADR algorithm for the given ID. An empty ID
+// returns the default algorithm.
+func GetAlgorithm(id string) (Algorithm, error) {
+	if id == "" {
+		id = DefaultAlgorithmID
+	}
+
+	a, ok := algorithms[id]
+	if !ok {
+		return nil, ErrUnknownAlgorithm
+	}
+	return a, nil
+}
@@ -0,0 +1,58 @@
+package adr
+
+import (
+	"github.com/brocaar/loraserver/internal/storage"
+)
+
+// defaultAlgorithm implements the ADR algorithm as recommended by Semtech.
+// It uses the max SNR of the uplink history for stepping up the data-rate
+// and stepping down the tx-power.
+type defaultAlgorithm struct{}
+
+func (defaultAlgorithm) ID() string {
+	return DefaultAlgorithmID
+}
+
+func (defaultAlgorithm) Name() string {
+	return "Default ADR algorithm"
+}
+
+func (defaultAlgorithm) Handle(req Request) (Response, error) {
+	ds := req.DeviceSession
+
+	// get the max SNR from the UplinkHistory
+	var snrM float64 = -999
+	var historyCount int
+	for _, uh := range ds.UplinkHistory {
+		if uh.TXPowerIndex == ds.TXPowerIndex {
+			historyCount++
+
+			if uh.MaxSNR > snrM {
+				snrM = uh.MaxSNR
+			}
+		}
+	}
+
+	// the data-rate of the device must be within the allowed range,
+	// regardless the SNR
+	resp := Response{
+		DR:           clampDR(ds.DR, req.MinDR, req.MaxDR),
+		TXPowerIndex: ds.TXPowerIndex,
+		NbTrans:      ds.NbTrans,
+	}
+
+	snrMargin := snrM - req.RequiredSNRForDR - req.InstallationMargin
+	nStep := int(snrMargin / 3)
+
+	// In case of negative steps the ADR algorithm will increase the TXPower
+	// if possible. To avoid up / down / up / down TXPower changes, wait until
+	// we have a full history table before making adjustments.
+	if nStep < 0 && historyCount != storage.UplinkHistorySize {
+		return resp, nil
+	}
+
+	resp.TXPowerIndex, resp.DR = getIdealTXPowerOffsetAndDR(nStep, ds.TXPowerIndex, resp.DR, ds.MinSupportedTXPowerIndex, req.MaxTXPowerIndex, req.MaxDR)
+	resp.NbTrans = getNbTrans(ds.NbTrans, ds.GetPacketLossPercentage(), req.ServiceProfile.TargetPER)
+
+	return resp, nil
+}
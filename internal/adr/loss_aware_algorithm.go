@@ -0,0 +1,65 @@
+package adr
+
+import (
+	"github.com/brocaar/loraserver/internal/storage"
+)
+
+// lossAwareMaxPacketLoss defines the packet-loss (%) from which the
+// loss-aware algorithm no longer increases the data-rate or decreases the
+// tx-power.
+const lossAwareMaxPacketLoss = 5
+
+// lossAwareAlgorithm implements a conservative variant of the default
+// algorithm, intended for devices with a varying link quality (e.g. mobile
+// trackers). It uses the average instead of the max SNR of the uplink
+// history, it only makes adjustments once the uplink history is complete
+// and it will not step up the data-rate or step down the tx-power while
+// the device is losing packets.
+type lossAwareAlgorithm struct{}
+
+func (lossAwareAlgorithm) ID() string {
+	return LossAwareAlgorithmID
+}
+
+func (lossAwareAlgorithm) Name() string {
+	return "Loss-aware ADR algorithm"
+}
+
+func (lossAwareAlgorithm) Handle(req Request) (Response, error) {
+	ds := req.DeviceSession
+
+	// the data-rate of the device must be within the allowed range, also
+	// when the uplink history is not complete
+	resp := Response{
+		DR:           clampDR(ds.DR, req.MinDR, req.MaxDR),
+		TXPowerIndex: ds.TXPowerIndex,
+		NbTrans:      ds.NbTrans,
+	}
+
+	// get the average SNR from the UplinkHistory
+	var snrSum float64
+	var historyCount int
+	for _, uh := range ds.UplinkHistory {
+		if uh.TXPowerIndex == ds.TXPowerIndex {
+			historyCount++
+			snrSum += uh.MaxSNR
+		}
+	}
+
+	if historyCount != storage.UplinkHistorySize {
+		return resp, nil
+	}
+
+	pktLoss := ds.GetPacketLossPercentage()
+	snrMargin := snrSum/float64(historyCount) - req.RequiredSNRForDR - req.InstallationMargin
+	nStep := int(snrMargin / 3)
+
+	if nStep > 0 && pktLoss >= lossAwareMaxPacketLoss {
+		nStep = 0
+	}
+
+	resp.TXPowerIndex, resp.DR = getIdealTXPowerOffsetAndDR(nStep, ds.TXPowerIndex, resp.DR, ds.MinSupportedTXPowerIndex, req.MaxTXPowerIndex, req.MaxDR)
+	resp.NbTrans = getNbTrans(ds.NbTrans, pktLoss, req.ServiceProfile.TargetPER)
+
+	return resp, nil
+}
@@ -0,0 +1,98 @@
+// Package airtime implements the calculation of the time-on-air of LoRa and
+// FSK modulated frames, see Semtech AN1200.13 and the SX1272/3 datasheet.
+package airtime
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/lorawan/band"
+)
+
+const (
+	// loRaPreambleSymbols contains the number of LoRa preamble symbols used
+	// by LoRaWAN.
+	loRaPreambleSymbols = 8
+
+	// fskOverheadBytes contains the number of FSK overhead bytes: preamble
+	// (5), sync-word (3), length (1) and CRC (2).
+	fskOverheadBytes = 11
+)
+
+// CalculateLoRaAirtime returns the time-on-air of a LoRa frame.
+// The bandwidth must be given in kHz, the coding-rate as the denominator
+// minus four (e.g. 1 for 4/5).
+func CalculateLoRaAirtime(payloadSize, sf, bandwidth, preambleNumber, codingRate int, headerEnabled, crc, lowDataRateOptimization bool) (time.Duration, error) {
+	if sf < 6 || sf > 12 {
+		return 0, fmt.Errorf("invalid spreading-factor: %d", sf)
+	}
+	if bandwidth <= 0 {
+		return 0, fmt.Errorf("invalid bandwidth: %d", bandwidth)
+	}
+	if codingRate < 1 || codingRate > 4 {
+		return 0, fmt.Errorf("invalid coding-rate: %d", codingRate)
+	}
+
+	symbolDuration := math.Pow(2, float64(sf)) / float64(bandwidth*1000)
+	preambleDuration := (float64(preambleNumber) + 4.25) * symbolDuration
+
+	var h, de, c float64
+	if !headerEnabled {
+		h = 1
+	}
+	if lowDataRateOptimization {
+		de = 1
+	}
+	if crc {
+		c = 1
+	}
+
+	payloadSymbols := 8 + math.Max(
+		math.Ceil((8*float64(payloadSize)-4*float64(sf)+28+16*c-20*h)/(4*(float64(sf)-2*de)))*float64(codingRate+4),
+		0,
+	)
+
+	seconds := preambleDuration + payloadSymbols*symbolDuration
+	return time.Duration(math.Round(seconds * float64(time.Second))), nil
+}
+
+// CalculateFSKAirtime returns the time-on-air of a FSK frame.
+// The bit-rate must be given in bits per second.
+func CalculateFSKAirtime(payloadSize, bitRate int) (time.Duration, error) {
+	if bitRate <= 0 {
+		return 0, fmt.Errorf("invalid bit-rate: %d", bitRate)
+	}
+
+	bits := (payloadSize + fskOverheadBytes) * 8
+	return time.Duration(bits) * time.Second / time.Duration(bitRate), nil
+}
+
+// CalculateDownlinkAirtime returns the time-on-air of a LoRaWAN downlink
+// frame with the given PHYPayload size, using the data-rate and code-rate
+// of the given TXInfo. LoRaWAN downlinks use no payload CRC and, unless
+// NoHeader is set (e.g. for Class-B beacons), an explicit header.
+func CalculateDownlinkAirtime(payloadSize int, txInfo gw.TXInfo) (time.Duration, error) {
+	switch txInfo.DataRate.Modulation {
+	case band.LoRaModulation:
+		var codingRate int
+		if _, err := fmt.Sscanf(txInfo.CodeRate, "4/%d", &codingRate); err != nil {
+			return 0, errors.Wrap(err, "parse code-rate error")
+		}
+
+		// the low data-rate optimization is mandated when the symbol
+		// duration exceeds 16ms (e.g. SF11 and SF12 at 125kHz)
+		sf := txInfo.DataRate.SpreadFactor
+		bw := txInfo.DataRate.Bandwidth
+		ldro := bw > 0 && math.Pow(2, float64(sf))/float64(bw) >= 16
+
+		return CalculateLoRaAirtime(payloadSize, sf, bw, loRaPreambleSymbols, codingRate-4, !txInfo.NoHeader, false, ldro)
+	case band.FSKModulation:
+		return CalculateFSKAirtime(payloadSize, txInfo.DataRate.BitRate)
+	default:
+		return 0, fmt.Errorf("unknown modulation: %s", txInfo.DataRate.Modulation)
+	}
+}
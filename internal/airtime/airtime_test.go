@@ -0,0 +1,99 @@
+package airtime
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/lorawan/band"
+)
+
+func TestCalculateLoRaAirtime(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		tests := []struct {
+			PayloadSize   int
+			SF            int
+			Bandwidth     int
+			CRC           bool
+			LDRO          bool
+			Expected      time.Duration
+			ExpectedError error
+		}{
+			{PayloadSize: 13, SF: 7, Bandwidth: 125, CRC: true, Expected: 46336 * time.Microsecond},
+			{PayloadSize: 13, SF: 7, Bandwidth: 125, Expected: 41216 * time.Microsecond},
+			{PayloadSize: 13, SF: 12, Bandwidth: 125, CRC: true, LDRO: true, Expected: 1155072 * time.Microsecond},
+			{PayloadSize: 51, SF: 9, Bandwidth: 125, Expected: 328704 * time.Microsecond},
+			{PayloadSize: 13, SF: 13, Bandwidth: 125, ExpectedError: fmt.Errorf("invalid spreading-factor: 13")},
+		}
+
+		for i, test := range tests {
+			Convey(fmt.Sprintf("Testing: %d bytes SF%dBW%d [%d]", test.PayloadSize, test.SF, test.Bandwidth, i), func() {
+				d, err := CalculateLoRaAirtime(test.PayloadSize, test.SF, test.Bandwidth, 8, 1, true, test.CRC, test.LDRO)
+				So(err, ShouldResemble, test.ExpectedError)
+				So(d, ShouldEqual, test.Expected)
+			})
+		}
+	})
+}
+
+func TestCalculateFSKAirtime(t *testing.T) {
+	Convey("Then the airtime of a 13 byte FSK frame at 50kbps is 3.84ms", t, func() {
+		d, err := CalculateFSKAirtime(13, 50000)
+		So(err, ShouldBeNil)
+		So(d, ShouldEqual, 3840*time.Microsecond)
+	})
+}
+
+func TestCalculateDownlinkAirtime(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		tests := []struct {
+			Name     string
+			TXInfo   gw.TXInfo
+			Expected time.Duration
+		}{
+			{
+				Name: "SF7",
+				TXInfo: gw.TXInfo{
+					CodeRate: "4/5",
+					DataRate: band.DataRate{Modulation: band.LoRaModulation, SpreadFactor: 7, Bandwidth: 125},
+				},
+				Expected: 41216 * time.Microsecond,
+			},
+			{
+				Name: "SF12 (low data-rate optimization)",
+				TXInfo: gw.TXInfo{
+					CodeRate: "4/5",
+					DataRate: band.DataRate{Modulation: band.LoRaModulation, SpreadFactor: 12, Bandwidth: 125},
+				},
+				Expected: 1155072 * time.Microsecond,
+			},
+			{
+				Name: "SF7 without header",
+				TXInfo: gw.TXInfo{
+					CodeRate: "4/5",
+					DataRate: band.DataRate{Modulation: band.LoRaModulation, SpreadFactor: 7, Bandwidth: 125},
+					NoHeader: true,
+				},
+				Expected: 36096 * time.Microsecond,
+			},
+			{
+				Name: "FSK",
+				TXInfo: gw.TXInfo{
+					DataRate: band.DataRate{Modulation: band.FSKModulation, BitRate: 50000},
+				},
+				Expected: 3840 * time.Microsecond,
+			},
+		}
+
+		for i, test := range tests {
+			Convey(fmt.Sprintf("Testing: %s [%d]", test.Name, i), func() {
+				d, err := CalculateDownlinkAirtime(13, test.TXInfo)
+				So(err, ShouldBeNil)
+				So(d, ShouldEqual, test.Expected)
+			})
+		}
+	})
+}
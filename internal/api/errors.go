@@ -5,19 +5,26 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 
+	"github.com/brocaar/loraserver/internal/adr"
 	"github.com/brocaar/loraserver/internal/downlink/data"
+	"github.com/brocaar/loraserver/internal/downlink/multicast"
 	"github.com/brocaar/loraserver/internal/downlink/proprietary"
 	"github.com/brocaar/loraserver/internal/storage"
 )
 
 var errToCode = map[error]codes.Code{
+	adr.ErrUnknownAlgorithm: codes.InvalidArgument,
+
 	data.ErrFPortMustNotBeZero:     codes.InvalidArgument,
 	data.ErrFPortMustBeZero:        codes.InvalidArgument,
 	data.ErrNoLastRXInfoSet:        codes.FailedPrecondition,
 	data.ErrInvalidDataRate:        codes.Internal,
 	data.ErrMaxPayloadSizeExceeded: codes.InvalidArgument,
 
-	proprietary.ErrInvalidDataRate: codes.Internal,
+	multicast.ErrNoGateways: codes.FailedPrecondition,
+
+	proprietary.ErrInvalidDataRate:          codes.Internal,
+	proprietary.ErrDutyCycleBudgetExhausted: codes.ResourceExhausted,
 
 	storage.ErrAlreadyExists:                  codes.AlreadyExists,
 	storage.ErrDoesNotExistOrFCntOrMICInvalid: codes.NotFound,
@@ -25,6 +32,11 @@ var errToCode = map[error]codes.Code{
 	storage.ErrInvalidName:                    codes.InvalidArgument,
 	storage.ErrInvalidAggregationInterval:     codes.InvalidArgument,
 	storage.ErrInvalidFPort:                   codes.InvalidArgument,
+	storage.ErrRateLimitExceeded:              codes.ResourceExhausted,
+	storage.ErrInvalidMulticastGroupType:      codes.InvalidArgument,
+	storage.ErrInvalidPingSlotPeriod:          codes.InvalidArgument,
+	storage.ErrInvalidFCnt:                    codes.InvalidArgument,
+	storage.ErrInvalidADRAckExp:               codes.InvalidArgument,
 }
 
 func errToRPCError(err error) error {
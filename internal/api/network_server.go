@@ -1,6 +1,7 @@
 package api
 
 import (
+	"strings"
 	"time"
 
 	"github.com/gofrs/uuid"
@@ -15,11 +16,15 @@ import (
 	"github.com/brocaar/loraserver/api/common"
 	gwPB "github.com/brocaar/loraserver/api/gw"
 	"github.com/brocaar/loraserver/api/ns"
+	"github.com/brocaar/loraserver/internal/adr"
 	"github.com/brocaar/loraserver/internal/config"
 	"github.com/brocaar/loraserver/internal/downlink/data/classb"
+	"github.com/brocaar/loraserver/internal/downlink/gateway"
+	"github.com/brocaar/loraserver/internal/downlink/multicast"
 	proprietarydown "github.com/brocaar/loraserver/internal/downlink/proprietary"
 	"github.com/brocaar/loraserver/internal/framelog"
 	"github.com/brocaar/loraserver/internal/gps"
+	"github.com/brocaar/loraserver/internal/maccommand"
 	"github.com/brocaar/loraserver/internal/storage"
 	"github.com/brocaar/lorawan"
 	"github.com/brocaar/lorawan/backend"
@@ -97,6 +102,21 @@ func (n *NetworkServerAPI) CreateServiceProfile(ctx context.Context, req *ns.Cre
 		sp.DLRatePolicy = storage.Drop
 	}
 
+	switch req.ServiceProfile.MinGwDiversityPolicy {
+	case ns.GatewayDiversityPolicy_DIVERSITY_MARK:
+		sp.MinGWDiversityPolicy = storage.GWDiversityMark
+	case ns.GatewayDiversityPolicy_DIVERSITY_DROP:
+		sp.MinGWDiversityPolicy = storage.GWDiversityDrop
+	case ns.GatewayDiversityPolicy_DIVERSITY_REPORT:
+		sp.MinGWDiversityPolicy = storage.GWDiversityReport
+	}
+
+	sp.DLChannelFrequencies = nil
+	for _, f := range req.ServiceProfile.DlChannelFrequencies {
+		sp.DLChannelFrequencies = append(sp.DLChannelFrequencies, int64(f))
+	}
+	sp.DLConfirmedRetries = int(req.ServiceProfile.DlConfirmedRetries)
+
 	if err := storage.CreateServiceProfile(config.C.PostgreSQL.DB, &sp); err != nil {
 		return nil, errToRPCError(err)
 	}
@@ -163,6 +183,20 @@ func (n *NetworkServerAPI) GetServiceProfile(ctx context.Context, req *ns.GetSer
 		resp.ServiceProfile.DlRatePolicy = ns.RatePolicy_DROP
 	}
 
+	switch sp.MinGWDiversityPolicy {
+	case storage.GWDiversityMark:
+		resp.ServiceProfile.MinGwDiversityPolicy = ns.GatewayDiversityPolicy_DIVERSITY_MARK
+	case storage.GWDiversityDrop:
+		resp.ServiceProfile.MinGwDiversityPolicy = ns.GatewayDiversityPolicy_DIVERSITY_DROP
+	case storage.GWDiversityReport:
+		resp.ServiceProfile.MinGwDiversityPolicy = ns.GatewayDiversityPolicy_DIVERSITY_REPORT
+	}
+
+	for _, f := range sp.DLChannelFrequencies {
+		resp.ServiceProfile.DlChannelFrequencies = append(resp.ServiceProfile.DlChannelFrequencies, uint32(f))
+	}
+	resp.ServiceProfile.DlConfirmedRetries = uint32(sp.DLConfirmedRetries)
+
 	return &resp, nil
 }
 
@@ -212,6 +246,21 @@ func (n *NetworkServerAPI) UpdateServiceProfile(ctx context.Context, req *ns.Upd
 		sp.DLRatePolicy = storage.Drop
 	}
 
+	switch req.ServiceProfile.MinGwDiversityPolicy {
+	case ns.GatewayDiversityPolicy_DIVERSITY_MARK:
+		sp.MinGWDiversityPolicy = storage.GWDiversityMark
+	case ns.GatewayDiversityPolicy_DIVERSITY_DROP:
+		sp.MinGWDiversityPolicy = storage.GWDiversityDrop
+	case ns.GatewayDiversityPolicy_DIVERSITY_REPORT:
+		sp.MinGWDiversityPolicy = storage.GWDiversityReport
+	}
+
+	sp.DLChannelFrequencies = nil
+	for _, f := range req.ServiceProfile.DlChannelFrequencies {
+		sp.DLChannelFrequencies = append(sp.DLChannelFrequencies, int64(f))
+	}
+	sp.DLConfirmedRetries = int(req.ServiceProfile.DlConfirmedRetries)
+
 	if err := storage.FlushServiceProfileCache(config.C.Redis.Pool, sp.ID); err != nil {
 		return nil, errToRPCError(err)
 	}
@@ -376,6 +425,18 @@ func (n *NetworkServerAPI) CreateDeviceProfile(ctx context.Context, req *ns.Crea
 		MaxDutyCycle:       int(req.DeviceProfile.MaxDutyCycle),
 		SupportsJoin:       req.DeviceProfile.SupportsJoin,
 		Supports32bitFCnt:  req.DeviceProfile.Supports_32BitFCnt,
+		ADRAlgorithmID:     req.DeviceProfile.AdrAlgorithmId,
+	}
+	dp.UplinkDwellTime400ms = req.DeviceProfile.UplinkDwellTime_400Ms
+	dp.DownlinkDwellTime400ms = req.DeviceProfile.DownlinkDwellTime_400Ms
+	dp.ADRAckLimitExp = int(req.DeviceProfile.AdrAckLimitExp)
+	dp.ADRAckDelayExp = int(req.DeviceProfile.AdrAckDelayExp)
+	dp.BeaconFreq = int(req.DeviceProfile.BeaconFreq)
+	dp.RXParametersSet = req.DeviceProfile.RxParametersSet
+	dp.PingSlotParametersSet = req.DeviceProfile.PingSlotParametersSet
+
+	if _, err := adr.GetAlgorithm(dp.ADRAlgorithmID); err != nil {
+		return nil, errToRPCError(err)
 	}
 
 	rfRegion, ok := rfRegionMapping[config.C.NetworkServer.Band.Name]
@@ -434,8 +495,16 @@ func (n *NetworkServerAPI) GetDeviceProfile(ctx context.Context, req *ns.GetDevi
 			SupportsJoin:       dp.SupportsJoin,
 			RfRegion:           string(dp.RFRegion),
 			Supports_32BitFCnt: dp.Supports32bitFCnt,
+			AdrAlgorithmId:     dp.ADRAlgorithmID,
 		},
 	}
+	resp.DeviceProfile.UplinkDwellTime_400Ms = dp.UplinkDwellTime400ms
+	resp.DeviceProfile.DownlinkDwellTime_400Ms = dp.DownlinkDwellTime400ms
+	resp.DeviceProfile.AdrAckLimitExp = uint32(dp.ADRAckLimitExp)
+	resp.DeviceProfile.AdrAckDelayExp = uint32(dp.ADRAckDelayExp)
+	resp.DeviceProfile.BeaconFreq = uint32(dp.BeaconFreq)
+	resp.DeviceProfile.RxParametersSet = dp.RXParametersSet
+	resp.DeviceProfile.PingSlotParametersSet = dp.PingSlotParametersSet
 
 	resp.CreatedAt, err = ptypes.TimestampProto(dp.CreatedAt)
 	if err != nil {
@@ -488,6 +557,18 @@ func (n *NetworkServerAPI) UpdateDeviceProfile(ctx context.Context, req *ns.Upda
 	dp.MaxDutyCycle = int(req.DeviceProfile.MaxDutyCycle)
 	dp.SupportsJoin = req.DeviceProfile.SupportsJoin
 	dp.Supports32bitFCnt = req.DeviceProfile.Supports_32BitFCnt
+	dp.ADRAlgorithmID = req.DeviceProfile.AdrAlgorithmId
+	dp.UplinkDwellTime400ms = req.DeviceProfile.UplinkDwellTime_400Ms
+	dp.DownlinkDwellTime400ms = req.DeviceProfile.DownlinkDwellTime_400Ms
+	dp.ADRAckLimitExp = int(req.DeviceProfile.AdrAckLimitExp)
+	dp.ADRAckDelayExp = int(req.DeviceProfile.AdrAckDelayExp)
+	dp.BeaconFreq = int(req.DeviceProfile.BeaconFreq)
+	dp.RXParametersSet = req.DeviceProfile.RxParametersSet
+	dp.PingSlotParametersSet = req.DeviceProfile.PingSlotParametersSet
+
+	if _, err := adr.GetAlgorithm(dp.ADRAlgorithmID); err != nil {
+		return nil, errToRPCError(err)
+	}
 
 	rfRegion, ok := rfRegionMapping[config.C.NetworkServer.Band.Name]
 	dp.RFRegion = string(rfRegion)
@@ -662,11 +743,6 @@ func (n *NetworkServerAPI) ActivateDevice(ctx context.Context, req *ns.ActivateD
 		return nil, errToRPCError(err)
 	}
 
-	sp, err := storage.GetServiceProfile(config.C.PostgreSQL.DB, d.ServiceProfileID)
-	if err != nil {
-		return nil, errToRPCError(err)
-	}
-
 	dp, err := storage.GetDeviceProfile(config.C.PostgreSQL.DB, d.DeviceProfileID)
 	if err != nil {
 		return nil, errToRPCError(err)
@@ -687,8 +763,7 @@ func (n *NetworkServerAPI) ActivateDevice(ctx context.Context, req *ns.ActivateD
 		AFCntDown:          req.DeviceActivation.AFCntDown,
 		SkipFCntValidation: req.DeviceActivation.SkipFCntCheck || d.SkipFCntCheck,
 
-		RXWindow:       storage.RX1,
-		MaxSupportedDR: sp.DRMax,
+		RXWindow: storage.RX1,
 
 		MACVersion: dp.MACVersion,
 	}
@@ -737,7 +812,7 @@ func (n *NetworkServerAPI) GetDeviceActivation(ctx context.Context, req *ns.GetD
 		return nil, errToRPCError(err)
 	}
 
-	return &ns.GetDeviceActivationResponse{
+	resp := ns.GetDeviceActivationResponse{
 		DeviceActivation: &ns.DeviceActivation{
 			DevEui:        ds.DevEUI[:],
 			DevAddr:       ds.DevAddr[:],
@@ -749,6 +824,45 @@ func (n *NetworkServerAPI) GetDeviceActivation(ctx context.Context, req *ns.GetD
 			AFCntDown:     ds.AFCntDown,
 			SkipFCntCheck: ds.SkipFCntValidation,
 		},
+	}
+
+	// an ABP activated device does not have device-activation records
+	da, err := storage.GetLastDeviceActivationForDevEUI(config.C.PostgreSQL.DB, devEUI)
+	if err != nil && err != storage.ErrDoesNotExist {
+		return nil, errToRPCError(err)
+	}
+
+	if err == nil && da.ForceRejoinReqType != nil {
+		resp.ForceRejoinOutcome = &ns.ForceRejoinOutcome{
+			RequestedRejoinType: uint32(*da.ForceRejoinReqType),
+			RejoinType:          uint32(da.JoinReqType),
+		}
+
+		resp.ForceRejoinOutcome.RejoinedAt, err = ptypes.TimestampProto(da.CreatedAt)
+		if err != nil {
+			return nil, errToRPCError(err)
+		}
+	}
+
+	return &resp, nil
+}
+
+// GetGatewayDiversityStatsForDevEUI returns the gateway diversity statistics
+// of the given device.
+func (n *NetworkServerAPI) GetGatewayDiversityStatsForDevEUI(ctx context.Context, req *ns.GetGatewayDiversityStatsForDevEUIRequest) (*ns.GetGatewayDiversityStatsForDevEUIResponse, error) {
+	var devEUI lorawan.EUI64
+	copy(devEUI[:], req.DevEui)
+
+	stats, err := storage.GetGatewayDiversityStats(config.C.Redis.Pool, devEUI)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &ns.GetGatewayDiversityStatsForDevEUIResponse{
+		UplinkCount:      uint32(stats.UplinkCount),
+		ViolationCount:   uint32(stats.ViolationCount),
+		AvgGatewayCount:  stats.AvgGatewayCount(),
+		LastGatewayCount: uint32(stats.LastGatewayCount),
 	}, nil
 }
 
@@ -793,6 +907,82 @@ func (n *NetworkServerAPI) CreateMACCommandQueueItem(ctx context.Context, req *n
 	return &empty.Empty{}, nil
 }
 
+// ForceDutyCycleReconfiguration adds a DutyCycleReq mac-command, using the
+// max duty-cycle of the device-profile, to the queue of the device. Unlike
+// the DutyCycleReq sent when the device-session and device-profile differ,
+// this mac-command is always sent.
+func (n *NetworkServerAPI) ForceDutyCycleReconfiguration(ctx context.Context, req *ns.ForceDutyCycleReconfigurationRequest) (*empty.Empty, error) {
+	var devEUI lorawan.EUI64
+	copy(devEUI[:], req.DevEui)
+
+	ds, err := storage.GetDeviceSession(config.C.Redis.Pool, devEUI)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	dp, err := storage.GetDeviceProfile(config.C.PostgreSQL.DB, ds.DeviceProfileID)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	// the block is not external, so that the DutyCycleAns is handled by the
+	// network-server
+	block := maccommand.RequestDutyCycle(dp.GetMaxDCycle())
+
+	if err := storage.CreateMACCommandQueueItem(config.C.Redis.Pool, devEUI, block); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// ForceRejoin adds a ForceRejoinReq mac-command to the queue of the device.
+// Once transmitted, the mac-command is kept pending until the device responds
+// with a rejoin-request.
+func (n *NetworkServerAPI) ForceRejoin(ctx context.Context, req *ns.ForceRejoinRequest) (*empty.Empty, error) {
+	var devEUI lorawan.EUI64
+	copy(devEUI[:], req.DevEui)
+
+	rejoinType := lorawan.JoinType(req.RejoinType)
+	if rejoinType != lorawan.RejoinRequestType0 && rejoinType != lorawan.RejoinRequestType2 {
+		return nil, grpc.Errorf(codes.InvalidArgument, "rejoin_type must be 0 or 2")
+	}
+	if req.MaxRetries > 7 {
+		return nil, grpc.Errorf(codes.InvalidArgument, "max_retries must be between 0 and 7")
+	}
+	if req.Period > 7 {
+		return nil, grpc.Errorf(codes.InvalidArgument, "period must be between 0 and 7")
+	}
+	if _, err := config.C.NetworkServer.Band.Band.GetDataRate(int(req.Dr)); err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "invalid dr: %s", err)
+	}
+
+	ds, err := storage.GetDeviceSession(config.C.Redis.Pool, devEUI)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	if strings.HasPrefix(ds.MACVersion, "1.0") {
+		return nil, grpc.Errorf(codes.FailedPrecondition, "force rejoin requires LoRaWAN 1.1+")
+	}
+
+	block := maccommand.RequestForceRejoin(rejoinType, int(req.Dr), int(req.MaxRetries), int(req.Period))
+
+	if err := storage.CreateMACCommandQueueItem(config.C.Redis.Pool, devEUI, block); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	log.WithFields(log.Fields{
+		"dev_eui":     devEUI,
+		"rejoin_type": rejoinType,
+		"dr":          req.Dr,
+		"max_retries": req.MaxRetries,
+		"period":      req.Period,
+	}).Info("force rejoin requested")
+
+	return &empty.Empty{}, nil
+}
+
 // SendProprietaryPayload send a payload using the 'Proprietary' LoRaWAN message-type.
 func (n *NetworkServerAPI) SendProprietaryPayload(ctx context.Context, req *ns.SendProprietaryPayloadRequest) (*empty.Empty, error) {
 	var mic lorawan.MIC
@@ -953,6 +1143,21 @@ func (n *NetworkServerAPI) GetGatewayStats(ctx context.Context, req *ns.GetGatew
 		resp.Result = append(resp.Result, &row)
 	}
 
+	usage, err := gateway.GetDutyCycleUsage(mac)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	for _, u := range usage {
+		resp.DutyCycleUsage = append(resp.DutyCycleUsage, &ns.GatewayDutyCycleUsage{
+			MinFrequency: uint32(u.MinFrequency),
+			MaxFrequency: uint32(u.MaxFrequency),
+			MaxDutyCycle: float32(u.MaxDutyCycle),
+			Airtime:      ptypes.DurationProto(u.Airtime),
+			Budget:       ptypes.DurationProto(u.Budget),
+		})
+	}
+
 	return &resp, nil
 }
 
@@ -1040,7 +1245,8 @@ func (n *NetworkServerAPI) CreateGatewayProfile(ctx context.Context, req *ns.Cre
 	copy(gpID[:], req.GatewayProfile.Id)
 
 	gc := storage.GatewayProfile{
-		ID: gpID,
+		ID:            gpID,
+		BeaconEnabled: req.GatewayProfile.BeaconEnabled,
 	}
 
 	for _, c := range req.GatewayProfile.Channels {
@@ -1090,7 +1296,8 @@ func (n *NetworkServerAPI) GetGatewayProfile(ctx context.Context, req *ns.GetGat
 
 	out := ns.GetGatewayProfileResponse{
 		GatewayProfile: &ns.GatewayProfile{
-			Id: gc.ID.Bytes(),
+			Id:            gc.ID.Bytes(),
+			BeaconEnabled: gc.BeaconEnabled,
 		},
 	}
 
@@ -1146,6 +1353,8 @@ func (n *NetworkServerAPI) UpdateGatewayProfile(ctx context.Context, req *ns.Upd
 		return nil, errToRPCError(err)
 	}
 
+	gc.BeaconEnabled = req.GatewayProfile.BeaconEnabled
+
 	gc.Channels = []int64{}
 	for _, c := range req.GatewayProfile.Channels {
 		gc.Channels = append(gc.Channels, int64(c))
@@ -1222,6 +1431,17 @@ func (n *NetworkServerAPI) CreateDeviceQueueItem(ctx context.Context, req *ns.Cr
 		Confirmed:  req.Item.Confirmed,
 	}
 
+	if req.Item.ExpiresAt != nil {
+		expiresAt, err := ptypes.Timestamp(req.Item.ExpiresAt)
+		if err != nil {
+			return nil, grpc.Errorf(codes.InvalidArgument, err.Error())
+		}
+		if expiresAt.Before(time.Now()) {
+			return nil, grpc.Errorf(codes.InvalidArgument, "expires_at must be in the future")
+		}
+		qi.ExpiresAt = &expiresAt
+	}
+
 	// When the device is operating in Class-B and has a beacon lock, calculate
 	// the next ping-slot.
 	if dp.SupportsClassB {
@@ -1255,11 +1475,52 @@ func (n *NetworkServerAPI) CreateDeviceQueueItem(ctx context.Context, req *ns.Cr
 		}
 	}
 
+	// enforce the downlink rate of the service-profile
+	sp, err := storage.GetServiceProfile(config.C.PostgreSQL.DB, d.ServiceProfileID)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	ok, err := storage.TakeDownlinkRateLimitToken(config.C.Redis.Pool, d.DevEUI, sp.DLRate, sp.DLBucketSize)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+	if !ok {
+		switch sp.DLRatePolicy {
+		case storage.Drop:
+			log.WithFields(log.Fields{
+				"dev_eui": d.DevEUI,
+				"dl_rate": sp.DLRate,
+			}).Warning("downlink rate exceeded, rejecting device-queue item")
+			return nil, errToRPCError(storage.ErrRateLimitExceeded)
+		default:
+			log.WithFields(log.Fields{
+				"dev_eui": d.DevEUI,
+				"dl_rate": sp.DLRate,
+			}).Info("downlink rate exceeded, marking device-queue item")
+			qi.RateLimitExceeded = true
+		}
+	}
+
 	err = storage.CreateDeviceQueueItem(config.C.PostgreSQL.DB, &qi)
 	if err != nil {
+		// the item has not been enqueued, give back the token taken for it
+		if ok {
+			if err := storage.ReturnDownlinkRateLimitToken(config.C.Redis.Pool, d.DevEUI, sp.DLRate, sp.DLBucketSize); err != nil {
+				log.WithError(err).WithField("dev_eui", d.DevEUI).Error("return downlink rate-limit token error")
+			}
+		}
 		return nil, errToRPCError(err)
 	}
 
+	// trigger the scheduler, the item will be picked up by the scheduler
+	// loop in case this fails
+	if dp.SupportsClassB || dp.SupportsClassC {
+		if err := storage.PublishDeviceQueueScheduleEvent(config.C.Redis.Pool, d.DevEUI); err != nil {
+			log.WithError(err).WithField("dev_eui", d.DevEUI).Error("publish device-queue schedule event error")
+		}
+	}
+
 	return &empty.Empty{}, nil
 }
 
@@ -1276,6 +1537,39 @@ func (n *NetworkServerAPI) FlushDeviceQueueForDevEUI(ctx context.Context, req *n
 	return &empty.Empty{}, nil
 }
 
+// DeleteDeviceQueueItem deletes a single device-queue item, identified by
+// its ID or (when no ID is given) its frame-counter.
+func (n *NetworkServerAPI) DeleteDeviceQueueItem(ctx context.Context, req *ns.DeleteDeviceQueueItemRequest) (*empty.Empty, error) {
+	var devEUI lorawan.EUI64
+	copy(devEUI[:], req.DevEui)
+
+	var qi storage.DeviceQueueItem
+	var err error
+
+	if req.Id != 0 {
+		qi, err = storage.GetDeviceQueueItem(config.C.PostgreSQL.DB, req.Id)
+		if err != nil {
+			return nil, errToRPCError(err)
+		}
+
+		// do not leak items of other devices
+		if qi.DevEUI != devEUI {
+			return nil, errToRPCError(storage.ErrDoesNotExist)
+		}
+	} else {
+		qi, err = storage.GetDeviceQueueItemForDevEUIAndFCnt(config.C.PostgreSQL.DB, devEUI, req.FCnt)
+		if err != nil {
+			return nil, errToRPCError(err)
+		}
+	}
+
+	if err := storage.DeleteDeviceQueueItem(config.C.PostgreSQL.DB, qi.ID); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &empty.Empty{}, nil
+}
+
 // GetDeviceQueueItemsForDevEUI returns all device-queue items for the given DevEUI.
 func (n *NetworkServerAPI) GetDeviceQueueItemsForDevEUI(ctx context.Context, req *ns.GetDeviceQueueItemsForDevEUIRequest) (*ns.GetDeviceQueueItemsForDevEUIResponse, error) {
 	var devEUI lorawan.EUI64
@@ -1289,11 +1583,20 @@ func (n *NetworkServerAPI) GetDeviceQueueItemsForDevEUI(ctx context.Context, req
 	var out ns.GetDeviceQueueItemsForDevEUIResponse
 	for i := range items {
 		qi := ns.DeviceQueueItem{
-			DevEui:     items[i].DevEUI[:],
-			FrmPayload: items[i].FRMPayload,
-			FCnt:       items[i].FCnt,
-			FPort:      uint32(items[i].FPort),
-			Confirmed:  items[i].Confirmed,
+			DevEui:            items[i].DevEUI[:],
+			FrmPayload:        items[i].FRMPayload,
+			FCnt:              items[i].FCnt,
+			FPort:             uint32(items[i].FPort),
+			Confirmed:         items[i].Confirmed,
+			RateLimitExceeded: items[i].RateLimitExceeded,
+			Id:                items[i].ID,
+		}
+
+		if items[i].ExpiresAt != nil {
+			qi.ExpiresAt, err = ptypes.TimestampProto(*items[i].ExpiresAt)
+			if err != nil {
+				return nil, errToRPCError(err)
+			}
 		}
 
 		out.Items = append(out.Items, &qi)
@@ -1334,6 +1637,196 @@ func (n *NetworkServerAPI) GetNextDownlinkFCntForDevEUI(ctx context.Context, req
 	return &resp, nil
 }
 
+// CreateMulticastGroup creates the given multicast-group.
+func (n *NetworkServerAPI) CreateMulticastGroup(ctx context.Context, req *ns.CreateMulticastGroupRequest) (*ns.CreateMulticastGroupResponse, error) {
+	if req.MulticastGroup == nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "multicast_group must not be nil")
+	}
+
+	var mg storage.MulticastGroup
+	if err := multicastGroupFromPB(req.MulticastGroup, &mg); err != nil {
+		return nil, err
+	}
+
+	if err := storage.CreateMulticastGroup(config.C.PostgreSQL.DB, &mg); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &ns.CreateMulticastGroupResponse{Id: mg.ID.Bytes()}, nil
+}
+
+// GetMulticastGroup returns the multicast-group given an id.
+func (n *NetworkServerAPI) GetMulticastGroup(ctx context.Context, req *ns.GetMulticastGroupRequest) (*ns.GetMulticastGroupResponse, error) {
+	var mgID uuid.UUID
+	copy(mgID[:], req.Id)
+
+	mg, err := storage.GetMulticastGroup(config.C.PostgreSQL.DB, mgID, false)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	out := ns.GetMulticastGroupResponse{
+		MulticastGroup: &ns.MulticastGroup{
+			Id:             mg.ID.Bytes(),
+			McAddr:         mg.MCAddr[:],
+			McNwkSKey:      mg.MCNwkSKey[:],
+			FCnt:           mg.FCnt,
+			Dr:             uint32(mg.DR),
+			Frequency:      uint32(mg.Frequency),
+			PingSlotPeriod: uint32(mg.PingSlotPeriod),
+		},
+	}
+
+	switch mg.GroupType {
+	case storage.MulticastGroupB:
+		out.MulticastGroup.GroupType = ns.MulticastGroupType_CLASS_B
+	case storage.MulticastGroupC:
+		out.MulticastGroup.GroupType = ns.MulticastGroupType_CLASS_C
+	}
+
+	out.CreatedAt, err = ptypes.TimestampProto(mg.CreatedAt)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	out.UpdatedAt, err = ptypes.TimestampProto(mg.UpdatedAt)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &out, nil
+}
+
+// UpdateMulticastGroup updates the given multicast-group.
+func (n *NetworkServerAPI) UpdateMulticastGroup(ctx context.Context, req *ns.UpdateMulticastGroupRequest) (*empty.Empty, error) {
+	if req.MulticastGroup == nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "multicast_group must not be nil")
+	}
+
+	var mg storage.MulticastGroup
+	if err := multicastGroupFromPB(req.MulticastGroup, &mg); err != nil {
+		return nil, err
+	}
+
+	if err := storage.UpdateMulticastGroup(config.C.PostgreSQL.DB, &mg); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// DeleteMulticastGroup deletes the multicast-group matching the given id.
+func (n *NetworkServerAPI) DeleteMulticastGroup(ctx context.Context, req *ns.DeleteMulticastGroupRequest) (*empty.Empty, error) {
+	var mgID uuid.UUID
+	copy(mgID[:], req.Id)
+
+	if err := storage.DeleteMulticastGroup(config.C.PostgreSQL.DB, mgID); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// AddDeviceToMulticastGroup adds the given device to the given multicast-group.
+func (n *NetworkServerAPI) AddDeviceToMulticastGroup(ctx context.Context, req *ns.AddDeviceToMulticastGroupRequest) (*empty.Empty, error) {
+	var devEUI lorawan.EUI64
+	var mgID uuid.UUID
+
+	copy(devEUI[:], req.DevEui)
+	copy(mgID[:], req.MulticastGroupId)
+
+	if err := storage.AddDeviceToMulticastGroup(config.C.PostgreSQL.DB, devEUI, mgID); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// RemoveDeviceFromMulticastGroup removes the given device from the given multicast-group.
+func (n *NetworkServerAPI) RemoveDeviceFromMulticastGroup(ctx context.Context, req *ns.RemoveDeviceFromMulticastGroupRequest) (*empty.Empty, error) {
+	var devEUI lorawan.EUI64
+	var mgID uuid.UUID
+
+	copy(devEUI[:], req.DevEui)
+	copy(mgID[:], req.MulticastGroupId)
+
+	if err := storage.RemoveDeviceFromMulticastGroup(config.C.PostgreSQL.DB, devEUI, mgID); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// EnqueueMulticastQueueItem creates the given multicast queue-item.
+func (n *NetworkServerAPI) EnqueueMulticastQueueItem(ctx context.Context, req *ns.EnqueueMulticastQueueItemRequest) (*empty.Empty, error) {
+	if req.MulticastQueueItem == nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "multicast_queue_item must not be nil")
+	}
+
+	if req.MulticastQueueItem.FPort == 0 || req.MulticastQueueItem.FPort > 255 {
+		return nil, grpc.Errorf(codes.InvalidArgument, "f_port must be between 1 and 255")
+	}
+
+	qi := storage.MulticastQueueItem{
+		FCnt:       req.MulticastQueueItem.FCnt,
+		FPort:      uint8(req.MulticastQueueItem.FPort),
+		FRMPayload: req.MulticastQueueItem.FrmPayload,
+	}
+	copy(qi.MulticastGroupID[:], req.MulticastQueueItem.MulticastGroupId)
+
+	err := storage.Transaction(config.C.PostgreSQL.DB, func(tx sqlx.Ext) error {
+		return multicast.EnqueueQueueItem(config.C.Redis.Pool, tx, qi)
+	})
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// FlushMulticastQueueForMulticastGroup flushes the multicast queue for the given multicast-group.
+func (n *NetworkServerAPI) FlushMulticastQueueForMulticastGroup(ctx context.Context, req *ns.FlushMulticastQueueForMulticastGroupRequest) (*empty.Empty, error) {
+	var mgID uuid.UUID
+	copy(mgID[:], req.MulticastGroupId)
+
+	if err := storage.FlushMulticastQueueForMulticastGroup(config.C.PostgreSQL.DB, mgID); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// GetMulticastQueueItemsForMulticastGroup returns the multicast queue-items
+// of the given multicast-group. As a queue-item is stored for each gateway,
+// only one item per frame-counter is returned.
+func (n *NetworkServerAPI) GetMulticastQueueItemsForMulticastGroup(ctx context.Context, req *ns.GetMulticastQueueItemsForMulticastGroupRequest) (*ns.GetMulticastQueueItemsForMulticastGroupResponse, error) {
+	var mgID uuid.UUID
+	copy(mgID[:], req.MulticastGroupId)
+
+	items, err := storage.GetMulticastQueueItemsForMulticastGroup(config.C.PostgreSQL.DB, mgID)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	var out ns.GetMulticastQueueItemsForMulticastGroupResponse
+	seen := make(map[uint32]struct{})
+	for i := range items {
+		if _, ok := seen[items[i].FCnt]; ok {
+			continue
+		}
+		seen[items[i].FCnt] = struct{}{}
+
+		out.MulticastQueueItems = append(out.MulticastQueueItems, &ns.MulticastQueueItem{
+			MulticastGroupId: items[i].MulticastGroupID.Bytes(),
+			FrmPayload:       items[i].FRMPayload,
+			FCnt:             items[i].FCnt,
+			FPort:            uint32(items[i].FPort),
+		})
+	}
+
+	return &out, nil
+}
+
 // GetVersion returns the LoRa Server version.
 func (n *NetworkServerAPI) GetVersion(ctx context.Context, req *empty.Empty) (*ns.GetVersionResponse, error) {
 	region, ok := map[band.Name]common.Region{
@@ -1391,3 +1884,26 @@ func gwToResp(gw storage.Gateway) *ns.GetGatewayResponse {
 
 	return &resp
 }
+
+// multicastGroupFromPB sets the multicast-group fields from the given
+// protobuf multicast-group.
+func multicastGroupFromPB(pb *ns.MulticastGroup, mg *storage.MulticastGroup) error {
+	copy(mg.ID[:], pb.Id)
+	copy(mg.MCAddr[:], pb.McAddr)
+	copy(mg.MCNwkSKey[:], pb.McNwkSKey)
+	mg.FCnt = pb.FCnt
+	mg.DR = int(pb.Dr)
+	mg.Frequency = int(pb.Frequency)
+	mg.PingSlotPeriod = int(pb.PingSlotPeriod)
+
+	switch pb.GroupType {
+	case ns.MulticastGroupType_CLASS_B:
+		mg.GroupType = storage.MulticastGroupB
+	case ns.MulticastGroupType_CLASS_C:
+		mg.GroupType = storage.MulticastGroupC
+	default:
+		return grpc.Errorf(codes.InvalidArgument, "invalid group_type: %s", pb.GroupType)
+	}
+
+	return nil
+}
@@ -275,6 +275,66 @@ func TestNetworkServerAPI(t *testing.T) {
 				})
 			})
 
+			Convey("Then UpdateServiceProfile updates the min gateway diversity policy", func() {
+				getResp, err := api.GetServiceProfile(ctx, &ns.GetServiceProfileRequest{
+					Id: resp.Id,
+				})
+				So(err, ShouldBeNil)
+				So(getResp.ServiceProfile.MinGwDiversityPolicy, ShouldEqual, ns.GatewayDiversityPolicy_DIVERSITY_MARK)
+
+				getResp.ServiceProfile.MinGwDiversityPolicy = ns.GatewayDiversityPolicy_DIVERSITY_REPORT
+				_, err = api.UpdateServiceProfile(ctx, &ns.UpdateServiceProfileRequest{
+					ServiceProfile: getResp.ServiceProfile,
+				})
+				So(err, ShouldBeNil)
+
+				getResp2, err := api.GetServiceProfile(ctx, &ns.GetServiceProfileRequest{
+					Id: resp.Id,
+				})
+				So(err, ShouldBeNil)
+				So(getResp2.ServiceProfile, ShouldResemble, getResp.ServiceProfile)
+			})
+
+			Convey("Then UpdateServiceProfile updates the downlink channel frequencies", func() {
+				getResp, err := api.GetServiceProfile(ctx, &ns.GetServiceProfileRequest{
+					Id: resp.Id,
+				})
+				So(err, ShouldBeNil)
+				So(getResp.ServiceProfile.DlChannelFrequencies, ShouldHaveLength, 0)
+
+				getResp.ServiceProfile.DlChannelFrequencies = []uint32{0, 869525000}
+				_, err = api.UpdateServiceProfile(ctx, &ns.UpdateServiceProfileRequest{
+					ServiceProfile: getResp.ServiceProfile,
+				})
+				So(err, ShouldBeNil)
+
+				getResp2, err := api.GetServiceProfile(ctx, &ns.GetServiceProfileRequest{
+					Id: resp.Id,
+				})
+				So(err, ShouldBeNil)
+				So(getResp2.ServiceProfile.DlChannelFrequencies, ShouldResemble, []uint32{0, 869525000})
+			})
+
+			Convey("Then UpdateServiceProfile updates the confirmed downlink retries", func() {
+				getResp, err := api.GetServiceProfile(ctx, &ns.GetServiceProfileRequest{
+					Id: resp.Id,
+				})
+				So(err, ShouldBeNil)
+				So(getResp.ServiceProfile.DlConfirmedRetries, ShouldEqual, 0)
+
+				getResp.ServiceProfile.DlConfirmedRetries = 3
+				_, err = api.UpdateServiceProfile(ctx, &ns.UpdateServiceProfileRequest{
+					ServiceProfile: getResp.ServiceProfile,
+				})
+				So(err, ShouldBeNil)
+
+				getResp2, err := api.GetServiceProfile(ctx, &ns.GetServiceProfileRequest{
+					Id: resp.Id,
+				})
+				So(err, ShouldBeNil)
+				So(getResp2.ServiceProfile.DlConfirmedRetries, ShouldEqual, 3)
+			})
+
 			Convey("Then DeleteServiceProfile deletes the service-profile", func() {
 				_, err := api.DeleteServiceProfile(ctx, &ns.DeleteServiceProfileRequest{
 					Id: resp.Id,
@@ -374,6 +434,7 @@ func TestNetworkServerAPI(t *testing.T) {
 					MaxDutyCycle:       1,
 					SupportsJoin:       true,
 					Supports_32BitFCnt: true,
+					AdrAlgorithmId:     "lora-loss-aware",
 				},
 			})
 			So(err, ShouldBeNil)
@@ -406,7 +467,98 @@ func TestNetworkServerAPI(t *testing.T) {
 					SupportsJoin:       true,
 					RfRegion:           "EU868", // set by the api
 					Supports_32BitFCnt: true,
+					AdrAlgorithmId:     "lora-loss-aware",
+				})
+			})
+
+			Convey("Then UpdateDeviceProfile rejects an unknown ADR algorithm", func() {
+				_, err := api.UpdateDeviceProfile(ctx, &ns.UpdateDeviceProfileRequest{
+					DeviceProfile: &ns.DeviceProfile{
+						Id:             resp.Id,
+						AdrAlgorithmId: "unknown",
+					},
+				})
+				So(err, ShouldNotBeNil)
+				So(grpc.Code(err), ShouldEqual, codes.InvalidArgument)
+			})
+
+			Convey("Then UpdateDeviceProfile rejects an ADR_ACK_LIMIT or ADR_ACK_DELAY exponent above 15", func() {
+				_, err := api.UpdateDeviceProfile(ctx, &ns.UpdateDeviceProfileRequest{
+					DeviceProfile: &ns.DeviceProfile{
+						Id:             resp.Id,
+						AdrAckLimitExp: 16,
+					},
+				})
+				So(grpc.Code(err), ShouldEqual, codes.InvalidArgument)
+
+				_, err = api.UpdateDeviceProfile(ctx, &ns.UpdateDeviceProfileRequest{
+					DeviceProfile: &ns.DeviceProfile{
+						Id:             resp.Id,
+						AdrAckDelayExp: 16,
+					},
+				})
+				So(grpc.Code(err), ShouldEqual, codes.InvalidArgument)
+			})
+
+			Convey("Then CreateDeviceProfile rejects an ADR_ACK_LIMIT exponent above 15", func() {
+				_, err := api.CreateDeviceProfile(ctx, &ns.CreateDeviceProfileRequest{
+					DeviceProfile: &ns.DeviceProfile{
+						AdrAckLimitExp: 16,
+					},
+				})
+				So(grpc.Code(err), ShouldEqual, codes.InvalidArgument)
+			})
+
+			Convey("Then UpdateDeviceProfile updates the dwell-time", func() {
+				_, err := api.UpdateDeviceProfile(ctx, &ns.UpdateDeviceProfileRequest{
+					DeviceProfile: &ns.DeviceProfile{
+						Id:                      resp.Id,
+						UplinkDwellTime_400Ms:   true,
+						DownlinkDwellTime_400Ms: true,
+					},
+				})
+				So(err, ShouldBeNil)
+
+				getResp, err := api.GetDeviceProfile(ctx, &ns.GetDeviceProfileRequest{
+					Id: resp.Id,
 				})
+				So(err, ShouldBeNil)
+				So(getResp.DeviceProfile.UplinkDwellTime_400Ms, ShouldBeTrue)
+				So(getResp.DeviceProfile.DownlinkDwellTime_400Ms, ShouldBeTrue)
+			})
+
+			Convey("Then UpdateDeviceProfile updates the adr parameters", func() {
+				_, err := api.UpdateDeviceProfile(ctx, &ns.UpdateDeviceProfileRequest{
+					DeviceProfile: &ns.DeviceProfile{
+						Id:             resp.Id,
+						AdrAckLimitExp: 8,
+						AdrAckDelayExp: 6,
+					},
+				})
+				So(err, ShouldBeNil)
+
+				getResp, err := api.GetDeviceProfile(ctx, &ns.GetDeviceProfileRequest{
+					Id: resp.Id,
+				})
+				So(err, ShouldBeNil)
+				So(getResp.DeviceProfile.AdrAckLimitExp, ShouldEqual, 8)
+				So(getResp.DeviceProfile.AdrAckDelayExp, ShouldEqual, 6)
+			})
+
+			Convey("Then UpdateDeviceProfile updates the beacon frequency", func() {
+				_, err := api.UpdateDeviceProfile(ctx, &ns.UpdateDeviceProfileRequest{
+					DeviceProfile: &ns.DeviceProfile{
+						Id:         resp.Id,
+						BeaconFreq: 869100000,
+					},
+				})
+				So(err, ShouldBeNil)
+
+				getResp, err := api.GetDeviceProfile(ctx, &ns.GetDeviceProfileRequest{
+					Id: resp.Id,
+				})
+				So(err, ShouldBeNil)
+				So(getResp.DeviceProfile.BeaconFreq, ShouldEqual, 869100000)
 			})
 		})
 
@@ -515,14 +667,15 @@ func TestNetworkServerAPI(t *testing.T) {
 					868300000,
 					868500000,
 				},
-				RXDelay1:       3,
-				RXDROffset1:    2,
-				RXDataRate2:    5,
-				RXFreq2:        868900000,
-				PingSlotPeriod: 32,
-				PingSlotFreq:   868100000,
-				PingSlotDR:     5,
-				MACVersion:     "1.0.2",
+				RXParametersSet: true,
+				RXDelay1:        3,
+				RXDROffset1:     2,
+				RXDataRate2:     5,
+				RXFreq2:         868900000,
+				PingSlotPeriod:  32,
+				PingSlotFreq:    868100000,
+				PingSlotDR:      5,
+				MACVersion:      "1.0.2",
 			}
 			So(storage.CreateDeviceProfile(config.C.PostgreSQL.DB, &dp), ShouldBeNil)
 
@@ -534,6 +687,69 @@ func TestNetworkServerAPI(t *testing.T) {
 			}
 			So(storage.CreateDevice(config.C.PostgreSQL.DB, &d), ShouldBeNil)
 
+			Convey("Given the service-profile has a downlink rate of 1 / hour with a bucket size of 1", func() {
+				sp.DLRate = 1
+				sp.DLBucketSize = 1
+
+				item := ns.DeviceQueueItem{
+					DevEui:     devEUI[:],
+					FrmPayload: []byte{1, 2, 3, 4},
+					FCnt:       10,
+					FPort:      20,
+				}
+
+				Convey("When the DLRatePolicy is Drop", func() {
+					sp.DLRatePolicy = storage.Drop
+					So(storage.UpdateServiceProfile(config.C.PostgreSQL.DB, &sp), ShouldBeNil)
+
+					Convey("Then CreateDeviceQueueItem rejects the item exceeding the rate", func() {
+						_, err := api.CreateDeviceQueueItem(ctx, &ns.CreateDeviceQueueItemRequest{Item: &item})
+						So(err, ShouldBeNil)
+
+						item.FCnt = 11
+						_, err = api.CreateDeviceQueueItem(ctx, &ns.CreateDeviceQueueItemRequest{Item: &item})
+						So(err, ShouldNotBeNil)
+						So(grpc.Code(err), ShouldEqual, codes.ResourceExhausted)
+
+						items, err := storage.GetDeviceQueueItemsForDevEUI(config.C.PostgreSQL.DB, devEUI)
+						So(err, ShouldBeNil)
+						So(items, ShouldHaveLength, 1)
+					})
+
+					Convey("Then an item which could not be created does not consume a token", func() {
+						item.FPort = 0
+						_, err := api.CreateDeviceQueueItem(ctx, &ns.CreateDeviceQueueItemRequest{Item: &item})
+						So(grpc.Code(err), ShouldEqual, codes.InvalidArgument)
+
+						item.FPort = 20
+						_, err = api.CreateDeviceQueueItem(ctx, &ns.CreateDeviceQueueItemRequest{Item: &item})
+						So(err, ShouldBeNil)
+					})
+				})
+
+				Convey("When the DLRatePolicy is Mark", func() {
+					sp.DLRatePolicy = storage.Mark
+					So(storage.UpdateServiceProfile(config.C.PostgreSQL.DB, &sp), ShouldBeNil)
+
+					Convey("Then CreateDeviceQueueItem marks the item exceeding the rate", func() {
+						_, err := api.CreateDeviceQueueItem(ctx, &ns.CreateDeviceQueueItemRequest{Item: &item})
+						So(err, ShouldBeNil)
+
+						item.FCnt = 11
+						_, err = api.CreateDeviceQueueItem(ctx, &ns.CreateDeviceQueueItemRequest{Item: &item})
+						So(err, ShouldBeNil)
+
+						resp, err := api.GetDeviceQueueItemsForDevEUI(ctx, &ns.GetDeviceQueueItemsForDevEUIRequest{
+							DevEui: devEUI[:],
+						})
+						So(err, ShouldBeNil)
+						So(resp.Items, ShouldHaveLength, 2)
+						So(resp.Items[0].RateLimitExceeded, ShouldBeFalse)
+						So(resp.Items[1].RateLimitExceeded, ShouldBeTrue)
+					})
+				})
+			})
+
 			Convey("Given an item in the device-queue", func() {
 				_, err := api.CreateDeviceQueueItem(ctx, &ns.CreateDeviceQueueItemRequest{
 					Item: &ns.DeviceQueueItem{
@@ -617,7 +833,6 @@ func TestNetworkServerAPI(t *testing.T) {
 							RX1DROffset:           2,
 							RX2DR:                 5,
 							RX2Frequency:          868900000,
-							MaxSupportedDR:        6,
 							UplinkGatewayHistory:  make(map[lorawan.EUI64]storage.UplinkGatewayHistory),
 							PingSlotNb:            128,
 							PingSlotDR:            5,
@@ -647,6 +862,47 @@ func TestNetworkServerAPI(t *testing.T) {
 						})
 					})
 
+					Convey("Given a device-activation triggered by a ForceRejoinReq", func() {
+						requestedRejoinType := lorawan.RejoinRequestType2
+						da := storage.DeviceActivation{
+							DevEUI:             devEUI,
+							DevAddr:            devAddr,
+							SNwkSIntKey:        sNwkSIntKey,
+							FNwkSIntKey:        fNwkSIntKey,
+							NwkSEncKey:         nwkSEncKey,
+							JoinReqType:        lorawan.RejoinRequestType0,
+							ForceRejoinReqType: &requestedRejoinType,
+						}
+						So(storage.CreateDeviceActivation(config.C.PostgreSQL.DB, &da), ShouldBeNil)
+
+						Convey("Then GetDeviceActivation returns the outcome of the ForceRejoinReq", func() {
+							resp, err := api.GetDeviceActivation(ctx, &ns.GetDeviceActivationRequest{
+								DevEui: devEUI[:],
+							})
+							So(err, ShouldBeNil)
+							So(resp.ForceRejoinOutcome, ShouldNotBeNil)
+							So(resp.ForceRejoinOutcome.RequestedRejoinType, ShouldEqual, 2)
+							So(resp.ForceRejoinOutcome.RejoinType, ShouldEqual, 0)
+							So(resp.ForceRejoinOutcome.RejoinedAt, ShouldNotBeNil)
+						})
+					})
+
+					Convey("Then GetGatewayDiversityStatsForDevEUI returns the expected response", func() {
+						So(storage.UpdateGatewayDiversityStats(config.C.Redis.Pool, devEUI, 1, true), ShouldBeNil)
+						So(storage.UpdateGatewayDiversityStats(config.C.Redis.Pool, devEUI, 3, false), ShouldBeNil)
+
+						resp, err := api.GetGatewayDiversityStatsForDevEUI(ctx, &ns.GetGatewayDiversityStatsForDevEUIRequest{
+							DevEui: devEUI[:],
+						})
+						So(err, ShouldBeNil)
+						So(resp, ShouldResemble, &ns.GetGatewayDiversityStatsForDevEUIResponse{
+							UplinkCount:      2,
+							ViolationCount:   1,
+							AvgGatewayCount:  2,
+							LastGatewayCount: 3,
+						})
+					})
+
 					Convey("For LoRaWAN 1.0", func() {
 						Convey("Then GetNextDownlinkFCntForDevEUI returns the expected FCnt", func() {
 							resp, err := api.GetNextDownlinkFCntForDevEUI(ctx, &ns.GetNextDownlinkFCntForDevEUIRequest{
@@ -752,6 +1008,95 @@ func TestNetworkServerAPI(t *testing.T) {
 							})
 						})
 					})
+
+					Convey("When calling ForceDutyCycleReconfiguration", func() {
+						dp.MaxDutyCycle = 10
+						So(storage.UpdateDeviceProfile(config.C.PostgreSQL.DB, &dp), ShouldBeNil)
+
+						_, err := api.ForceDutyCycleReconfiguration(ctx, &ns.ForceDutyCycleReconfigurationRequest{
+							DevEui: devEUI[:],
+						})
+						So(err, ShouldBeNil)
+
+						Convey("Then a duty-cycle request has been added to the queue", func() {
+							queue, err := storage.GetMACCommandQueueItems(config.C.Redis.Pool, devEUI)
+							So(err, ShouldBeNil)
+							So(queue, ShouldResemble, []storage.MACCommandBlock{
+								{
+									CID: lorawan.DutyCycleReq,
+									MACCommands: []lorawan.MACCommand{
+										{
+											CID:     lorawan.DutyCycleReq,
+											Payload: &lorawan.DutyCycleReqPayload{MaxDCycle: 4},
+										},
+									},
+								},
+							})
+						})
+					})
+
+					Convey("When calling ForceRejoin for a LoRaWAN 1.0 device", func() {
+						ds, err := storage.GetDeviceSession(config.C.Redis.Pool, devEUI)
+						So(err, ShouldBeNil)
+
+						ds.MACVersion = "1.0.2"
+						So(storage.SaveDeviceSession(config.C.Redis.Pool, ds), ShouldBeNil)
+
+						_, err = api.ForceRejoin(ctx, &ns.ForceRejoinRequest{
+							DevEui:     devEUI[:],
+							RejoinType: 0,
+						})
+
+						Convey("Then an error is returned", func() {
+							So(grpc.Code(err), ShouldEqual, codes.FailedPrecondition)
+						})
+					})
+
+					Convey("When calling ForceRejoin for a LoRaWAN 1.1 device", func() {
+						ds, err := storage.GetDeviceSession(config.C.Redis.Pool, devEUI)
+						So(err, ShouldBeNil)
+
+						ds.MACVersion = "1.1.0"
+						So(storage.SaveDeviceSession(config.C.Redis.Pool, ds), ShouldBeNil)
+
+						Convey("Then an invalid rejoin-type returns an error", func() {
+							_, err := api.ForceRejoin(ctx, &ns.ForceRejoinRequest{
+								DevEui:     devEUI[:],
+								RejoinType: 1,
+							})
+							So(grpc.Code(err), ShouldEqual, codes.InvalidArgument)
+						})
+
+						Convey("Then a force rejoin request has been added to the queue", func() {
+							_, err := api.ForceRejoin(ctx, &ns.ForceRejoinRequest{
+								DevEui:     devEUI[:],
+								RejoinType: 2,
+								Dr:         3,
+								MaxRetries: 2,
+								Period:     1,
+							})
+							So(err, ShouldBeNil)
+
+							queue, err := storage.GetMACCommandQueueItems(config.C.Redis.Pool, devEUI)
+							So(err, ShouldBeNil)
+							So(queue, ShouldResemble, []storage.MACCommandBlock{
+								{
+									CID: lorawan.ForceRejoinReq,
+									MACCommands: []lorawan.MACCommand{
+										{
+											CID: lorawan.ForceRejoinReq,
+											Payload: &lorawan.ForceRejoinReqPayload{
+												Period:     1,
+												MaxRetries: 2,
+												RejoinType: 2,
+												DR:         3,
+											},
+										},
+									},
+								},
+							})
+						})
+					})
 				})
 			})
 
@@ -830,12 +1175,16 @@ func TestNetworkServerAPI(t *testing.T) {
 				So(err, ShouldBeNil)
 
 				Convey("Then GetDeviceQueueItemsForDevEUI returns the item", func() {
+					qi, err := storage.GetDeviceQueueItemForDevEUIAndFCnt(config.C.PostgreSQL.DB, devEUI, 10)
+					So(err, ShouldBeNil)
+
 					resp, err := api.GetDeviceQueueItemsForDevEUI(ctx, &ns.GetDeviceQueueItemsForDevEUIRequest{
 						DevEui: devEUI[:],
 					})
 					So(err, ShouldBeNil)
 					So(resp.Items, ShouldHaveLength, 1)
 					So(resp.Items[0], ShouldResemble, &ns.DeviceQueueItem{
+						Id:         qi.ID,
 						DevEui:     devEUI[:],
 						FrmPayload: []byte{1, 2, 3, 4},
 						FCnt:       10,
@@ -844,6 +1193,42 @@ func TestNetworkServerAPI(t *testing.T) {
 					})
 				})
 
+				Convey("Then DeleteDeviceQueueItem deletes the item by FCnt", func() {
+					_, err := api.DeleteDeviceQueueItem(ctx, &ns.DeleteDeviceQueueItemRequest{
+						DevEui: devEUI[:],
+						FCnt:   10,
+					})
+					So(err, ShouldBeNil)
+
+					resp, err := api.GetDeviceQueueItemsForDevEUI(ctx, &ns.GetDeviceQueueItemsForDevEUIRequest{
+						DevEui: devEUI[:],
+					})
+					So(err, ShouldBeNil)
+					So(resp.Items, ShouldHaveLength, 0)
+				})
+
+				Convey("Then DeleteDeviceQueueItem deletes the item by ID", func() {
+					qi, err := storage.GetDeviceQueueItemForDevEUIAndFCnt(config.C.PostgreSQL.DB, devEUI, 10)
+					So(err, ShouldBeNil)
+
+					_, err = api.DeleteDeviceQueueItem(ctx, &ns.DeleteDeviceQueueItemRequest{
+						DevEui: devEUI[:],
+						Id:     qi.ID,
+					})
+					So(err, ShouldBeNil)
+
+					_, err = storage.GetDeviceQueueItem(config.C.PostgreSQL.DB, qi.ID)
+					So(err, ShouldEqual, storage.ErrDoesNotExist)
+				})
+
+				Convey("Then DeleteDeviceQueueItem returns NotFound for an unknown FCnt", func() {
+					_, err := api.DeleteDeviceQueueItem(ctx, &ns.DeleteDeviceQueueItemRequest{
+						DevEui: devEUI[:],
+						FCnt:   11,
+					})
+					So(grpc.Code(err), ShouldEqual, codes.NotFound)
+				})
+
 				Convey("Then FlushDeviceQueueForDevEUI flushes the device-queue", func() {
 					_, err := api.FlushDeviceQueueForDevEUI(ctx, &ns.FlushDeviceQueueForDevEUIRequest{
 						DevEui: devEUI[:],
@@ -858,6 +1243,54 @@ func TestNetworkServerAPI(t *testing.T) {
 				})
 			})
 
+			Convey("When calling CreateDeviceQueueItem with expiry", func() {
+				expiresAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+				expiresAtProto, err := ptypes.TimestampProto(expiresAt)
+				So(err, ShouldBeNil)
+
+				_, err = api.CreateDeviceQueueItem(ctx, &ns.CreateDeviceQueueItemRequest{
+					Item: &ns.DeviceQueueItem{
+						DevEui:     devEUI[:],
+						FrmPayload: []byte{1, 2, 3, 4},
+						FCnt:       10,
+						FPort:      20,
+						ExpiresAt:  expiresAtProto,
+					},
+				})
+				So(err, ShouldBeNil)
+
+				Convey("Then GetDeviceQueueItemsForDevEUI returns the expiry", func() {
+					resp, err := api.GetDeviceQueueItemsForDevEUI(ctx, &ns.GetDeviceQueueItemsForDevEUIRequest{
+						DevEui: devEUI[:],
+					})
+					So(err, ShouldBeNil)
+					So(resp.Items, ShouldHaveLength, 1)
+
+					ts, err := ptypes.Timestamp(resp.Items[0].ExpiresAt)
+					So(err, ShouldBeNil)
+					So(ts.Equal(expiresAt), ShouldBeTrue)
+				})
+			})
+
+			Convey("When calling CreateDeviceQueueItem with an expiry in the past", func() {
+				expiresAtProto, err := ptypes.TimestampProto(time.Now().Add(-time.Minute))
+				So(err, ShouldBeNil)
+
+				_, err = api.CreateDeviceQueueItem(ctx, &ns.CreateDeviceQueueItemRequest{
+					Item: &ns.DeviceQueueItem{
+						DevEui:     devEUI[:],
+						FrmPayload: []byte{1, 2, 3, 4},
+						FCnt:       10,
+						FPort:      20,
+						ExpiresAt:  expiresAtProto,
+					},
+				})
+
+				Convey("Then an InvalidArgument error is returned", func() {
+					So(grpc.Code(err), ShouldEqual, codes.InvalidArgument)
+				})
+			})
+
 			Convey("When calling GetRandomDevAddr", func() {
 				resp, err := api.GetRandomDevAddr(ctx, &empty.Empty{})
 				So(err, ShouldBeNil)
@@ -1047,6 +1480,7 @@ func TestNetworkServerAPI(t *testing.T) {
 									SpreadingFactors: []uint32{10, 11, 12},
 								},
 							},
+							BeaconEnabled: true,
 						},
 					}
 					_, err := api.UpdateGatewayProfile(ctx, &updateReq)
@@ -1073,6 +1507,7 @@ func TestNetworkServerAPI(t *testing.T) {
 								SpreadingFactors: []uint32{10, 11, 12},
 							},
 						},
+						BeaconEnabled: true,
 					})
 				})
 
@@ -1101,5 +1536,194 @@ func TestNetworkServerAPI(t *testing.T) {
 				})
 			})
 		})
+
+		Convey("When calling CreateMulticastGroup", func() {
+			mg := ns.MulticastGroup{
+				McAddr:    []byte{1, 2, 3, 4},
+				McNwkSKey: []byte{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8},
+				FCnt:      10,
+				GroupType: ns.MulticastGroupType_CLASS_C,
+				Dr:        5,
+				Frequency: 868300000,
+			}
+			createResp, err := api.CreateMulticastGroup(ctx, &ns.CreateMulticastGroupRequest{
+				MulticastGroup: &mg,
+			})
+			So(err, ShouldBeNil)
+			So(createResp.Id, ShouldHaveLength, 16)
+			So(createResp.Id, ShouldNotResemble, uuid.Nil[:])
+			mg.Id = createResp.Id
+
+			Convey("Then GetMulticastGroup returns the multicast-group", func() {
+				getResp, err := api.GetMulticastGroup(ctx, &ns.GetMulticastGroupRequest{
+					Id: createResp.Id,
+				})
+				So(err, ShouldBeNil)
+				So(getResp.CreatedAt, ShouldNotBeNil)
+				So(getResp.UpdatedAt, ShouldNotBeNil)
+				mg.XXX_sizecache = 0
+				So(getResp.MulticastGroup, ShouldResemble, &mg)
+			})
+
+			Convey("Then UpdateMulticastGroup updates the multicast-group", func() {
+				mg.GroupType = ns.MulticastGroupType_CLASS_B
+				mg.PingSlotPeriod = 128
+				_, err := api.UpdateMulticastGroup(ctx, &ns.UpdateMulticastGroupRequest{
+					MulticastGroup: &mg,
+				})
+				So(err, ShouldBeNil)
+
+				getResp, err := api.GetMulticastGroup(ctx, &ns.GetMulticastGroupRequest{
+					Id: createResp.Id,
+				})
+				So(err, ShouldBeNil)
+				mg.XXX_sizecache = 0
+				So(getResp.MulticastGroup, ShouldResemble, &mg)
+			})
+
+			Convey("Then an invalid ping-slot period returns an error", func() {
+				mg.GroupType = ns.MulticastGroupType_CLASS_B
+				_, err := api.UpdateMulticastGroup(ctx, &ns.UpdateMulticastGroupRequest{
+					MulticastGroup: &mg,
+				})
+				So(grpc.Code(err), ShouldEqual, codes.InvalidArgument)
+			})
+
+			Convey("Then DeleteMulticastGroup deletes the multicast-group", func() {
+				_, err := api.DeleteMulticastGroup(ctx, &ns.DeleteMulticastGroupRequest{
+					Id: createResp.Id,
+				})
+				So(err, ShouldBeNil)
+
+				_, err = api.GetMulticastGroup(ctx, &ns.GetMulticastGroupRequest{
+					Id: createResp.Id,
+				})
+				So(grpc.Code(err), ShouldEqual, codes.NotFound)
+			})
+
+			Convey("Given a device and gateway", func() {
+				sp := storage.ServiceProfile{}
+				So(storage.CreateServiceProfile(config.C.PostgreSQL.DB, &sp), ShouldBeNil)
+
+				rp := storage.RoutingProfile{}
+				So(storage.CreateRoutingProfile(config.C.PostgreSQL.DB, &rp), ShouldBeNil)
+
+				dp := storage.DeviceProfile{}
+				So(storage.CreateDeviceProfile(config.C.PostgreSQL.DB, &dp), ShouldBeNil)
+
+				d := storage.Device{
+					DevEUI:           devEUI,
+					DeviceProfileID:  dp.ID,
+					RoutingProfileID: rp.ID,
+					ServiceProfileID: sp.ID,
+				}
+				So(storage.CreateDevice(config.C.PostgreSQL.DB, &d), ShouldBeNil)
+
+				g := storage.Gateway{
+					MAC: lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1},
+				}
+				So(storage.CreateGateway(config.C.PostgreSQL.DB, &g), ShouldBeNil)
+
+				Convey("When calling AddDeviceToMulticastGroup", func() {
+					_, err := api.AddDeviceToMulticastGroup(ctx, &ns.AddDeviceToMulticastGroupRequest{
+						DevEui:           devEUI[:],
+						MulticastGroupId: createResp.Id,
+					})
+					So(err, ShouldBeNil)
+
+					Convey("Then EnqueueMulticastQueueItem returns an error when the device has no gateways", func() {
+						_, err := api.EnqueueMulticastQueueItem(ctx, &ns.EnqueueMulticastQueueItemRequest{
+							MulticastQueueItem: &ns.MulticastQueueItem{
+								MulticastGroupId: createResp.Id,
+								FrmPayload:       []byte{1, 2, 3, 4},
+								FCnt:             10,
+								FPort:            20,
+							},
+						})
+						So(grpc.Code(err), ShouldEqual, codes.FailedPrecondition)
+					})
+
+					Convey("Given the device has been received by the gateway", func() {
+						ds := storage.DeviceSession{
+							DevEUI: devEUI,
+							UplinkGatewayHistory: map[lorawan.EUI64]storage.UplinkGatewayHistory{
+								g.MAC: storage.UplinkGatewayHistory{},
+							},
+						}
+						So(storage.SaveDeviceSession(config.C.Redis.Pool, ds), ShouldBeNil)
+
+						Convey("Then EnqueueMulticastQueueItem returns an error for an invalid frame-counter", func() {
+							_, err := api.EnqueueMulticastQueueItem(ctx, &ns.EnqueueMulticastQueueItemRequest{
+								MulticastQueueItem: &ns.MulticastQueueItem{
+									MulticastGroupId: createResp.Id,
+									FrmPayload:       []byte{1, 2, 3, 4},
+									FCnt:             9,
+									FPort:            20,
+								},
+							})
+							So(grpc.Code(err), ShouldEqual, codes.InvalidArgument)
+						})
+
+						Convey("When calling EnqueueMulticastQueueItem", func() {
+							qi := ns.MulticastQueueItem{
+								MulticastGroupId: createResp.Id,
+								FrmPayload:       []byte{1, 2, 3, 4},
+								FCnt:             10,
+								FPort:            20,
+							}
+							_, err := api.EnqueueMulticastQueueItem(ctx, &ns.EnqueueMulticastQueueItemRequest{
+								MulticastQueueItem: &qi,
+							})
+							So(err, ShouldBeNil)
+
+							Convey("Then the frame-counter of the multicast-group was incremented", func() {
+								getResp, err := api.GetMulticastGroup(ctx, &ns.GetMulticastGroupRequest{
+									Id: createResp.Id,
+								})
+								So(err, ShouldBeNil)
+								So(getResp.MulticastGroup.FCnt, ShouldEqual, 11)
+							})
+
+							Convey("Then GetMulticastQueueItemsForMulticastGroup returns the queue-item", func() {
+								resp, err := api.GetMulticastQueueItemsForMulticastGroup(ctx, &ns.GetMulticastQueueItemsForMulticastGroupRequest{
+									MulticastGroupId: createResp.Id,
+								})
+								So(err, ShouldBeNil)
+								So(resp.MulticastQueueItems, ShouldHaveLength, 1)
+								qi.XXX_sizecache = 0
+								So(resp.MulticastQueueItems[0], ShouldResemble, &qi)
+							})
+
+							Convey("Then FlushMulticastQueueForMulticastGroup flushes the queue", func() {
+								_, err := api.FlushMulticastQueueForMulticastGroup(ctx, &ns.FlushMulticastQueueForMulticastGroupRequest{
+									MulticastGroupId: createResp.Id,
+								})
+								So(err, ShouldBeNil)
+
+								resp, err := api.GetMulticastQueueItemsForMulticastGroup(ctx, &ns.GetMulticastQueueItemsForMulticastGroupRequest{
+									MulticastGroupId: createResp.Id,
+								})
+								So(err, ShouldBeNil)
+								So(resp.MulticastQueueItems, ShouldHaveLength, 0)
+							})
+						})
+					})
+
+					Convey("Then RemoveDeviceFromMulticastGroup removes the device", func() {
+						_, err := api.RemoveDeviceFromMulticastGroup(ctx, &ns.RemoveDeviceFromMulticastGroupRequest{
+							DevEui:           devEUI[:],
+							MulticastGroupId: createResp.Id,
+						})
+						So(err, ShouldBeNil)
+
+						_, err = api.RemoveDeviceFromMulticastGroup(ctx, &ns.RemoveDeviceFromMulticastGroupRequest{
+							DevEui:           devEUI[:],
+							MulticastGroupId: createResp.Id,
+						})
+						So(grpc.Code(err), ShouldEqual, codes.NotFound)
+					})
+				})
+			})
+		})
 	})
 }
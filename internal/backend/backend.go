@@ -9,5 +9,6 @@ type Gateway interface {
 	SendGatewayConfigPacket(gw.GatewayConfigPacket) error // SendGatewayConfigPacket sends the given GatewayConfigPacket to the gateway.
 	RXPacketChan() chan gw.RXPacket                       // channel containing the received packets
 	StatsPacketChan() chan gw.GatewayStatsPacket          // channel containing the received gateway stats
+	TXAckChan() chan gw.TXAck                             // channel containing the received downlink tx acknowledgements
 	Close() error                                         // close the gateway backend.
 }
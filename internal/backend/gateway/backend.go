@@ -5,7 +5,6 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/base64"
-	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"sync"
@@ -14,6 +13,7 @@ import (
 
 	"github.com/brocaar/loraserver/api/gw"
 	"github.com/brocaar/loraserver/internal/backend"
+	"github.com/brocaar/loraserver/internal/backend/gateway/marshaler"
 	"github.com/brocaar/lorawan"
 	"github.com/eclipse/paho.mqtt.golang"
 	"github.com/garyburd/redigo/redis"
@@ -23,6 +23,7 @@ import (
 
 const uplinkLockTTL = time.Millisecond * 500
 const statsLockTTL = time.Millisecond * 500
+const ackLockTTL = time.Millisecond * 500
 
 // MQTTBackendConfig holds the MQTT backend configuration.
 type MQTTBackendConfig struct {
@@ -40,6 +41,7 @@ type MQTTBackendConfig struct {
 	StatsTopicTemplate    string `mapstructure:"stats_topic_template"`
 	AckTopicTemplate      string `mapstructure:"ack_topic_template"`
 	ConfigTopicTemplate   string `mapstructure:"config_topic_template"`
+	Marshaler             string `mapstructure:"marshaler"`
 }
 
 // MQTTBackend implements a MQTT pub-sub backend.
@@ -47,11 +49,13 @@ type MQTTBackend struct {
 	conn             mqtt.Client
 	rxPacketChan     chan gw.RXPacket
 	statsPacketChan  chan gw.GatewayStatsPacket
+	txAckChan        chan gw.TXAck
 	wg               sync.WaitGroup
 	redisPool        *redis.Pool
 	config           MQTTBackendConfig
 	downlinkTemplate *template.Template
 	configTemplate   *template.Template
+	marshaler        marshaler.Type
 }
 
 // NewMQTTBackend creates a new Backend.
@@ -60,10 +64,16 @@ func NewMQTTBackend(redisPool *redis.Pool, c MQTTBackendConfig) (backend.Gateway
 	b := MQTTBackend{
 		rxPacketChan:    make(chan gw.RXPacket),
 		statsPacketChan: make(chan gw.GatewayStatsPacket),
+		txAckChan:       make(chan gw.TXAck),
 		redisPool:       redisPool,
 		config:          c,
 	}
 
+	b.marshaler, err = marshaler.GetType(b.config.Marshaler)
+	if err != nil {
+		return nil, errors.Wrap(err, "get marshaler error")
+	}
+
 	b.downlinkTemplate, err = template.New("downlink").Parse(b.config.DownlinkTopicTemplate)
 	if err != nil {
 		return nil, errors.Wrap(err, "parse downlink template error")
@@ -162,10 +172,15 @@ func (b *MQTTBackend) Close() error {
 	if token := b.conn.Unsubscribe(b.config.StatsTopicTemplate); token.Wait() && token.Error() != nil {
 		return fmt.Errorf("backend/gateway: unsubscribe from %s error: %s", b.config.StatsTopicTemplate, token.Error())
 	}
+	log.WithField("topic", b.config.AckTopicTemplate).Info("backend/gateway: unsubscribing from ack topic")
+	if token := b.conn.Unsubscribe(b.config.AckTopicTemplate); token.Wait() && token.Error() != nil {
+		return fmt.Errorf("backend/gateway: unsubscribe from %s error: %s", b.config.AckTopicTemplate, token.Error())
+	}
 	log.Info("backend/gateway: handling last messages")
 	b.wg.Wait()
 	close(b.rxPacketChan)
 	close(b.statsPacketChan)
+	close(b.txAckChan)
 	return nil
 }
 
@@ -179,17 +194,14 @@ func (b *MQTTBackend) StatsPacketChan() chan gw.GatewayStatsPacket {
 	return b.statsPacketChan
 }
 
+// TXAckChan returns the downlink tx acknowledgement channel.
+func (b *MQTTBackend) TXAckChan() chan gw.TXAck {
+	return b.txAckChan
+}
+
 // SendTXPacket sends the given TXPacket to the gateway.
 func (b *MQTTBackend) SendTXPacket(txPacket gw.TXPacket) error {
-	phyB, err := txPacket.PHYPayload.MarshalBinary()
-	if err != nil {
-		return errors.Wrap(err, "marshal binary error")
-	}
-	bb, err := json.Marshal(gw.TXPacketBytes{
-		Token:      txPacket.Token,
-		TXInfo:     txPacket.TXInfo,
-		PHYPayload: phyB,
-	})
+	bb, err := marshaler.MarshalTXPacket(b.marshaler, txPacket)
 	if err != nil {
 		return fmt.Errorf("backend/gateway: tx packet marshal error: %s", err)
 	}
@@ -211,9 +223,9 @@ func (b *MQTTBackend) SendTXPacket(txPacket gw.TXPacket) error {
 
 // SendGatewayConfigPacket sends the given GatewayConfigPacket to the gateway.
 func (b *MQTTBackend) SendGatewayConfigPacket(configPacket gw.GatewayConfigPacket) error {
-	bb, err := json.Marshal(configPacket)
+	bb, err := marshaler.MarshalGatewayConfigPacket(b.marshaler, configPacket)
 	if err != nil {
-		return errors.Wrap(err, "backend/gateway: json marshal error")
+		return errors.Wrap(err, "backend/gateway: marshal config packet error")
 	}
 
 	topic := bytes.NewBuffer(nil)
@@ -238,31 +250,24 @@ func (b *MQTTBackend) rxPacketHandler(c mqtt.Client, msg mqtt.Message) {
 
 	log.Info("backend/gateway: rx packet received")
 
-	var phy lorawan.PHYPayload
-	var rxPacketBytes gw.RXPacketBytes
-	if err := json.Unmarshal(msg.Payload(), &rxPacketBytes); err != nil {
+	rxPacket, err := marshaler.UnmarshalRXPacket(b.uplinkMarshaler(msg.Payload()), msg.Payload())
+	if err != nil {
 		log.WithFields(log.Fields{
 			"data_base64": base64.StdEncoding.EncodeToString(msg.Payload()),
 		}).Errorf("backend/gateway: unmarshal rx packet error: %s", err)
 		return
 	}
 
-	if err := phy.UnmarshalBinary(rxPacketBytes.PHYPayload); err != nil {
-		log.WithFields(log.Fields{
-			"data_base64": base64.StdEncoding.EncodeToString(msg.Payload()),
-		}).Errorf("backend/gateway: unmarshal phypayload error: %s", err)
-	}
-
 	// Since with MQTT all subscribers will receive the uplink messages sent
 	// by all the gatewyas, the first instance receiving the message must lock it,
 	// so that other instances can ignore the same message (from the same gw).
 	// As an unique id, the gw mac + base64 encoded payload is used. This is because
 	// we can't trust any of the data, as the MIC hasn't been validated yet.
-	strB, err := phy.MarshalText()
+	strB, err := rxPacket.PHYPayload.MarshalText()
 	if err != nil {
 		log.Errorf("backend/gateway: marshal text error: %s", err)
 	}
-	key := fmt.Sprintf("lora:ns:uplink:lock:%s:%s", rxPacketBytes.RXInfo.MAC, string(strB))
+	key := fmt.Sprintf("lora:ns:uplink:lock:%s:%s", rxPacket.RXInfo.MAC, string(strB))
 	redisConn := b.redisPool.Get()
 	defer redisConn.Close()
 
@@ -276,18 +281,15 @@ func (b *MQTTBackend) rxPacketHandler(c mqtt.Client, msg mqtt.Message) {
 		return
 	}
 
-	b.rxPacketChan <- gw.RXPacket{
-		RXInfo:     rxPacketBytes.RXInfo,
-		PHYPayload: phy,
-	}
+	b.rxPacketChan <- rxPacket
 }
 
 func (b *MQTTBackend) statsPacketHandler(c mqtt.Client, msg mqtt.Message) {
 	b.wg.Add(1)
 	defer b.wg.Done()
 
-	var statsPacket gw.GatewayStatsPacket
-	if err := json.Unmarshal(msg.Payload(), &statsPacket); err != nil {
+	statsPacket, err := marshaler.UnmarshalGatewayStatsPacket(b.uplinkMarshaler(msg.Payload()), msg.Payload())
+	if err != nil {
 		log.WithFields(log.Fields{
 			"data_base64": base64.StdEncoding.EncodeToString(msg.Payload()),
 		}).Errorf("backend/gateway: unmarshal stats packet error: %s", err)
@@ -303,7 +305,7 @@ func (b *MQTTBackend) statsPacketHandler(c mqtt.Client, msg mqtt.Message) {
 	redisConn := b.redisPool.Get()
 	defer redisConn.Close()
 
-	_, err := redis.String(redisConn.Do("SET", key, "lock", "PX", int64(statsLockTTL/time.Millisecond), "NX"))
+	_, err = redis.String(redisConn.Do("SET", key, "lock", "PX", int64(statsLockTTL/time.Millisecond), "NX"))
 	if err != nil {
 		if err == redis.ErrNil {
 			// the payload is already being processed by an other instance
@@ -317,6 +319,53 @@ func (b *MQTTBackend) statsPacketHandler(c mqtt.Client, msg mqtt.Message) {
 	b.statsPacketChan <- statsPacket
 }
 
+func (b *MQTTBackend) txAckHandler(c mqtt.Client, msg mqtt.Message) {
+	b.wg.Add(1)
+	defer b.wg.Done()
+
+	txAck, err := marshaler.UnmarshalTXAck(b.uplinkMarshaler(msg.Payload()), msg.Payload())
+	if err != nil {
+		log.WithFields(log.Fields{
+			"data_base64": base64.StdEncoding.EncodeToString(msg.Payload()),
+		}).Errorf("backend/gateway: unmarshal tx ack error: %s", err)
+		return
+	}
+
+	// As with the uplink and stats messages, all subscribers receive the
+	// acknowledgement. Only the first instance acquiring the lock for the
+	// gateway + token combination will handle it.
+	key := fmt.Sprintf("lora:ns:ack:lock:%s:%d", txAck.MAC, txAck.Token)
+	redisConn := b.redisPool.Get()
+	defer redisConn.Close()
+
+	_, err = redis.String(redisConn.Do("SET", key, "lock", "PX", int64(ackLockTTL/time.Millisecond), "NX"))
+	if err != nil {
+		if err == redis.ErrNil {
+			// the ack is already being processed by an other instance
+			return
+		}
+		log.Errorf("backend/gateway: acquire ack lock error: %s", err)
+		return
+	}
+
+	log.WithFields(log.Fields{
+		"mac":   txAck.MAC,
+		"token": txAck.Token,
+		"error": txAck.Error,
+	}).Info("backend/gateway: downlink tx ack received")
+	b.txAckChan <- txAck
+}
+
+// uplinkMarshaler returns the marshaler to use for the given message
+// received from the gateway. In protobuf mode, the encoding is detected per
+// message, so that gateways can be migrated one at a time.
+func (b *MQTTBackend) uplinkMarshaler(payload []byte) marshaler.Type {
+	if b.marshaler == marshaler.JSON {
+		return marshaler.JSON
+	}
+	return marshaler.DetectType(payload)
+}
+
 func (b *MQTTBackend) onConnected(c mqtt.Client) {
 	log.Info("backend/gateway: connected to mqtt server")
 
@@ -351,6 +400,22 @@ func (b *MQTTBackend) onConnected(c mqtt.Client) {
 		}
 		break
 	}
+
+	for {
+		log.WithFields(log.Fields{
+			"topic": b.config.AckTopicTemplate,
+			"qos":   b.config.QOS,
+		}).Info("backend/gateway: subscribing to ack topic")
+		if token := b.conn.Subscribe(b.config.AckTopicTemplate, b.config.QOS, b.txAckHandler); token.Wait() && token.Error() != nil {
+			log.WithFields(log.Fields{
+				"topic": b.config.AckTopicTemplate,
+				"qos":   b.config.QOS,
+			}).Errorf("backend/gateway: subscribe error: %s", token.Error())
+			time.Sleep(time.Second)
+			continue
+		}
+		break
+	}
 }
 
 func (b *MQTTBackend) onConnectionLost(c mqtt.Client, reason error) {
@@ -0,0 +1,399 @@
+// Package marshaler implements the (un)marshaling of the messages exchanged
+// with the gateways, using either the JSON or the Protobuf encoding.
+package marshaler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/loraserver/api/common"
+	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/band"
+)
+
+// Type defines the marshaler type.
+type Type int
+
+// Marshaler types.
+const (
+	JSON Type = iota
+	Protobuf
+)
+
+// String implements the fmt.Stringer interface.
+func (t Type) String() string {
+	switch t {
+	case JSON:
+		return "json"
+	case Protobuf:
+		return "protobuf"
+	default:
+		return fmt.Sprintf("Type(%d)", int(t))
+	}
+}
+
+// GetType returns the marshaler type for the given configuration value.
+func GetType(s string) (Type, error) {
+	switch s {
+	case "", "json":
+		return JSON, nil
+	case "protobuf":
+		return Protobuf, nil
+	default:
+		return JSON, fmt.Errorf("unknown marshaler: %s", s)
+	}
+}
+
+// DetectType detects the marshaler type of the given payload.
+// A JSON object always starts with '{', which can't be the first byte of
+// any of the gateway Protobuf messages (it would encode field 15 with the
+// deprecated start-group wire-type).
+func DetectType(b []byte) Type {
+	if b = bytes.TrimLeft(b, " \t\r\n"); len(b) > 0 && b[0] == '{' {
+		return JSON
+	}
+	return Protobuf
+}
+
+// UnmarshalRXPacket unmarshals the given uplink frame.
+func UnmarshalRXPacket(t Type, b []byte) (gw.RXPacket, error) {
+	switch t {
+	case JSON:
+		var rxPacketBytes gw.RXPacketBytes
+		if err := json.Unmarshal(b, &rxPacketBytes); err != nil {
+			return gw.RXPacket{}, errors.Wrap(err, "json unmarshal error")
+		}
+
+		rxPacket := gw.RXPacket{
+			RXInfo: rxPacketBytes.RXInfo,
+		}
+		if err := rxPacket.PHYPayload.UnmarshalBinary(rxPacketBytes.PHYPayload); err != nil {
+			return gw.RXPacket{}, errors.Wrap(err, "unmarshal phypayload error")
+		}
+		return rxPacket, nil
+	case Protobuf:
+		var frame gw.UplinkFrame
+		if err := proto.Unmarshal(b, &frame); err != nil {
+			return gw.RXPacket{}, errors.Wrap(err, "protobuf unmarshal error")
+		}
+		return rxPacketFromUplinkFrame(frame)
+	default:
+		return gw.RXPacket{}, fmt.Errorf("unknown marshaler: %s", t)
+	}
+}
+
+// UnmarshalGatewayStatsPacket unmarshals the given gateway stats.
+func UnmarshalGatewayStatsPacket(t Type, b []byte) (gw.GatewayStatsPacket, error) {
+	switch t {
+	case JSON:
+		var statsPacket gw.GatewayStatsPacket
+		if err := json.Unmarshal(b, &statsPacket); err != nil {
+			return statsPacket, errors.Wrap(err, "json unmarshal error")
+		}
+		return statsPacket, nil
+	case Protobuf:
+		var stats gw.GatewayStats
+		if err := proto.Unmarshal(b, &stats); err != nil {
+			return gw.GatewayStatsPacket{}, errors.Wrap(err, "protobuf unmarshal error")
+		}
+		return statsPacketFromGatewayStats(stats)
+	default:
+		return gw.GatewayStatsPacket{}, fmt.Errorf("unknown marshaler: %s", t)
+	}
+}
+
+// UnmarshalTXAck unmarshals the given downlink tx acknowledgement.
+func UnmarshalTXAck(t Type, b []byte) (gw.TXAck, error) {
+	switch t {
+	case JSON:
+		var txAck gw.TXAck
+		if err := json.Unmarshal(b, &txAck); err != nil {
+			return txAck, errors.Wrap(err, "json unmarshal error")
+		}
+		return txAck, nil
+	case Protobuf:
+		var ack gw.DownlinkTXAck
+		if err := proto.Unmarshal(b, &ack); err != nil {
+			return gw.TXAck{}, errors.Wrap(err, "protobuf unmarshal error")
+		}
+
+		txAck := gw.TXAck{
+			Token: uint16(ack.Token),
+			Error: ack.Error,
+		}
+		if err := setMAC(&txAck.MAC, ack.GatewayId); err != nil {
+			return gw.TXAck{}, err
+		}
+		return txAck, nil
+	default:
+		return gw.TXAck{}, fmt.Errorf("unknown marshaler: %s", t)
+	}
+}
+
+// MarshalTXPacket marshals the given downlink frame.
+func MarshalTXPacket(t Type, txPacket gw.TXPacket) ([]byte, error) {
+	phyB, err := txPacket.PayloadBytes()
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal phypayload error")
+	}
+
+	switch t {
+	case JSON:
+		return json.Marshal(gw.TXPacketBytes{
+			Token:      txPacket.Token,
+			TXInfo:     txPacket.TXInfo,
+			PHYPayload: phyB,
+		})
+	case Protobuf:
+		frame, err := downlinkFrameFromTXPacket(txPacket.Token, phyB, txPacket.TXInfo)
+		if err != nil {
+			return nil, err
+		}
+		return proto.Marshal(&frame)
+	default:
+		return nil, fmt.Errorf("unknown marshaler: %s", t)
+	}
+}
+
+// MarshalGatewayConfigPacket marshals the given gateway configuration.
+func MarshalGatewayConfigPacket(t Type, configPacket gw.GatewayConfigPacket) ([]byte, error) {
+	switch t {
+	case JSON:
+		return json.Marshal(configPacket)
+	case Protobuf:
+		conf, err := gatewayConfigurationFromConfigPacket(configPacket)
+		if err != nil {
+			return nil, err
+		}
+		return proto.Marshal(&conf)
+	default:
+		return nil, fmt.Errorf("unknown marshaler: %s", t)
+	}
+}
+
+func setMAC(mac *lorawan.EUI64, gatewayID []byte) error {
+	if len(gatewayID) != len(mac) {
+		return fmt.Errorf("gateway id must be exactly %d bytes", len(mac))
+	}
+	copy(mac[:], gatewayID)
+	return nil
+}
+
+func rxPacketFromUplinkFrame(frame gw.UplinkFrame) (gw.RXPacket, error) {
+	if frame.TxInfo == nil || frame.RxInfo == nil {
+		return gw.RXPacket{}, errors.New("tx_info and rx_info must be set")
+	}
+
+	rxPacket := gw.RXPacket{
+		RXInfo: gw.RXInfo{
+			Timestamp: frame.RxInfo.Timestamp,
+			Frequency: int(frame.TxInfo.Frequency),
+			Channel:   int(frame.RxInfo.Channel),
+			RFChain:   int(frame.RxInfo.RfChain),
+			// only frames with a valid CRC are forwarded by the gateway
+			CRCStatus: 1,
+			RSSI:      int(frame.RxInfo.Rssi),
+			LoRaSNR:   frame.RxInfo.LoraSnr,
+			Size:      len(frame.PhyPayload),
+			Board:     int(frame.RxInfo.Board),
+			Antenna:   int(frame.RxInfo.Antenna),
+		},
+	}
+
+	if err := setMAC(&rxPacket.RXInfo.MAC, frame.RxInfo.GatewayId); err != nil {
+		return gw.RXPacket{}, err
+	}
+
+	if frame.RxInfo.Time != nil {
+		ts, err := ptypes.Timestamp(frame.RxInfo.Time)
+		if err != nil {
+			return gw.RXPacket{}, errors.Wrap(err, "get time error")
+		}
+		rxPacket.RXInfo.Time = &ts
+	}
+
+	if frame.RxInfo.TimeSinceGpsEpoch != nil {
+		d, err := ptypes.Duration(frame.RxInfo.TimeSinceGpsEpoch)
+		if err != nil {
+			return gw.RXPacket{}, errors.Wrap(err, "get time since gps epoch error")
+		}
+		gpsEpoch := gw.Duration(d)
+		rxPacket.RXInfo.TimeSinceGPSEpoch = &gpsEpoch
+	}
+
+	if frame.RxInfo.FineTimeSinceGpsEpoch != nil {
+		d, err := ptypes.Duration(frame.RxInfo.FineTimeSinceGpsEpoch)
+		if err != nil {
+			return gw.RXPacket{}, errors.Wrap(err, "get fine time since gps epoch error")
+		}
+		fineGPSEpoch := gw.Duration(d)
+		rxPacket.RXInfo.FineTimeSinceGPSEpoch = &fineGPSEpoch
+	}
+
+	switch frame.TxInfo.Modulation {
+	case common.Modulation_LORA:
+		modInfo := frame.TxInfo.GetLoraModulationInfo()
+		if modInfo == nil {
+			return gw.RXPacket{}, errors.New("lora_modulation_info must be set")
+		}
+		rxPacket.RXInfo.CodeRate = modInfo.CodeRate
+		rxPacket.RXInfo.DataRate = band.DataRate{
+			Modulation:   band.LoRaModulation,
+			SpreadFactor: int(modInfo.SpreadingFactor),
+			Bandwidth:    int(modInfo.Bandwidth),
+		}
+	case common.Modulation_FSK:
+		modInfo := frame.TxInfo.GetFskModulationInfo()
+		if modInfo == nil {
+			return gw.RXPacket{}, errors.New("fsk_modulation_info must be set")
+		}
+		rxPacket.RXInfo.DataRate = band.DataRate{
+			Modulation: band.FSKModulation,
+			BitRate:    int(modInfo.Bitrate),
+		}
+	default:
+		return gw.RXPacket{}, fmt.Errorf("unknown modulation: %s", frame.TxInfo.Modulation)
+	}
+
+	if err := rxPacket.PHYPayload.UnmarshalBinary(frame.PhyPayload); err != nil {
+		return gw.RXPacket{}, errors.Wrap(err, "unmarshal phypayload error")
+	}
+
+	return rxPacket, nil
+}
+
+func statsPacketFromGatewayStats(stats gw.GatewayStats) (gw.GatewayStatsPacket, error) {
+	statsPacket := gw.GatewayStatsPacket{
+		ConfigVersion:       stats.ConfigVersion,
+		RXPacketsReceived:   int(stats.RxPacketsReceived),
+		RXPacketsReceivedOK: int(stats.RxPacketsReceivedOk),
+		TXPacketsReceived:   int(stats.TxPacketsReceived),
+		TXPacketsEmitted:    int(stats.TxPacketsEmitted),
+	}
+
+	if err := setMAC(&statsPacket.MAC, stats.GatewayId); err != nil {
+		return gw.GatewayStatsPacket{}, err
+	}
+
+	if stats.Time != nil {
+		ts, err := ptypes.Timestamp(stats.Time)
+		if err != nil {
+			return gw.GatewayStatsPacket{}, errors.Wrap(err, "get time error")
+		}
+		statsPacket.Time = ts
+	}
+
+	if loc := stats.Location; loc != nil {
+		statsPacket.Latitude = &loc.Latitude
+		statsPacket.Longitude = &loc.Longitude
+		statsPacket.Altitude = &loc.Altitude
+	}
+
+	return statsPacket, nil
+}
+
+func downlinkFrameFromTXPacket(token uint16, phyB []byte, txInfo gw.TXInfo) (gw.DownlinkFrame, error) {
+	frame := gw.DownlinkFrame{
+		PhyPayload: phyB,
+		Token:      uint32(token),
+		TxInfo: &gw.DownlinkTXInfo{
+			GatewayId:   txInfo.MAC[:],
+			Immediately: txInfo.Immediately,
+			Frequency:   uint32(txInfo.Frequency),
+			Power:       int32(txInfo.Power),
+			Board:       uint32(txInfo.Board),
+			Antenna:     uint32(txInfo.Antenna),
+			NoCrc:       txInfo.NoCRC,
+			NoHeader:    txInfo.NoHeader,
+		},
+	}
+
+	if txInfo.Timestamp != nil {
+		frame.TxInfo.Timestamp = *txInfo.Timestamp
+	}
+
+	if txInfo.TimeSinceGPSEpoch != nil {
+		frame.TxInfo.TimeSinceGpsEpoch = ptypes.DurationProto(time.Duration(*txInfo.TimeSinceGPSEpoch))
+	}
+
+	switch txInfo.DataRate.Modulation {
+	case band.LoRaModulation:
+		// LoRa downlinks use an inverted polarization, unless set otherwise
+		iPol := true
+		if txInfo.IPol != nil {
+			iPol = *txInfo.IPol
+		}
+
+		frame.TxInfo.Modulation = common.Modulation_LORA
+		frame.TxInfo.ModulationInfo = &gw.DownlinkTXInfo_LoraModulationInfo{
+			LoraModulationInfo: &gw.LoRaModulationInfo{
+				Bandwidth:             uint32(txInfo.DataRate.Bandwidth),
+				SpreadingFactor:       uint32(txInfo.DataRate.SpreadFactor),
+				CodeRate:              txInfo.CodeRate,
+				PolarizationInversion: iPol,
+			},
+		}
+	case band.FSKModulation:
+		frame.TxInfo.Modulation = common.Modulation_FSK
+		frame.TxInfo.ModulationInfo = &gw.DownlinkTXInfo_FskModulationInfo{
+			FskModulationInfo: &gw.FSKModulationInfo{
+				Bandwidth: uint32(txInfo.DataRate.Bandwidth),
+				Bitrate:   uint32(txInfo.DataRate.BitRate),
+			},
+		}
+	default:
+		return gw.DownlinkFrame{}, fmt.Errorf("unknown modulation: %s", txInfo.DataRate.Modulation)
+	}
+
+	return frame, nil
+}
+
+func gatewayConfigurationFromConfigPacket(configPacket gw.GatewayConfigPacket) (gw.GatewayConfiguration, error) {
+	conf := gw.GatewayConfiguration{
+		GatewayId: configPacket.MAC[:],
+		Version:   configPacket.Version,
+	}
+
+	for _, c := range configPacket.Channels {
+		channel := gw.ChannelConfiguration{
+			Frequency: uint32(c.Frequency),
+		}
+
+		switch c.Modulation {
+		case band.LoRaModulation:
+			var sfs []uint32
+			for _, sf := range c.SpreadingFactors {
+				sfs = append(sfs, uint32(sf))
+			}
+
+			channel.Modulation = common.Modulation_LORA
+			channel.ModulationConfig = &gw.ChannelConfiguration_LoraModulationConfig{
+				LoraModulationConfig: &gw.LoRaModulationConfig{
+					Bandwidth:        uint32(c.Bandwidth),
+					SpreadingFactors: sfs,
+				},
+			}
+		case band.FSKModulation:
+			channel.Modulation = common.Modulation_FSK
+			channel.ModulationConfig = &gw.ChannelConfiguration_FskModulationConfig{
+				FskModulationConfig: &gw.FSKModulationConfig{
+					Bandwidth: uint32(c.Bandwidth),
+					Bitrate:   uint32(c.Bitrate),
+				},
+			}
+		default:
+			return gw.GatewayConfiguration{}, fmt.Errorf("unknown modulation: %s", c.Modulation)
+		}
+
+		conf.Channels = append(conf.Channels, &channel)
+	}
+
+	return conf, nil
+}
@@ -0,0 +1,298 @@
+package marshaler
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/loraserver/api/common"
+	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/band"
+)
+
+func TestMarshaler(t *testing.T) {
+	Convey("Given a PHYPayload and gateway MAC", t, func() {
+		mac := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+		phy := lorawan.PHYPayload{
+			MHDR: lorawan.MHDR{
+				MType: lorawan.UnconfirmedDataUp,
+				Major: lorawan.LoRaWANR1,
+			},
+			MACPayload: &lorawan.MACPayload{
+				FHDR: lorawan.FHDR{
+					DevAddr: lorawan.DevAddr{1, 2, 3, 4},
+				},
+			},
+		}
+		phyB, err := phy.MarshalBinary()
+		So(err, ShouldBeNil)
+
+		Convey("Then DetectType detects the JSON and Protobuf encodings", func() {
+			So(DetectType([]byte(` {"mac": "0102030405060708"}`)), ShouldEqual, JSON)
+			So(DetectType([]byte{0x0a, 0x02, 0x01, 0x02}), ShouldEqual, Protobuf)
+		})
+
+		Convey("Then GetType returns the configured marshaler", func() {
+			for _, test := range []struct {
+				Value string
+				Type  Type
+				Error bool
+			}{
+				{"", JSON, false},
+				{"json", JSON, false},
+				{"protobuf", Protobuf, false},
+				{"xml", JSON, true},
+			} {
+				typ, err := GetType(test.Value)
+				So(typ, ShouldEqual, test.Type)
+				So(err != nil, ShouldEqual, test.Error)
+			}
+		})
+
+		Convey("Given an UplinkFrame encoded as Protobuf", func() {
+			now := time.Now().UTC().Truncate(time.Millisecond)
+			nowPB, err := ptypes.TimestampProto(now)
+			So(err, ShouldBeNil)
+
+			b, err := proto.Marshal(&gw.UplinkFrame{
+				PhyPayload: phyB,
+				TxInfo: &gw.UplinkTXInfo{
+					Frequency:  868100000,
+					Modulation: common.Modulation_LORA,
+					ModulationInfo: &gw.UplinkTXInfo_LoraModulationInfo{
+						LoraModulationInfo: &gw.LoRaModulationInfo{
+							Bandwidth:       125,
+							SpreadingFactor: 7,
+							CodeRate:        "4/5",
+						},
+					},
+				},
+				RxInfo: &gw.UplinkRXInfo{
+					GatewayId:             mac[:],
+					Time:                  nowPB,
+					TimeSinceGpsEpoch:     ptypes.DurationProto(time.Second),
+					FineTimeSinceGpsEpoch: ptypes.DurationProto(time.Second + 123456789*time.Nanosecond),
+					Timestamp:             12345,
+					Rssi:                  -60,
+					LoraSnr:               5.5,
+					Channel:               1,
+					RfChain:               1,
+					Antenna:               2,
+				},
+			})
+			So(err, ShouldBeNil)
+
+			Convey("Then UnmarshalRXPacket returns the expected RXPacket", func() {
+				rxPacket, err := UnmarshalRXPacket(DetectType(b), b)
+				So(err, ShouldBeNil)
+
+				gpsEpoch := gw.Duration(time.Second)
+				fineGPSEpoch := gw.Duration(time.Second + 123456789*time.Nanosecond)
+				So(rxPacket, ShouldResemble, gw.RXPacket{
+					RXInfo: gw.RXInfo{
+						MAC:                   mac,
+						Time:                  &now,
+						TimeSinceGPSEpoch:     &gpsEpoch,
+						FineTimeSinceGPSEpoch: &fineGPSEpoch,
+						Timestamp:             12345,
+						Frequency:             868100000,
+						Channel:               1,
+						RFChain:               1,
+						CRCStatus:             1,
+						CodeRate:              "4/5",
+						RSSI:                  -60,
+						LoRaSNR:               5.5,
+						Size:                  len(phyB),
+						DataRate: band.DataRate{
+							Modulation:   band.LoRaModulation,
+							SpreadFactor: 7,
+							Bandwidth:    125,
+						},
+						Antenna: 2,
+					},
+					PHYPayload: phy,
+				})
+			})
+		})
+
+		Convey("Given a TXPacket", func() {
+			timestamp := uint32(12345)
+			iPol := false
+			txPacket := gw.TXPacket{
+				Token: 1234,
+				TXInfo: gw.TXInfo{
+					MAC:       mac,
+					Timestamp: &timestamp,
+					Frequency: 868100000,
+					Power:     14,
+					DataRate: band.DataRate{
+						Modulation:   band.LoRaModulation,
+						SpreadFactor: 12,
+						Bandwidth:    125,
+					},
+					CodeRate: "4/5",
+					IPol:     &iPol,
+				},
+				PHYPayload: phy,
+			}
+
+			Convey("Then MarshalTXPacket using Protobuf returns the expected DownlinkFrame", func() {
+				b, err := MarshalTXPacket(Protobuf, txPacket)
+				So(err, ShouldBeNil)
+
+				var frame gw.DownlinkFrame
+				So(proto.Unmarshal(b, &frame), ShouldBeNil)
+				So(frame.Token, ShouldEqual, 1234)
+				So(frame.PhyPayload, ShouldResemble, phyB)
+				So(frame.TxInfo.GatewayId, ShouldResemble, mac[:])
+				So(frame.TxInfo.Timestamp, ShouldEqual, 12345)
+				So(frame.TxInfo.Frequency, ShouldEqual, 868100000)
+				So(frame.TxInfo.Power, ShouldEqual, 14)
+				So(frame.TxInfo.Modulation, ShouldEqual, common.Modulation_LORA)
+				So(frame.TxInfo.GetLoraModulationInfo(), ShouldResemble, &gw.LoRaModulationInfo{
+					Bandwidth:             125,
+					SpreadingFactor:       12,
+					CodeRate:              "4/5",
+					PolarizationInversion: false,
+				})
+			})
+
+			Convey("Then MarshalTXPacket using JSON returns a TXPacketBytes object", func() {
+				b, err := MarshalTXPacket(JSON, txPacket)
+				So(err, ShouldBeNil)
+				So(DetectType(b), ShouldEqual, JSON)
+			})
+
+			Convey("Given the TXPacket is a beacon", func() {
+				txPacket.Beacon = []byte{1, 2, 3, 4, 5}
+				txPacket.TXInfo.NoCRC = true
+				txPacket.TXInfo.NoHeader = true
+
+				Convey("Then MarshalTXPacket using Protobuf disables the CRC and header", func() {
+					b, err := MarshalTXPacket(Protobuf, txPacket)
+					So(err, ShouldBeNil)
+
+					var frame gw.DownlinkFrame
+					So(proto.Unmarshal(b, &frame), ShouldBeNil)
+					So(frame.PhyPayload, ShouldResemble, txPacket.Beacon)
+					So(frame.TxInfo.NoCrc, ShouldBeTrue)
+					So(frame.TxInfo.NoHeader, ShouldBeTrue)
+				})
+
+				Convey("Then MarshalTXPacket using JSON disables the CRC and header", func() {
+					b, err := MarshalTXPacket(JSON, txPacket)
+					So(err, ShouldBeNil)
+
+					var txPacketBytes gw.TXPacketBytes
+					So(json.Unmarshal(b, &txPacketBytes), ShouldBeNil)
+					So(txPacketBytes.PHYPayload, ShouldResemble, txPacket.Beacon)
+					So(txPacketBytes.TXInfo.NoCRC, ShouldBeTrue)
+					So(txPacketBytes.TXInfo.NoHeader, ShouldBeTrue)
+				})
+			})
+		})
+
+		Convey("Given a GatewayStats message encoded as Protobuf", func() {
+			b, err := proto.Marshal(&gw.GatewayStats{
+				GatewayId: mac[:],
+				Location: &gw.Location{
+					Latitude:  1.123,
+					Longitude: 2.123,
+					Altitude:  3.123,
+				},
+				ConfigVersion:       "1.2.3",
+				RxPacketsReceived:   10,
+				RxPacketsReceivedOk: 9,
+				TxPacketsReceived:   8,
+				TxPacketsEmitted:    7,
+			})
+			So(err, ShouldBeNil)
+
+			Convey("Then UnmarshalGatewayStatsPacket returns the expected GatewayStatsPacket", func() {
+				stats, err := UnmarshalGatewayStatsPacket(Protobuf, b)
+				So(err, ShouldBeNil)
+
+				lat := 1.123
+				long := 2.123
+				alt := 3.123
+				So(stats, ShouldResemble, gw.GatewayStatsPacket{
+					MAC:                 mac,
+					Latitude:            &lat,
+					Longitude:           &long,
+					Altitude:            &alt,
+					ConfigVersion:       "1.2.3",
+					RXPacketsReceived:   10,
+					RXPacketsReceivedOK: 9,
+					TXPacketsReceived:   8,
+					TXPacketsEmitted:    7,
+				})
+			})
+		})
+
+		Convey("Given a DownlinkTXAck message encoded as Protobuf", func() {
+			b, err := proto.Marshal(&gw.DownlinkTXAck{
+				GatewayId: mac[:],
+				Token:     1234,
+				Error:     gw.ErrTooLate,
+			})
+			So(err, ShouldBeNil)
+
+			Convey("Then UnmarshalTXAck returns the expected TXAck", func() {
+				txAck, err := UnmarshalTXAck(Protobuf, b)
+				So(err, ShouldBeNil)
+				So(txAck, ShouldResemble, gw.TXAck{
+					MAC:   mac,
+					Token: 1234,
+					Error: gw.ErrTooLate,
+				})
+			})
+		})
+
+		Convey("Given a GatewayConfigPacket", func() {
+			configPacket := gw.GatewayConfigPacket{
+				MAC:     mac,
+				Version: "1.2.3",
+				Channels: []gw.Channel{
+					{
+						Modulation:       band.LoRaModulation,
+						Frequency:        868100000,
+						Bandwidth:        125,
+						SpreadingFactors: []int{7, 8, 9},
+					},
+					{
+						Modulation: band.FSKModulation,
+						Frequency:  868800000,
+						Bandwidth:  125,
+						Bitrate:    50000,
+					},
+				},
+			}
+
+			Convey("Then MarshalGatewayConfigPacket using Protobuf returns the expected GatewayConfiguration", func() {
+				b, err := MarshalGatewayConfigPacket(Protobuf, configPacket)
+				So(err, ShouldBeNil)
+
+				var conf gw.GatewayConfiguration
+				So(proto.Unmarshal(b, &conf), ShouldBeNil)
+				So(conf.GatewayId, ShouldResemble, mac[:])
+				So(conf.Version, ShouldEqual, "1.2.3")
+				So(conf.Channels, ShouldHaveLength, 2)
+				So(conf.Channels[0].Frequency, ShouldEqual, 868100000)
+				So(conf.Channels[0].GetLoraModulationConfig(), ShouldResemble, &gw.LoRaModulationConfig{
+					Bandwidth:        125,
+					SpreadingFactors: []uint32{7, 8, 9},
+				})
+				So(conf.Channels[1].Modulation, ShouldEqual, common.Modulation_FSK)
+				So(conf.Channels[1].GetFskModulationConfig(), ShouldResemble, &gw.FSKModulationConfig{
+					Bandwidth: 125,
+					Bitrate:   50000,
+				})
+			})
+		})
+	})
+}
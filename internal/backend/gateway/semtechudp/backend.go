@@ -0,0 +1,488 @@
+// Package semtechudp implements a gateway backend speaking the Semtech UDP
+// packet-forwarder protocol, so that gateways can connect to LoRa Server
+// without a MQTT broker and LoRa Gateway Bridge.
+package semtechudp
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/loraserver/internal/backend"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/band"
+)
+
+// gatewayCleanupDuration defines after which duration a gateway is removed
+// from the registry when no PULL_DATA keep-alive has been received.
+const gatewayCleanupDuration = time.Minute
+
+// ErrGatewayDoesNotExist is returned when sending to a gateway from which no
+// PULL_DATA packet has been received (yet).
+var ErrGatewayDoesNotExist = errors.New("gateway does not exist")
+
+// ErrGatewayConfigNotSupported is returned when sending a gateway
+// configuration, as this is not part of the packet-forwarder protocol.
+var ErrGatewayConfigNotSupported = errors.New("gateway configuration is not supported by the semtech udp backend")
+
+// Config holds the Semtech UDP backend configuration.
+type Config struct {
+	Bind string `mapstructure:"bind"`
+}
+
+type gateway struct {
+	addr            *net.UDPAddr
+	protocolVersion uint8
+	lastSeen        time.Time
+}
+
+// Backend implements a Semtech UDP packet-forwarder backend.
+type Backend struct {
+	sync.RWMutex
+
+	conn            *net.UDPConn
+	rxPacketChan    chan gw.RXPacket
+	statsPacketChan chan gw.GatewayStatsPacket
+	txAckChan       chan gw.TXAck
+	wg              sync.WaitGroup
+	closed          bool
+	gateways        map[lorawan.EUI64]gateway
+}
+
+// NewBackend creates a new Semtech UDP backend, listening on the configured
+// bind address.
+func NewBackend(c Config) (backend.Gateway, error) {
+	addr, err := net.ResolveUDPAddr("udp", c.Bind)
+	if err != nil {
+		return nil, errors.Wrap(err, "resolve udp addr error")
+	}
+
+	log.WithField("addr", addr).Info("backend/semtechudp: starting gateway udp listener")
+	conn, err := net.ListenUDP("udp", addr)
+	if err != nil {
+		return nil, errors.Wrap(err, "listen udp error")
+	}
+
+	b := Backend{
+		conn:            conn,
+		rxPacketChan:    make(chan gw.RXPacket),
+		statsPacketChan: make(chan gw.GatewayStatsPacket),
+		txAckChan:       make(chan gw.TXAck),
+		gateways:        make(map[lorawan.EUI64]gateway),
+	}
+
+	go func() {
+		b.wg.Add(1)
+		defer b.wg.Done()
+		if err := b.readPackets(); err != nil && !b.isClosed() {
+			log.WithError(err).Error("backend/semtechudp: read udp packets error")
+		}
+	}()
+
+	go b.cleanupGateways()
+
+	return &b, nil
+}
+
+// Close closes the backend.
+func (b *Backend) Close() error {
+	log.Info("backend/semtechudp: closing backend")
+	b.Lock()
+	b.closed = true
+	b.Unlock()
+
+	if err := b.conn.Close(); err != nil {
+		return errors.Wrap(err, "close udp listener error")
+	}
+
+	log.Info("backend/semtechudp: handling last packets")
+	b.wg.Wait()
+	close(b.rxPacketChan)
+	close(b.statsPacketChan)
+	close(b.txAckChan)
+	return nil
+}
+
+// RXPacketChan returns the RXPacket channel.
+func (b *Backend) RXPacketChan() chan gw.RXPacket {
+	return b.rxPacketChan
+}
+
+// StatsPacketChan returns the gateway stats channel.
+func (b *Backend) StatsPacketChan() chan gw.GatewayStatsPacket {
+	return b.statsPacketChan
+}
+
+// TXAckChan returns the downlink tx acknowledgement channel.
+func (b *Backend) TXAckChan() chan gw.TXAck {
+	return b.txAckChan
+}
+
+// SendTXPacket sends the given TXPacket to the gateway as PULL_RESP packet.
+func (b *Backend) SendTXPacket(txPacket gw.TXPacket) error {
+	b.RLock()
+	g, ok := b.gateways[txPacket.TXInfo.MAC]
+	b.RUnlock()
+	if !ok {
+		return ErrGatewayDoesNotExist
+	}
+
+	txpk, err := newTXPKFromTXPacket(txPacket)
+	if err != nil {
+		return errors.Wrap(err, "get txpk error")
+	}
+
+	pullResp := PullRespPacket{
+		ProtocolVersion: g.protocolVersion,
+		RandomToken:     txPacket.Token,
+		Payload: PullRespPayload{
+			TXPK: txpk,
+		},
+	}
+
+	bb, err := pullResp.MarshalBinary()
+	if err != nil {
+		return errors.Wrap(err, "marshal binary error")
+	}
+
+	log.WithFields(log.Fields{
+		"mac":   txPacket.TXInfo.MAC,
+		"addr":  g.addr,
+		"token": txPacket.Token,
+	}).Info("backend/semtechudp: sending pull_resp packet")
+
+	if _, err := b.conn.WriteToUDP(bb, g.addr); err != nil {
+		return errors.Wrap(err, "write to udp error")
+	}
+	return nil
+}
+
+// SendGatewayConfigPacket is not supported by the packet-forwarder protocol.
+func (b *Backend) SendGatewayConfigPacket(configPacket gw.GatewayConfigPacket) error {
+	return ErrGatewayConfigNotSupported
+}
+
+func (b *Backend) isClosed() bool {
+	b.RLock()
+	defer b.RUnlock()
+	return b.closed
+}
+
+func (b *Backend) cleanupGateways() {
+	for !b.isClosed() {
+		time.Sleep(gatewayCleanupDuration)
+
+		b.Lock()
+		for mac, g := range b.gateways {
+			if time.Now().Sub(g.lastSeen) > gatewayCleanupDuration {
+				log.WithFields(log.Fields{
+					"mac":  mac,
+					"addr": g.addr,
+				}).Info("backend/semtechudp: removing inactive gateway")
+				delete(b.gateways, mac)
+			}
+		}
+		b.Unlock()
+	}
+}
+
+func (b *Backend) readPackets() error {
+	buf := make([]byte, 65507) // max udp data size
+	for {
+		i, addr, err := b.conn.ReadFromUDP(buf)
+		if err != nil {
+			return errors.Wrap(err, "read from udp error")
+		}
+		data := make([]byte, i)
+		copy(data, buf[:i])
+
+		go func(addr *net.UDPAddr, data []byte) {
+			b.wg.Add(1)
+			defer b.wg.Done()
+			if err := b.handlePacket(addr, data); err != nil {
+				log.WithError(err).WithFields(log.Fields{
+					"addr":        addr,
+					"data_base64": base64.StdEncoding.EncodeToString(data),
+				}).Error("backend/semtechudp: could not handle packet")
+			}
+		}(addr, data)
+	}
+}
+
+func (b *Backend) handlePacket(addr *net.UDPAddr, data []byte) error {
+	pt, err := GetPacketType(data)
+	if err != nil {
+		return errors.Wrap(err, "get packet type error")
+	}
+
+	log.WithFields(log.Fields{
+		"addr": addr,
+		"type": pt,
+	}).Debug("backend/semtechudp: received udp packet from gateway")
+
+	switch pt {
+	case PushData:
+		return b.handlePushData(addr, data)
+	case PullData:
+		return b.handlePullData(addr, data)
+	case TXACK:
+		return b.handleTXACK(addr, data)
+	default:
+		return fmt.Errorf("unexpected packet type: %s", pt)
+	}
+}
+
+func (b *Backend) handlePullData(addr *net.UDPAddr, data []byte) error {
+	var p PullDataPacket
+	if err := p.UnmarshalBinary(data); err != nil {
+		return errors.Wrap(err, "unmarshal pull_data packet error")
+	}
+
+	ack := PullACKPacket{
+		ProtocolVersion: p.ProtocolVersion,
+		RandomToken:     p.RandomToken,
+	}
+	bb, err := ack.MarshalBinary()
+	if err != nil {
+		return errors.Wrap(err, "marshal pull_ack packet error")
+	}
+
+	b.Lock()
+	if _, ok := b.gateways[p.GatewayMAC]; !ok {
+		log.WithFields(log.Fields{
+			"mac":  p.GatewayMAC,
+			"addr": addr,
+		}).Info("backend/semtechudp: new gateway")
+	}
+	b.gateways[p.GatewayMAC] = gateway{
+		addr:            addr,
+		protocolVersion: p.ProtocolVersion,
+		lastSeen:        time.Now(),
+	}
+	b.Unlock()
+
+	if _, err := b.conn.WriteToUDP(bb, addr); err != nil {
+		return errors.Wrap(err, "write to udp error")
+	}
+	return nil
+}
+
+func (b *Backend) handlePushData(addr *net.UDPAddr, data []byte) error {
+	var p PushDataPacket
+	if err := p.UnmarshalBinary(data); err != nil {
+		return errors.Wrap(err, "unmarshal push_data packet error")
+	}
+
+	// ack the packet before processing, the processing might take a while
+	ack := PushACKPacket{
+		ProtocolVersion: p.ProtocolVersion,
+		RandomToken:     p.RandomToken,
+	}
+	bb, err := ack.MarshalBinary()
+	if err != nil {
+		return errors.Wrap(err, "marshal push_ack packet error")
+	}
+	if _, err := b.conn.WriteToUDP(bb, addr); err != nil {
+		return errors.Wrap(err, "write to udp error")
+	}
+
+	if p.Payload.Stat != nil {
+		log.WithField("mac", p.GatewayMAC).Info("backend/semtechudp: gateway stats packet received")
+		b.statsPacketChan <- newGatewayStatsPacketFromStat(p.GatewayMAC, *p.Payload.Stat)
+	}
+
+	for _, rxpk := range p.Payload.RXPK {
+		// only packets with a valid CRC are forwarded
+		if rxpk.Stat != 1 {
+			log.WithFields(log.Fields{
+				"mac":  p.GatewayMAC,
+				"stat": rxpk.Stat,
+			}).Debug("backend/semtechudp: ignoring rx packet with invalid or without crc")
+			continue
+		}
+
+		rxPacket, err := newRXPacketFromRXPK(p.GatewayMAC, rxpk)
+		if err != nil {
+			log.WithError(err).WithField("mac", p.GatewayMAC).Error("backend/semtechudp: could not get rx packet from rxpk")
+			continue
+		}
+
+		log.WithField("mac", p.GatewayMAC).Info("backend/semtechudp: rx packet received")
+		b.rxPacketChan <- rxPacket
+	}
+
+	return nil
+}
+
+func (b *Backend) handleTXACK(addr *net.UDPAddr, data []byte) error {
+	var p TXACKPacket
+	if err := p.UnmarshalBinary(data); err != nil {
+		return errors.Wrap(err, "unmarshal tx_ack packet error")
+	}
+
+	txAck := gw.TXAck{
+		MAC:   p.GatewayMAC,
+		Token: p.RandomToken,
+	}
+
+	if p.Payload != nil && p.Payload.TXPKACK.Error != "" && p.Payload.TXPKACK.Error != "NONE" {
+		txAck.Error = p.Payload.TXPKACK.Error
+	}
+
+	log.WithFields(log.Fields{
+		"mac":   txAck.MAC,
+		"token": txAck.Token,
+		"error": txAck.Error,
+	}).Info("backend/semtechudp: downlink tx ack received")
+	b.txAckChan <- txAck
+
+	return nil
+}
+
+func newGatewayStatsPacketFromStat(mac lorawan.EUI64, stat Stat) gw.GatewayStatsPacket {
+	out := gw.GatewayStatsPacket{
+		MAC:                 mac,
+		Time:                time.Time(stat.Time).UTC(),
+		Latitude:            stat.Lati,
+		Longitude:           stat.Long,
+		RXPacketsReceived:   int(stat.RXNb),
+		RXPacketsReceivedOK: int(stat.RXOK),
+		TXPacketsReceived:   int(stat.DWNb),
+		TXPacketsEmitted:    int(stat.TXNb),
+	}
+
+	if stat.Alti != nil {
+		alt := float64(*stat.Alti)
+		out.Altitude = &alt
+	}
+
+	return out
+}
+
+func newRXPacketFromRXPK(mac lorawan.EUI64, rxpk RXPK) (gw.RXPacket, error) {
+	dr, err := newDataRateFromDatR(rxpk.Modu, rxpk.DatR)
+	if err != nil {
+		return gw.RXPacket{}, errors.Wrap(err, "get data-rate error")
+	}
+
+	rxPacket := gw.RXPacket{
+		RXInfo: gw.RXInfo{
+			MAC:       mac,
+			Timestamp: rxpk.Tmst,
+			Frequency: int(math.Round(rxpk.Freq * 1000000)),
+			Channel:   int(rxpk.Chan),
+			RFChain:   int(rxpk.RFCh),
+			CRCStatus: int(rxpk.Stat),
+			CodeRate:  rxpk.CodR,
+			RSSI:      int(rxpk.RSSI),
+			LoRaSNR:   rxpk.LSNR,
+			Size:      int(rxpk.Size),
+			DataRate:  dr,
+			Board:     int(rxpk.Brd),
+			Antenna:   int(rxpk.Ant),
+		},
+	}
+
+	if rxpk.Time != nil {
+		ts := time.Time(*rxpk.Time)
+		rxPacket.RXInfo.Time = &ts
+	}
+
+	if rxpk.Tmms != nil {
+		d := gw.Duration(time.Duration(*rxpk.Tmms) * time.Millisecond)
+		rxPacket.RXInfo.TimeSinceGPSEpoch = &d
+
+		// the fine timestamp holds the nanoseconds since the last PPS,
+		// thus the start of the GPS second
+		for _, rsig := range rxpk.RSig {
+			if rsig.FTime == nil {
+				continue
+			}
+
+			fine := gw.Duration(time.Duration(*rxpk.Tmms)*time.Millisecond/time.Second*time.Second + time.Duration(*rsig.FTime))
+			rxPacket.RXInfo.FineTimeSinceGPSEpoch = &fine
+			break
+		}
+	}
+
+	if err := rxPacket.PHYPayload.UnmarshalBinary(rxpk.Data); err != nil {
+		return gw.RXPacket{}, errors.Wrap(err, "unmarshal phypayload error")
+	}
+
+	return rxPacket, nil
+}
+
+func newTXPKFromTXPacket(txPacket gw.TXPacket) (TXPK, error) {
+	b, err := txPacket.PayloadBytes()
+	if err != nil {
+		return TXPK{}, errors.Wrap(err, "marshal phypayload error")
+	}
+
+	txpk := TXPK{
+		Imme: txPacket.TXInfo.Immediately,
+		Tmst: txPacket.TXInfo.Timestamp,
+		Freq: float64(txPacket.TXInfo.Frequency) / 1000000,
+		Powe: uint8(txPacket.TXInfo.Power),
+		Modu: string(txPacket.TXInfo.DataRate.Modulation),
+		CodR: txPacket.TXInfo.CodeRate,
+		Size: uint16(len(b)),
+		Data: b,
+		Brd:  uint8(txPacket.TXInfo.Board),
+		Ant:  uint8(txPacket.TXInfo.Antenna),
+	}
+
+	if txPacket.TXInfo.TimeSinceGPSEpoch != nil {
+		tmms := int64(time.Duration(*txPacket.TXInfo.TimeSinceGPSEpoch) / time.Millisecond)
+		txpk.Tmms = &tmms
+	}
+
+	txpk.NCRC = txPacket.TXInfo.NoCRC
+	txpk.NHdr = txPacket.TXInfo.NoHeader
+
+	switch txPacket.TXInfo.DataRate.Modulation {
+	case band.LoRaModulation:
+		txpk.DatR.LoRa = fmt.Sprintf("SF%dBW%d", txPacket.TXInfo.DataRate.SpreadFactor, txPacket.TXInfo.DataRate.Bandwidth)
+		// the polarization is inverted for LoRa downlinks, unless set
+		// otherwise (e.g. for proprietary payloads)
+		txpk.IPol = true
+		if txPacket.TXInfo.IPol != nil {
+			txpk.IPol = *txPacket.TXInfo.IPol
+		}
+	case band.FSKModulation:
+		txpk.DatR.FSK = uint32(txPacket.TXInfo.DataRate.BitRate)
+		txpk.FDev = uint16(txPacket.TXInfo.DataRate.BitRate / 2)
+		if txPacket.TXInfo.IPol != nil {
+			txpk.IPol = *txPacket.TXInfo.IPol
+		}
+	default:
+		return TXPK{}, fmt.Errorf("unknown modulation: %s", txPacket.TXInfo.DataRate.Modulation)
+	}
+
+	return txpk, nil
+}
+
+func newDataRateFromDatR(modu string, datr DatR) (band.DataRate, error) {
+	var dr band.DataRate
+
+	switch band.Modulation(modu) {
+	case band.LoRaModulation:
+		dr.Modulation = band.LoRaModulation
+		if _, err := fmt.Sscanf(datr.LoRa, "SF%dBW%d", &dr.SpreadFactor, &dr.Bandwidth); err != nil {
+			return dr, errors.Wrap(err, "parse lora datr error")
+		}
+	case band.FSKModulation:
+		dr.Modulation = band.FSKModulation
+		dr.BitRate = int(datr.FSK)
+	default:
+		return dr, fmt.Errorf("unknown modulation: %s", modu)
+	}
+
+	return dr, nil
+}
@@ -0,0 +1,306 @@
+package semtechudp
+
+import (
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/band"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestBackend(t *testing.T) {
+	Convey("Given a new Semtech UDP backend", t, func() {
+		backend, err := NewBackend(Config{Bind: "127.0.0.1:0"})
+		So(err, ShouldBeNil)
+		defer backend.Close()
+
+		serverAddr := backend.(*Backend).conn.LocalAddr().(*net.UDPAddr)
+
+		Convey("Given a fake gateway UDP connection", func() {
+			gwConn, err := net.DialUDP("udp", nil, serverAddr)
+			So(err, ShouldBeNil)
+			defer gwConn.Close()
+			So(gwConn.SetDeadline(time.Now().Add(time.Second)), ShouldBeNil)
+
+			mac := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+			buf := make([]byte, 65507)
+
+			Convey("When sending a PULL_DATA packet", func() {
+				_, err := gwConn.Write([]byte{2, 123, 0, 2, 1, 2, 3, 4, 5, 6, 7, 8})
+				So(err, ShouldBeNil)
+
+				Convey("Then a PULL_ACK is returned", func() {
+					i, err := gwConn.Read(buf)
+					So(err, ShouldBeNil)
+					So(buf[:i], ShouldResemble, []byte{2, 123, 0, 4})
+
+					Convey("When sending a TXPacket", func() {
+						timestamp := uint32(2000000)
+						txPacket := gw.TXPacket{
+							Token: 1234,
+							TXInfo: gw.TXInfo{
+								MAC:       mac,
+								Timestamp: &timestamp,
+								Frequency: 868100000,
+								Power:     14,
+								DataRate:  band.DataRate{Modulation: band.LoRaModulation, SpreadFactor: 12, Bandwidth: 125},
+								CodeRate:  "4/5",
+							},
+							PHYPayload: lorawan.PHYPayload{
+								MHDR: lorawan.MHDR{
+									MType: lorawan.UnconfirmedDataDown,
+									Major: lorawan.LoRaWANR1,
+								},
+								MACPayload: &lorawan.MACPayload{},
+							},
+						}
+						So(backend.SendTXPacket(txPacket), ShouldBeNil)
+
+						Convey("Then the gateway receives the PULL_RESP packet", func() {
+							i, err := gwConn.Read(buf)
+							So(err, ShouldBeNil)
+							So(buf[:4], ShouldResemble, []byte{2, 210, 4, 3})
+
+							var pl PullRespPayload
+							So(json.Unmarshal(buf[4:i], &pl), ShouldBeNil)
+
+							phyB, err := txPacket.PHYPayload.MarshalBinary()
+							So(err, ShouldBeNil)
+
+							So(pl.TXPK, ShouldResemble, TXPK{
+								Tmst: &timestamp,
+								Freq: 868.1,
+								Powe: 14,
+								Modu: "LORA",
+								DatR: DatR{LoRa: "SF12BW125"},
+								CodR: "4/5",
+								IPol: true,
+								Size: uint16(len(phyB)),
+								Data: phyB,
+							})
+						})
+					})
+
+					Convey("When sending a beacon TXPacket", func() {
+						iPol := false
+						tsGPS := gw.Duration(128 * time.Second)
+						txPacket := gw.TXPacket{
+							Token: 1234,
+							TXInfo: gw.TXInfo{
+								MAC:               mac,
+								TimeSinceGPSEpoch: &tsGPS,
+								Frequency:         869525000,
+								Power:             14,
+								DataRate:          band.DataRate{Modulation: band.LoRaModulation, SpreadFactor: 9, Bandwidth: 125},
+								CodeRate:          "4/5",
+								IPol:              &iPol,
+								NoCRC:             true,
+								NoHeader:          true,
+							},
+							Beacon: []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17},
+						}
+						So(backend.SendTXPacket(txPacket), ShouldBeNil)
+
+						Convey("Then the gateway receives the beacon without header and CRC", func() {
+							i, err := gwConn.Read(buf)
+							So(err, ShouldBeNil)
+
+							var pl PullRespPayload
+							So(json.Unmarshal(buf[4:i], &pl), ShouldBeNil)
+
+							tmms := int64(128000)
+							So(pl.TXPK, ShouldResemble, TXPK{
+								Tmms: &tmms,
+								Freq: 869.525,
+								Powe: 14,
+								Modu: "LORA",
+								DatR: DatR{LoRa: "SF9BW125"},
+								CodR: "4/5",
+								Size: 17,
+								NCRC: true,
+								NHdr: true,
+								Data: txPacket.Beacon,
+							})
+						})
+					})
+
+					Convey("When sending a TX_ACK packet with error", func() {
+						_, err := gwConn.Write(append([]byte{2, 210, 4, 5, 1, 2, 3, 4, 5, 6, 7, 8}, []byte(`{"txpk_ack":{"error":"TOO_LATE"}}`)...))
+						So(err, ShouldBeNil)
+
+						Convey("Then the TXAck is received by the backend", func() {
+							So(<-backend.TXAckChan(), ShouldResemble, gw.TXAck{
+								MAC:   mac,
+								Token: 1234,
+								Error: gw.ErrTooLate,
+							})
+						})
+					})
+				})
+			})
+
+			Convey("When sending a TXPacket for an unknown gateway", func() {
+				err := backend.SendTXPacket(gw.TXPacket{TXInfo: gw.TXInfo{MAC: lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1}}})
+
+				Convey("Then ErrGatewayDoesNotExist is returned", func() {
+					So(err, ShouldEqual, ErrGatewayDoesNotExist)
+				})
+			})
+
+			Convey("When sending a PUSH_DATA packet with rxpk and stat", func() {
+				phy := lorawan.PHYPayload{
+					MHDR: lorawan.MHDR{
+						MType: lorawan.UnconfirmedDataUp,
+						Major: lorawan.LoRaWANR1,
+					},
+					MACPayload: &lorawan.MACPayload{},
+				}
+				phyB, err := phy.MarshalBinary()
+				So(err, ShouldBeNil)
+
+				lat := 1.123
+				long := 2.123
+				alt := int32(10)
+				pl := PushDataPayload{
+					RXPK: []RXPK{
+						{
+							Tmst: 1000000,
+							Freq: 868.3,
+							Chan: 1,
+							RFCh: 1,
+							Stat: 1,
+							Modu: "LORA",
+							DatR: DatR{LoRa: "SF7BW125"},
+							CodR: "4/5",
+							RSSI: -60,
+							LSNR: 7.5,
+							Size: uint16(len(phyB)),
+							Data: phyB,
+						},
+						{
+							Stat: -1,
+							Modu: "LORA",
+							DatR: DatR{LoRa: "SF7BW125"},
+							Data: []byte{1, 2, 3},
+						},
+					},
+					Stat: &Stat{
+						Time: ExpandedTime(time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC)),
+						Lati: &lat,
+						Long: &long,
+						Alti: &alt,
+						RXNb: 10,
+						RXOK: 9,
+						DWNb: 3,
+						TXNb: 2,
+					},
+				}
+				b, err := json.Marshal(pl)
+				So(err, ShouldBeNil)
+
+				_, err = gwConn.Write(append([]byte{2, 1, 0, 0, 1, 2, 3, 4, 5, 6, 7, 8}, b...))
+				So(err, ShouldBeNil)
+
+				Convey("Then a PUSH_ACK is returned", func() {
+					i, err := gwConn.Read(buf)
+					So(err, ShouldBeNil)
+					So(buf[:i], ShouldResemble, []byte{2, 1, 0, 1})
+
+					Convey("Then the stats packet is received by the backend", func() {
+						altF := float64(alt)
+						So(<-backend.StatsPacketChan(), ShouldResemble, gw.GatewayStatsPacket{
+							MAC:                 mac,
+							Time:                time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC),
+							Latitude:            &lat,
+							Longitude:           &long,
+							Altitude:            &altF,
+							RXPacketsReceived:   10,
+							RXPacketsReceivedOK: 9,
+							TXPacketsReceived:   3,
+							TXPacketsEmitted:    2,
+						})
+
+						Convey("Then only the rx packet with valid CRC is received by the backend", func() {
+							So(<-backend.RXPacketChan(), ShouldResemble, gw.RXPacket{
+								RXInfo: gw.RXInfo{
+									MAC:       mac,
+									Timestamp: 1000000,
+									Frequency: 868300000,
+									Channel:   1,
+									RFChain:   1,
+									CRCStatus: 1,
+									CodeRate:  "4/5",
+									RSSI:      -60,
+									LoRaSNR:   7.5,
+									Size:      len(phyB),
+									DataRate:  band.DataRate{Modulation: band.LoRaModulation, SpreadFactor: 7, Bandwidth: 125},
+								},
+								PHYPayload: phy,
+							})
+
+							var received bool
+							select {
+							case <-backend.RXPacketChan():
+								received = true
+							case <-time.After(time.Millisecond * 100):
+							}
+							So(received, ShouldBeFalse)
+						})
+					})
+				})
+			})
+		})
+	})
+}
+
+func TestNewRXPacketFromRXPK(t *testing.T) {
+	Convey("Given an RXPK with GPS time and a fine-timestamp", t, func() {
+		mac := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+		phy := lorawan.PHYPayload{
+			MHDR: lorawan.MHDR{
+				MType: lorawan.UnconfirmedDataUp,
+				Major: lorawan.LoRaWANR1,
+			},
+			MACPayload: &lorawan.MACPayload{},
+		}
+		phyB, err := phy.MarshalBinary()
+		So(err, ShouldBeNil)
+
+		tmms := int64(1234567)
+		fTime := int64(567123456)
+		rxpk := RXPK{
+			Tmms: &tmms,
+			Modu: "LORA",
+			DatR: DatR{LoRa: "SF7BW125"},
+			Data: phyB,
+			RSig: []RSig{
+				{Ant: 0},
+				{Ant: 1, FTime: &fTime},
+			},
+		}
+
+		Convey("Then the fine time since GPS epoch has nanosecond precision", func() {
+			rxPacket, err := newRXPacketFromRXPK(mac, rxpk)
+			So(err, ShouldBeNil)
+
+			gpsEpoch := gw.Duration(1234567 * time.Millisecond)
+			fineGPSEpoch := gw.Duration(1234*time.Second + 567123456*time.Nanosecond)
+			So(rxPacket.RXInfo.TimeSinceGPSEpoch, ShouldResemble, &gpsEpoch)
+			So(rxPacket.RXInfo.FineTimeSinceGPSEpoch, ShouldResemble, &fineGPSEpoch)
+		})
+
+		Convey("When the RXPK has no fine-timestamp", func() {
+			rxpk.RSig = nil
+
+			Convey("Then the fine time since GPS epoch is not set", func() {
+				rxPacket, err := newRXPacketFromRXPK(mac, rxpk)
+				So(err, ShouldBeNil)
+				So(rxPacket.RXInfo.FineTimeSinceGPSEpoch, ShouldBeNil)
+			})
+		})
+	})
+}
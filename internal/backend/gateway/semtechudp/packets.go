@@ -0,0 +1,372 @@
+package semtechudp
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+)
+
+// PacketType defines the packet type.
+type PacketType byte
+
+// Available packet types
+const (
+	PushData PacketType = iota
+	PushACK
+	PullData
+	PullResp
+	PullACK
+	TXACK
+)
+
+// Protocol versions
+const (
+	ProtocolVersion1 uint8 = 0x01
+	ProtocolVersion2 uint8 = 0x02
+)
+
+// Errors
+var (
+	ErrInvalidProtocolVersion = errors.New("invalid protocol version")
+	ErrInvalidPacketType      = errors.New("invalid packet type")
+	ErrInvalidPacketLength    = errors.New("invalid packet length")
+)
+
+func (p PacketType) String() string {
+	switch p {
+	case PushData:
+		return "PushData"
+	case PushACK:
+		return "PushACK"
+	case PullData:
+		return "PullData"
+	case PullResp:
+		return "PullResp"
+	case PullACK:
+		return "PullACK"
+	case TXACK:
+		return "TXACK"
+	default:
+		return fmt.Sprintf("PacketType(%d)", p)
+	}
+}
+
+// GetPacketType returns the packet type for the given packet data.
+func GetPacketType(data []byte) (PacketType, error) {
+	if len(data) < 4 {
+		return PacketType(0), ErrInvalidPacketLength
+	}
+
+	if data[0] != ProtocolVersion1 && data[0] != ProtocolVersion2 {
+		return PacketType(0), ErrInvalidProtocolVersion
+	}
+
+	if data[3] > byte(TXACK) {
+		return PacketType(0), ErrInvalidPacketType
+	}
+
+	return PacketType(data[3]), nil
+}
+
+// PushDataPacket type is used by the gateway mainly to forward the RF packets
+// received, and associated metadata, to the server.
+type PushDataPacket struct {
+	ProtocolVersion uint8
+	RandomToken     uint16
+	GatewayMAC      lorawan.EUI64
+	Payload         PushDataPayload
+}
+
+// UnmarshalBinary decodes the object from binary form.
+func (p *PushDataPacket) UnmarshalBinary(data []byte) error {
+	if len(data) < 13 {
+		return ErrInvalidPacketLength
+	}
+	if data[3] != byte(PushData) {
+		return ErrInvalidPacketType
+	}
+	if data[0] != ProtocolVersion1 && data[0] != ProtocolVersion2 {
+		return ErrInvalidProtocolVersion
+	}
+
+	p.ProtocolVersion = data[0]
+	p.RandomToken = binary.LittleEndian.Uint16(data[1:3])
+	copy(p.GatewayMAC[:], data[4:12])
+
+	return json.Unmarshal(data[12:], &p.Payload)
+}
+
+// PushACKPacket is used by the server to acknowledge immediately all the
+// PUSH_DATA packets received.
+type PushACKPacket struct {
+	ProtocolVersion uint8
+	RandomToken     uint16
+}
+
+// MarshalBinary encodes the object into binary form.
+func (p PushACKPacket) MarshalBinary() ([]byte, error) {
+	out := make([]byte, 4)
+	out[0] = p.ProtocolVersion
+	binary.LittleEndian.PutUint16(out[1:3], p.RandomToken)
+	out[3] = byte(PushACK)
+	return out, nil
+}
+
+// PullDataPacket is used by the gateway to poll data from the server.
+type PullDataPacket struct {
+	ProtocolVersion uint8
+	RandomToken     uint16
+	GatewayMAC      lorawan.EUI64
+}
+
+// UnmarshalBinary decodes the object from binary form.
+func (p *PullDataPacket) UnmarshalBinary(data []byte) error {
+	if len(data) != 12 {
+		return ErrInvalidPacketLength
+	}
+	if data[3] != byte(PullData) {
+		return ErrInvalidPacketType
+	}
+	if data[0] != ProtocolVersion1 && data[0] != ProtocolVersion2 {
+		return ErrInvalidProtocolVersion
+	}
+
+	p.ProtocolVersion = data[0]
+	p.RandomToken = binary.LittleEndian.Uint16(data[1:3])
+	copy(p.GatewayMAC[:], data[4:12])
+	return nil
+}
+
+// PullACKPacket is used by the server to confirm that the network route is
+// open and that the server can send PULL_RESP packets at any time.
+type PullACKPacket struct {
+	ProtocolVersion uint8
+	RandomToken     uint16
+}
+
+// MarshalBinary encodes the object into binary form.
+func (p PullACKPacket) MarshalBinary() ([]byte, error) {
+	out := make([]byte, 4)
+	out[0] = p.ProtocolVersion
+	binary.LittleEndian.PutUint16(out[1:3], p.RandomToken)
+	out[3] = byte(PullACK)
+	return out, nil
+}
+
+// PullRespPacket is used by the server to send RF packets and associated
+// metadata that will have to be emitted by the gateway.
+type PullRespPacket struct {
+	ProtocolVersion uint8
+	RandomToken     uint16
+	Payload         PullRespPayload
+}
+
+// MarshalBinary encodes the object into binary form.
+func (p PullRespPacket) MarshalBinary() ([]byte, error) {
+	pb, err := json.Marshal(&p.Payload)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]byte, 4, 4+len(pb))
+	out[0] = p.ProtocolVersion
+
+	// the token is only used by protocol version 2, for protocol version 1
+	// it is unused and must be 0
+	if p.ProtocolVersion != ProtocolVersion1 {
+		binary.LittleEndian.PutUint16(out[1:3], p.RandomToken)
+	}
+	out[3] = byte(PullResp)
+	return append(out, pb...), nil
+}
+
+// TXACKPacket is used by the gateway to send a feedback to the server
+// to inform if a downlink request has been accepted or rejected by the
+// gateway.
+type TXACKPacket struct {
+	ProtocolVersion uint8
+	RandomToken     uint16
+	GatewayMAC      lorawan.EUI64
+	Payload         *TXACKPayload
+}
+
+// UnmarshalBinary decodes the object from binary form.
+func (p *TXACKPacket) UnmarshalBinary(data []byte) error {
+	if len(data) < 12 {
+		return ErrInvalidPacketLength
+	}
+	if data[3] != byte(TXACK) {
+		return ErrInvalidPacketType
+	}
+	if data[0] != ProtocolVersion2 {
+		return ErrInvalidProtocolVersion
+	}
+
+	p.ProtocolVersion = data[0]
+	p.RandomToken = binary.LittleEndian.Uint16(data[1:3])
+	copy(p.GatewayMAC[:], data[4:12])
+
+	// the packet-forwarder might append a null-terminated empty JSON object
+	payload := strings.TrimRight(string(data[12:]), "\x00")
+	if len(payload) > 0 {
+		p.Payload = &TXACKPayload{}
+		return json.Unmarshal([]byte(payload), p.Payload)
+	}
+	return nil
+}
+
+// PushDataPayload represents the upstream JSON data structure.
+type PushDataPayload struct {
+	RXPK []RXPK `json:"rxpk,omitempty"`
+	Stat *Stat  `json:"stat,omitempty"`
+}
+
+// PullRespPayload represents the downstream JSON data structure.
+type PullRespPayload struct {
+	TXPK TXPK `json:"txpk"`
+}
+
+// TXACKPayload contains the TXACKPayload structure.
+type TXACKPayload struct {
+	TXPKACK TXPKACK `json:"txpk_ack"`
+}
+
+// TXPKACK contains the status information of the associated PULL_RESP
+// packet.
+type TXPKACK struct {
+	Error string `json:"error"`
+}
+
+// CompactTime implements time.Time but (un)marshals to and from
+// ISO 8601 'compact' format.
+type CompactTime time.Time
+
+// MarshalJSON implements the json.Marshaler interface.
+func (t CompactTime) MarshalJSON() ([]byte, error) {
+	return []byte(time.Time(t).UTC().Format(`"` + time.RFC3339Nano + `"`)), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (t *CompactTime) UnmarshalJSON(data []byte) error {
+	t2, err := time.Parse(`"`+time.RFC3339Nano+`"`, string(data))
+	if err != nil {
+		return err
+	}
+	*t = CompactTime(t2)
+	return nil
+}
+
+// ExpandedTime implements time.Time but (un)marshals to and from
+// ISO 8601 'expanded' format.
+type ExpandedTime time.Time
+
+// MarshalJSON implements the json.Marshaler interface.
+func (t ExpandedTime) MarshalJSON() ([]byte, error) {
+	return []byte(time.Time(t).UTC().Format(`"2006-01-02 15:04:05 MST"`)), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (t *ExpandedTime) UnmarshalJSON(data []byte) error {
+	t2, err := time.Parse(`"2006-01-02 15:04:05 MST"`, string(data))
+	if err != nil {
+		return err
+	}
+	*t = ExpandedTime(t2)
+	return nil
+}
+
+// DatR implements the data rate which can be either a string (LoRa identifier)
+// or an unsigned integer in case of FSK (bits per second).
+type DatR struct {
+	LoRa string
+	FSK  uint32
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (d DatR) MarshalJSON() ([]byte, error) {
+	if d.LoRa != "" {
+		return []byte(`"` + d.LoRa + `"`), nil
+	}
+	return []byte(strconv.FormatUint(uint64(d.FSK), 10)), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (d *DatR) UnmarshalJSON(data []byte) error {
+	i, err := strconv.ParseUint(string(data), 10, 32)
+	if err != nil {
+		d.LoRa = strings.Trim(string(data), `"`)
+		return nil
+	}
+	d.FSK = uint32(i)
+	return nil
+}
+
+// RXPK contain a RF packet and associated metadata.
+type RXPK struct {
+	Time *CompactTime `json:"time"` // UTC time of pkt RX, us precision, ISO 8601 'compact' format (e.g. 2013-03-31T16:21:17.528002Z)
+	Tmms *int64       `json:"tmms"` // GPS time of pkt RX, number of milliseconds since 06.Jan.1980
+	Tmst uint32       `json:"tmst"` // Internal timestamp of "RX finished" event (32b unsigned)
+	Freq float64      `json:"freq"` // RX central frequency in MHz (unsigned float, Hz precision)
+	Chan uint8        `json:"chan"` // Concentrator "IF" channel used for RX (unsigned integer)
+	RFCh uint8        `json:"rfch"` // Concentrator "RF chain" used for RX (unsigned integer)
+	Stat int8         `json:"stat"` // CRC status: 1 = OK, -1 = fail, 0 = no CRC
+	Modu string       `json:"modu"` // Modulation identifier "LORA" or "FSK"
+	DatR DatR         `json:"datr"` // LoRa datarate identifier (eg. SF12BW500) || FSK datarate (unsigned, in bits per second)
+	CodR string       `json:"codr"` // LoRa ECC coding rate identifier
+	RSSI int16        `json:"rssi"` // RSSI in dBm (signed integer, 1 dB precision)
+	LSNR float64      `json:"lsnr"` // Lora SNR ratio in dB (signed float, 0.1 dB precision)
+	Size uint16       `json:"size"` // RF packet payload size in bytes (unsigned integer)
+	Data []byte       `json:"data"` // Base64 encoded RF packet payload, padded
+	Brd  uint8        `json:"brd"`  // Concentrator board used for RX (unsigned integer)
+	Ant  uint8        `json:"ant"`  // Concentrator antenna used for RX (unsigned integer)
+	RSig []RSig       `json:"rsig"` // Received signal information, per antenna (only sent by gateways supporting fine-timestamping)
+}
+
+// RSig contains the received signal information of a single antenna.
+type RSig struct {
+	Ant   uint8  `json:"ant"`   // Antenna number on which signal has been received
+	Chan  uint8  `json:"chan"`  // Concentrator "IF" channel used for RX (unsigned integer)
+	FTime *int64 `json:"ftime"` // Fine timestamp, number of nanoseconds since last PPS [0..999999999]
+}
+
+// Stat contains the status of the gateway.
+type Stat struct {
+	Time ExpandedTime `json:"time"` // UTC 'system' time of the gateway, ISO 8601 'expanded' format (e.g 2014-01-12 08:59:28 GMT)
+	Lati *float64     `json:"lati"` // GPS latitude of the gateway in degree (float, N is +)
+	Long *float64     `json:"long"` // GPS latitude of the gateway in degree (float, E is +)
+	Alti *int32       `json:"alti"` // GPS altitude of the gateway in meter RX (integer)
+	RXNb uint32       `json:"rxnb"` // Number of radio packets received (unsigned integer)
+	RXOK uint32       `json:"rxok"` // Number of radio packets received with a valid PHY CRC
+	RXFW uint32       `json:"rxfw"` // Number of radio packets forwarded (unsigned integer)
+	ACKR float64      `json:"ackr"` // Percentage of upstream datagrams that were acknowledged
+	DWNb uint32       `json:"dwnb"` // Number of downlink datagrams received (unsigned integer)
+	TXNb uint32       `json:"txnb"` // Number of packets emitted (unsigned integer)
+}
+
+// TXPK contains a RF packet to be emitted and associated metadata.
+type TXPK struct {
+	Imme bool    `json:"imme"`           // Send packet immediately (will ignore tmst & time)
+	Tmst *uint32 `json:"tmst,omitempty"` // Send packet on a certain timestamp value (will ignore time)
+	Tmms *int64  `json:"tmms,omitempty"` // Send packet at a certain GPS time (GPS synchronization required)
+	Freq float64 `json:"freq"`           // TX central frequency in MHz (unsigned float, Hz precision)
+	RFCh uint8   `json:"rfch"`           // Concentrator "RF chain" used for TX (unsigned integer)
+	Powe uint8   `json:"powe"`           // TX output power in dBm (unsigned integer, dBm precision)
+	Modu string  `json:"modu"`           // Modulation identifier "LORA" or "FSK"
+	DatR DatR    `json:"datr"`           // LoRa datarate identifier (eg. SF12BW500) || FSK datarate (unsigned, in bits per second)
+	CodR string  `json:"codr,omitempty"` // LoRa ECC coding rate identifier
+	FDev uint16  `json:"fdev,omitempty"` // FSK frequency deviation (unsigned integer, in Hz)
+	IPol bool    `json:"ipol"`           // Lora modulation polarization inversion
+	Prea uint16  `json:"prea,omitempty"` // RF preamble size (unsigned integer)
+	Size uint16  `json:"size"`           // RF packet payload size in bytes (unsigned integer)
+	NCRC bool    `json:"ncrc,omitempty"` // If true, disable the CRC of the physical layer (optional)
+	NHdr bool    `json:"nhdr,omitempty"` // If true, disable the header of the physical layer (optional)
+	Data []byte  `json:"data"`           // Base64 encoded RF packet payload, padding optional
+	Brd  uint8   `json:"brd"`            // Concentrator board used for TX (unsigned integer)
+	Ant  uint8   `json:"ant"`            // Concentrator antenna used for TX (unsigned integer)
+}
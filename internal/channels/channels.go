@@ -1,21 +1,40 @@
 package channels
 
 import (
+	"github.com/pkg/errors"
+
 	"github.com/brocaar/loraserver/internal/config"
+	"github.com/brocaar/loraserver/internal/maccommand"
 	"github.com/brocaar/loraserver/internal/storage"
 	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/band"
 )
 
 // HandleChannelReconfigure handles the reconfiguration of active channels
 // on the node. This is needed in case only a sub-set of channels is used
 // (e.g. for the US band) or when a reconfiguration of active channels
-// happens.
-func HandleChannelReconfigure(ds storage.DeviceSession) ([]storage.MACCommandBlock, error) {
+// happens. It also handles the reconfiguration of the downlink channel
+// frequencies, as configured by the service-profile.
+func HandleChannelReconfigure(sp storage.ServiceProfile, ds storage.DeviceSession) ([]storage.MACCommandBlock, error) {
+	var out []storage.MACCommandBlock
+
 	payloads := config.C.NetworkServer.Band.Band.GetLinkADRReqPayloadsForEnabledUplinkChannelIndices(ds.EnabledUplinkChannels)
-	if len(payloads) == 0 {
-		return nil, nil
+	if len(payloads) != 0 {
+		out = append(out, getLinkADRReqBlock(ds, payloads))
+	}
+
+	block, err := getDLChannelReqBlock(sp, ds)
+	if err != nil {
+		return nil, err
+	}
+	if block != nil {
+		out = append(out, *block)
 	}
 
+	return out, nil
+}
+
+func getLinkADRReqBlock(ds storage.DeviceSession, payloads []lorawan.LinkADRReqPayload) storage.MACCommandBlock {
 	payloads[len(payloads)-1].TXPower = uint8(ds.TXPowerIndex)
 	payloads[len(payloads)-1].DataRate = uint8(ds.DR)
 	payloads[len(payloads)-1].Redundancy.NbRep = ds.NbTrans
@@ -30,5 +49,142 @@ func HandleChannelReconfigure(ds storage.DeviceSession) ([]storage.MACCommandBlo
 		})
 	}
 
-	return []storage.MACCommandBlock{block}, nil
+	return block
+}
+
+// getDLChannelReqBlock returns the DLChannelReq mac-commands needed to set
+// the downlink channel frequencies of the service-profile for the enabled
+// uplink channels of the device. Channels without frequency in the
+// service-profile are reset to the band default in case these were
+// modified before. This is not supported by fixed channel-plan bands and
+// LoRaWAN 1.0.0 / 1.0.1 devices.
+func getDLChannelReqBlock(sp storage.ServiceProfile, ds storage.DeviceSession) (*storage.MACCommandBlock, error) {
+	switch config.C.NetworkServer.Band.Name {
+	case band.US_902_928, band.AU_915_928, band.CN_470_510:
+		return nil, nil
+	}
+	if ds.MACVersion == "1.0.0" || ds.MACVersion == "1.0.1" {
+		return nil, nil
+	}
+
+	wanted := make(map[int]int)
+	for _, i := range ds.EnabledUplinkChannels {
+		if i < len(sp.DLChannelFrequencies) && sp.DLChannelFrequencies[i] != 0 {
+			wanted[i] = int(sp.DLChannelFrequencies[i])
+			continue
+		}
+
+		if _, ok := ds.DLChannelFrequencies[i]; !ok {
+			continue
+		}
+
+		c, err := config.C.NetworkServer.Band.Band.GetUplinkChannel(i)
+		if err != nil {
+			return nil, errors.Wrap(err, "get uplink channel error")
+		}
+		wanted[i], err = config.C.NetworkServer.Band.Band.GetRX1FrequencyForUplinkFrequency(c.Frequency)
+		if err != nil {
+			return nil, errors.Wrap(err, "get rx1 frequency for uplink frequency error")
+		}
+	}
+
+	return maccommand.RequestDLChannels(3, ds.DLChannelFrequencies, wanted), nil
+}
+
+// GetLinkADRReqPayloadsForEnabledUplinkChannels returns the LinkADRReq
+// payloads that set exactly the given channels as enabled on the device.
+// The payloads must be sent as a single block, as the device processes
+// them in order as one atomic command. Note that the data-rate, tx-power
+// and NbRep of the last payload must be set by the caller.
+func GetLinkADRReqPayloadsForEnabledUplinkChannels(enabledChannels []int) []lorawan.LinkADRReqPayload {
+	enabled := make(map[int]bool)
+	for _, c := range enabledChannels {
+		enabled[c] = true
+	}
+
+	switch config.C.NetworkServer.Band.Name {
+	case band.US_902_928, band.AU_915_928:
+		return getUS915LinkADRReqPayloads(enabled)
+	}
+
+	// one payload for each block of 16 channels
+	var out []lorawan.LinkADRReqPayload
+	for i := 0; i < getUplinkChannelCount(); i += 16 {
+		pl := lorawan.LinkADRReqPayload{
+			Redundancy: lorawan.Redundancy{
+				ChMaskCntl: uint8(i / 16),
+			},
+		}
+		for j := range pl.ChMask {
+			pl.ChMask[j] = enabled[i+j]
+		}
+		out = append(out, pl)
+	}
+
+	return out
+}
+
+// getUS915LinkADRReqPayloads returns the LinkADRReq payloads for the
+// US915 and AU915 bands (64 x 125 kHz and 8 x 500 kHz channels). It uses
+// ChMaskCntl 6 (all 125 kHz channels on) when possible, else ChMaskCntl 7
+// (all 125 kHz channels off) followed by a payload for each block of 16
+// channels that contains enabled 125 kHz channels.
+func getUS915LinkADRReqPayloads(enabled map[int]bool) []lorawan.LinkADRReqPayload {
+	all125kHz := true
+	for i := 0; i < 64; i++ {
+		if !enabled[i] {
+			all125kHz = false
+		}
+	}
+
+	// ChMaskCntl 6 and 7 set the 500 kHz channels (64 - 71)
+	first := lorawan.LinkADRReqPayload{
+		Redundancy: lorawan.Redundancy{
+			ChMaskCntl: 7,
+		},
+	}
+	if all125kHz {
+		first.Redundancy.ChMaskCntl = 6
+	}
+	for i := 0; i < 8; i++ {
+		first.ChMask[i] = enabled[64+i]
+	}
+
+	out := []lorawan.LinkADRReqPayload{first}
+	if all125kHz {
+		return out
+	}
+
+	for i := 0; i < 64; i += 16 {
+		pl := lorawan.LinkADRReqPayload{
+			Redundancy: lorawan.Redundancy{
+				ChMaskCntl: uint8(i / 16),
+			},
+		}
+
+		var hasEnabled bool
+		for j := range pl.ChMask {
+			if enabled[i+j] {
+				pl.ChMask[j] = true
+				hasEnabled = true
+			}
+		}
+
+		if hasEnabled {
+			out = append(out, pl)
+		}
+	}
+
+	return out
+}
+
+func getUplinkChannelCount() int {
+	var count int
+	for {
+		if _, err := config.C.NetworkServer.Band.Band.GetUplinkChannel(count); err != nil {
+			break
+		}
+		count++
+	}
+	return count
 }
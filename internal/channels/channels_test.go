@@ -4,9 +4,11 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/brocaar/loraserver/internal/config"
 	"github.com/brocaar/loraserver/internal/storage"
 	"github.com/brocaar/loraserver/internal/test"
 	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/band"
 	. "github.com/smartystreets/goconvey/convey"
 )
 
@@ -15,9 +17,10 @@ func TestHandleChannelReconfigure(t *testing.T) {
 
 	Convey("Given a set of tests", t, func() {
 		tests := []struct {
-			Name          string
-			DeviceSession storage.DeviceSession
-			Expected      []storage.MACCommandBlock
+			Name           string
+			ServiceProfile storage.ServiceProfile
+			DeviceSession  storage.DeviceSession
+			Expected       []storage.MACCommandBlock
 		}{
 			{
 				Name: "no channels to reconfigure",
@@ -54,14 +57,169 @@ func TestHandleChannelReconfigure(t *testing.T) {
 					},
 				},
 			},
+			{
+				Name: "downlink channel frequency to configure",
+				ServiceProfile: storage.ServiceProfile{
+					DLChannelFrequencies: []int64{0, 869525000},
+				},
+				DeviceSession: storage.DeviceSession{
+					MACVersion:            "1.0.2",
+					EnabledUplinkChannels: []int{0, 1, 2},
+				},
+				Expected: []storage.MACCommandBlock{
+					{
+						CID: lorawan.DLChannelReq,
+						MACCommands: storage.MACCommands{
+							{
+								CID: lorawan.DLChannelReq,
+								Payload: &lorawan.DLChannelReqPayload{
+									ChIndex: 1,
+									Freq:    869525000,
+								},
+							},
+						},
+					},
+				},
+			},
+			{
+				Name: "downlink channel frequency to reset",
+				DeviceSession: storage.DeviceSession{
+					MACVersion:            "1.0.2",
+					EnabledUplinkChannels: []int{0, 1, 2},
+					DLChannelFrequencies:  map[int]int{1: 869525000},
+				},
+				Expected: []storage.MACCommandBlock{
+					{
+						CID: lorawan.DLChannelReq,
+						MACCommands: storage.MACCommands{
+							{
+								CID: lorawan.DLChannelReq,
+								Payload: &lorawan.DLChannelReqPayload{
+									ChIndex: 1,
+									Freq:    868300000,
+								},
+							},
+						},
+					},
+				},
+			},
+			{
+				Name: "downlink channel frequency acknowledged",
+				ServiceProfile: storage.ServiceProfile{
+					DLChannelFrequencies: []int64{0, 869525000},
+				},
+				DeviceSession: storage.DeviceSession{
+					MACVersion:            "1.0.2",
+					EnabledUplinkChannels: []int{0, 1, 2},
+					DLChannelFrequencies:  map[int]int{1: 869525000},
+				},
+			},
 		}
 
 		for i, test := range tests {
 			Convey(fmt.Sprintf("test: %s [%d]", test.Name, i), func() {
-				blocks, err := HandleChannelReconfigure(test.DeviceSession)
+				blocks, err := HandleChannelReconfigure(test.ServiceProfile, test.DeviceSession)
 				So(err, ShouldBeNil)
 				So(blocks, ShouldResemble, test.Expected)
 			})
 		}
 	})
 }
+
+func TestGetLinkADRReqPayloadsForEnabledUplinkChannels(t *testing.T) {
+	_ = test.GetConfig()
+
+	Convey("Given the EU band", t, func() {
+		config.C.NetworkServer.Band.Name = band.EU_863_870
+
+		Convey("Then a single payload is returned for the first block of channels", func() {
+			So(GetLinkADRReqPayloadsForEnabledUplinkChannels([]int{0, 2}), ShouldResemble, []lorawan.LinkADRReqPayload{
+				{ChMask: lorawan.ChMask{true, false, true}},
+			})
+		})
+	})
+
+	Convey("Given the US band", t, func() {
+		config.C.NetworkServer.Band.Name = band.US_902_928
+		config.C.NetworkServer.Band.Band, _ = band.GetConfig(band.US_902_928, false, lorawan.DwellTimeNoLimit)
+
+		Reset(func() {
+			_ = test.GetConfig()
+			config.C.NetworkServer.Band.Name = ""
+		})
+
+		channelRange := func(start, end int) []int {
+			var out []int
+			for i := start; i <= end; i++ {
+				out = append(out, i)
+			}
+			return out
+		}
+
+		tests := []struct {
+			Name            string
+			EnabledChannels []int
+			Expected        []lorawan.LinkADRReqPayload
+		}{
+			{
+				Name:            "all channels",
+				EnabledChannels: channelRange(0, 71),
+				Expected: []lorawan.LinkADRReqPayload{
+					{
+						ChMask:     lorawan.ChMask{true, true, true, true, true, true, true, true},
+						Redundancy: lorawan.Redundancy{ChMaskCntl: 6},
+					},
+				},
+			},
+			{
+				Name:            "only 500 kHz channels",
+				EnabledChannels: channelRange(64, 71),
+				Expected: []lorawan.LinkADRReqPayload{
+					{
+						ChMask:     lorawan.ChMask{true, true, true, true, true, true, true, true},
+						Redundancy: lorawan.Redundancy{ChMaskCntl: 7},
+					},
+				},
+			},
+			{
+				Name:            "hybrid mode, second sub-band",
+				EnabledChannels: append(channelRange(8, 15), 65),
+				Expected: []lorawan.LinkADRReqPayload{
+					{
+						ChMask:     lorawan.ChMask{false, true},
+						Redundancy: lorawan.Redundancy{ChMaskCntl: 7},
+					},
+					{
+						ChMask: lorawan.ChMask{false, false, false, false, false, false, false, false, true, true, true, true, true, true, true, true},
+					},
+				},
+			},
+			{
+				Name:            "channels in multiple blocks",
+				EnabledChannels: []int{0, 17, 63},
+				Expected: []lorawan.LinkADRReqPayload{
+					{
+						Redundancy: lorawan.Redundancy{ChMaskCntl: 7},
+					},
+					{
+						ChMask: lorawan.ChMask{true},
+					},
+					{
+						ChMask:     lorawan.ChMask{false, true},
+						Redundancy: lorawan.Redundancy{ChMaskCntl: 1},
+					},
+					{
+						ChMask:     lorawan.ChMask{false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, true},
+						Redundancy: lorawan.Redundancy{ChMaskCntl: 3},
+					},
+				},
+			},
+		}
+
+		for i, test := range tests {
+			Convey(fmt.Sprintf("test: %s [%d]", test.Name, i), func() {
+				So(GetLinkADRReqPayloadsForEnabledUplinkChannels(test.EnabledChannels), ShouldResemble, test.Expected)
+			})
+		}
+	})
+}
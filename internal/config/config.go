@@ -10,6 +10,7 @@ import (
 	"github.com/brocaar/loraserver/internal/api/client/jsclient"
 	"github.com/brocaar/loraserver/internal/backend"
 	"github.com/brocaar/loraserver/internal/backend/gateway"
+	"github.com/brocaar/loraserver/internal/backend/gateway/semtechudp"
 	"github.com/brocaar/loraserver/internal/common"
 	"github.com/brocaar/lorawan"
 	"github.com/brocaar/lorawan/band"
@@ -80,6 +81,11 @@ type Config struct {
 			} `mapstructure:"rejoin_request"`
 		} `mapstructure:"network_settings"`
 
+		Scheduler struct {
+			Partitions    int           `mapstructure:"partitions"`
+			LeaseDuration time.Duration `mapstructure:"lease_duration"`
+		} `mapstructure:"scheduler"`
+
 		API struct {
 			Bind    string
 			CACert  string `mapstructure:"ca_cert"`
@@ -95,9 +101,27 @@ type Config struct {
 				AggregationIntervals []string `mapstructure:"aggregation_intervals"`
 			}
 
+			DownlinkGatewaySelection struct {
+				TieBreaker       string        `mapstructure:"tie_breaker"`
+				LinkBudgetMargin float64       `mapstructure:"link_budget_margin"`
+				HealthTimeout    time.Duration `mapstructure:"health_timeout"`
+			} `mapstructure:"downlink_gateway_selection"`
+
+			DutyCycle struct {
+				Enabled  bool          `mapstructure:"enabled"`
+				Window   time.Duration `mapstructure:"window"`
+				SubBands []struct {
+					MinFrequency int     `mapstructure:"min_frequency"`
+					MaxFrequency int     `mapstructure:"max_frequency"`
+					MaxDutyCycle float64 `mapstructure:"max_duty_cycle"`
+				} `mapstructure:"sub_bands"`
+			} `mapstructure:"duty_cycle"`
+
 			Backend struct {
-				Backend backend.Gateway
-				MQTT    gateway.MQTTBackendConfig
+				Backend    backend.Gateway
+				Type       string
+				MQTT       gateway.MQTTBackendConfig
+				SemtechUDP semtechudp.Config `mapstructure:"semtech_udp"`
 			}
 		}
 	} `mapstructure:"network_server"`
@@ -157,6 +181,27 @@ var ClassCScheduleInterval = time.Second
 // ClassCScheduleBatchSize contains the batch size of the Class-C scheduler
 var ClassCScheduleBatchSize = 100
 
+// ScheduleEventWorkers contains the number of workers handling the
+// device-queue schedule events.
+var ScheduleEventWorkers = 10
+
+// ScheduleEventQueueSize contains the max. number of devices waiting to be
+// scheduled by the schedule event workers.
+var ScheduleEventQueueSize = 1000
+
 // ClassCDownlinkLockDuration contains the duration to lock the downlink
 // Class-C transmissions after a preceeding downlink tx.
 var ClassCDownlinkLockDuration = time.Second * 2
+
+// GetBandForDwellTime returns the band configuration for the given
+// dwell-time. When the 400ms dwell-time is not enforced by the configured
+// band, a band configuration enforcing the dwell-time is returned for
+// lorawan.DwellTime400ms. This configuration does not contain the extra
+// channels and must only be used for data-rate and max payload-size lookups.
+func GetBandForDwellTime(dwellTime lorawan.DwellTime) (band.Band, error) {
+	if dwellTime == lorawan.DwellTimeNoLimit || C.NetworkServer.Band.DwellTime400ms {
+		return C.NetworkServer.Band.Band, nil
+	}
+
+	return band.GetConfig(C.NetworkServer.Band.Name, C.NetworkServer.Band.RepeaterCompatible, lorawan.DwellTime400ms)
+}
@@ -0,0 +1,240 @@
+package ack
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/loraserver/api/as"
+	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/loraserver/internal/config"
+	"github.com/brocaar/loraserver/internal/downlink/gateway"
+	"github.com/brocaar/loraserver/internal/framelog"
+	"github.com/brocaar/loraserver/internal/storage"
+	"github.com/brocaar/lorawan"
+)
+
+// ErrAbort is used to abort the flow without error
+var ErrAbort = errors.New("nothing to do")
+
+var handleDownlinkTXAckTasks = []func(*ackContext) error{
+	getDownlinkFrames,
+	checkTXAckError,
+	sendDownlinkFrameFallback,
+	deleteDownlinkFrames,
+	sendErrorToApplicationServer,
+}
+
+type ackContext struct {
+	// TXAck holds the acknowledgement as received from the gateway.
+	TXAck gw.TXAck
+
+	// DownlinkFrames holds the frame (and fallback options) to which the
+	// acknowledgement belongs.
+	DownlinkFrames storage.DownlinkFrames
+}
+
+// HandleDownlinkTXAck handles the given downlink tx acknowledgement.
+// On error, the next fallback option (an other gateway or RX2) is used.
+// When no fallback options are left, the application-server is informed.
+func HandleDownlinkTXAck(txAck gw.TXAck) error {
+	ctx := ackContext{
+		TXAck: txAck,
+	}
+
+	for _, t := range handleDownlinkTXAckTasks {
+		if err := t(&ctx); err != nil {
+			if err == ErrAbort {
+				return nil
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func getDownlinkFrames(ctx *ackContext) error {
+	var err error
+	ctx.DownlinkFrames, err = storage.GetDownlinkFrames(config.C.Redis.Pool, ctx.TXAck.MAC, ctx.TXAck.Token)
+	if err != nil {
+		if errors.Cause(err) == storage.ErrDoesNotExist {
+			log.WithFields(log.Fields{
+				"mac":   ctx.TXAck.MAC,
+				"token": ctx.TXAck.Token,
+			}).Debug("no downlink frames for tx ack token")
+			return ErrAbort
+		}
+		return errors.Wrap(err, "get downlink frames error")
+	}
+
+	if len(ctx.DownlinkFrames.TXPackets) == 0 {
+		return errors.New("downlink frames do not contain any tx packet")
+	}
+
+	return nil
+}
+
+func checkTXAckError(ctx *ackContext) error {
+	if ctx.TXAck.Error == "" {
+		if err := storage.DeleteDownlinkFrames(config.C.Redis.Pool, ctx.TXAck.MAC, ctx.TXAck.Token); err != nil && errors.Cause(err) != storage.ErrDoesNotExist {
+			return errors.Wrap(err, "delete downlink frames error")
+		}
+		return ErrAbort
+	}
+
+	log.WithFields(log.Fields{
+		"dev_eui": ctx.DownlinkFrames.DevEUI,
+		"mac":     ctx.TXAck.MAC,
+		"token":   ctx.TXAck.Token,
+		"error":   ctx.TXAck.Error,
+	}).Warning("gateway reported downlink transmission error")
+
+	return nil
+}
+
+func sendDownlinkFrameFallback(ctx *ackContext) error {
+	failed := ctx.DownlinkFrames.TXPackets[0]
+	var fallbacks []gw.TXPacketBytes
+
+	for _, txPacket := range ctx.DownlinkFrames.TXPackets[1:] {
+		// the other gateways share the same receive-window timing, in case
+		// it was too late for one gateway, it will be too late for the others
+		if ctx.TXAck.Error == gw.ErrTooLate && txPacket.TXInfo.Frequency == failed.TXInfo.Frequency && txPacket.TXInfo.DataRate == failed.TXInfo.DataRate {
+			continue
+		}
+		fallbacks = append(fallbacks, txPacket)
+	}
+
+	if len(fallbacks) == 0 {
+		return nil
+	}
+
+	ctx.DownlinkFrames.TXPackets = fallbacks
+	txPacket := fallbacks[0]
+
+	var phy lorawan.PHYPayload
+	if err := phy.UnmarshalBinary(txPacket.PHYPayload); err != nil {
+		return errors.Wrap(err, "unmarshal phypayload error")
+	}
+
+	// save before sending, as the ack might be received before the save
+	// would have completed
+	if err := storage.SaveDownlinkFrames(config.C.Redis.Pool, ctx.DownlinkFrames); err != nil {
+		return errors.Wrap(err, "save downlink frames error")
+	}
+
+	// the frames are stored by gateway MAC, remove the frames of the failed
+	// gateway in case the fallback uses an other gateway
+	if txPacket.TXInfo.MAC != failed.TXInfo.MAC {
+		if err := storage.DeleteDownlinkFrames(config.C.Redis.Pool, failed.TXInfo.MAC, ctx.TXAck.Token); err != nil && errors.Cause(err) != storage.ErrDoesNotExist {
+			return errors.Wrap(err, "delete downlink frames error")
+		}
+	}
+
+	if err := config.C.NetworkServer.Gateway.Backend.Backend.SendTXPacket(gw.TXPacket{
+		Token:      txPacket.Token,
+		TXInfo:     txPacket.TXInfo,
+		PHYPayload: phy,
+	}); err != nil {
+		return errors.Wrap(err, "send tx packet to gateway error")
+	}
+
+	if err := storage.IncrGatewayTXLoad(config.C.Redis.Pool, txPacket.TXInfo.MAC); err != nil {
+		log.WithError(err).WithField("mac", txPacket.TXInfo.MAC).Error("increment gateway tx load error")
+	}
+
+	if err := gateway.RecordAirtime(txPacket.TXInfo, len(txPacket.PHYPayload)); err != nil {
+		log.WithError(err).WithField("mac", txPacket.TXInfo.MAC).Error("record gateway airtime error")
+	}
+
+	log.WithFields(log.Fields{
+		"dev_eui":   ctx.DownlinkFrames.DevEUI,
+		"mac":       txPacket.TXInfo.MAC,
+		"frequency": txPacket.TXInfo.Frequency,
+		"token":     txPacket.Token,
+	}).Info("downlink frame re-sent using fallback tx-info")
+
+	downlinkFrame, err := framelog.CreateDownlinkFrame(txPacket.Token, phy, txPacket.TXInfo)
+	if err != nil {
+		return errors.Wrap(err, "create downlink frame error")
+	}
+
+	if err := framelog.LogDownlinkFrameForGateway(downlinkFrame); err != nil {
+		log.WithError(err).Error("log downlink frame for gateway error")
+	}
+
+	return ErrAbort
+}
+
+func deleteDownlinkFrames(ctx *ackContext) error {
+	if err := storage.DeleteDownlinkFrames(config.C.Redis.Pool, ctx.TXAck.MAC, ctx.TXAck.Token); err != nil && errors.Cause(err) != storage.ErrDoesNotExist {
+		return errors.Wrap(err, "delete downlink frames error")
+	}
+	return nil
+}
+
+func sendErrorToApplicationServer(ctx *ackContext) error {
+	// only frames containing an application payload are reported
+	if ctx.DownlinkFrames.FPort == 0 {
+		log.WithFields(log.Fields{
+			"dev_eui": ctx.DownlinkFrames.DevEUI,
+			"token":   ctx.TXAck.Token,
+		}).Warning("downlink frame discarded, no fallback options left")
+		return nil
+	}
+
+	rp, err := storage.GetRoutingProfile(config.C.PostgreSQL.DB, ctx.DownlinkFrames.RoutingProfileID)
+	if err != nil {
+		return errors.Wrap(err, "get routing-profile error")
+	}
+	asClient, err := config.C.ApplicationServer.Pool.Get(rp.ASID, []byte(rp.CACert), []byte(rp.TLSCert), []byte(rp.TLSKey))
+	if err != nil {
+		return errors.Wrap(err, "get application-server client error")
+	}
+
+	_, err = asClient.HandleError(context.Background(), &as.HandleErrorRequest{
+		DevEui: ctx.DownlinkFrames.DevEUI[:],
+		Type:   as.ErrorType_DATA_DOWN_GATEWAY,
+		FCnt:   ctx.DownlinkFrames.FCnt,
+		Error:  ctx.TXAck.Error,
+	})
+	if err != nil {
+		return errors.Wrap(err, "application-server client error")
+	}
+
+	// the confirmed device-queue item will never be acknowledged by the
+	// device, remove it and report the nACK so that the application-server
+	// does not wait for the timeout
+	if !ctx.DownlinkFrames.Confirmed {
+		return nil
+	}
+
+	qi, err := storage.GetPendingDeviceQueueItemForDevEUI(config.C.PostgreSQL.DB, ctx.DownlinkFrames.DevEUI)
+	if err != nil {
+		if errors.Cause(err) == storage.ErrDoesNotExist {
+			return nil
+		}
+		return errors.Wrap(err, "get pending device-queue item error")
+	}
+
+	if qi.FCnt != ctx.DownlinkFrames.FCnt {
+		return nil
+	}
+
+	if err := storage.DeleteDeviceQueueItem(config.C.PostgreSQL.DB, qi.ID); err != nil {
+		return errors.Wrap(err, "delete device-queue item error")
+	}
+
+	_, err = asClient.HandleDownlinkACK(context.Background(), &as.HandleDownlinkACKRequest{
+		DevEui:       ctx.DownlinkFrames.DevEUI[:],
+		FCnt:         qi.FCnt,
+		Acknowledged: false,
+	})
+	if err != nil {
+		return errors.Wrap(err, "application-server client error")
+	}
+
+	return nil
+}
@@ -0,0 +1,151 @@
+package ack
+
+import (
+	"testing"
+
+	"github.com/brocaar/loraserver/api/as"
+	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/loraserver/internal/common"
+	"github.com/brocaar/loraserver/internal/config"
+	"github.com/brocaar/loraserver/internal/storage"
+	"github.com/brocaar/loraserver/internal/test"
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestHandleDownlinkTXAck(t *testing.T) {
+	conf := test.GetConfig()
+	db, err := common.OpenDatabase(conf.PostgresDSN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.C.PostgreSQL.DB = db
+	config.C.Redis.Pool = common.NewRedisPool(conf.RedisURL)
+
+	Convey("Given a clean database", t, func() {
+		test.MustResetDB(config.C.PostgreSQL.DB)
+		test.MustFlushRedis(config.C.Redis.Pool)
+
+		asClient := test.NewApplicationClient()
+		config.C.ApplicationServer.Pool = test.NewApplicationServerPool(asClient)
+		gwBackend := test.NewGatewayBackend()
+		config.C.NetworkServer.Gateway.Backend.Backend = gwBackend
+
+		rp := storage.RoutingProfile{}
+		So(storage.CreateRoutingProfile(db, &rp), ShouldBeNil)
+
+		phy := lorawan.PHYPayload{
+			MHDR: lorawan.MHDR{
+				MType: lorawan.UnconfirmedDataDown,
+				Major: lorawan.LoRaWANR1,
+			},
+			MACPayload: &lorawan.MACPayload{
+				FHDR: lorawan.FHDR{
+					DevAddr: lorawan.DevAddr{1, 2, 3, 4},
+					FCnt:    10,
+				},
+			},
+		}
+		phyB, err := phy.MarshalBinary()
+		So(err, ShouldBeNil)
+
+		dr0, err := config.C.NetworkServer.Band.Band.GetDataRate(0)
+		So(err, ShouldBeNil)
+		dr5, err := config.C.NetworkServer.Band.Band.GetDataRate(5)
+		So(err, ShouldBeNil)
+
+		timestamp := uint32(1000000)
+		rx1 := gw.TXInfo{
+			MAC:       lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1},
+			Timestamp: &timestamp,
+			Frequency: 868100000,
+			DataRate:  dr5,
+		}
+		rx1OtherGW := rx1
+		rx1OtherGW.MAC = lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2}
+		rx2 := rx1
+		rx2.Frequency = 869525000
+		rx2.DataRate = dr0
+
+		frames := storage.DownlinkFrames{
+			Token:            1234,
+			DevEUI:           lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			RoutingProfileID: rp.ID,
+			FCnt:             10,
+			FPort:            1,
+			TXPackets: []gw.TXPacketBytes{
+				{Token: 1234, TXInfo: rx1, PHYPayload: phyB},
+				{Token: 1234, TXInfo: rx1OtherGW, PHYPayload: phyB},
+				{Token: 1234, TXInfo: rx2, PHYPayload: phyB},
+			},
+		}
+		So(storage.SaveDownlinkFrames(config.C.Redis.Pool, frames), ShouldBeNil)
+
+		Convey("When handling an ack without error", func() {
+			So(HandleDownlinkTXAck(gw.TXAck{MAC: rx1.MAC, Token: 1234}), ShouldBeNil)
+
+			Convey("Then the downlink frames have been removed", func() {
+				_, err := storage.GetDownlinkFrames(config.C.Redis.Pool, rx1.MAC, 1234)
+				So(err, ShouldEqual, storage.ErrDoesNotExist)
+				So(gwBackend.TXPacketChan, ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When handling an ack from an other gateway", func() {
+			So(HandleDownlinkTXAck(gw.TXAck{MAC: rx1OtherGW.MAC, Token: 1234, Error: gw.ErrCollisionPacket}), ShouldBeNil)
+
+			Convey("Then the ack is ignored", func() {
+				So(gwBackend.TXPacketChan, ShouldHaveLength, 0)
+				So(asClient.HandleErrorChan, ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When handling a COLLISION_PACKET error", func() {
+			So(HandleDownlinkTXAck(gw.TXAck{MAC: rx1.MAC, Token: 1234, Error: gw.ErrCollisionPacket}), ShouldBeNil)
+
+			Convey("Then the frame is sent to the other gateway", func() {
+				txPacket := <-gwBackend.TXPacketChan
+				So(txPacket.Token, ShouldEqual, 1234)
+				So(txPacket.TXInfo, ShouldResemble, rx1OtherGW)
+				So(txPacket.PHYPayload, ShouldResemble, phy)
+			})
+
+			Convey("Then the downlink frames are stored for the other gateway", func() {
+				_, err := storage.GetDownlinkFrames(config.C.Redis.Pool, rx1.MAC, 1234)
+				So(err, ShouldEqual, storage.ErrDoesNotExist)
+
+				f, err := storage.GetDownlinkFrames(config.C.Redis.Pool, rx1OtherGW.MAC, 1234)
+				So(err, ShouldBeNil)
+				So(f.TXPackets, ShouldHaveLength, 2)
+				So(f.TXPackets[0].TXInfo, ShouldResemble, rx1OtherGW)
+			})
+		})
+
+		Convey("When handling a TOO_LATE error", func() {
+			So(HandleDownlinkTXAck(gw.TXAck{MAC: rx1.MAC, Token: 1234, Error: gw.ErrTooLate}), ShouldBeNil)
+
+			Convey("Then the frame is sent using RX2", func() {
+				txPacket := <-gwBackend.TXPacketChan
+				So(txPacket.TXInfo, ShouldResemble, rx2)
+
+				Convey("When RX2 fails too", func() {
+					So(HandleDownlinkTXAck(gw.TXAck{MAC: rx2.MAC, Token: 1234, Error: gw.ErrTooLate}), ShouldBeNil)
+
+					Convey("Then the error is reported to the application-server", func() {
+						So(gwBackend.TXPacketChan, ShouldHaveLength, 0)
+						req := <-asClient.HandleErrorChan
+						So(req, ShouldResemble, as.HandleErrorRequest{
+							DevEui: frames.DevEUI[:],
+							Type:   as.ErrorType_DATA_DOWN_GATEWAY,
+							FCnt:   10,
+							Error:  gw.ErrTooLate,
+						})
+
+						_, err := storage.GetDownlinkFrames(config.C.Redis.Pool, rx1.MAC, 1234)
+						So(err, ShouldEqual, storage.ErrDoesNotExist)
+					})
+				})
+			})
+		})
+	})
+}
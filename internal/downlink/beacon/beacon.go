@@ -0,0 +1,270 @@
+// Package beacon implements the scheduling of the Class-B beacons, for the
+// gateways using a gateway-profile with beacons enabled.
+package beacon
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/loraserver/internal/config"
+	"github.com/brocaar/loraserver/internal/downlink/data/classb"
+	"github.com/brocaar/loraserver/internal/downlink/gateway"
+	"github.com/brocaar/loraserver/internal/gps"
+	"github.com/brocaar/loraserver/internal/storage"
+	"github.com/brocaar/lorawan/band"
+)
+
+const (
+	beaconPeriod = 128 * time.Second
+	codeRate     = "4/5"
+
+	// scheduleMargin defines the time before the beacon at which the
+	// beacon is sent to the gateways.
+	scheduleMargin = 5 * time.Second
+)
+
+// regionParameters contains the beacon parameters of a region.
+type regionParameters struct {
+	// frequency returns the beacon frequency for the given beacon time.
+	frequency func(beaconTime time.Duration) int
+	dr        int
+	rfu1Size  int
+	rfu2Size  int
+}
+
+func fixedFrequency(freq int) func(time.Duration) int {
+	return func(time.Duration) int {
+		return freq
+	}
+}
+
+// hoppingFrequency returns a frequency function which hops over 8 channels,
+// based on the beacon time.
+func hoppingFrequency(base, step int) func(time.Duration) int {
+	return func(beaconTime time.Duration) int {
+		channel := int((beaconTime / beaconPeriod) % 8)
+		return base + channel*step
+	}
+}
+
+var regions = map[band.Name]regionParameters{
+	band.EU_863_870: {frequency: fixedFrequency(869525000), dr: 3, rfu1Size: 2},
+	band.US_902_928: {frequency: hoppingFrequency(923300000, 600000), dr: 8, rfu1Size: 5, rfu2Size: 3},
+	band.AU_915_928: {frequency: hoppingFrequency(923300000, 600000), dr: 8, rfu1Size: 5, rfu2Size: 3},
+	band.CN_470_510: {frequency: hoppingFrequency(508300000, 200000), dr: 2, rfu1Size: 3, rfu2Size: 1},
+	band.AS_923:     {frequency: fixedFrequency(923400000), dr: 3, rfu1Size: 2},
+	band.KR_920_923: {frequency: fixedFrequency(923100000), dr: 3, rfu1Size: 2},
+	band.IN_865_867: {frequency: fixedFrequency(866550000), dr: 4, rfu1Size: 1, rfu2Size: 3},
+	band.EU_433:     {frequency: fixedFrequency(434665000), dr: 3, rfu1Size: 2},
+	band.CN_779_787: {frequency: fixedFrequency(785000000), dr: 3, rfu1Size: 2},
+}
+
+// SchedulerLoop starts an infinit loop sending the beacon to the
+// beacon-enabled gateways, shortly before each beacon period. When running
+// multiple network-server instances, each beacon is sent by the instance
+// acquiring the beacon lock.
+func SchedulerLoop() {
+	for {
+		// the margin is added so that the beacon that has just been sent
+		// is not scheduled again
+		beaconTime := classb.GetBeaconStartForTime(time.Now().Add(scheduleMargin)) + beaconPeriod
+		sendAt := time.Time(gps.NewFromTimeSinceGPSEpoch(beaconTime - scheduleMargin))
+		time.Sleep(time.Until(sendAt))
+
+		ok, err := storage.AcquireBeaconLock(config.C.Redis.Pool, beaconTime, beaconPeriod)
+		if err != nil {
+			log.WithError(err).Error("acquire beacon lock error")
+			continue
+		}
+		if !ok {
+			log.WithField("beacon_time", beaconTime).Debug("beacon is sent by an other instance")
+			continue
+		}
+
+		log.WithField("beacon_time", beaconTime).Debug("running beacon scheduler")
+		if err := SendBeacons(beaconTime); err != nil {
+			log.WithError(err).Error("beacon scheduler error")
+		}
+	}
+}
+
+// SendBeacons sends the beacon for the given beacon time (duration since
+// GPS epoch) to all gateways having beacons enabled.
+func SendBeacons(beaconTime time.Duration) error {
+	rp, ok := regions[config.C.NetworkServer.Band.Name]
+	if !ok {
+		return fmt.Errorf("beacon is not supported for band %s", config.C.NetworkServer.Band.Name)
+	}
+
+	dr, err := config.C.NetworkServer.Band.Band.GetDataRate(rp.dr)
+	if err != nil {
+		return errors.Wrap(err, "get data-rate error")
+	}
+
+	gws, err := storage.GetBeaconEnabledGateways(config.C.PostgreSQL.DB)
+	if err != nil {
+		return errors.Wrap(err, "get beacon enabled gateways error")
+	}
+
+	for _, g := range gws {
+		token, err := getToken()
+		if err != nil {
+			return err
+		}
+
+		deviceFreqs, err := storage.GetGatewayBeaconFrequencies(config.C.Redis.Pool, g.MAC)
+		if err != nil {
+			log.WithError(err).WithField("mac", g.MAC).Error("get gateway beacon frequencies error")
+			continue
+		}
+		if len(deviceFreqs) > 1 {
+			log.WithFields(log.Fields{
+				"mac":         g.MAC,
+				"frequencies": deviceFreqs,
+			}).Warning("gateway serves class-b devices using different beacon frequencies")
+		}
+
+		freq := getFrequency(rp, deviceFreqs, beaconTime)
+		power := config.C.NetworkServer.Band.Band.GetDownlinkTXPower(freq)
+		if config.C.NetworkServer.NetworkSettings.DownlinkTXPower != -1 {
+			power = config.C.NetworkServer.NetworkSettings.DownlinkTXPower
+		}
+
+		timeSinceGPSEpoch := gw.Duration(beaconTime)
+		iPol := false
+
+		txInfo := gw.TXInfo{
+			MAC:               g.MAC,
+			TimeSinceGPSEpoch: &timeSinceGPSEpoch,
+			Frequency:         freq,
+			Power:             power,
+			DataRate:          dr,
+			CodeRate:          codeRate,
+			IPol:              &iPol,
+			// beacons are sent without physical header and CRC
+			NoCRC:    true,
+			NoHeader: true,
+		}
+		beacon := NewBeacon(rp.rfu1Size, rp.rfu2Size, beaconTime, g.Location)
+
+		ok, err := gateway.CheckDutyCycle(txInfo, len(beacon))
+		if err != nil {
+			log.WithError(err).WithField("mac", g.MAC).Error("check duty-cycle error")
+			continue
+		}
+		if !ok {
+			log.WithFields(log.Fields{
+				"mac":       g.MAC,
+				"frequency": freq,
+			}).Warning("gateway duty-cycle budget exhausted, skipping beacon")
+			continue
+		}
+
+		err = config.C.NetworkServer.Gateway.Backend.Backend.SendTXPacket(gw.TXPacket{
+			Token:  token,
+			TXInfo: txInfo,
+			Beacon: beacon,
+		})
+		if err != nil {
+			log.WithError(err).WithField("mac", g.MAC).Error("send beacon to gateway error")
+			continue
+		}
+
+		if err := gateway.RecordAirtime(txInfo, len(beacon)); err != nil {
+			log.WithError(err).WithField("mac", g.MAC).Error("record gateway airtime error")
+		}
+
+		log.WithFields(log.Fields{
+			"mac":         g.MAC,
+			"beacon_time": beaconTime,
+			"frequency":   freq,
+		}).Info("beacon sent to gateway")
+	}
+
+	return nil
+}
+
+// getFrequency returns the beacon frequency of a gateway for the given beacon
+// time. As a gateway transmits a single beacon per period, the frequency used
+// by most of the Class-B devices in range of the gateway (given as number of
+// devices by frequency, 0 = band default) is used. Without devices, or on a
+// tie, the lowest frequency (thus the band default) is used.
+func getFrequency(rp regionParameters, deviceFreqs map[int]int, beaconTime time.Duration) int {
+	var freq, count int
+	for f, c := range deviceFreqs {
+		if c > count || (c == count && f < freq) {
+			freq = f
+			count = c
+		}
+	}
+
+	if freq == 0 {
+		return rp.frequency(beaconTime)
+	}
+	return freq
+}
+
+// NewBeacon returns the beacon frame for the given beacon time, using the
+// gateway coordinates as info descriptor. The rfu sizes are region specific.
+func NewBeacon(rfu1Size, rfu2Size int, beaconTime time.Duration, loc storage.GPSPoint) []byte {
+	// network common part
+	b := make([]byte, rfu1Size+4)
+	binary.LittleEndian.PutUint32(b[rfu1Size:], uint32(int64(beaconTime/time.Second)%(1<<32)))
+	b = appendCRC(b, b)
+
+	// gateway specific part (info descriptor 0 = gps coordinates of the
+	// first antenna)
+	gwSpecific := make([]byte, 7+rfu2Size)
+	putInt24(gwSpecific[1:4], int32(loc.Latitude*(1<<23)/90))
+	putInt24(gwSpecific[4:7], int32(loc.Longitude*(1<<23)/180))
+	gwSpecific = appendCRC(gwSpecific, gwSpecific)
+
+	return append(b, gwSpecific...)
+}
+
+// appendCRC appends the little endian CRC-16 (as defined by IEEE 802.15.4)
+// of the given data to b.
+func appendCRC(b, data []byte) []byte {
+	var crc uint16
+	for _, d := range data {
+		crc ^= uint16(d)
+		for i := 0; i < 8; i++ {
+			if crc&1 != 0 {
+				crc = (crc >> 1) ^ 0x8408
+			} else {
+				crc >>= 1
+			}
+		}
+	}
+
+	return append(b, byte(crc), byte(crc>>8))
+}
+
+// putInt24 puts the (little endian) 24 bit two's complement of v into b.
+func putInt24(b []byte, v int32) {
+	if v > (1<<23)-1 {
+		v = (1 << 23) - 1
+	}
+	if v < -(1 << 23) {
+		v = -(1 << 23)
+	}
+
+	b[0] = byte(v)
+	b[1] = byte(v >> 8)
+	b[2] = byte(v >> 16)
+}
+
+func getToken() (uint16, error) {
+	b := make([]byte, 2)
+	_, err := rand.Read(b)
+	if err != nil {
+		return 0, errors.Wrap(err, "read random error")
+	}
+	return binary.BigEndian.Uint16(b), nil
+}
@@ -0,0 +1,104 @@
+package beacon
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/loraserver/internal/storage"
+	"github.com/brocaar/lorawan/band"
+)
+
+func TestAppendCRC(t *testing.T) {
+	Convey("When calculating the CRC of 123456789", t, func() {
+		b := appendCRC(nil, []byte("123456789"))
+
+		Convey("Then the little endian CRC-16 is returned", func() {
+			So(b, ShouldResemble, []byte{0x89, 0x21})
+		})
+	})
+}
+
+func TestNewBeacon(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		tests := []struct {
+			Name       string
+			RFU1Size   int
+			RFU2Size   int
+			BeaconTime time.Duration
+			Location   storage.GPSPoint
+			Expected   []byte
+		}{
+			{
+				Name:       "EU868 beacon",
+				RFU1Size:   2,
+				BeaconTime: 158024576 * time.Second,
+				Location:   storage.GPSPoint{Latitude: 52.3676, Longitude: 4.9041},
+				Expected:   []byte{0x00, 0x00, 0x80, 0x43, 0x6b, 0x09, 0x40, 0xd8, 0x00, 0x76, 0x7a, 0x4a, 0xc3, 0x7c, 0x03, 0xb8, 0xc0},
+			},
+			{
+				Name:       "US915 beacon with negative coordinates",
+				RFU1Size:   5,
+				RFU2Size:   3,
+				BeaconTime: 158024704 * time.Second,
+				Location:   storage.GPSPoint{Latitude: -33.8688, Longitude: -151.2093},
+				Expected:   []byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x44, 0x6b, 0x09, 0x2b, 0x79, 0x00, 0xc0, 0xd4, 0xcf, 0x30, 0x79, 0x94, 0x00, 0x00, 0x00, 0x8a, 0x86},
+			},
+		}
+
+		for _, test := range tests {
+			Convey("Testing: "+test.Name, func() {
+				So(NewBeacon(test.RFU1Size, test.RFU2Size, test.BeaconTime, test.Location), ShouldResemble, test.Expected)
+			})
+		}
+	})
+}
+
+func TestBeaconFrequency(t *testing.T) {
+	Convey("Given the US915 region parameters", t, func() {
+		rp := regions[band.US_902_928]
+
+		Convey("Then the frequency hops over 8 channels", func() {
+			So(rp.frequency(0), ShouldEqual, 923300000)
+			So(rp.frequency(beaconPeriod), ShouldEqual, 923900000)
+			So(rp.frequency(7*beaconPeriod), ShouldEqual, 927500000)
+			So(rp.frequency(8*beaconPeriod), ShouldEqual, 923300000)
+		})
+	})
+
+	Convey("Given the EU868 region parameters", t, func() {
+		rp := regions[band.EU_863_870]
+
+		Convey("Then the frequency is fixed", func() {
+			So(rp.frequency(0), ShouldEqual, 869525000)
+			So(rp.frequency(beaconPeriod), ShouldEqual, 869525000)
+		})
+
+		Convey("When no devices are using the gateway", func() {
+			Convey("Then the default frequency is used", func() {
+				So(getFrequency(rp, nil, 0), ShouldEqual, 869525000)
+			})
+		})
+
+		Convey("When the devices use a custom beacon frequency", func() {
+			Convey("Then the custom frequency is used for every beacon", func() {
+				deviceFreqs := map[int]int{869100000: 2}
+				So(getFrequency(rp, deviceFreqs, 0), ShouldEqual, 869100000)
+				So(getFrequency(rp, deviceFreqs, beaconPeriod), ShouldEqual, 869100000)
+			})
+		})
+
+		Convey("When the devices use different beacon frequencies", func() {
+			Convey("Then the frequency used by most devices is used", func() {
+				So(getFrequency(rp, map[int]int{0: 1, 869100000: 2, 869300000: 1}, 0), ShouldEqual, 869100000)
+				So(getFrequency(rp, map[int]int{0: 3, 869100000: 2}, beaconPeriod), ShouldEqual, 869525000)
+			})
+
+			Convey("Then on a tie the lowest frequency is used", func() {
+				So(getFrequency(rp, map[int]int{869100000: 1, 869300000: 1}, 0), ShouldEqual, 869100000)
+				So(getFrequency(rp, map[int]int{0: 1, 869100000: 1}, 0), ShouldEqual, 869525000)
+			})
+		})
+	})
+}
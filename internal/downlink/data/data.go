@@ -13,7 +13,10 @@ import (
 	"github.com/brocaar/loraserver/internal/adr"
 	"github.com/brocaar/loraserver/internal/channels"
 	"github.com/brocaar/loraserver/internal/config"
+	"github.com/brocaar/loraserver/internal/downlink/data/classb"
+	"github.com/brocaar/loraserver/internal/downlink/gateway"
 	"github.com/brocaar/loraserver/internal/framelog"
+	"github.com/brocaar/loraserver/internal/gps"
 	"github.com/brocaar/loraserver/internal/maccommand"
 	"github.com/brocaar/loraserver/internal/models"
 	"github.com/brocaar/loraserver/internal/storage"
@@ -47,7 +50,11 @@ var setMACCommandsSet = setMACCommands(
 	requestDevStatus,
 	requestRejoinParamSetup,
 	setPingSlotParameters,
+	requestBeaconFreq,
 	setRXParameters,
+	requestDutyCycle,
+	requestTXParamSetup,
+	requestADRParamSetup,
 	getMACCommandsFromQueue,
 )
 
@@ -59,9 +66,15 @@ var responseTasks = []func(*dataContext) error{
 	getNextDeviceQueueItem,
 	setMACCommandsSet,
 	stopOnNothingToSend,
+	checkDownlinkRateLimit,
+	getDataTXInfoFallbacks,
 	setPHYPayload,
 	encryptMACCommands,
 	setMIC,
+	checkDutyCycle,
+	setMACCommandsPending,
+	updateDeviceQueueItem,
+	saveDownlinkFrames,
 	sendDataDown,
 	saveDeviceSession,
 	logDownlinkFrameForGateway,
@@ -85,9 +98,14 @@ var scheduleNextQueueItemTasks = []func(*dataContext) error{
 	getNextDeviceQueueItem,
 	setMACCommandsSet,
 	stopOnNothingToSend,
+	checkDownlinkRateLimit,
 	setPHYPayload,
 	encryptMACCommands,
 	setMIC,
+	checkDutyCycle,
+	setMACCommandsPending,
+	updateDeviceQueueItem,
+	saveDownlinkFrames,
 	sendDataDown,
 	saveDeviceSession,
 	logDownlinkFrameForGateway,
@@ -112,6 +130,15 @@ type dataContext struct {
 	// TXInfo holds the data needed for transmission.
 	TXInfo gw.TXInfo
 
+	// RXInfoSet holds the RXInfo elements of the uplink, ranked for the
+	// downlink transmission (Class-A).
+	RXInfoSet models.RXInfoSet
+
+	// FallbackTXInfo holds the alternative TXInfo items (e.g. an other
+	// gateway or RX2) to use, in order, when the gateway reports that the
+	// transmission failed.
+	FallbackTXInfo []gw.TXInfo
+
 	// DataRate holds the data-rate for transmission.
 	DataRate int
 
@@ -143,6 +170,9 @@ type dataContext struct {
 	// MoreData defines if there is more data pending.
 	MoreData bool
 
+	// DeviceQueueItem holds the device-queue item to send (if any).
+	DeviceQueueItem *storage.DeviceQueueItem
+
 	// Data contains the bytes to send. Note that this requires FPort to be a
 	// value other than 0.
 	Data []byte
@@ -152,6 +182,11 @@ type dataContext struct {
 
 	// RXPacket holds the received uplink packet (in case of Class-A downlink).
 	RXPacket *models.RXPacket
+
+	// DownlinkRateLimitToken is set when a token has been taken from the
+	// downlink token-bucket of the device for this frame. The token is
+	// given back when the frame is not sent.
+	DownlinkRateLimitToken bool
 }
 
 func (ctx dataContext) Validate() error {
@@ -199,6 +234,8 @@ func HandleResponse(rxPacket models.RXPacket, sp storage.ServiceProfile, ds stor
 
 	for _, t := range responseTasks {
 		if err := t(&ctx); err != nil {
+			returnDownlinkRateLimitToken(&ctx)
+
 			if err == ErrAbort {
 				return nil
 			}
@@ -218,6 +255,8 @@ func HandleScheduleNextQueueItem(ds storage.DeviceSession) error {
 
 	for _, t := range scheduleNextQueueItemTasks {
 		if err := t(&ctx); err != nil {
+			returnDownlinkRateLimitToken(&ctx)
+
 			if err == ErrAbort {
 				return nil
 			}
@@ -275,37 +314,135 @@ func setPingSlotParameters(ctx *dataContext) error {
 		return nil
 	}
 
-	dr := config.C.NetworkServer.NetworkSettings.ClassB.PingSlotDR
-	freq := config.C.NetworkServer.NetworkSettings.ClassB.PingSlotFrequency
+	// as the device-profile is read on every downlink, changes to the
+	// ping-slot parameters are pushed to the device on the next downlink
+	params := ctx.DeviceProfile.GetPingSlotParameters()
 
-	if dr != ctx.DeviceSession.PingSlotDR || freq != ctx.DeviceSession.PingSlotFrequency {
-		block := maccommand.RequestPingSlotChannel(ctx.DeviceSession.DevEUI, dr, freq)
+	if params.DR != ctx.DeviceSession.PingSlotDR || params.Frequency != ctx.DeviceSession.PingSlotFrequency {
+		block := maccommand.RequestPingSlotChannel(ctx.DeviceSession.DevEUI, params.DR, params.Frequency)
 		ctx.MACCommands = append(ctx.MACCommands, block)
 	}
 
 	return nil
 }
 
+// requestBeaconFreq requests the device to use the beacon frequency of the
+// device-profile, in case it differs from the frequency acknowledged by
+// the device.
+func requestBeaconFreq(ctx *dataContext) error {
+	if !ctx.DeviceProfile.SupportsClassB {
+		return nil
+	}
+
+	if ctx.DeviceProfile.BeaconFreq != ctx.DeviceSession.BeaconFrequency {
+		ctx.MACCommands = append(ctx.MACCommands, maccommand.RequestBeaconFreq(ctx.DeviceProfile.BeaconFreq))
+	}
+
+	return nil
+}
+
 func setRXParameters(ctx *dataContext) error {
-	if ctx.DeviceSession.RX2Frequency != config.C.NetworkServer.NetworkSettings.RX2Frequency || ctx.DeviceSession.RX2DR != uint8(config.C.NetworkServer.NetworkSettings.RX2DR) || ctx.DeviceSession.RX1DROffset != uint8(config.C.NetworkServer.NetworkSettings.RX1DROffset) {
-		block := maccommand.RequestRXParamSetup(config.C.NetworkServer.NetworkSettings.RX1DROffset, config.C.NetworkServer.NetworkSettings.RX2Frequency, config.C.NetworkServer.NetworkSettings.RX2DR)
+	// as the device-profile is read on every downlink, changes to the
+	// device-profile are pushed to the device on the next downlink
+	rxParams := ctx.DeviceProfile.GetRXParameters()
+
+	if ctx.DeviceSession.RX2Frequency != rxParams.RX2Frequency || ctx.DeviceSession.RX2DR != uint8(rxParams.RX2DR) || ctx.DeviceSession.RX1DROffset != uint8(rxParams.RX1DROffset) {
+		block := maccommand.RequestRXParamSetup(rxParams.RX1DROffset, rxParams.RX2Frequency, rxParams.RX2DR)
 		ctx.MACCommands = append(ctx.MACCommands, block)
 	}
 
-	if ctx.DeviceSession.RXDelay != uint8(config.C.NetworkServer.NetworkSettings.RX1Delay) {
-		block := maccommand.RequestRXTimingSetup(config.C.NetworkServer.NetworkSettings.RX1Delay)
+	if ctx.DeviceSession.RXDelay != uint8(rxParams.RX1Delay) {
+		block := maccommand.RequestRXTimingSetup(rxParams.RX1Delay)
 		ctx.MACCommands = append(ctx.MACCommands, block)
 	}
 
 	return nil
 }
 
+// requestDutyCycle requests the device to use the max duty-cycle of the
+// device-profile, in case it differs from the duty-cycle acknowledged by
+// the device.
+func requestDutyCycle(ctx *dataContext) error {
+	maxDCycle := ctx.DeviceProfile.GetMaxDCycle()
+
+	if ctx.DeviceSession.MaxDCycle != uint8(maxDCycle) {
+		ctx.MACCommands = append(ctx.MACCommands, maccommand.RequestDutyCycle(maxDCycle))
+	}
+
+	return nil
+}
+
+// requestTXParamSetup requests the device to use the dwell-time and max
+// EIRP of the device-profile, in case these differ from the parameters
+// acknowledged by the device. This mac-command is only implemented by the
+// AS923 band, for LoRaWAN 1.0.2+ devices.
+func requestTXParamSetup(ctx *dataContext) error {
+	if config.C.NetworkServer.Band.Name != band.AS_923 {
+		return nil
+	}
+	if ctx.DeviceProfile.MACVersion == "1.0.0" || ctx.DeviceProfile.MACVersion == "1.0.1" {
+		return nil
+	}
+
+	// the dwell-time is always limited when enforced by the band
+	// configuration, in which case the device-profile can not lift it
+	dwellTimeEnforced := config.C.NetworkServer.Band.DwellTime400ms
+	uplinkDwellTime := ctx.DeviceProfile.UplinkDwellTime400ms || dwellTimeEnforced
+	downlinkDwellTime := ctx.DeviceProfile.DownlinkDwellTime400ms || dwellTimeEnforced
+
+	maxEIRPIndex := maccommand.GetTXParamSetupEIRPIndex(ctx.DeviceProfile.MaxEIRP)
+	maxEIRP, err := maccommand.GetTXParamSetupEIRP(maxEIRPIndex)
+	if err != nil {
+		return errors.Wrap(err, "get max eirp error")
+	}
+
+	if (ctx.DeviceSession.GetUplinkDwellTime() == lorawan.DwellTime400ms) != uplinkDwellTime ||
+		(ctx.DeviceSession.GetDownlinkDwellTime() == lorawan.DwellTime400ms) != downlinkDwellTime ||
+		(ctx.DeviceProfile.MaxEIRP != 0 && ctx.DeviceSession.MaxEIRP != maxEIRP) {
+		ctx.MACCommands = append(ctx.MACCommands, maccommand.RequestTXParamSetup(uplinkDwellTime, downlinkDwellTime, maxEIRPIndex))
+	}
+
+	return nil
+}
+
+// requestADRParamSetup requests the device to use the ADR_ACK_LIMIT and
+// ADR_ACK_DELAY exponents of the device-profile (LoRaWAN 1.1+).
+func requestADRParamSetup(ctx *dataContext) error {
+	if ctx.DeviceSession.GetMACVersion() == lorawan.LoRaWAN1_0 {
+		return nil
+	}
+
+	wantedLimitExp, wantedDelayExp := getADRParam(ctx.DeviceProfile.ADRAckLimitExp, ctx.DeviceProfile.ADRAckDelayExp)
+	currentLimitExp, currentDelayExp := getADRParam(ctx.DeviceSession.ADRAckLimitExp, ctx.DeviceSession.ADRAckDelayExp)
+
+	if wantedLimitExp != currentLimitExp || wantedDelayExp != currentDelayExp {
+		ctx.MACCommands = append(ctx.MACCommands, maccommand.RequestADRParamSetup(wantedLimitExp, wantedDelayExp))
+	}
+
+	return nil
+}
+
+// getADRParam returns the given ADR_ACK_LIMIT and ADR_ACK_DELAY exponents,
+// or the defaults when both are 0.
+func getADRParam(limitExp, delayExp int) (int, int) {
+	if limitExp == 0 && delayExp == 0 {
+		return maccommand.DefaultADRAckLimitExp, maccommand.DefaultADRAckDelayExp
+	}
+	return limitExp, delayExp
+}
+
 func getDataTXInfo(ctx *dataContext) error {
 	if len(ctx.RXPacket.RXInfoSet) == 0 {
 		return ErrNoLastRXInfoSet
 	}
-	rxInfo := ctx.RXPacket.RXInfoSet[0]
+
 	var err error
+	ctx.RXInfoSet, err = gateway.RankRXInfoSet(ctx.RXPacket.RXInfoSet)
+	if err != nil {
+		return errors.Wrap(err, "rank downlink gateways error")
+	}
+
+	rxInfo := ctx.RXInfoSet[0]
 	ctx.TXInfo, ctx.DataRate, err = getDataDownTXInfoAndDR(ctx.DeviceSession, ctx.RXPacket.TXInfo, rxInfo)
 	if err != nil {
 		return errors.Wrap(err, "get data down tx-info error")
@@ -314,34 +451,85 @@ func getDataTXInfo(ctx *dataContext) error {
 	return nil
 }
 
-func getDataTXInfoForRX2(ctx *dataContext) error {
-	mac, err := ctx.DeviceSession.GetDownlinkGatewayMAC()
+// getDataTXInfoFallbacks sets the TXInfo alternatives, in case the gateway
+// reports that it was unable to send the downlink. First the other gateways
+// within the same receive-window are tried, then RX2 (if the payload fits).
+func getDataTXInfoFallbacks(ctx *dataContext) error {
+	for _, rxInfo := range ctx.RXInfoSet[1:] {
+		txInfo, _, err := getDataDownTXInfoAndDR(ctx.DeviceSession, ctx.RXPacket.TXInfo, rxInfo)
+		if err != nil {
+			return errors.Wrap(err, "get data down tx-info error")
+		}
+		ctx.FallbackTXInfo = append(ctx.FallbackTXInfo, txInfo)
+	}
+
+	if ctx.DeviceSession.RXWindow != storage.RX1 {
+		return nil
+	}
+
+	plSize, err := getMaxPayloadSize(ctx, int(ctx.DeviceSession.RX2DR))
 	if err != nil {
 		return err
 	}
 
+	size := len(ctx.Data)
+	for _, block := range ctx.MACCommands {
+		s, err := block.Size()
+		if err != nil {
+			return errors.Wrap(err, "get mac-command block size error")
+		}
+		size += s
+	}
+
+	// the frame would not fit within RX2
+	if size > plSize {
+		return nil
+	}
+
+	ds := ctx.DeviceSession
+	ds.RXWindow = storage.RX2
+
+	for _, rxInfo := range ctx.RXInfoSet {
+		txInfo, _, err := getDataDownTXInfoAndDR(ds, ctx.RXPacket.TXInfo, rxInfo)
+		if err != nil {
+			return errors.Wrap(err, "get data down tx-info error")
+		}
+		ctx.FallbackTXInfo = append(ctx.FallbackTXInfo, txInfo)
+	}
+
+	return nil
+}
+
+func getDataTXInfoForRX2(ctx *dataContext) error {
+	macs, err := gateway.RankUplinkGatewayHistory(ctx.DeviceSession.UplinkGatewayHistory)
+	if err != nil {
+		return errors.Wrap(err, "rank downlink gateways error")
+	}
+
 	dr, err := config.C.NetworkServer.Band.Band.GetDataRate(int(ctx.DeviceSession.RX2DR))
 	if err != nil {
 		return errors.Wrap(err, "get data-rate error")
 	}
 
-	var board, antenna int = 0, 0
+	for i, mac := range macs {
+		h := ctx.DeviceSession.UplinkGatewayHistory[mac]
 
-	if ctx.RXPacket != nil {
-		rxInfo := ctx.RXPacket.RXInfoSet[0]
-		board = rxInfo.Board
-		antenna = rxInfo.Antenna
-	}
+		txInfo := gw.TXInfo{
+			MAC:         mac,
+			Immediately: true,
+			Frequency:   ctx.DeviceSession.RX2Frequency,
+			Power:       config.C.NetworkServer.Band.Band.GetDownlinkTXPower(ctx.DeviceSession.RX2Frequency),
+			DataRate:    dr,
+			CodeRate:    defaultCodeRate,
+			Board:       h.Board,
+			Antenna:     h.Antenna,
+		}
 
-	ctx.TXInfo = gw.TXInfo{
-		MAC:         mac,
-		Immediately: true,
-		Frequency:   ctx.DeviceSession.RX2Frequency,
-		Power:       config.C.NetworkServer.Band.Band.GetDownlinkTXPower(ctx.DeviceSession.RX2Frequency),
-		DataRate:    dr,
-		CodeRate:    defaultCodeRate,
-		Board:       board,
-		Antenna:     antenna,
+		if i == 0 {
+			ctx.TXInfo = txInfo
+		} else {
+			ctx.FallbackTXInfo = append(ctx.FallbackTXInfo, txInfo)
+		}
 	}
 	ctx.DataRate = int(ctx.DeviceSession.RX2DR)
 
@@ -356,9 +544,9 @@ func checkBeaconLocked(ctx *dataContext) error {
 }
 
 func setTXInfoForClassB(ctx *dataContext) error {
-	mac, err := ctx.DeviceSession.GetDownlinkGatewayMAC()
+	macs, err := gateway.RankUplinkGatewayHistory(ctx.DeviceSession.UplinkGatewayHistory)
 	if err != nil {
-		return err
+		return errors.Wrap(err, "rank downlink gateways error")
 	}
 
 	dr, err := config.C.NetworkServer.Band.Band.GetDataRate(ctx.DeviceSession.PingSlotDR)
@@ -366,22 +554,24 @@ func setTXInfoForClassB(ctx *dataContext) error {
 		return errors.Wrap(err, "get data-rate error")
 	}
 
-	var board, antenna int = 0, 0
+	for i, mac := range macs {
+		h := ctx.DeviceSession.UplinkGatewayHistory[mac]
 
-	if ctx.RXPacket != nil {
-		rxInfo := ctx.RXPacket.RXInfoSet[0]
-		board = rxInfo.Board
-		antenna = rxInfo.Antenna
-	}
+		txInfo := gw.TXInfo{
+			MAC:       mac,
+			Frequency: ctx.DeviceSession.PingSlotFrequency,
+			Power:     config.C.NetworkServer.Band.Band.GetDownlinkTXPower(ctx.DeviceSession.PingSlotFrequency),
+			DataRate:  dr,
+			CodeRate:  defaultCodeRate,
+			Board:     h.Board,
+			Antenna:   h.Antenna,
+		}
 
-	ctx.TXInfo = gw.TXInfo{
-		MAC:       mac,
-		Frequency: ctx.DeviceSession.PingSlotFrequency,
-		Power:     config.C.NetworkServer.Band.Band.GetDownlinkTXPower(ctx.DeviceSession.PingSlotFrequency),
-		DataRate:  dr,
-		CodeRate:  defaultCodeRate,
-		Board:     board,
-		Antenna:   antenna,
+		if i == 0 {
+			ctx.TXInfo = txInfo
+		} else {
+			ctx.FallbackTXInfo = append(ctx.FallbackTXInfo, txInfo)
+		}
 	}
 	ctx.DataRate = ctx.DeviceSession.PingSlotDR
 
@@ -389,12 +579,12 @@ func setTXInfoForClassB(ctx *dataContext) error {
 }
 
 func setRemainingPayloadSize(ctx *dataContext) error {
-	plSize, err := config.C.NetworkServer.Band.Band.GetMaxPayloadSizeForDataRateIndex(ctx.DeviceProfile.MACVersion, ctx.DeviceProfile.RegParamsRevision, ctx.DataRate)
+	plSize, err := getMaxPayloadSize(ctx, ctx.DataRate)
 	if err != nil {
-		return errors.Wrap(err, "get max-payload size error")
+		return err
 	}
 
-	ctx.RemainingPayloadSize = plSize.N - len(ctx.Data)
+	ctx.RemainingPayloadSize = plSize - len(ctx.Data)
 
 	if ctx.RemainingPayloadSize < 0 {
 		return ErrMaxPayloadSizeExceeded
@@ -403,6 +593,22 @@ func setRemainingPayloadSize(ctx *dataContext) error {
 	return nil
 }
 
+// getMaxPayloadSize returns the max payload size for the given data-rate,
+// taking the downlink dwell-time of the device into account.
+func getMaxPayloadSize(ctx *dataContext, dr int) (int, error) {
+	b, err := config.GetBandForDwellTime(ctx.DeviceSession.GetDownlinkDwellTime())
+	if err != nil {
+		return 0, errors.Wrap(err, "get band for dwell-time error")
+	}
+
+	plSize, err := b.GetMaxPayloadSizeForDataRateIndex(ctx.DeviceProfile.MACVersion, ctx.DeviceProfile.RegParamsRevision, dr)
+	if err != nil {
+		return 0, errors.Wrap(err, "get max-payload size error")
+	}
+
+	return plSize.N, nil
+}
+
 func getNextDeviceQueueItem(ctx *dataContext) error {
 	var fCnt uint32
 	if ctx.DeviceSession.GetMACVersion() == lorawan.LoRaWAN1_0 {
@@ -411,7 +617,7 @@ func getNextDeviceQueueItem(ctx *dataContext) error {
 		fCnt = ctx.DeviceSession.AFCntDown
 	}
 
-	qi, err := storage.GetNextDeviceQueueItemForDevEUIMaxPayloadSizeAndFCnt(config.C.PostgreSQL.DB, ctx.DeviceSession.DevEUI, ctx.RemainingPayloadSize, fCnt, ctx.DeviceSession.RoutingProfileID)
+	qi, err := storage.GetNextDeviceQueueItemForDevEUIMaxPayloadSizeAndFCnt(config.C.PostgreSQL.DB, ctx.DeviceSession.DevEUI, ctx.RemainingPayloadSize, fCnt, ctx.ServiceProfile.DLConfirmedRetries, ctx.DeviceSession.RoutingProfileID)
 	if err != nil {
 		if errors.Cause(err) == storage.ErrDoesNotExist {
 			return nil
@@ -419,6 +625,21 @@ func getNextDeviceQueueItem(ctx *dataContext) error {
 		return errors.Wrap(err, "get next device-queue item for max payload error")
 	}
 
+	// The ping-slot of a Class-B retransmission has passed, re-schedule the
+	// device-queue so that it will be sent within the next ping-slot.
+	if ctx.RXPacket == nil && qi.RetryCount > 0 && qi.EmitAtTimeSinceGPSEpoch != nil && *qi.EmitAtTimeSinceGPSEpoch < gps.Time(time.Now()).TimeSinceGPSEpoch() {
+		if err := storage.UpdateDeviceQueueItem(config.C.PostgreSQL.DB, &qi); err != nil {
+			return errors.Wrap(err, "update device-queue item error")
+		}
+
+		if err := classb.ScheduleDeviceQueueToPingSlotsForDevEUI(config.C.PostgreSQL.DB, ctx.DeviceProfile, ctx.DeviceSession); err != nil {
+			return errors.Wrap(err, "schedule device-queue to ping-slots error")
+		}
+
+		return ErrAbort
+	}
+
+	ctx.DeviceQueueItem = &qi
 	ctx.Confirmed = qi.Confirmed
 	ctx.Data = qi.FRMPayload
 	ctx.FPort = qi.FPort
@@ -452,8 +673,25 @@ func getNextDeviceQueueItem(ctx *dataContext) error {
 				return errors.Wrap(err, "get ping-slot frequency error")
 			}
 		}
+
+		// the fallback gateways must transmit within the same ping-slot
+		for i := range ctx.FallbackTXInfo {
+			ctx.FallbackTXInfo[i].TimeSinceGPSEpoch = &gwDuration
+			ctx.FallbackTXInfo[i].Frequency = ctx.TXInfo.Frequency
+		}
 	}
 
+	return nil
+}
+
+// updateDeviceQueueItem deletes the device-queue item that is about to be
+// sent or, in case of a confirmed downlink, marks it as pending.
+func updateDeviceQueueItem(ctx *dataContext) error {
+	if ctx.DeviceQueueItem == nil {
+		return nil
+	}
+	qi := *ctx.DeviceQueueItem
+
 	// delete when not confirmed
 	if !qi.Confirmed {
 		if err := storage.DeleteDeviceQueueItem(config.C.PostgreSQL.DB, qi.ID); err != nil {
@@ -472,7 +710,8 @@ func getNextDeviceQueueItem(ctx *dataContext) error {
 		qi.IsPending = true
 
 		// in case of class-b it is already set, we don't want to overwrite it
-		if qi.TimeoutAfter == nil {
+		// (unless this is a retransmission of a timed-out item)
+		if qi.TimeoutAfter == nil || qi.TimeoutAfter.Before(time.Now()) {
 			qi.TimeoutAfter = &timeout
 		}
 
@@ -546,24 +785,32 @@ func setMACCommands(funcs ...func(*dataContext) error) func(*dataContext) error
 				ctx.MoreData = true
 				break
 			}
+
+			remainingMACCommandSize -= macSize
 		}
 
-		for _, block := range ctx.MACCommands {
-			// set mac-command pending
-			if err := storage.SetPendingMACCommand(config.C.Redis.Pool, ctx.DeviceSession.DevEUI, block); err != nil {
-				return errors.Wrap(err, "set mac-command pending error")
-			}
+		return nil
+	}
+}
 
-			// delete from queue, if external
-			if block.External {
-				if err := storage.DeleteMACCommandQueueItem(config.C.Redis.Pool, ctx.DeviceSession.DevEUI, block); err != nil {
-					return errors.Wrap(err, "delete mac-command block from queue error")
-				}
-			}
+// setMACCommandsPending marks the mac-commands that will be sent as pending.
+// This is done after the rate-limit check, so that mac-commands of a dropped
+// frame stay in the queue.
+func setMACCommandsPending(ctx *dataContext) error {
+	for _, block := range ctx.MACCommands {
+		// set mac-command pending
+		if err := storage.SetPendingMACCommand(config.C.Redis.Pool, ctx.DeviceSession.DevEUI, block); err != nil {
+			return errors.Wrap(err, "set mac-command pending error")
 		}
 
-		return nil
+		// delete from queue, in case the block was enqueued (by the
+		// network-controller or through the api)
+		if err := storage.DeleteMACCommandQueueItem(config.C.Redis.Pool, ctx.DeviceSession.DevEUI, block); err != nil && err != storage.ErrDoesNotExist {
+			return errors.Wrap(err, "delete mac-command block from queue error")
+		}
 	}
+
+	return nil
 }
 
 func requestCustomChannelReconfiguration(ctx *dataContext) error {
@@ -595,7 +842,7 @@ func requestCustomChannelReconfiguration(ctx *dataContext) error {
 func requestChannelMaskReconfiguration(ctx *dataContext) error {
 	// handle channel configuration
 	// note that this must come before ADR!
-	blocks, err := channels.HandleChannelReconfigure(ctx.DeviceSession)
+	blocks, err := channels.HandleChannelReconfigure(ctx.ServiceProfile, ctx.DeviceSession)
 	if err != nil {
 		log.WithFields(log.Fields{
 			"dev_eui": ctx.DeviceSession.DevEUI,
@@ -615,7 +862,7 @@ func requestADRChange(ctx *dataContext) error {
 		}
 	}
 
-	blocks, err := adr.HandleADR(ctx.DeviceSession, linkADRReq)
+	blocks, err := adr.HandleADR(ctx.ServiceProfile, ctx.DeviceProfile, ctx.DeviceSession, linkADRReq)
 	if err != nil {
 		log.WithError(err).WithFields(log.Fields{
 			"dev_eui": ctx.DeviceSession.DevEUI,
@@ -636,12 +883,31 @@ func getMACCommandsFromQueue(ctx *dataContext) error {
 	}
 
 	for i := range blocks {
+		// an internal block (e.g. enqueued through the api) replaces the
+		// block with the same CID set by the network-server, so that the
+		// frame does not contain the same mac-command twice
+		if !blocks[i].External && replaceMACCommandBlock(ctx.MACCommands, blocks[i]) {
+			continue
+		}
+
 		ctx.MACCommands = append(ctx.MACCommands, blocks[i])
 	}
 
 	return nil
 }
 
+// replaceMACCommandBlock replaces the block with the same CID as the given
+// block. It returns false when there is no such block.
+func replaceMACCommandBlock(blocks []storage.MACCommandBlock, block storage.MACCommandBlock) bool {
+	for i := range blocks {
+		if blocks[i].CID == block.CID {
+			blocks[i] = block
+			return true
+		}
+	}
+	return false
+}
+
 func stopOnNothingToSend(ctx *dataContext) error {
 	if ctx.FPort == 0 && len(ctx.MACCommands) == 0 && !ctx.ACK && !ctx.MustSend {
 		// ErrAbort will not be handled as a real error
@@ -651,6 +917,46 @@ func stopOnNothingToSend(ctx *dataContext) error {
 	return nil
 }
 
+// checkDownlinkRateLimit enforces the downlink rate of the service-profile
+// for the frames which have not been accounted for when the device-queue
+// item was enqueued: retransmissions of a device-queue item and frames
+// containing only mac-commands. Frames which must be sent (e.g. to
+// acknowledge an uplink or to answer an ADRACKReq) are never rate-limited.
+func checkDownlinkRateLimit(ctx *dataContext) error {
+	if ctx.ACK || ctx.MustSend {
+		return nil
+	}
+
+	if ctx.DeviceQueueItem != nil && ctx.DeviceQueueItem.RetryCount == 0 {
+		return nil
+	}
+
+	ok, err := storage.TakeDownlinkRateLimitToken(config.C.Redis.Pool, ctx.DeviceSession.DevEUI, ctx.ServiceProfile.DLRate, ctx.ServiceProfile.DLBucketSize)
+	if err != nil {
+		return errors.Wrap(err, "take downlink rate-limit token error")
+	}
+	if ok {
+		ctx.DownlinkRateLimitToken = true
+		return nil
+	}
+
+	switch ctx.ServiceProfile.DLRatePolicy {
+	case storage.Drop:
+		log.WithFields(log.Fields{
+			"dev_eui": ctx.DeviceSession.DevEUI,
+			"dl_rate": ctx.ServiceProfile.DLRate,
+		}).Warning("downlink rate exceeded, dropping downlink frame")
+		return ErrAbort
+	default:
+		log.WithFields(log.Fields{
+			"dev_eui": ctx.DeviceSession.DevEUI,
+			"dl_rate": ctx.ServiceProfile.DLRate,
+		}).Info("downlink rate exceeded, sending downlink frame")
+	}
+
+	return nil
+}
+
 func setPHYPayload(ctx *dataContext) error {
 	if err := ctx.Validate(); err != nil {
 		return errors.Wrap(err, "validation error")
@@ -763,6 +1069,52 @@ func decryptMACCommands(ctx *dataContext) error {
 	return nil
 }
 
+// checkDutyCycle validates that the downlink fits within the remaining
+// duty-cycle budget of the gateway. When it does not, the first fallback
+// option that fits (e.g. an other gateway or RX2) is used. When none of the
+// options fit, the downlink is not sent and the device-queue item is left
+// in the queue, so that a Class-B or Class-C downlink is deferred to the
+// next scheduler run.
+func checkDutyCycle(ctx *dataContext) error {
+	phyB, err := ctx.PHYPayload.MarshalBinary()
+	if err != nil {
+		return errors.Wrap(err, "marshal binary error")
+	}
+
+	var txInfos []gw.TXInfo
+	for _, txInfo := range append([]gw.TXInfo{ctx.TXInfo}, ctx.FallbackTXInfo...) {
+		ok, err := gateway.CheckDutyCycle(txInfo, len(phyB))
+		if err != nil {
+			return errors.Wrap(err, "check duty-cycle error")
+		}
+
+		if !ok {
+			log.WithFields(log.Fields{
+				"dev_eui":   ctx.DeviceSession.DevEUI,
+				"mac":       txInfo.MAC,
+				"frequency": txInfo.Frequency,
+			}).Info("gateway duty-cycle budget exhausted, skipping tx-info")
+			continue
+		}
+
+		txInfos = append(txInfos, txInfo)
+	}
+
+	if len(txInfos) == 0 {
+		if ctx.RXPacket == nil {
+			log.WithField("dev_eui", ctx.DeviceSession.DevEUI).Warning("gateway duty-cycle budget exhausted, deferring downlink")
+		} else {
+			log.WithField("dev_eui", ctx.DeviceSession.DevEUI).Warning("gateway duty-cycle budget exhausted, dropping downlink")
+		}
+		return ErrAbort
+	}
+
+	ctx.TXInfo = txInfos[0]
+	ctx.FallbackTXInfo = txInfos[1:]
+
+	return nil
+}
+
 func setMIC(ctx *dataContext) error {
 	if err := ctx.PHYPayload.SetDownlinkDataMIC(ctx.DeviceSession.GetMACVersion(), ctx.DeviceSession.FCntUp-1, ctx.DeviceSession.SNwkSIntKey); err != nil {
 		return errors.Wrap(err, "set MIC error")
@@ -781,9 +1133,77 @@ func sendDataDown(ctx *dataContext) error {
 		return errors.Wrap(err, "send tx packet to gateway error")
 	}
 
+	if err := storage.IncrGatewayTXLoad(config.C.Redis.Pool, ctx.TXInfo.MAC); err != nil {
+		log.WithError(err).WithField("mac", ctx.TXInfo.MAC).Error("increment gateway tx load error")
+	}
+
+	phyB, err := ctx.PHYPayload.MarshalBinary()
+	if err != nil {
+		return errors.Wrap(err, "marshal binary error")
+	}
+
+	if err := gateway.RecordAirtime(ctx.TXInfo, len(phyB)); err != nil {
+		log.WithError(err).WithField("mac", ctx.TXInfo.MAC).Error("record gateway airtime error")
+	}
+
 	// set last downlink tx timestamp
 	ctx.DeviceSession.LastDownlinkTX = time.Now()
 
+	// the frame has been sent, the rate-limit token has been used
+	ctx.DownlinkRateLimitToken = false
+
+	return nil
+}
+
+// returnDownlinkRateLimitToken gives back the downlink rate-limit token
+// taken for the frame, in case the frame was not sent.
+func returnDownlinkRateLimitToken(ctx *dataContext) {
+	if !ctx.DownlinkRateLimitToken {
+		return
+	}
+
+	if err := storage.ReturnDownlinkRateLimitToken(config.C.Redis.Pool, ctx.DeviceSession.DevEUI, ctx.ServiceProfile.DLRate, ctx.ServiceProfile.DLBucketSize); err != nil {
+		log.WithError(err).WithField("dev_eui", ctx.DeviceSession.DevEUI).Error("return downlink rate-limit token error")
+	}
+	ctx.DownlinkRateLimitToken = false
+}
+
+// saveDownlinkFrames stores the frame and its fallback options, so that
+// the tx acknowledgement of the gateway can be correlated by gateway MAC and
+// token. This must happen before sending, as the acknowledgement might be
+// received before the save would have completed.
+func saveDownlinkFrames(ctx *dataContext) error {
+	phyB, err := ctx.PHYPayload.MarshalBinary()
+	if err != nil {
+		return errors.Wrap(err, "marshal binary error")
+	}
+
+	macPL, ok := ctx.PHYPayload.MACPayload.(*lorawan.MACPayload)
+	if !ok {
+		return fmt.Errorf("expected *lorawan.MACPayload, got: %T", ctx.PHYPayload.MACPayload)
+	}
+
+	frames := storage.DownlinkFrames{
+		Token:            ctx.Token,
+		DevEUI:           ctx.DeviceSession.DevEUI,
+		RoutingProfileID: ctx.DeviceSession.RoutingProfileID,
+		FCnt:             macPL.FHDR.FCnt,
+		FPort:            ctx.FPort,
+		Confirmed:        ctx.Confirmed,
+	}
+
+	for _, txInfo := range append([]gw.TXInfo{ctx.TXInfo}, ctx.FallbackTXInfo...) {
+		frames.TXPackets = append(frames.TXPackets, gw.TXPacketBytes{
+			Token:      ctx.Token,
+			TXInfo:     txInfo,
+			PHYPayload: phyB,
+		})
+	}
+
+	if err := storage.SaveDownlinkFrames(config.C.Redis.Pool, frames); err != nil {
+		return errors.Wrap(err, "save downlink frames error")
+	}
+
 	return nil
 }
 
@@ -813,9 +1233,13 @@ func getServiceProfile(ctx *dataContext) error {
 }
 
 func checkLastDownlinkTimestamp(ctx *dataContext) error {
+	if !ctx.DeviceProfile.SupportsClassC {
+		return nil
+	}
+
 	// in case of Class-C validate that between now and the last downlink
 	// tx timestamp is at least the class-c lock duration
-	if ctx.DeviceProfile.SupportsClassC && time.Now().Sub(ctx.DeviceSession.LastDownlinkTX) < config.ClassCDownlinkLockDuration {
+	if time.Now().Sub(ctx.DeviceSession.LastDownlinkTX) < config.ClassCDownlinkLockDuration {
 		log.WithFields(log.Fields{
 			"time":                           time.Now(),
 			"last_downlink_tx_time":          ctx.DeviceSession.LastDownlinkTX,
@@ -824,9 +1248,37 @@ func checkLastDownlinkTimestamp(ctx *dataContext) error {
 		return ErrAbort
 	}
 
+	// the class-c lock set after a multicast downlink
+	locked, err := storage.GetClassCDownlinkLock(config.C.Redis.Pool, ctx.DeviceSession.DevEUI)
+	if err != nil {
+		return errors.Wrap(err, "get class-c downlink lock error")
+	}
+	if locked {
+		log.WithField("dev_eui", ctx.DeviceSession.DevEUI).Debug("skip next downlink queue scheduling due to class-c downlink lock (multicast)")
+		return ErrAbort
+	}
+
 	return nil
 }
 
+// getRX1Frequency returns the RX1 frequency for the given uplink frequency.
+// In case the downlink frequency of the uplink channel has been modified
+// (DLChannelReq), this frequency is returned.
+func getRX1Frequency(ds storage.DeviceSession, uplinkFrequency int) (int, error) {
+	for i, f := range ds.DLChannelFrequencies {
+		c, err := config.C.NetworkServer.Band.Band.GetUplinkChannel(i)
+		if err != nil {
+			continue
+		}
+
+		if c.Frequency == uplinkFrequency {
+			return f, nil
+		}
+	}
+
+	return config.C.NetworkServer.Band.Band.GetRX1FrequencyForUplinkFrequency(uplinkFrequency)
+}
+
 func getDataDownTXInfoAndDR(ds storage.DeviceSession, lastTXInfo models.TXInfo, rxInfo models.RXInfo) (gw.TXInfo, int, error) {
 	var dr int
 	txInfo := gw.TXInfo{
@@ -844,18 +1296,23 @@ func getDataDownTXInfoAndDR(ds storage.DeviceSession, lastTXInfo models.TXInfo,
 			return txInfo, 0, errors.Wrap(err, "get data-rate index error")
 		}
 
-		// get rx1 dr
-		dr, err = config.C.NetworkServer.Band.Band.GetRX1DataRateIndex(uplinkDR, int(ds.RX1DROffset))
+		// get rx1 dr, taking the downlink dwell-time of the device into
+		// account
+		b, err := config.GetBandForDwellTime(ds.GetDownlinkDwellTime())
+		if err != nil {
+			return txInfo, 0, errors.Wrap(err, "get band for dwell-time error")
+		}
+		dr, err = b.GetRX1DataRateIndex(uplinkDR, int(ds.RX1DROffset))
 		if err != nil {
 			return txInfo, dr, errors.Wrap(err, "get rx1 data-rate index error")
 		}
-		txInfo.DataRate, err = config.C.NetworkServer.Band.Band.GetDataRate(dr)
+		txInfo.DataRate, err = b.GetDataRate(dr)
 		if err != nil {
 			return txInfo, dr, errors.Wrap(err, "get data-rate error")
 		}
 
 		// get rx1 frequency
-		txInfo.Frequency, err = config.C.NetworkServer.Band.Band.GetRX1FrequencyForUplinkFrequency(lastTXInfo.Frequency)
+		txInfo.Frequency, err = getRX1Frequency(ds, lastTXInfo.Frequency)
 		if err != nil {
 			return txInfo, dr, errors.Wrap(err, "get rx1 frequency for uplink frequency error")
 		}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/brocaar/loraserver/internal/common"
 	"github.com/brocaar/loraserver/internal/config"
+	"github.com/brocaar/loraserver/internal/models"
 	"github.com/brocaar/loraserver/internal/storage"
 	"github.com/brocaar/loraserver/internal/test"
 	"github.com/brocaar/lorawan"
@@ -71,6 +72,11 @@ func TestGetNextDeviceQueueItem(t *testing.T) {
 			}
 			for i := range items {
 				So(storage.CreateDeviceQueueItem(config.C.PostgreSQL.DB, &items[i]), ShouldBeNil)
+
+				// reload the item so that the timestamps match the stored
+				// representation
+				items[i], err = storage.GetDeviceQueueItem(config.C.PostgreSQL.DB, items[i].ID)
+				So(err, ShouldBeNil)
 			}
 
 			tests := []struct {
@@ -97,6 +103,7 @@ func TestGetNextDeviceQueueItem(t *testing.T) {
 					Name: "first queue item (unconfirmed)",
 					ExpecteddataContext: dataContext{
 						DeviceSession:        ctx.DeviceSession,
+						DeviceQueueItem:      &items[0],
 						RemainingPayloadSize: 242 - len(items[0].FRMPayload),
 						Confirmed:            false,
 						Data:                 items[0].FRMPayload,
@@ -125,6 +132,7 @@ func TestGetNextDeviceQueueItem(t *testing.T) {
 							NFCntDown:        11,
 							ConfFCnt:         11,
 						},
+						DeviceQueueItem:      &items[1],
 						RemainingPayloadSize: 242 - len(items[1].FRMPayload),
 						Confirmed:            true,
 						Data:                 items[1].FRMPayload,
@@ -149,6 +157,7 @@ func TestGetNextDeviceQueueItem(t *testing.T) {
 					}
 
 					So(getNextDeviceQueueItem(&ctx), ShouldBeNil)
+					So(updateDeviceQueueItem(&ctx), ShouldBeNil)
 					So(test.ExpecteddataContext, ShouldResemble, ctx)
 
 					if test.ExpectedNextDeviceQueueItem != nil {
@@ -302,6 +311,69 @@ func TestSetMACCommandsSet(t *testing.T) {
 					},
 				},
 			},
+			{
+				Name: "trigger ping-slot parameters from device-profile",
+				Context: dataContext{
+					RemainingPayloadSize: 200,
+					DeviceProfile: storage.DeviceProfile{
+						SupportsClassB:        true,
+						PingSlotParametersSet: true,
+						PingSlotDR:            4,
+						PingSlotFreq:          868500000,
+					},
+					DeviceSession: storage.DeviceSession{
+						PingSlotDR:            3,
+						PingSlotFrequency:     868100000,
+						EnabledUplinkChannels: []int{0, 1, 2},
+						RX2Frequency:          869525000,
+					},
+				},
+				ExpectedMACCommands: []storage.MACCommandBlock{
+					{
+						CID: lorawan.PingSlotChannelReq,
+						MACCommands: storage.MACCommands{
+							{
+								CID: lorawan.PingSlotChannelReq,
+								Payload: &lorawan.PingSlotChannelReqPayload{
+									Frequency: 868500000,
+									DR:        4,
+								},
+							},
+						},
+					},
+				},
+			},
+			{
+				Name: "trigger beacon frequency",
+				Context: dataContext{
+					RemainingPayloadSize: 200,
+					DeviceProfile: storage.DeviceProfile{
+						SupportsClassB: true,
+						PingSlotDR:     3,
+						PingSlotFreq:   868100000,
+						BeaconFreq:     869100000,
+					},
+					DeviceSession: storage.DeviceSession{
+						PingSlotDR:            3,
+						PingSlotFrequency:     868100000,
+						EnabledUplinkChannels: []int{0, 1, 2},
+						RX2Frequency:          869525000,
+					},
+				},
+				ExpectedMACCommands: []storage.MACCommandBlock{
+					{
+						CID: lorawan.BeaconFreqReq,
+						MACCommands: storage.MACCommands{
+							{
+								CID: lorawan.BeaconFreqReq,
+								Payload: &lorawan.BeaconFreqReqPayload{
+									Frequency: 869100000,
+								},
+							},
+						},
+					},
+				},
+			},
 			{
 				Name: "trigger channel-mask reconfiguration",
 				Context: dataContext{
@@ -493,6 +565,141 @@ func TestSetMACCommandsSet(t *testing.T) {
 					},
 				},
 			},
+			{
+				Name: "trigger duty cycle",
+				Context: dataContext{
+					RemainingPayloadSize: 200,
+					DeviceProfile: storage.DeviceProfile{
+						MaxDutyCycle: 10,
+					},
+					DeviceSession: storage.DeviceSession{
+						EnabledUplinkChannels: []int{0, 1, 2},
+						RX2Frequency:          869525000,
+						MaxDCycle:             2,
+					},
+				},
+				ExpectedMACCommands: []storage.MACCommandBlock{
+					{
+						CID: lorawan.DutyCycleReq,
+						MACCommands: []lorawan.MACCommand{
+							{
+								CID: lorawan.DutyCycleReq,
+								Payload: &lorawan.DutyCycleReqPayload{
+									MaxDCycle: 4,
+								},
+							},
+						},
+					},
+				},
+			},
+			{
+				BeforeFunc: func() error {
+					return storage.CreateMACCommandQueueItem(config.C.Redis.Pool, lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2}, storage.MACCommandBlock{
+						CID: lorawan.DutyCycleReq,
+						MACCommands: []lorawan.MACCommand{
+							{
+								CID: lorawan.DutyCycleReq,
+								Payload: &lorawan.DutyCycleReqPayload{
+									MaxDCycle: 4,
+								},
+							},
+						},
+					})
+				},
+				Name: "trigger duty cycle with forced duty cycle in queue",
+				Context: dataContext{
+					RemainingPayloadSize: 200,
+					DeviceProfile: storage.DeviceProfile{
+						MaxDutyCycle: 10,
+					},
+					DeviceSession: storage.DeviceSession{
+						DevEUI:                lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2},
+						EnabledUplinkChannels: []int{0, 1, 2},
+						RX2Frequency:          869525000,
+						MaxDCycle:             2,
+					},
+				},
+				ExpectedMACCommands: []storage.MACCommandBlock{
+					{
+						CID: lorawan.DutyCycleReq,
+						MACCommands: []lorawan.MACCommand{
+							{
+								CID: lorawan.DutyCycleReq,
+								Payload: &lorawan.DutyCycleReqPayload{
+									MaxDCycle: 4,
+								},
+							},
+						},
+					},
+				},
+			},
+			{
+				Name: "trigger adr param setup",
+				Context: dataContext{
+					RemainingPayloadSize: 200,
+					DeviceProfile: storage.DeviceProfile{
+						ADRAckLimitExp: 8,
+						ADRAckDelayExp: 6,
+					},
+					DeviceSession: storage.DeviceSession{
+						MACVersion:            "1.1.0",
+						EnabledUplinkChannels: []int{0, 1, 2},
+						RX2Frequency:          869525000,
+					},
+				},
+				ExpectedMACCommands: []storage.MACCommandBlock{
+					{
+						CID: lorawan.ADRParamSetupReq,
+						MACCommands: []lorawan.MACCommand{
+							{
+								CID: lorawan.ADRParamSetupReq,
+								Payload: &lorawan.ADRParamSetupReqPayload{
+									ADRParam: lorawan.ADRParam{
+										LimitExp: 8,
+										DelayExp: 6,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			{
+				BeforeFunc: func() error {
+					var err error
+					config.C.NetworkServer.Band.Name = band.AS_923
+					config.C.NetworkServer.Band.Band, err = band.GetConfig(band.AS_923, false, lorawan.DwellTimeNoLimit)
+					return err
+				},
+				Name: "trigger tx param setup",
+				Context: dataContext{
+					RemainingPayloadSize: 200,
+					DeviceProfile: storage.DeviceProfile{
+						MACVersion:           "1.0.2",
+						MaxEIRP:              16,
+						UplinkDwellTime400ms: true,
+					},
+					DeviceSession: storage.DeviceSession{
+						EnabledUplinkChannels: []int{0, 1},
+						RX2Frequency:          869525000,
+					},
+				},
+				ExpectedMACCommands: []storage.MACCommandBlock{
+					{
+						CID: lorawan.TXParamSetupReq,
+						MACCommands: []lorawan.MACCommand{
+							{
+								CID: lorawan.TXParamSetupReq,
+								Payload: &lorawan.TXParamSetupReqPayload{
+									UplinkDwellTime:   lorawan.DwellTime400ms,
+									DownlinkDwelltime: lorawan.DwellTimeNoLimit,
+									MaxEIRP:           5,
+								},
+							},
+						},
+					},
+				},
+			},
 			{
 				// This tests that in case a LinkADRReq -and- a NewChannelReq
 				// is requested, the LinkADRReq is dropped.
@@ -610,6 +817,93 @@ func TestSetMACCommandsSet(t *testing.T) {
 					},
 				},
 			},
+			{
+				BeforeFunc: func() error {
+					test.MustFlushRedis(config.C.Redis.Pool)
+
+					for _, block := range []storage.MACCommandBlock{
+						{
+							CID: lorawan.RXParamSetupReq,
+							MACCommands: storage.MACCommands{
+								{
+									CID: lorawan.RXParamSetupReq,
+									Payload: &lorawan.RXParamSetupReqPayload{
+										Frequency: 869525000,
+									},
+								},
+							},
+						},
+						{
+							CID: lorawan.DLChannelReq,
+							MACCommands: storage.MACCommands{
+								{
+									CID: lorawan.DLChannelReq,
+									Payload: &lorawan.DLChannelReqPayload{
+										ChIndex: 1,
+										Freq:    868100000,
+									},
+								},
+							},
+						},
+						{
+							CID: lorawan.NewChannelReq,
+							MACCommands: storage.MACCommands{
+								{
+									CID: lorawan.NewChannelReq,
+									Payload: &lorawan.NewChannelReqPayload{
+										ChIndex: 3,
+										Freq:    867100000,
+										MaxDR:   5,
+									},
+								},
+							},
+						},
+					} {
+						if err := storage.CreateMACCommandQueueItem(config.C.Redis.Pool, lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}, block); err != nil {
+							return err
+						}
+					}
+					return nil
+				},
+				Name: "mac-commands exceeding the max FOpts size are truncated",
+				Context: dataContext{
+					RemainingPayloadSize: 200,
+					FPort:                10,
+					DeviceSession: storage.DeviceSession{
+						DevEUI:                lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+						EnabledUplinkChannels: []int{0, 1, 2},
+						TXPowerIndex:          2,
+						DR:                    5,
+						NbTrans:               2,
+						RX2Frequency:          869525000,
+					},
+				},
+				ExpectedMACCommands: []storage.MACCommandBlock{
+					{
+						CID: lorawan.RXParamSetupReq,
+						MACCommands: storage.MACCommands{
+							{
+								CID: lorawan.RXParamSetupReq,
+								Payload: &lorawan.RXParamSetupReqPayload{
+									Frequency: 869525000,
+								},
+							},
+						},
+					},
+					{
+						CID: lorawan.DLChannelReq,
+						MACCommands: storage.MACCommands{
+							{
+								CID: lorawan.DLChannelReq,
+								Payload: &lorawan.DLChannelReqPayload{
+									ChIndex: 1,
+									Freq:    868100000,
+								},
+							},
+						},
+					},
+				},
+			},
 		}
 
 		for i, test := range tests {
@@ -686,3 +980,284 @@ func TestFilterIncompatibleMACCommands(t *testing.T) {
 	})
 
 }
+
+func TestGetMACCommandsFromQueue(t *testing.T) {
+	conf := test.GetConfig()
+	config.C.Redis.Pool = common.NewRedisPool(conf.RedisURL)
+
+	Convey("Given a clean Redis database", t, func() {
+		test.MustFlushRedis(config.C.Redis.Pool)
+
+		devEUI := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+		nsDutyCycleReq := storage.MACCommandBlock{
+			CID: lorawan.DutyCycleReq,
+			MACCommands: []lorawan.MACCommand{
+				{
+					CID:     lorawan.DutyCycleReq,
+					Payload: &lorawan.DutyCycleReqPayload{MaxDCycle: 2},
+				},
+			},
+		}
+		nsDevStatusReq := storage.MACCommandBlock{
+			CID: lorawan.DevStatusReq,
+			MACCommands: []lorawan.MACCommand{
+				{CID: lorawan.DevStatusReq},
+			},
+		}
+		queuedDutyCycleReq := storage.MACCommandBlock{
+			CID: lorawan.DutyCycleReq,
+			MACCommands: []lorawan.MACCommand{
+				{
+					CID:     lorawan.DutyCycleReq,
+					Payload: &lorawan.DutyCycleReqPayload{MaxDCycle: 4},
+				},
+			},
+		}
+		queuedDevStatusReq := storage.MACCommandBlock{
+			CID:      lorawan.DevStatusReq,
+			External: true,
+			MACCommands: []lorawan.MACCommand{
+				{CID: lorawan.DevStatusReq},
+			},
+		}
+		queuedRXTimingSetupReq := storage.MACCommandBlock{
+			CID: lorawan.RXTimingSetupReq,
+			MACCommands: []lorawan.MACCommand{
+				{
+					CID:     lorawan.RXTimingSetupReq,
+					Payload: &lorawan.RXTimingSetupReqPayload{Delay: 3},
+				},
+			},
+		}
+
+		for _, block := range []storage.MACCommandBlock{queuedDutyCycleReq, queuedDevStatusReq, queuedRXTimingSetupReq} {
+			So(storage.CreateMACCommandQueueItem(config.C.Redis.Pool, devEUI, block), ShouldBeNil)
+		}
+
+		Convey("When adding the queued mac-commands to the mac-commands set by the network-server", func() {
+			ctx := dataContext{
+				DeviceSession: storage.DeviceSession{DevEUI: devEUI},
+				MACCommands:   []storage.MACCommandBlock{nsDutyCycleReq, nsDevStatusReq},
+			}
+			So(getMACCommandsFromQueue(&ctx), ShouldBeNil)
+
+			Convey("Then an internal block replaces the block with the same CID and other blocks are appended", func() {
+				So(ctx.MACCommands, ShouldResemble, []storage.MACCommandBlock{
+					queuedDutyCycleReq,
+					nsDevStatusReq,
+					queuedDevStatusReq,
+					queuedRXTimingSetupReq,
+				})
+			})
+		})
+	})
+}
+
+func TestSetMACCommandsPending(t *testing.T) {
+	conf := test.GetConfig()
+	config.C.Redis.Pool = common.NewRedisPool(conf.RedisURL)
+
+	Convey("Given a clean Redis database", t, func() {
+		test.MustFlushRedis(config.C.Redis.Pool)
+
+		devEUI := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+		queuedInternal := storage.MACCommandBlock{
+			CID: lorawan.DutyCycleReq,
+			MACCommands: []lorawan.MACCommand{
+				{
+					CID:     lorawan.DutyCycleReq,
+					Payload: &lorawan.DutyCycleReqPayload{MaxDCycle: 4},
+				},
+			},
+		}
+		queuedExternal := storage.MACCommandBlock{
+			CID:      lorawan.DevStatusReq,
+			External: true,
+			MACCommands: []lorawan.MACCommand{
+				{CID: lorawan.DevStatusReq},
+			},
+		}
+		queuedNotSent := storage.MACCommandBlock{
+			CID: lorawan.RXTimingSetupReq,
+			MACCommands: []lorawan.MACCommand{
+				{
+					CID:     lorawan.RXTimingSetupReq,
+					Payload: &lorawan.RXTimingSetupReqPayload{Delay: 3},
+				},
+			},
+		}
+		notQueued := storage.MACCommandBlock{
+			CID: lorawan.LinkCheckAns,
+			MACCommands: []lorawan.MACCommand{
+				{
+					CID:     lorawan.LinkCheckAns,
+					Payload: &lorawan.LinkCheckAnsPayload{Margin: 10, GwCnt: 1},
+				},
+			},
+		}
+
+		for _, block := range []storage.MACCommandBlock{queuedInternal, queuedExternal, queuedNotSent} {
+			So(storage.CreateMACCommandQueueItem(config.C.Redis.Pool, devEUI, block), ShouldBeNil)
+		}
+
+		Convey("When setting the mac-commands of the frame pending", func() {
+			ctx := dataContext{
+				DeviceSession: storage.DeviceSession{DevEUI: devEUI},
+				MACCommands:   []storage.MACCommandBlock{queuedInternal, queuedExternal, notQueued},
+			}
+			So(setMACCommandsPending(&ctx), ShouldBeNil)
+
+			Convey("Then the mac-commands are pending", func() {
+				for _, block := range ctx.MACCommands {
+					pending, err := storage.GetPendingMACCommand(config.C.Redis.Pool, devEUI, block.CID)
+					So(err, ShouldBeNil)
+					So(pending, ShouldNotBeNil)
+				}
+			})
+
+			Convey("Then the sent internal and external mac-commands are removed from the queue", func() {
+				queue, err := storage.GetMACCommandQueueItems(config.C.Redis.Pool, devEUI)
+				So(err, ShouldBeNil)
+				So(queue, ShouldResemble, []storage.MACCommandBlock{queuedNotSent})
+			})
+		})
+	})
+}
+
+func TestCheckDownlinkRateLimit(t *testing.T) {
+	conf := test.GetConfig()
+	config.C.Redis.Pool = common.NewRedisPool(conf.RedisURL)
+
+	Convey("Given a clean Redis database and an exhausted downlink rate-limit", t, func() {
+		test.MustFlushRedis(config.C.Redis.Pool)
+
+		devEUI := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+		sp := storage.ServiceProfile{
+			DLRate:       1,
+			DLBucketSize: 1,
+			DLRatePolicy: storage.Drop,
+		}
+
+		ok, err := storage.TakeDownlinkRateLimitToken(config.C.Redis.Pool, devEUI, sp.DLRate, sp.DLBucketSize)
+		So(err, ShouldBeNil)
+		So(ok, ShouldBeTrue)
+
+		tests := []struct {
+			Name          string
+			Context       dataContext
+			ExpectedError error
+		}{
+			{
+				Name: "ACK is sent",
+				Context: dataContext{
+					ACK: true,
+				},
+			},
+			{
+				Name: "must send is sent",
+				Context: dataContext{
+					MustSend: true,
+				},
+			},
+			{
+				Name: "first transmission of device-queue item is sent",
+				Context: dataContext{
+					FPort:           10,
+					DeviceQueueItem: &storage.DeviceQueueItem{},
+				},
+			},
+			{
+				Name: "retransmission of device-queue item is dropped",
+				Context: dataContext{
+					FPort:           10,
+					DeviceQueueItem: &storage.DeviceQueueItem{RetryCount: 1},
+				},
+				ExpectedError: ErrAbort,
+			},
+			{
+				Name: "mac-commands only is dropped",
+				Context: dataContext{
+					MACCommands: []storage.MACCommandBlock{
+						{CID: lorawan.DevStatusReq},
+					},
+				},
+				ExpectedError: ErrAbort,
+			},
+		}
+
+		for i, test := range tests {
+			Convey(fmt.Sprintf("Testing: %s [%d]", test.Name, i), func() {
+				ctx := test.Context
+				ctx.ServiceProfile = sp
+				ctx.DeviceSession = storage.DeviceSession{DevEUI: devEUI}
+
+				So(checkDownlinkRateLimit(&ctx), ShouldEqual, test.ExpectedError)
+			})
+		}
+
+		Convey("When a token has been taken for a frame which is not sent", func() {
+			So(storage.ReturnDownlinkRateLimitToken(config.C.Redis.Pool, devEUI, sp.DLRate, sp.DLBucketSize), ShouldBeNil)
+
+			ctx := dataContext{
+				ServiceProfile: sp,
+				DeviceSession:  storage.DeviceSession{DevEUI: devEUI},
+			}
+			So(checkDownlinkRateLimit(&ctx), ShouldBeNil)
+			So(ctx.DownlinkRateLimitToken, ShouldBeTrue)
+
+			returnDownlinkRateLimitToken(&ctx)
+
+			Convey("Then the token can be taken again", func() {
+				So(ctx.DownlinkRateLimitToken, ShouldBeFalse)
+
+				ok, err := storage.TakeDownlinkRateLimitToken(config.C.Redis.Pool, devEUI, sp.DLRate, sp.DLBucketSize)
+				So(err, ShouldBeNil)
+				So(ok, ShouldBeTrue)
+			})
+		})
+	})
+}
+
+func TestGetDataDownTXInfoAndDR(t *testing.T) {
+	Convey("Given the AS923 band without enforced dwell-time", t, func() {
+		config.C.NetworkServer.Band.Name = band.AS_923
+		config.C.NetworkServer.Band.DwellTime400ms = false
+		config.C.NetworkServer.Band.Band, _ = band.GetConfig(config.C.NetworkServer.Band.Name, false, lorawan.DwellTimeNoLimit)
+		config.C.NetworkServer.NetworkSettings.DownlinkTXPower = -1
+
+		lastTXInfo := models.TXInfo{
+			Frequency: 923200000,
+			DataRate:  band.DataRate{Modulation: band.LoRaModulation, SpreadFactor: 12, Bandwidth: 125},
+		}
+
+		tests := []struct {
+			Name          string
+			DeviceSession storage.DeviceSession
+			ExpectedDR    int
+		}{
+			{
+				Name: "no downlink dwell-time",
+				DeviceSession: storage.DeviceSession{
+					RXWindow: storage.RX1,
+				},
+				ExpectedDR: 0,
+			},
+			{
+				Name: "downlink dwell-time 400ms",
+				DeviceSession: storage.DeviceSession{
+					RXWindow:               storage.RX1,
+					DownlinkDwellTime400ms: true,
+				},
+				ExpectedDR: 2,
+			},
+		}
+
+		for i, test := range tests {
+			Convey(fmt.Sprintf("Testing: %s [%d]", test.Name, i), func() {
+				_, dr, err := getDataDownTXInfoAndDR(test.DeviceSession, lastTXInfo, models.RXInfo{})
+				So(err, ShouldBeNil)
+				So(dr, ShouldEqual, test.ExpectedDR)
+			})
+		}
+	})
+}
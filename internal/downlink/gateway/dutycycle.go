@@ -0,0 +1,152 @@
+package gateway
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/loraserver/internal/airtime"
+	"github.com/brocaar/loraserver/internal/config"
+	"github.com/brocaar/loraserver/internal/storage"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/band"
+)
+
+// SubBand defines a frequency range (max. frequency exclusive) with its
+// max. duty-cycle (in percent).
+type SubBand struct {
+	MinFrequency int
+	MaxFrequency int
+	MaxDutyCycle float64
+}
+
+// SubBandUsage defines the downlink airtime usage of a gateway within
+// a sub-band.
+type SubBandUsage struct {
+	SubBand
+	Airtime time.Duration
+	Budget  time.Duration
+}
+
+// eu868SubBands contains the ETSI EN 300 220 sub-bands as used by the
+// EU_863_870 band.
+var eu868SubBands = []SubBand{
+	{MinFrequency: 863000000, MaxFrequency: 865000000, MaxDutyCycle: 0.1},
+	{MinFrequency: 865000000, MaxFrequency: 868000000, MaxDutyCycle: 1},
+	{MinFrequency: 868000000, MaxFrequency: 868600000, MaxDutyCycle: 1},
+	{MinFrequency: 868700000, MaxFrequency: 869200000, MaxDutyCycle: 0.1},
+	{MinFrequency: 869400000, MaxFrequency: 869650000, MaxDutyCycle: 10},
+	{MinFrequency: 869700000, MaxFrequency: 870000000, MaxDutyCycle: 1},
+}
+
+// GetSubBands returns the configured duty-cycle sub-bands. When these are
+// not configured, the default sub-bands of the band are returned.
+func GetSubBands() []SubBand {
+	var out []SubBand
+	for _, sb := range config.C.NetworkServer.Gateway.DutyCycle.SubBands {
+		out = append(out, SubBand{
+			MinFrequency: sb.MinFrequency,
+			MaxFrequency: sb.MaxFrequency,
+			MaxDutyCycle: sb.MaxDutyCycle,
+		})
+	}
+
+	if len(out) == 0 && config.C.NetworkServer.Band.Name == band.EU_863_870 {
+		out = eu868SubBands
+	}
+
+	return out
+}
+
+// Budget returns the max. airtime within the given window.
+func (sb SubBand) Budget(window time.Duration) time.Duration {
+	return time.Duration(float64(window) * sb.MaxDutyCycle / 100)
+}
+
+func getSubBandForFrequency(frequency int) (SubBand, bool) {
+	for _, sb := range GetSubBands() {
+		if frequency >= sb.MinFrequency && frequency < sb.MaxFrequency {
+			return sb, true
+		}
+	}
+	return SubBand{}, false
+}
+
+// CheckDutyCycle returns true when the transmission of a PHYPayload of the
+// given size, using the given TXInfo, fits within the remaining duty-cycle
+// budget of the gateway. Frequencies outside the sub-bands are not limited.
+func CheckDutyCycle(txInfo gw.TXInfo, size int) (bool, error) {
+	if !config.C.NetworkServer.Gateway.DutyCycle.Enabled {
+		return true, nil
+	}
+
+	sb, ok := getSubBandForFrequency(txInfo.Frequency)
+	if !ok {
+		return true, nil
+	}
+
+	d, err := airtime.CalculateDownlinkAirtime(size, txInfo)
+	if err != nil {
+		return false, errors.Wrap(err, "calculate airtime error")
+	}
+
+	window := config.C.NetworkServer.Gateway.DutyCycle.Window
+	used, err := storage.GetGatewayAirtime(config.C.Redis.Pool, txInfo.MAC, sb.MinFrequency, window)
+	if err != nil {
+		return false, errors.Wrap(err, "get gateway airtime error")
+	}
+
+	return used+d <= sb.Budget(window), nil
+}
+
+// RecordAirtime adds the airtime of the transmission of a PHYPayload of the
+// given size, using the given TXInfo, to the duty-cycle usage of the gateway.
+func RecordAirtime(txInfo gw.TXInfo, size int) error {
+	if !config.C.NetworkServer.Gateway.DutyCycle.Enabled {
+		return nil
+	}
+
+	sb, ok := getSubBandForFrequency(txInfo.Frequency)
+	if !ok {
+		return nil
+	}
+
+	d, err := airtime.CalculateDownlinkAirtime(size, txInfo)
+	if err != nil {
+		return errors.Wrap(err, "calculate airtime error")
+	}
+
+	if err := storage.IncrGatewayAirtime(config.C.Redis.Pool, txInfo.MAC, sb.MinFrequency, d, config.C.NetworkServer.Gateway.DutyCycle.Window); err != nil {
+		return errors.Wrap(err, "increment gateway airtime error")
+	}
+
+	return nil
+}
+
+// GetDutyCycleUsage returns the downlink airtime usage of the given gateway
+// for each sub-band. Nothing is returned when duty-cycle accounting is
+// disabled.
+func GetDutyCycleUsage(mac lorawan.EUI64) ([]SubBandUsage, error) {
+	if !config.C.NetworkServer.Gateway.DutyCycle.Enabled {
+		return nil, nil
+	}
+
+	window := config.C.NetworkServer.Gateway.DutyCycle.Window
+
+	var out []SubBandUsage
+	for _, sb := range GetSubBands() {
+		used, err := storage.GetGatewayAirtime(config.C.Redis.Pool, mac, sb.MinFrequency, window)
+		if err != nil {
+			return nil, errors.Wrap(err, "get gateway airtime error")
+		}
+
+		out = append(out, SubBandUsage{
+			SubBand: sb,
+			Airtime: used,
+			Budget:  sb.Budget(window),
+		})
+	}
+
+	return out, nil
+}
@@ -0,0 +1,85 @@
+package gateway
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/loraserver/internal/common"
+	"github.com/brocaar/loraserver/internal/config"
+	"github.com/brocaar/loraserver/internal/storage"
+	"github.com/brocaar/loraserver/internal/test"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/band"
+)
+
+func TestDutyCycle(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database and duty-cycle accounting enabled for EU868", t, func() {
+		config.C.Redis.Pool = common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(config.C.Redis.Pool)
+
+		config.C.NetworkServer.Band.Name = band.EU_863_870
+		config.C.NetworkServer.Gateway.DutyCycle.Enabled = true
+		config.C.NetworkServer.Gateway.DutyCycle.Window = time.Minute
+
+		Reset(func() {
+			config.C.NetworkServer.Gateway.DutyCycle.Enabled = false
+		})
+
+		txInfo := gw.TXInfo{
+			MAC:       lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			Frequency: 869525000,
+			CodeRate:  "4/5",
+			DataRate:  band.DataRate{Modulation: band.LoRaModulation, SpreadFactor: 12, Bandwidth: 125},
+		}
+
+		Convey("Then the downlink fits within the budget", func() {
+			ok, err := CheckDutyCycle(txInfo, 13)
+			So(err, ShouldBeNil)
+			So(ok, ShouldBeTrue)
+		})
+
+		Convey("When the sub-band budget (10% of a minute) has been used", func() {
+			for i := 0; i < 5; i++ {
+				So(RecordAirtime(txInfo, 13), ShouldBeNil)
+			}
+
+			Convey("Then the downlink does not fit within the budget", func() {
+				ok, err := CheckDutyCycle(txInfo, 13)
+				So(err, ShouldBeNil)
+				So(ok, ShouldBeFalse)
+			})
+
+			Convey("Then an other gateway is not affected", func() {
+				txInfo.MAC = lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1}
+				ok, err := CheckDutyCycle(txInfo, 13)
+				So(err, ShouldBeNil)
+				So(ok, ShouldBeTrue)
+			})
+
+			Convey("Then a frequency outside the sub-bands is not limited", func() {
+				txInfo.Frequency = 869300000
+				ok, err := CheckDutyCycle(txInfo, 13)
+				So(err, ShouldBeNil)
+				So(ok, ShouldBeTrue)
+			})
+
+			Convey("Then GetDutyCycleUsage returns the usage of the sub-band", func() {
+				usage, err := GetDutyCycleUsage(txInfo.MAC)
+				So(err, ShouldBeNil)
+				So(usage, ShouldHaveLength, len(eu868SubBands))
+				So(usage[4].SubBand, ShouldResemble, eu868SubBands[4])
+				So(usage[4].Airtime, ShouldEqual, 5*1155072*time.Microsecond)
+				So(usage[4].Budget, ShouldEqual, 6*time.Second)
+
+				d, err := storage.GetGatewayAirtime(config.C.Redis.Pool, txInfo.MAC, 868000000, time.Minute)
+				So(err, ShouldBeNil)
+				So(d, ShouldEqual, 0)
+			})
+		})
+	})
+}
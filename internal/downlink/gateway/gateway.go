@@ -0,0 +1,267 @@
+// Package gateway implements the ranking of the gateways that can be used
+// for a downlink transmission to a device and the accounting of their
+// downlink duty-cycle.
+package gateway
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/loraserver/internal/config"
+	"github.com/brocaar/loraserver/internal/models"
+	"github.com/brocaar/loraserver/internal/storage"
+	"github.com/brocaar/lorawan"
+)
+
+// Available tie-breakers.
+const (
+	TieBreakerSNR    = "snr"
+	TieBreakerRSSI   = "rssi"
+	TieBreakerRecent = "recent"
+	TieBreakerMAC    = "mac"
+)
+
+// Candidate defines a gateway that received an uplink of the device.
+type Candidate struct {
+	MAC     lorawan.EUI64
+	RSSI    int
+	LoRaSNR float64
+	Time    time.Time
+}
+
+// LinkBudget returns the estimated signal strength (dBm) of the device at
+// the gateway. When the SNR is negative, the signal is below the noise floor
+// and the RSSI mostly contains noise, which is corrected by the SNR.
+func (c Candidate) LinkBudget() float64 {
+	if c.LoRaSNR < 0 {
+		return float64(c.RSSI) + c.LoRaSNR
+	}
+	return float64(c.RSSI)
+}
+
+type rankingCandidate struct {
+	Candidate
+	index   int
+	healthy bool
+	txLoad  int
+}
+
+// RankCandidates returns the indices of the given candidates, ordered from
+// the most to the least suitable gateway for a downlink transmission.
+func RankCandidates(candidates []Candidate) ([]int, error) {
+	tieBreaker := config.C.NetworkServer.Gateway.DownlinkGatewaySelection.TieBreaker
+	if tieBreaker == "" {
+		tieBreaker = TieBreakerSNR
+	}
+
+	switch tieBreaker {
+	case TieBreakerSNR, TieBreakerRSSI, TieBreakerRecent, TieBreakerMAC:
+	default:
+		return nil, fmt.Errorf("unknown downlink gateway tie-breaker: %s", tieBreaker)
+	}
+
+	var macs []lorawan.EUI64
+	for _, c := range candidates {
+		macs = append(macs, c.MAC)
+	}
+
+	txLoads, err := storage.GetGatewayTXLoads(config.C.Redis.Pool, macs)
+	if err != nil {
+		return nil, errors.Wrap(err, "get gateway tx loads error")
+	}
+
+	healthTimeout := config.C.NetworkServer.Gateway.DownlinkGatewaySelection.HealthTimeout
+
+	var lastSeen map[lorawan.EUI64]time.Time
+	if healthTimeout != 0 {
+		lastSeen, err = storage.GetGatewayLastSeenAtForMACs(config.C.PostgreSQL.DB, macs)
+		if err != nil {
+			return nil, errors.Wrap(err, "get gateway last-seen timestamps error")
+		}
+	}
+
+	var rcs []rankingCandidate
+	for i, c := range candidates {
+		rc := rankingCandidate{
+			Candidate: c,
+			index:     i,
+			txLoad:    txLoads[c.MAC],
+			healthy:   true,
+		}
+
+		if healthTimeout != 0 {
+			ts, ok := lastSeen[c.MAC]
+			rc.healthy = ok && time.Since(ts) < healthTimeout
+		}
+
+		rcs = append(rcs, rc)
+	}
+
+	return rank(rcs, tieBreaker, config.C.NetworkServer.Gateway.DownlinkGatewaySelection.LinkBudgetMargin), nil
+}
+
+// RankUplinkGatewayHistory returns the gateway MACs of the given uplink
+// gateway history, ordered from the most to the least suitable gateway for
+// a downlink transmission.
+func RankUplinkGatewayHistory(history map[lorawan.EUI64]storage.UplinkGatewayHistory) ([]lorawan.EUI64, error) {
+	if len(history) == 0 {
+		return nil, errors.New("uplink gateway-history is empty")
+	}
+
+	var candidates []Candidate
+	for mac, h := range history {
+		candidates = append(candidates, Candidate{
+			MAC:     mac,
+			RSSI:    h.RSSI,
+			LoRaSNR: h.LoRaSNR,
+			Time:    h.Time,
+		})
+	}
+
+	// as the map is unordered, the candidates are sorted by MAC so that
+	// the ranking is deterministic
+	sort.Slice(candidates, func(i, j int) bool {
+		return bytes.Compare(candidates[i].MAC[:], candidates[j].MAC[:]) < 0
+	})
+
+	indices, err := RankCandidates(candidates)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []lorawan.EUI64
+	for _, i := range indices {
+		out = append(out, candidates[i].MAC)
+	}
+
+	return out, nil
+}
+
+// RankRXInfoSet returns the given RXInfo set, ordered from the most to the
+// least suitable gateway for a downlink transmission.
+func RankRXInfoSet(rxInfoSet models.RXInfoSet) (models.RXInfoSet, error) {
+	var candidates []Candidate
+	for _, rxInfo := range rxInfoSet {
+		c := Candidate{
+			MAC:     rxInfo.MAC,
+			RSSI:    rxInfo.RSSI,
+			LoRaSNR: rxInfo.LoRaSNR,
+		}
+		if rxInfo.Time != nil {
+			c.Time = *rxInfo.Time
+		}
+		candidates = append(candidates, c)
+	}
+
+	indices, err := RankCandidates(candidates)
+	if err != nil {
+		return nil, err
+	}
+
+	var out models.RXInfoSet
+	for _, i := range indices {
+		out = append(out, rxInfoSet[i])
+	}
+
+	return out, nil
+}
+
+// rank returns the candidate indices, ordered by repeatedly selecting the
+// best of the remaining candidates.
+func rank(candidates []rankingCandidate, tieBreaker string, margin float64) []int {
+	remaining := make([]rankingCandidate, len(candidates))
+	copy(remaining, candidates)
+
+	var out []int
+	for len(remaining) > 0 {
+		i := selectBest(remaining, tieBreaker, margin)
+		out = append(out, remaining[i].index)
+		remaining = append(remaining[:i], remaining[i+1:]...)
+	}
+
+	return out
+}
+
+// selectBest returns the position of the best candidate. Healthy gateways
+// are preferred. Of these, the gateways within the link-budget margin of the
+// best gateway are considered, of which the ones with the lowest tx load
+// remain. The tie-breaker decides between the remaining gateways.
+func selectBest(candidates []rankingCandidate, tieBreaker string, margin float64) int {
+	var pool []int
+	for i := range candidates {
+		if candidates[i].healthy {
+			pool = append(pool, i)
+		}
+	}
+	if len(pool) == 0 {
+		for i := range candidates {
+			pool = append(pool, i)
+		}
+	}
+
+	bestLinkBudget := candidates[pool[0]].LinkBudget()
+	for _, i := range pool {
+		if lb := candidates[i].LinkBudget(); lb > bestLinkBudget {
+			bestLinkBudget = lb
+		}
+	}
+	pool = filter(pool, func(i int) bool {
+		return candidates[i].LinkBudget() >= bestLinkBudget-margin
+	})
+
+	minTXLoad := candidates[pool[0]].txLoad
+	for _, i := range pool {
+		if candidates[i].txLoad < minTXLoad {
+			minTXLoad = candidates[i].txLoad
+		}
+	}
+	pool = filter(pool, func(i int) bool {
+		return candidates[i].txLoad == minTXLoad
+	})
+
+	sort.SliceStable(pool, func(a, b int) bool {
+		return tieBreakerLess(candidates[pool[a]], candidates[pool[b]], tieBreaker)
+	})
+
+	return pool[0]
+}
+
+// tieBreakerLess returns true when a must be preferred over b. When equal,
+// the order of the candidates is preserved.
+func tieBreakerLess(a, b rankingCandidate, tieBreaker string) bool {
+	switch tieBreaker {
+	case TieBreakerSNR:
+		if a.LoRaSNR != b.LoRaSNR {
+			return a.LoRaSNR > b.LoRaSNR
+		}
+		if a.RSSI != b.RSSI {
+			return a.RSSI > b.RSSI
+		}
+	case TieBreakerRSSI:
+		if a.RSSI != b.RSSI {
+			return a.RSSI > b.RSSI
+		}
+	case TieBreakerRecent:
+		if !a.Time.Equal(b.Time) {
+			return a.Time.After(b.Time)
+		}
+	case TieBreakerMAC:
+		return bytes.Compare(a.MAC[:], b.MAC[:]) < 0
+	}
+
+	return a.index < b.index
+}
+
+func filter(pool []int, f func(int) bool) []int {
+	var out []int
+	for _, i := range pool {
+		if f(i) {
+			out = append(out, i)
+		}
+	}
+	return out
+}
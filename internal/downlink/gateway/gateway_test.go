@@ -0,0 +1,117 @@
+package gateway
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lorawan"
+)
+
+func TestRank(t *testing.T) {
+	Convey("Given a testtable", t, func() {
+		now := time.Now()
+
+		tests := []struct {
+			Name       string
+			Candidates []rankingCandidate
+			TieBreaker string
+			Margin     float64
+			Expected   []int
+		}{
+			{
+				Name: "the gateway with the best link budget is preferred",
+				Candidates: []rankingCandidate{
+					{Candidate: Candidate{RSSI: -100, LoRaSNR: 5}, index: 0, healthy: true},
+					{Candidate: Candidate{RSSI: -60, LoRaSNR: 5}, index: 1, healthy: true},
+				},
+				TieBreaker: TieBreakerSNR,
+				Expected:   []int{1, 0},
+			},
+			{
+				Name: "a negative snr lowers the link budget",
+				Candidates: []rankingCandidate{
+					{Candidate: Candidate{RSSI: -100, LoRaSNR: -15}, index: 0, healthy: true},
+					{Candidate: Candidate{RSSI: -105, LoRaSNR: 2}, index: 1, healthy: true},
+				},
+				TieBreaker: TieBreakerRSSI,
+				Expected:   []int{1, 0},
+			},
+			{
+				Name: "healthy gateways are preferred",
+				Candidates: []rankingCandidate{
+					{Candidate: Candidate{RSSI: -60, LoRaSNR: 5}, index: 0, healthy: false},
+					{Candidate: Candidate{RSSI: -100, LoRaSNR: 5}, index: 1, healthy: true},
+				},
+				TieBreaker: TieBreakerSNR,
+				Expected:   []int{1, 0},
+			},
+			{
+				Name: "within the margin, the gateway with the lowest tx load is preferred",
+				Candidates: []rankingCandidate{
+					{Candidate: Candidate{RSSI: -60, LoRaSNR: 5}, index: 0, healthy: true, txLoad: 10},
+					{Candidate: Candidate{RSSI: -62, LoRaSNR: 5}, index: 1, healthy: true, txLoad: 2},
+					{Candidate: Candidate{RSSI: -90, LoRaSNR: 5}, index: 2, healthy: true},
+				},
+				TieBreaker: TieBreakerSNR,
+				Margin:     3,
+				Expected:   []int{1, 0, 2},
+			},
+			{
+				Name: "snr tie-breaker",
+				Candidates: []rankingCandidate{
+					{Candidate: Candidate{RSSI: -60, LoRaSNR: 5}, index: 0, healthy: true},
+					{Candidate: Candidate{RSSI: -61, LoRaSNR: 9}, index: 1, healthy: true},
+				},
+				TieBreaker: TieBreakerSNR,
+				Margin:     3,
+				Expected:   []int{1, 0},
+			},
+			{
+				Name: "rssi tie-breaker",
+				Candidates: []rankingCandidate{
+					{Candidate: Candidate{RSSI: -61, LoRaSNR: 9}, index: 0, healthy: true},
+					{Candidate: Candidate{RSSI: -60, LoRaSNR: 5}, index: 1, healthy: true},
+				},
+				TieBreaker: TieBreakerRSSI,
+				Margin:     3,
+				Expected:   []int{1, 0},
+			},
+			{
+				Name: "recent tie-breaker",
+				Candidates: []rankingCandidate{
+					{Candidate: Candidate{RSSI: -60, Time: now.Add(-time.Minute)}, index: 0, healthy: true},
+					{Candidate: Candidate{RSSI: -60, Time: now}, index: 1, healthy: true},
+				},
+				TieBreaker: TieBreakerRecent,
+				Expected:   []int{1, 0},
+			},
+			{
+				Name: "mac tie-breaker",
+				Candidates: []rankingCandidate{
+					{Candidate: Candidate{MAC: lorawan.EUI64{2}, RSSI: -60}, index: 0, healthy: true},
+					{Candidate: Candidate{MAC: lorawan.EUI64{1}, RSSI: -60}, index: 1, healthy: true},
+				},
+				TieBreaker: TieBreakerMAC,
+				Expected:   []int{1, 0},
+			},
+			{
+				Name: "on equal candidates the input order is preserved",
+				Candidates: []rankingCandidate{
+					{Candidate: Candidate{RSSI: -60}, index: 0, healthy: true},
+					{Candidate: Candidate{RSSI: -60}, index: 1, healthy: true},
+				},
+				TieBreaker: TieBreakerSNR,
+				Expected:   []int{0, 1},
+			},
+		}
+
+		for i, test := range tests {
+			Convey(fmt.Sprintf("Testing: %s [%d]", test.Name, i), func() {
+				So(rank(test.Candidates, test.TieBreaker, test.Margin), ShouldResemble, test.Expected)
+			})
+		}
+	})
+}
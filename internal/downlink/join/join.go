@@ -11,15 +11,20 @@ import (
 
 	"github.com/brocaar/loraserver/api/gw"
 	"github.com/brocaar/loraserver/internal/config"
+	"github.com/brocaar/loraserver/internal/downlink/gateway"
 	"github.com/brocaar/loraserver/internal/framelog"
 	"github.com/brocaar/loraserver/internal/models"
 	"github.com/brocaar/loraserver/internal/storage"
 	"github.com/brocaar/lorawan"
 )
 
+// ErrAbort is used to abort the flow without error
+var ErrAbort = errors.New("nothing to do")
+
 var tasks = []func(*joinContext) error{
 	setToken,
 	getJoinAcceptTXInfo,
+	checkDutyCycle,
 	sendJoinAcceptResponse,
 	logDownlinkFrame,
 }
@@ -30,6 +35,11 @@ type joinContext struct {
 	RXPacket      models.RXPacket
 	TXInfo        gw.TXInfo
 	PHYPayload    lorawan.PHYPayload
+
+	// FallbackTXInfo holds the alternative TXInfo items (the other gateways
+	// and RX2) to use, in order, when the duty-cycle budget of the gateway
+	// is exhausted.
+	FallbackTXInfo []gw.TXInfo
 }
 
 // Handle handles a downlink join-response.
@@ -42,6 +52,9 @@ func Handle(ds storage.DeviceSession, rxPacket models.RXPacket, phy lorawan.PHYP
 
 	for _, t := range tasks {
 		if err := t(&ctx); err != nil {
+			if err == ErrAbort {
+				return nil
+			}
 			return err
 		}
 	}
@@ -63,9 +76,36 @@ func getJoinAcceptTXInfo(ctx *joinContext) error {
 	if len(ctx.RXPacket.RXInfoSet) == 0 {
 		return errors.New("empty RXInfoSet")
 	}
-	rxInfo := ctx.RXPacket.RXInfoSet[0]
 
-	ctx.TXInfo = gw.TXInfo{
+	rxInfoSet, err := gateway.RankRXInfoSet(ctx.RXPacket.RXInfoSet)
+	if err != nil {
+		return errors.Wrap(err, "rank downlink gateways error")
+	}
+
+	rxWindows := []storage.RXWindow{ctx.DeviceSession.RXWindow}
+	if ctx.DeviceSession.RXWindow == storage.RX1 {
+		rxWindows = append(rxWindows, storage.RX2)
+	}
+
+	var txInfos []gw.TXInfo
+	for _, rxWindow := range rxWindows {
+		for _, rxInfo := range rxInfoSet {
+			txInfo, err := getJoinAcceptTXInfoForRXInfo(ctx, rxWindow, rxInfo)
+			if err != nil {
+				return err
+			}
+			txInfos = append(txInfos, txInfo)
+		}
+	}
+
+	ctx.TXInfo = txInfos[0]
+	ctx.FallbackTXInfo = txInfos[1:]
+
+	return nil
+}
+
+func getJoinAcceptTXInfoForRXInfo(ctx *joinContext, rxWindow storage.RXWindow, rxInfo models.RXInfo) (gw.TXInfo, error) {
+	txInfo := gw.TXInfo{
 		MAC:      rxInfo.MAC,
 		CodeRate: ctx.RXPacket.TXInfo.CodeRate,
 		Board:    rxInfo.Board,
@@ -74,52 +114,82 @@ func getJoinAcceptTXInfo(ctx *joinContext) error {
 
 	var timestamp uint32
 
-	if ctx.DeviceSession.RXWindow == storage.RX1 {
+	if rxWindow == storage.RX1 {
 		timestamp = rxInfo.Timestamp + uint32(config.C.NetworkServer.Band.Band.GetDefaults().JoinAcceptDelay1/time.Microsecond)
 
 		// get uplink dr
 		uplinkDR, err := config.C.NetworkServer.Band.Band.GetDataRateIndex(true, ctx.RXPacket.TXInfo.DataRate)
 		if err != nil {
-			return errors.Wrap(err, "get data-rate index error")
+			return txInfo, errors.Wrap(err, "get data-rate index error")
 		}
 
-		// get RX1 DR
-		rx1DR, err := config.C.NetworkServer.Band.Band.GetRX1DataRateIndex(uplinkDR, 0)
+		// get RX1 DR, taking the downlink dwell-time of the device into
+		// account
+		b, err := config.GetBandForDwellTime(ctx.DeviceSession.GetDownlinkDwellTime())
+		if err != nil {
+			return txInfo, errors.Wrap(err, "get band for dwell-time error")
+		}
+		rx1DR, err := b.GetRX1DataRateIndex(uplinkDR, 0)
 		if err != nil {
-			return errors.Wrap(err, "get rx1 data-rate index error")
+			return txInfo, errors.Wrap(err, "get rx1 data-rate index error")
 		}
-		ctx.TXInfo.DataRate, err = config.C.NetworkServer.Band.Band.GetDataRate(rx1DR)
+		txInfo.DataRate, err = b.GetDataRate(rx1DR)
 		if err != nil {
-			return errors.Wrap(err, "get data-rate error")
+			return txInfo, errors.Wrap(err, "get data-rate error")
 		}
 
 		// get RX1 frequency
-		ctx.TXInfo.Frequency, err = config.C.NetworkServer.Band.Band.GetRX1FrequencyForUplinkFrequency(ctx.RXPacket.TXInfo.Frequency)
+		txInfo.Frequency, err = config.C.NetworkServer.Band.Band.GetRX1FrequencyForUplinkFrequency(ctx.RXPacket.TXInfo.Frequency)
 		if err != nil {
-			return errors.Wrap(err, "get rx1 frequency error")
+			return txInfo, errors.Wrap(err, "get rx1 frequency error")
 		}
 
-	} else if ctx.DeviceSession.RXWindow == storage.RX2 {
+	} else if rxWindow == storage.RX2 {
 		var err error
 
 		timestamp = rxInfo.Timestamp + uint32(config.C.NetworkServer.Band.Band.GetDefaults().JoinAcceptDelay2/time.Microsecond)
-		ctx.TXInfo.Frequency = config.C.NetworkServer.Band.Band.GetDefaults().RX2Frequency
-		ctx.TXInfo.DataRate, err = config.C.NetworkServer.Band.Band.GetDataRate(config.C.NetworkServer.Band.Band.GetDefaults().RX2DataRate)
+		txInfo.Frequency = config.C.NetworkServer.Band.Band.GetDefaults().RX2Frequency
+		txInfo.DataRate, err = config.C.NetworkServer.Band.Band.GetDataRate(config.C.NetworkServer.Band.Band.GetDefaults().RX2DataRate)
 		if err != nil {
-			return errors.Wrap(err, "get data-rate error")
+			return txInfo, errors.Wrap(err, "get data-rate error")
 		}
 	} else {
-		return fmt.Errorf("unknown RXWindow defined %d", ctx.DeviceSession.RXWindow)
+		return txInfo, fmt.Errorf("unknown RXWindow defined %d", rxWindow)
 	}
 
-	ctx.TXInfo.Timestamp = &timestamp
+	txInfo.Timestamp = &timestamp
 	if config.C.NetworkServer.NetworkSettings.DownlinkTXPower != -1 {
-		ctx.TXInfo.Power = config.C.NetworkServer.NetworkSettings.DownlinkTXPower
+		txInfo.Power = config.C.NetworkServer.NetworkSettings.DownlinkTXPower
 	} else {
-		ctx.TXInfo.Power = config.C.NetworkServer.Band.Band.GetDownlinkTXPower(ctx.TXInfo.Frequency)
+		txInfo.Power = config.C.NetworkServer.Band.Band.GetDownlinkTXPower(txInfo.Frequency)
 	}
 
-	return nil
+	return txInfo, nil
+}
+
+// checkDutyCycle selects the first TXInfo that fits within the remaining
+// duty-cycle budget of the gateway. When none fits, the join-accept is
+// dropped, as the device will retry the join-request.
+func checkDutyCycle(ctx *joinContext) error {
+	phyB, err := ctx.PHYPayload.MarshalBinary()
+	if err != nil {
+		return errors.Wrap(err, "marshal binary error")
+	}
+
+	for _, txInfo := range append([]gw.TXInfo{ctx.TXInfo}, ctx.FallbackTXInfo...) {
+		ok, err := gateway.CheckDutyCycle(txInfo, len(phyB))
+		if err != nil {
+			return errors.Wrap(err, "check duty-cycle error")
+		}
+
+		if ok {
+			ctx.TXInfo = txInfo
+			return nil
+		}
+	}
+
+	log.WithField("dev_eui", ctx.DeviceSession.DevEUI).Warning("gateway duty-cycle budget exhausted, dropping join-accept")
+	return ErrAbort
 }
 
 func sendJoinAcceptResponse(ctx *joinContext) error {
@@ -132,6 +202,19 @@ func sendJoinAcceptResponse(ctx *joinContext) error {
 		return errors.Wrap(err, "send tx-packet error")
 	}
 
+	if err := storage.IncrGatewayTXLoad(config.C.Redis.Pool, ctx.TXInfo.MAC); err != nil {
+		log.WithError(err).WithField("mac", ctx.TXInfo.MAC).Error("increment gateway tx load error")
+	}
+
+	phyB, err := ctx.PHYPayload.MarshalBinary()
+	if err != nil {
+		return errors.Wrap(err, "marshal binary error")
+	}
+
+	if err := gateway.RecordAirtime(ctx.TXInfo, len(phyB)); err != nil {
+		log.WithError(err).WithField("mac", ctx.TXInfo.MAC).Error("record gateway airtime error")
+	}
+
 	return nil
 }
 
@@ -0,0 +1,240 @@
+package multicast
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/loraserver/internal/config"
+	"github.com/brocaar/loraserver/internal/downlink/data/classb"
+	"github.com/brocaar/loraserver/internal/gps"
+	"github.com/brocaar/loraserver/internal/storage"
+	"github.com/brocaar/lorawan"
+)
+
+// classBScheduleMargin contains a Class-B scheduling margin to make sure
+// there is enough time between scheduling and the actual Class-B ping-slot.
+const classBScheduleMargin = 5 * time.Second
+
+// EnqueueQueueItem adds the given payload to the multicast queue. As the
+// devices of the multicast-group might be covered by different gateways,
+// a queue-item is created for each gateway of the minimal set of gateways
+// covering all devices. These queue-items are scheduled one after the other,
+// as a gateway is not able to transmit simultaneously.
+//
+// This function must be called within a transaction, as the multicast-group
+// is locked for update.
+func EnqueueQueueItem(p *redis.Pool, db sqlx.Ext, qi storage.MulticastQueueItem) error {
+	if err := qi.Validate(); err != nil {
+		return err
+	}
+
+	mg, err := storage.GetMulticastGroup(db, qi.MulticastGroupID, true)
+	if err != nil {
+		return errors.Wrap(err, "get multicast-group error")
+	}
+
+	if qi.FCnt < mg.FCnt {
+		return storage.ErrInvalidFCnt
+	}
+
+	mg.FCnt = qi.FCnt + 1
+	if err := storage.UpdateMulticastGroup(db, &mg); err != nil {
+		return errors.Wrap(err, "update multicast-group error")
+	}
+
+	devEUIs, err := storage.GetDevEUIsForMulticastGroup(db, mg.ID)
+	if err != nil {
+		return errors.Wrap(err, "get deveuis for multicast-group error")
+	}
+
+	var macs []lorawan.EUI64
+	devGateways := make(map[lorawan.EUI64][]lorawan.EUI64)
+	for _, devEUI := range devEUIs {
+		ds, err := storage.GetDeviceSession(p, devEUI)
+		if err != nil {
+			if errors.Cause(err) == storage.ErrDoesNotExist {
+				// the device is not (yet) activated
+				continue
+			}
+			return errors.Wrap(err, "get device-session error")
+		}
+
+		for mac := range ds.UplinkGatewayHistory {
+			devGateways[devEUI] = append(devGateways[devEUI], mac)
+			macs = append(macs, mac)
+		}
+	}
+
+	// gateways which are not (or no longer) registered can't be used for
+	// the multicast downlink and are left out of the set-cover
+	registered, err := storage.GetRegisteredGatewayMACs(db, macs)
+	if err != nil {
+		return errors.Wrap(err, "get registered gateway macs error")
+	}
+	devGateways = filterGateways(devGateways, registered)
+
+	gatewayMACs := getMinimumGatewaySet(devGateways)
+	if len(gatewayMACs) == 0 {
+		return ErrNoGateways
+	}
+
+	switch mg.GroupType {
+	case storage.MulticastGroupB:
+		err = scheduleClassBQueueItems(db, mg, qi, gatewayMACs)
+	case storage.MulticastGroupC:
+		err = scheduleClassCQueueItems(db, mg, qi, gatewayMACs)
+	default:
+		err = storage.ErrInvalidMulticastGroupType
+	}
+	if err != nil {
+		return err
+	}
+
+	log.WithFields(log.Fields{
+		"multicast_group_id": mg.ID,
+		"f_cnt":              qi.FCnt,
+		"gateway_count":      len(gatewayMACs),
+		"device_count":       len(devGateways),
+	}).Info("multicast queue-item enqueued")
+
+	return nil
+}
+
+// scheduleClassCQueueItems creates a queue-item for each gateway, spacing
+// the transmissions of the gateways by the Class-C downlink lock duration.
+func scheduleClassCQueueItems(db sqlx.Ext, mg storage.MulticastGroup, qi storage.MulticastQueueItem, gatewayMACs []lorawan.EUI64) error {
+	scheduleAt, err := storage.GetMaxScheduleAtForMulticastGroup(db, mg.ID)
+	if err != nil {
+		return errors.Wrap(err, "get max schedule_at for multicast-group error")
+	}
+
+	if scheduleAt.IsZero() {
+		scheduleAt = time.Now()
+	} else {
+		scheduleAt = scheduleAt.Add(config.ClassCDownlinkLockDuration)
+		if scheduleAt.Before(time.Now()) {
+			scheduleAt = time.Now()
+		}
+	}
+
+	for _, mac := range gatewayMACs {
+		qi.GatewayMAC = mac
+		qi.ScheduleAt = scheduleAt
+		qi.EmitAtTimeSinceGPSEpoch = nil
+
+		if err := storage.CreateMulticastQueueItem(db, &qi); err != nil {
+			return errors.Wrap(err, "create multicast queue-item error")
+		}
+
+		scheduleAt = scheduleAt.Add(config.ClassCDownlinkLockDuration)
+	}
+
+	return nil
+}
+
+// scheduleClassBQueueItems creates a queue-item for each gateway, using the
+// next ping-slot of the multicast-group for each gateway.
+func scheduleClassBQueueItems(db sqlx.Ext, mg storage.MulticastGroup, qi storage.MulticastQueueItem, gatewayMACs []lorawan.EUI64) error {
+	afterGPSEpochTS, err := storage.GetMaxEmitAtTimeSinceGPSEpochForMulticastGroup(db, mg.ID)
+	if err != nil {
+		return errors.Wrap(err, "get max emit-at time since gps epoch for multicast-group error")
+	}
+
+	nowGPSEpochTS := gps.Time(time.Now()).TimeSinceGPSEpoch()
+	if afterGPSEpochTS < nowGPSEpochTS {
+		afterGPSEpochTS = nowGPSEpochTS
+	}
+	afterGPSEpochTS += classBScheduleMargin
+
+	for _, mac := range gatewayMACs {
+		emitAt, err := classb.GetNextPingSlotAfter(afterGPSEpochTS, mg.MCAddr, mg.GetPingSlotNb())
+		if err != nil {
+			return errors.Wrap(err, "get next ping-slot after error")
+		}
+
+		qi.GatewayMAC = mac
+		qi.EmitAtTimeSinceGPSEpoch = &emitAt
+		qi.ScheduleAt = time.Time(gps.NewFromTimeSinceGPSEpoch(emitAt)).Add(-2 * config.ClassCScheduleInterval)
+
+		if err := storage.CreateMulticastQueueItem(db, &qi); err != nil {
+			return errors.Wrap(err, "create multicast queue-item error")
+		}
+
+		afterGPSEpochTS = emitAt
+	}
+
+	return nil
+}
+
+// filterGateways returns the given devices (devEUI => gateways that received
+// the device), containing only the given gateways.
+func filterGateways(devGateways map[lorawan.EUI64][]lorawan.EUI64, gateways map[lorawan.EUI64]struct{}) map[lorawan.EUI64][]lorawan.EUI64 {
+	out := make(map[lorawan.EUI64][]lorawan.EUI64)
+	for devEUI, macs := range devGateways {
+		for _, mac := range macs {
+			if _, ok := gateways[mac]; !ok {
+				log.WithFields(log.Fields{
+					"dev_eui": devEUI,
+					"mac":     mac,
+				}).Debug("gateway is not registered, skipping it for multicast")
+				continue
+			}
+			out[devEUI] = append(out[devEUI], mac)
+		}
+	}
+
+	return out
+}
+
+// getMinimumGatewaySet returns the minimum set of gateways covering all the
+// given devices (devEUI => gateways that received the device). As this is
+// the set cover problem, a greedy approach is used: the gateway covering the
+// most uncovered devices is selected until all devices are covered.
+func getMinimumGatewaySet(devGateways map[lorawan.EUI64][]lorawan.EUI64) []lorawan.EUI64 {
+	uncovered := make(map[lorawan.EUI64]struct{})
+	gatewayDevs := make(map[lorawan.EUI64][]lorawan.EUI64)
+	for devEUI, macs := range devGateways {
+		if len(macs) == 0 {
+			continue
+		}
+		uncovered[devEUI] = struct{}{}
+		for _, mac := range macs {
+			gatewayDevs[mac] = append(gatewayDevs[mac], devEUI)
+		}
+	}
+
+	var out []lorawan.EUI64
+	for len(uncovered) > 0 {
+		var best lorawan.EUI64
+		var bestCount int
+
+		for mac, devEUIs := range gatewayDevs {
+			var count int
+			for _, devEUI := range devEUIs {
+				if _, ok := uncovered[devEUI]; ok {
+					count++
+				}
+			}
+
+			// on a tie, the lowest MAC is selected to make the result
+			// deterministic
+			if count > bestCount || (count == bestCount && count > 0 && bytes.Compare(mac[:], best[:]) < 0) {
+				best = mac
+				bestCount = count
+			}
+		}
+
+		for _, devEUI := range gatewayDevs[best] {
+			delete(uncovered, devEUI)
+		}
+		delete(gatewayDevs, best)
+		out = append(out, best)
+	}
+
+	return out
+}
@@ -0,0 +1,90 @@
+package multicast
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lorawan"
+)
+
+func TestGetMinimumGatewaySet(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		gw1 := lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}
+		gw2 := lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2}
+		gw3 := lorawan.EUI64{3, 3, 3, 3, 3, 3, 3, 3}
+
+		dev1 := lorawan.EUI64{1, 0, 0, 0, 0, 0, 0, 0}
+		dev2 := lorawan.EUI64{2, 0, 0, 0, 0, 0, 0, 0}
+		dev3 := lorawan.EUI64{3, 0, 0, 0, 0, 0, 0, 0}
+
+		tests := []struct {
+			Name        string
+			DevGateways map[lorawan.EUI64][]lorawan.EUI64
+			Expected    []lorawan.EUI64
+		}{
+			{
+				Name:        "no devices",
+				DevGateways: map[lorawan.EUI64][]lorawan.EUI64{},
+			},
+			{
+				Name: "device without gateways",
+				DevGateways: map[lorawan.EUI64][]lorawan.EUI64{
+					dev1: nil,
+				},
+			},
+			{
+				Name: "one gateway covers all devices",
+				DevGateways: map[lorawan.EUI64][]lorawan.EUI64{
+					dev1: {gw1, gw2},
+					dev2: {gw2},
+					dev3: {gw2, gw3},
+				},
+				Expected: []lorawan.EUI64{gw2},
+			},
+			{
+				Name: "two gateways are needed",
+				DevGateways: map[lorawan.EUI64][]lorawan.EUI64{
+					dev1: {gw1},
+					dev2: {gw1, gw3},
+					dev3: {gw3},
+				},
+				Expected: []lorawan.EUI64{gw1, gw3},
+			},
+			{
+				Name: "tie is resolved by lowest MAC",
+				DevGateways: map[lorawan.EUI64][]lorawan.EUI64{
+					dev1: {gw3, gw2},
+				},
+				Expected: []lorawan.EUI64{gw2},
+			},
+		}
+
+		for _, test := range tests {
+			Convey("Testing: "+test.Name, func() {
+				So(getMinimumGatewaySet(test.DevGateways), ShouldResemble, test.Expected)
+			})
+		}
+	})
+}
+
+func TestFilterGateways(t *testing.T) {
+	Convey("Given devices received by a registered and an unregistered gateway", t, func() {
+		gw1 := lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}
+		gw2 := lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2}
+
+		dev1 := lorawan.EUI64{1, 0, 0, 0, 0, 0, 0, 0}
+		dev2 := lorawan.EUI64{2, 0, 0, 0, 0, 0, 0, 0}
+
+		devGateways := map[lorawan.EUI64][]lorawan.EUI64{
+			dev1: {gw1, gw2},
+			dev2: {gw2},
+		}
+
+		Convey("Then only the registered gateway is kept", func() {
+			So(filterGateways(devGateways, map[lorawan.EUI64]struct{}{gw1: {}}), ShouldResemble, map[lorawan.EUI64][]lorawan.EUI64{
+				dev1: {gw1},
+			})
+		})
+	})
+}
@@ -0,0 +1,10 @@
+package multicast
+
+import "github.com/pkg/errors"
+
+// multicast errors
+var (
+	ErrNoGateways               = errors.New("no gateways available to reach the multicast-group devices")
+	ErrDutyCycleBudgetExhausted = errors.New("gateway duty-cycle budget exhausted")
+	ErrAbort                    = errors.New("nothing to do")
+)
@@ -0,0 +1,287 @@
+// Package multicast implements the enqueueing and scheduling of multicast
+// downlinks for Class-B and Class-C multicast-groups.
+package multicast
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/loraserver/internal/config"
+	"github.com/brocaar/loraserver/internal/downlink/gateway"
+	"github.com/brocaar/loraserver/internal/framelog"
+	"github.com/brocaar/loraserver/internal/storage"
+	"github.com/brocaar/lorawan"
+)
+
+const defaultCodeRate = "4/5"
+
+var tasks = []func(*multicastContext) error{
+	setToken,
+	getMulticastGroup,
+	getDeviceSessions,
+	checkClassCDownlinkLock,
+	setTXInfo,
+	setPHYPayload,
+	checkDutyCycle,
+	sendDownlinkData,
+	setClassCDownlinkLock,
+	logDownlinkFrameForGateway,
+	deleteQueueItem,
+}
+
+type multicastContext struct {
+	DB                 sqlx.Ext
+	Token              uint16
+	MulticastQueueItem storage.MulticastQueueItem
+	MulticastGroup     storage.MulticastGroup
+	TXInfo             gw.TXInfo
+	PHYPayload         lorawan.PHYPayload
+
+	// DeviceSessions holds the sessions of the Class-C multicast-group
+	// devices in range of the gateway.
+	DeviceSessions []storage.DeviceSession
+}
+
+// HandleScheduleQueueItem handles the scheduling of the given
+// multicast queue-item.
+func HandleScheduleQueueItem(db sqlx.Ext, qi storage.MulticastQueueItem) error {
+	ctx := multicastContext{
+		DB:                 db,
+		MulticastQueueItem: qi,
+	}
+
+	for _, t := range tasks {
+		if err := t(&ctx); err != nil {
+			if err == ErrAbort {
+				return nil
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func setToken(ctx *multicastContext) error {
+	b := make([]byte, 2)
+	_, err := rand.Read(b)
+	if err != nil {
+		return errors.Wrap(err, "read random error")
+	}
+	ctx.Token = binary.BigEndian.Uint16(b)
+	return nil
+}
+
+func getMulticastGroup(ctx *multicastContext) error {
+	var err error
+	ctx.MulticastGroup, err = storage.GetMulticastGroup(ctx.DB, ctx.MulticastQueueItem.MulticastGroupID, false)
+	if err != nil {
+		return errors.Wrap(err, "get multicast-group error")
+	}
+	return nil
+}
+
+func getDeviceSessions(ctx *multicastContext) error {
+	if ctx.MulticastGroup.GroupType != storage.MulticastGroupC {
+		return nil
+	}
+
+	devEUIs, err := storage.GetDevEUIsForMulticastGroup(ctx.DB, ctx.MulticastGroup.ID)
+	if err != nil {
+		return errors.Wrap(err, "get deveuis for multicast-group error")
+	}
+
+	deviceSessions, err := storage.GetDeviceSessionsForDevEUIs(config.C.Redis.Pool, devEUIs)
+	if err != nil {
+		return errors.Wrap(err, "get device-sessions error")
+	}
+
+	for _, ds := range deviceSessions {
+		if _, ok := ds.UplinkGatewayHistory[ctx.MulticastQueueItem.GatewayMAC]; ok {
+			ctx.DeviceSessions = append(ctx.DeviceSessions, ds)
+		}
+	}
+
+	return nil
+}
+
+// checkClassCDownlinkLock defers the multicast downlink to the next
+// scheduler run when one of the devices in range of the gateway is within
+// its Class-C downlink lock (e.g. after a unicast Class-C downlink).
+func checkClassCDownlinkLock(ctx *multicastContext) error {
+	for _, ds := range ctx.DeviceSessions {
+		if time.Now().Sub(ds.LastDownlinkTX) < config.ClassCDownlinkLockDuration {
+			log.WithFields(log.Fields{
+				"multicast_group_id":             ctx.MulticastGroup.ID,
+				"dev_eui":                        ds.DevEUI,
+				"last_downlink_tx_time":          ds.LastDownlinkTX,
+				"class_c_downlink_lock_duration": config.ClassCDownlinkLockDuration,
+			}).Debug("skip multicast downlink due to class-c downlink lock")
+			return ErrAbort
+		}
+	}
+
+	return nil
+}
+
+func setTXInfo(ctx *multicastContext) error {
+	dr, err := config.C.NetworkServer.Band.Band.GetDataRate(ctx.MulticastGroup.DR)
+	if err != nil {
+		return errors.Wrap(err, "get data-rate error")
+	}
+
+	txPower := config.C.NetworkServer.Band.Band.GetDownlinkTXPower(ctx.MulticastGroup.Frequency)
+	if config.C.NetworkServer.NetworkSettings.DownlinkTXPower != -1 {
+		txPower = config.C.NetworkServer.NetworkSettings.DownlinkTXPower
+	}
+
+	ctx.TXInfo = gw.TXInfo{
+		MAC:       ctx.MulticastQueueItem.GatewayMAC,
+		Frequency: ctx.MulticastGroup.Frequency,
+		Power:     txPower,
+		DataRate:  dr,
+		CodeRate:  defaultCodeRate,
+	}
+
+	if ctx.MulticastQueueItem.EmitAtTimeSinceGPSEpoch == nil {
+		ctx.TXInfo.Immediately = true
+	} else {
+		timeSinceGPSEpoch := gw.Duration(*ctx.MulticastQueueItem.EmitAtTimeSinceGPSEpoch)
+		ctx.TXInfo.TimeSinceGPSEpoch = &timeSinceGPSEpoch
+	}
+
+	return nil
+}
+
+func setPHYPayload(ctx *multicastContext) error {
+	fPort := ctx.MulticastQueueItem.FPort
+
+	ctx.PHYPayload = lorawan.PHYPayload{
+		MHDR: lorawan.MHDR{
+			MType: lorawan.UnconfirmedDataDown,
+			Major: lorawan.LoRaWANR1,
+		},
+		MACPayload: &lorawan.MACPayload{
+			FHDR: lorawan.FHDR{
+				DevAddr: ctx.MulticastGroup.MCAddr,
+				FCnt:    ctx.MulticastQueueItem.FCnt,
+			},
+			FPort: &fPort,
+			FRMPayload: []lorawan.Payload{
+				&lorawan.DataPayload{Bytes: ctx.MulticastQueueItem.FRMPayload},
+			},
+		},
+	}
+
+	// the multicast MIC is calculated using the LoRaWAN 1.0 scheme, using
+	// the McNwkSKey of the multicast-group
+	if err := ctx.PHYPayload.SetDownlinkDataMIC(lorawan.LoRaWAN1_0, 0, ctx.MulticastGroup.MCNwkSKey); err != nil {
+		return errors.Wrap(err, "set MIC error")
+	}
+
+	return nil
+}
+
+// checkDutyCycle validates that the multicast downlink fits within the
+// duty-cycle budget of the gateway. When it does not, a Class-C queue-item
+// is left in the queue so that it is retried on the next scheduler run.
+// A Class-B queue-item can't be sent after its ping-slot and is dropped.
+func checkDutyCycle(ctx *multicastContext) error {
+	phyB, err := ctx.PHYPayload.MarshalBinary()
+	if err != nil {
+		return errors.Wrap(err, "marshal binary error")
+	}
+
+	ok, err := gateway.CheckDutyCycle(ctx.TXInfo, len(phyB))
+	if err != nil {
+		return errors.Wrap(err, "check duty-cycle error")
+	}
+	if ok {
+		return nil
+	}
+
+	if ctx.TXInfo.Immediately {
+		log.WithFields(log.Fields{
+			"multicast_group_id": ctx.MulticastGroup.ID,
+			"mac":                ctx.TXInfo.MAC,
+		}).Warning("gateway duty-cycle budget exhausted, deferring multicast downlink")
+		return ErrAbort
+	}
+
+	return ErrDutyCycleBudgetExhausted
+}
+
+func sendDownlinkData(ctx *multicastContext) error {
+	if err := config.C.NetworkServer.Gateway.Backend.Backend.SendTXPacket(gw.TXPacket{
+		Token:      ctx.Token,
+		TXInfo:     ctx.TXInfo,
+		PHYPayload: ctx.PHYPayload,
+	}); err != nil {
+		return errors.Wrap(err, "send tx packet to gateway error")
+	}
+
+	if err := storage.IncrGatewayTXLoad(config.C.Redis.Pool, ctx.TXInfo.MAC); err != nil {
+		log.WithError(err).WithField("mac", ctx.TXInfo.MAC).Error("increment gateway tx load error")
+	}
+
+	phyB, err := ctx.PHYPayload.MarshalBinary()
+	if err != nil {
+		return errors.Wrap(err, "marshal binary error")
+	}
+
+	if err := gateway.RecordAirtime(ctx.TXInfo, len(phyB)); err != nil {
+		log.WithError(err).WithField("mac", ctx.TXInfo.MAC).Error("record gateway airtime error")
+	}
+
+	return nil
+}
+
+// setClassCDownlinkLock sets the Class-C downlink lock of the devices in
+// range of the gateway, so that it also applies to the unicast downlinks
+// following the multicast downlink. The device-sessions are not saved, as
+// these might have been updated in the meantime (e.g. by an uplink).
+func setClassCDownlinkLock(ctx *multicastContext) error {
+	var devEUIs []lorawan.EUI64
+	for _, ds := range ctx.DeviceSessions {
+		devEUIs = append(devEUIs, ds.DevEUI)
+	}
+
+	if err := storage.SetClassCDownlinkLock(config.C.Redis.Pool, config.ClassCDownlinkLockDuration, devEUIs...); err != nil {
+		return errors.Wrap(err, "set class-c downlink lock error")
+	}
+
+	return nil
+}
+
+func logDownlinkFrameForGateway(ctx *multicastContext) error {
+	downlinkFrame, err := framelog.CreateDownlinkFrame(ctx.Token, ctx.PHYPayload, ctx.TXInfo)
+	if err != nil {
+		return errors.Wrap(err, "create downlink frame error")
+	}
+
+	if err := framelog.LogDownlinkFrameForGateway(downlinkFrame); err != nil {
+		log.WithError(err).Error("log downlink frame for gateway error")
+	}
+
+	return nil
+}
+
+func deleteQueueItem(ctx *multicastContext) error {
+	if err := storage.DeleteMulticastQueueItem(ctx.DB, ctx.MulticastQueueItem.ID); err != nil {
+		return errors.Wrap(err, "delete multicast queue-item error")
+	}
+
+	log.WithFields(log.Fields{
+		"multicast_group_id": ctx.MulticastGroup.ID,
+		"gateway_mac":        ctx.MulticastQueueItem.GatewayMAC,
+		"f_cnt":              ctx.MulticastQueueItem.FCnt,
+	}).Info("multicast downlink sent to gateway")
+
+	return nil
+}
@@ -4,5 +4,6 @@ import "errors"
 
 // errors
 var (
-	ErrInvalidDataRate = errors.New("invalid data-rate")
+	ErrInvalidDataRate          = errors.New("invalid data-rate")
+	ErrDutyCycleBudgetExhausted = errors.New("gateway duty-cycle budget exhausted")
 )
@@ -5,9 +5,11 @@ import (
 	"encoding/binary"
 
 	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
 
 	"github.com/brocaar/loraserver/api/gw"
 	"github.com/brocaar/loraserver/internal/config"
+	"github.com/brocaar/loraserver/internal/downlink/gateway"
 	"github.com/brocaar/lorawan"
 )
 
@@ -80,6 +82,12 @@ func sendProprietaryDown(ctx *proprietaryContext) error {
 		MIC:        ctx.MIC,
 	}
 
+	phyB, err := phy.MarshalBinary()
+	if err != nil {
+		return errors.Wrap(err, "marshal binary error")
+	}
+
+	var sent bool
 	for _, mac := range ctx.GatewayMACs {
 		txInfo := gw.TXInfo{
 			MAC:         mac,
@@ -91,6 +99,18 @@ func sendProprietaryDown(ctx *proprietaryContext) error {
 			IPol:        &ctx.IPol,
 		}
 
+		ok, err := gateway.CheckDutyCycle(txInfo, len(phyB))
+		if err != nil {
+			return errors.Wrap(err, "check duty-cycle error")
+		}
+		if !ok {
+			log.WithFields(log.Fields{
+				"mac":       mac,
+				"frequency": ctx.Frequency,
+			}).Warning("gateway duty-cycle budget exhausted, skipping proprietary downlink")
+			continue
+		}
+
 		if err := config.C.NetworkServer.Gateway.Backend.Backend.SendTXPacket(gw.TXPacket{
 			Token:      ctx.Token,
 			TXInfo:     txInfo,
@@ -98,6 +118,15 @@ func sendProprietaryDown(ctx *proprietaryContext) error {
 		}); err != nil {
 			return errors.Wrap(err, "send tx packet to gateway error")
 		}
+		sent = true
+
+		if err := gateway.RecordAirtime(txInfo, len(phyB)); err != nil {
+			log.WithError(err).WithField("mac", mac).Error("record gateway airtime error")
+		}
+	}
+
+	if len(ctx.GatewayMACs) != 0 && !sent {
+		return ErrDutyCycleBudgetExhausted
 	}
 
 	return nil
@@ -1,33 +1,271 @@
 package downlink
 
 import (
+	"context"
+	"sort"
+	"sync"
 	"time"
 
+	"github.com/gofrs/uuid"
 	"github.com/jmoiron/sqlx"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 
+	"github.com/brocaar/loraserver/api/as"
 	"github.com/brocaar/loraserver/internal/config"
 	"github.com/brocaar/loraserver/internal/downlink/data"
+	"github.com/brocaar/loraserver/internal/downlink/multicast"
 	"github.com/brocaar/loraserver/internal/storage"
+	"github.com/brocaar/lorawan"
 )
 
+// leases holds the scheduler partitions leased by this instance.
+var leases = partitionLeases{
+	partitions: make(map[int]struct{}),
+}
+
+// partitionLeases implements the leasing of scheduler partitions. Each
+// instance leases an equal share of the partitions, so that the devices are
+// divided over the running instances.
+type partitionLeases struct {
+	sync.RWMutex
+	id         string
+	partitions map[int]struct{}
+	renewedAt  time.Time
+}
+
+// owned returns the (sorted) partitions leased by this instance.
+func (l *partitionLeases) owned() []int {
+	l.RLock()
+	defer l.RUnlock()
+
+	var out []int
+	for p := range l.partitions {
+		out = append(out, p)
+	}
+	sort.Ints(out)
+	return out
+}
+
+// owns returns true when this instance holds the lease of the partition of
+// the given DevEUI.
+func (l *partitionLeases) owns(devEUI lorawan.EUI64) bool {
+	l.RLock()
+	defer l.RUnlock()
+
+	_, ok := l.partitions[storage.GetSchedulerPartitionForDevEUI(devEUI, config.C.NetworkServer.Scheduler.Partitions)]
+	return ok
+}
+
+// update renews the leases held by this instance and acquires the leases of
+// free partitions, up to its share of the partitions. Leases exceeding the
+// share (e.g. after an other instance has been started) are released.
+func (l *partitionLeases) update() error {
+	count := config.C.NetworkServer.Scheduler.Partitions
+	duration := config.C.NetworkServer.Scheduler.LeaseDuration
+
+	l.Lock()
+	defer l.Unlock()
+
+	// renew at a third of the lease duration, to make sure a lease does not
+	// expire between two renewals
+	if time.Since(l.renewedAt) < duration/3 {
+		return nil
+	}
+
+	if l.id == "" {
+		id, err := uuid.NewV4()
+		if err != nil {
+			return errors.Wrap(err, "new uuid v4 error")
+		}
+		l.id = id.String()
+	}
+
+	instances, err := storage.RegisterSchedulerInstance(config.C.Redis.Pool, l.id, duration)
+	if err != nil {
+		return errors.Wrap(err, "register scheduler instance error")
+	}
+	share := (count + instances - 1) / instances
+
+	var current []int
+	for p := range l.partitions {
+		current = append(current, p)
+	}
+	sort.Ints(current)
+
+	partitions := make(map[int]struct{})
+	for _, p := range current {
+		if len(partitions) >= share || p >= count {
+			if err := storage.ReleaseSchedulerPartitionLease(config.C.Redis.Pool, p, l.id); err != nil {
+				return errors.Wrap(err, "release scheduler partition lease error")
+			}
+			continue
+		}
+
+		ok, err := storage.AcquireSchedulerPartitionLease(config.C.Redis.Pool, p, l.id, duration)
+		if err != nil {
+			return errors.Wrap(err, "renew scheduler partition lease error")
+		}
+		if ok {
+			partitions[p] = struct{}{}
+		}
+	}
+
+	for p := 0; p < count && len(partitions) < share; p++ {
+		if _, ok := partitions[p]; ok {
+			continue
+		}
+
+		ok, err := storage.AcquireSchedulerPartitionLease(config.C.Redis.Pool, p, l.id, duration)
+		if err != nil {
+			return errors.Wrap(err, "acquire scheduler partition lease error")
+		}
+		if ok {
+			partitions[p] = struct{}{}
+		}
+	}
+
+	if len(partitions) != len(l.partitions) {
+		log.WithFields(log.Fields{
+			"instances":  instances,
+			"partitions": len(partitions),
+		}).Info("scheduler partition leases updated")
+	}
+
+	l.partitions = partitions
+	l.renewedAt = time.Now()
+
+	return nil
+}
+
 // SchedulerLoop starts an infinit loop calling the scheduler loop for Class-B
-// and Class-C sheduling.
+// and Class-C sheduling. Device-queue schedule events are handled
+// immediately, the loop itself acts as a safety net (e.g. for Class-B
+// ping-slots and missed events).
 func SchedulerLoop() {
+	go scheduleEventLoop()
+
 	for {
+		if config.C.NetworkServer.Scheduler.Partitions > 0 {
+			if err := leases.update(); err != nil {
+				log.WithError(err).Error("update scheduler partition leases error")
+			}
+		}
+
+		if err := PurgeExpiredDeviceQueueItems(); err != nil {
+			log.WithError(err).Error("purge expired device-queue items error")
+		}
+
 		log.Debug("running class-c scheduler batch")
 		if err := ScheduleBatch(config.ClassCScheduleBatchSize); err != nil {
 			log.WithError(err).Error("class-c scheduler error")
 		}
+
+		log.Debug("running multicast scheduler batch")
+		if err := ScheduleMulticastBatch(config.ClassCScheduleBatchSize); err != nil {
+			log.WithError(err).Error("multicast scheduler error")
+		}
+		time.Sleep(config.ClassCScheduleInterval)
+	}
+}
+
+// scheduleQueue holds the DevEUIs of the devices waiting to be scheduled by
+// the schedule event workers. A DevEUI which is already waiting is not
+// queued twice.
+type scheduleQueue struct {
+	sync.Mutex
+	queue   chan lorawan.EUI64
+	pending map[lorawan.EUI64]struct{}
+}
+
+func newScheduleQueue(size int) *scheduleQueue {
+	return &scheduleQueue{
+		queue:   make(chan lorawan.EUI64, size),
+		pending: make(map[lorawan.EUI64]struct{}),
+	}
+}
+
+// add queues the given DevEUI, unless it is already waiting. An error is
+// returned when the queue is full.
+func (q *scheduleQueue) add(devEUI lorawan.EUI64) error {
+	q.Lock()
+	defer q.Unlock()
+
+	if _, ok := q.pending[devEUI]; ok {
+		return nil
+	}
+
+	select {
+	case q.queue <- devEUI:
+		q.pending[devEUI] = struct{}{}
+		return nil
+	default:
+		return errors.New("schedule queue is full")
+	}
+}
+
+// next blocks until a DevEUI is waiting and returns it.
+func (q *scheduleQueue) next() lorawan.EUI64 {
+	devEUI := <-q.queue
+
+	q.Lock()
+	delete(q.pending, devEUI)
+	q.Unlock()
+
+	return devEUI
+}
+
+// scheduleEventLoop subscribes to the device-queue schedule events and
+// schedules the device-queue of each device within the leased partitions,
+// using a fixed number of workers. On a subscription error, it re-subscribes
+// after the schedule interval.
+func scheduleEventLoop() {
+	queue := newScheduleQueue(config.ScheduleEventQueueSize)
+	devEUIChan := make(chan lorawan.EUI64)
+
+	for i := 0; i < config.ScheduleEventWorkers; i++ {
+		go func() {
+			for {
+				devEUI := queue.next()
+				if err := ScheduleDevice(devEUI); err != nil {
+					log.WithError(err).WithField("dev_eui", devEUI).Error("schedule device-queue error")
+				}
+			}
+		}()
+	}
+
+	go func() {
+		for devEUI := range devEUIChan {
+			// the device is scheduled by the scheduler loop in case the
+			// event is dropped
+			if err := queue.add(devEUI); err != nil {
+				log.WithError(err).WithField("dev_eui", devEUI).Warning("device-queue schedule event dropped")
+			}
+		}
+	}()
+
+	for {
+		if err := storage.SubscribeDeviceQueueScheduleEvents(context.Background(), config.C.Redis.Pool, devEUIChan); err != nil {
+			log.WithError(err).Error("subscribe to device-queue schedule events error")
+		}
 		time.Sleep(config.ClassCScheduleInterval)
 	}
 }
 
 // ScheduleBatch schedules a downlink batch.
+// When partitioning is enabled, only the devices within the partitions
+// leased by this instance are scheduled.
 func ScheduleBatch(size int) error {
+	var owned []int
+	if config.C.NetworkServer.Scheduler.Partitions > 0 {
+		owned = leases.owned()
+		if len(owned) == 0 {
+			return nil
+		}
+	}
+
 	return storage.Transaction(config.C.PostgreSQL.DB, func(tx sqlx.Ext) error {
-		devices, err := storage.GetDevicesWithClassBOrClassCDeviceQueueItems(tx, size)
+		devices, err := storage.GetDevicesWithClassBOrClassCDeviceQueueItems(tx, size, config.C.NetworkServer.Scheduler.Partitions, owned)
 		if err != nil {
 			return errors.Wrap(err, "get deveuis with class-c device-queue items error")
 		}
@@ -48,3 +286,154 @@ func ScheduleBatch(size int) error {
 		return nil
 	})
 }
+
+// ScheduleDevice schedules the next device-queue item of the given device,
+// in case it qualifies for Class-B or Class-C transmission. When
+// partitioning is enabled, devices outside the partitions leased by this
+// instance are ignored as these are handled by an other instance.
+func ScheduleDevice(devEUI lorawan.EUI64) error {
+	if config.C.NetworkServer.Scheduler.Partitions > 0 && !leases.owns(devEUI) {
+		return nil
+	}
+
+	return storage.Transaction(config.C.PostgreSQL.DB, func(tx sqlx.Ext) error {
+		_, err := storage.GetDeviceWithClassBOrClassCDeviceQueueItems(tx, devEUI)
+		if err != nil {
+			if errors.Cause(err) == storage.ErrDoesNotExist {
+				return nil
+			}
+			return errors.Wrap(err, "get device with class-b or class-c device-queue items error")
+		}
+
+		ds, err := storage.GetDeviceSession(config.C.Redis.Pool, devEUI)
+		if err != nil {
+			return errors.Wrap(err, "get device-session error")
+		}
+
+		if err := data.HandleScheduleNextQueueItem(ds); err != nil {
+			return errors.Wrap(err, "schedule next device-queue item error")
+		}
+
+		return nil
+	})
+}
+
+// PurgeExpiredDeviceQueueItems deletes the expired device-queue items and
+// the timed-out pending (confirmed) device-queue items of which all
+// retransmissions have been used. The expired items are reported to the
+// application-server as error, for the timed-out items a negative
+// acknowledgement is sent.
+func PurgeExpiredDeviceQueueItems() error {
+	items, err := storage.DeleteExpiredDeviceQueueItems(config.C.PostgreSQL.DB)
+	if err != nil {
+		return errors.Wrap(err, "delete expired device-queue items error")
+	}
+
+	for _, qi := range items {
+		if err := reportExpiredDeviceQueueItem(qi); err != nil {
+			log.WithError(err).WithFields(log.Fields{
+				"dev_eui": qi.DevEUI,
+				"f_cnt":   qi.FCnt,
+			}).Error("report expired device-queue item error")
+		}
+	}
+
+	items, err = storage.DeleteTimedOutPendingDeviceQueueItems(config.C.PostgreSQL.DB)
+	if err != nil {
+		return errors.Wrap(err, "delete timed-out pending device-queue items error")
+	}
+
+	for _, qi := range items {
+		if err := reportTimedOutDeviceQueueItem(qi); err != nil {
+			log.WithError(err).WithFields(log.Fields{
+				"dev_eui": qi.DevEUI,
+				"f_cnt":   qi.FCnt,
+			}).Error("report timed-out device-queue item error")
+		}
+	}
+
+	return nil
+}
+
+func getApplicationServerClientForDevEUI(devEUI lorawan.EUI64) (as.ApplicationServerServiceClient, error) {
+	d, err := storage.GetDevice(config.C.PostgreSQL.DB, devEUI)
+	if err != nil {
+		return nil, errors.Wrap(err, "get device error")
+	}
+
+	rp, err := storage.GetRoutingProfile(config.C.PostgreSQL.DB, d.RoutingProfileID)
+	if err != nil {
+		return nil, errors.Wrap(err, "get routing-profile error")
+	}
+
+	asClient, err := config.C.ApplicationServer.Pool.Get(rp.ASID, []byte(rp.CACert), []byte(rp.TLSCert), []byte(rp.TLSKey))
+	if err != nil {
+		return nil, errors.Wrap(err, "get application-server client error")
+	}
+
+	return asClient, nil
+}
+
+func reportTimedOutDeviceQueueItem(qi storage.DeviceQueueItem) error {
+	asClient, err := getApplicationServerClientForDevEUI(qi.DevEUI)
+	if err != nil {
+		return err
+	}
+
+	_, err = asClient.HandleDownlinkACK(context.Background(), &as.HandleDownlinkACKRequest{
+		DevEui:       qi.DevEUI[:],
+		FCnt:         qi.FCnt,
+		Acknowledged: false,
+	})
+	if err != nil {
+		return errors.Wrap(err, "application-server client error")
+	}
+
+	return nil
+}
+
+func reportExpiredDeviceQueueItem(qi storage.DeviceQueueItem) error {
+	asClient, err := getApplicationServerClientForDevEUI(qi.DevEUI)
+	if err != nil {
+		return err
+	}
+
+	_, err = asClient.HandleError(context.Background(), &as.HandleErrorRequest{
+		DevEui: qi.DevEUI[:],
+		Type:   as.ErrorType_DEVICE_QUEUE_ITEM_EXPIRED,
+		FCnt:   qi.FCnt,
+		Error:  "device-queue item expired",
+	})
+	if err != nil {
+		return errors.Wrap(err, "application-server client error")
+	}
+
+	return nil
+}
+
+// ScheduleMulticastBatch schedules a multicast downlink batch.
+func ScheduleMulticastBatch(size int) error {
+	return storage.Transaction(config.C.PostgreSQL.DB, func(tx sqlx.Ext) error {
+		items, err := storage.GetSchedulableMulticastQueueItems(tx, size)
+		if err != nil {
+			return errors.Wrap(err, "get schedulable multicast queue-items error")
+		}
+
+		for _, qi := range items {
+			if err := multicast.HandleScheduleQueueItem(tx, qi); err != nil {
+				log.WithError(err).WithFields(log.Fields{
+					"multicast_group_id": qi.MulticastGroupID,
+					"id":                 qi.ID,
+				}).Error("schedule multicast queue-item error")
+
+				// remove the item to avoid retrying it on every batch, as
+				// its transmission window has passed
+				if err := storage.DeleteMulticastQueueItem(tx, qi.ID); err != nil {
+					log.WithError(err).WithField("id", qi.ID).Error("delete multicast queue-item error")
+				}
+			}
+		}
+
+		return nil
+	})
+}
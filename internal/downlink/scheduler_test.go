@@ -0,0 +1,40 @@
+package downlink
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lorawan"
+)
+
+func TestScheduleQueue(t *testing.T) {
+	Convey("Given a schedule queue with a size of 2", t, func() {
+		q := newScheduleQueue(2)
+
+		Convey("When adding the same DevEUI twice", func() {
+			So(q.add(lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}), ShouldBeNil)
+			So(q.add(lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}), ShouldBeNil)
+
+			Convey("Then it is queued only once", func() {
+				So(q.queue, ShouldHaveLength, 1)
+			})
+
+			Convey("Then it can be queued again once it has been taken from the queue", func() {
+				So(q.next(), ShouldEqual, lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1})
+				So(q.add(lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}), ShouldBeNil)
+				So(q.queue, ShouldHaveLength, 1)
+			})
+		})
+
+		Convey("When the queue is full", func() {
+			So(q.add(lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}), ShouldBeNil)
+			So(q.add(lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2}), ShouldBeNil)
+
+			Convey("Then adding an other DevEUI returns an error", func() {
+				So(q.add(lorawan.EUI64{3, 3, 3, 3, 3, 3, 3, 3}), ShouldNotBeNil)
+				So(q.pending, ShouldHaveLength, 2)
+			})
+		})
+	})
+}
@@ -0,0 +1,235 @@
+// Package geolocation implements the network-side geolocation of devices,
+// based on the locations of the gateways that received an uplink.
+package geolocation
+
+import (
+	"math"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// earthRadius defines the (mean) radius of the earth in meters.
+	earthRadius = 6371000.0
+
+	// speedOfLight defines the speed of light in meters / second.
+	speedOfLight = 299792458.0
+
+	// tdoaMaxIterations defines the max number of iterations of the TDOA
+	// solver.
+	tdoaMaxIterations = 50
+
+	// tdoaConvergence defines the step-size (meters) at which the TDOA
+	// solver has converged.
+	tdoaConvergence = 0.01
+
+	// tdoaMaxDistance defines the max distance (meters) between the TDOA
+	// solution and the RSSI weighted centroid. A solution outside this range
+	// is considered invalid (e.g. caused by unsynchronized gateway clocks).
+	tdoaMaxDistance = 100000.0
+)
+
+// Method defines the method used to resolve the location.
+type Method int
+
+// Available geolocation methods.
+const (
+	RSSICentroid Method = iota
+	TDOA
+)
+
+// ErrNoReceivers is returned when none of the receivers has a location.
+var ErrNoReceivers = errors.New("geolocation requires at least one receiver")
+
+// ErrTDOANotPossible is returned when the location can't be resolved using
+// TDOA.
+var ErrTDOANotPossible = errors.New("location can not be resolved using tdoa")
+
+// Location defines a location.
+type Location struct {
+	Latitude  float64
+	Longitude float64
+	Altitude  float64
+}
+
+// Receiver defines a gateway which received the uplink. The
+// FineTimeSinceGPSEpoch must have a nanosecond precision (fine-timestamp), as
+// the millisecond precision GPS time does not allow to resolve the TDOA.
+type Receiver struct {
+	Location              Location
+	RSSI                  int
+	FineTimeSinceGPSEpoch *time.Duration
+}
+
+// Result contains the resolved location.
+type Result struct {
+	Location     Location
+	Method       Method
+	GatewayCount int
+}
+
+// Resolve resolves the location of the device. When at least three receivers
+// provide a fine-timestamp, TDOA is used. In all other cases (or when
+// the TDOA solver fails), the RSSI weighted centroid is returned.
+func Resolve(receivers []Receiver) (Result, error) {
+	centroid, err := RSSICentroidLocation(receivers)
+	if err != nil {
+		return Result{}, err
+	}
+
+	loc, err := TDOALocation(receivers, centroid)
+	if err == nil {
+		return Result{
+			Location:     loc,
+			Method:       TDOA,
+			GatewayCount: len(tdoaReceivers(receivers)),
+		}, nil
+	}
+
+	return Result{
+		Location:     centroid,
+		Method:       RSSICentroid,
+		GatewayCount: len(receivers),
+	}, nil
+}
+
+// RSSICentroidLocation returns the centroid of the receiver locations,
+// weighted by the (linear) RSSI of each receiver.
+func RSSICentroidLocation(receivers []Receiver) (Location, error) {
+	if len(receivers) == 0 {
+		return Location{}, ErrNoReceivers
+	}
+
+	var loc Location
+	var sum float64
+
+	for _, r := range receivers {
+		w := math.Pow(10, float64(r.RSSI)/20)
+		loc.Latitude += w * r.Location.Latitude
+		loc.Longitude += w * r.Location.Longitude
+		loc.Altitude += w * r.Location.Altitude
+		sum += w
+	}
+
+	loc.Latitude /= sum
+	loc.Longitude /= sum
+	loc.Altitude /= sum
+
+	return loc, nil
+}
+
+// TDOALocation returns the location based on the time difference of arrival
+// between the receivers, using the given location as starting point. This
+// requires at least three receivers with a fine-timestamp. As the
+// gateways are located at (roughly) the same altitude, the altitude of the
+// given location is returned.
+func TDOALocation(receivers []Receiver, start Location) (Location, error) {
+	rs := tdoaReceivers(receivers)
+	if len(rs) < 3 {
+		return Location{}, ErrTDOANotPossible
+	}
+
+	// use the first receiver as reference
+	ref := *rs[0].FineTimeSinceGPSEpoch
+	gws := make([]point, len(rs))
+	diffs := make([]float64, len(rs))
+	for i, r := range rs {
+		gws[i] = toPoint(start, r.Location)
+		diffs[i] = speedOfLight * (*r.FineTimeSinceGPSEpoch - ref).Seconds()
+	}
+
+	var p point
+	for i := 0; i < tdoaMaxIterations; i++ {
+		// Gauss-Newton step, solving (JᵀJ)Δ = -Jᵀr
+		var a11, a12, a22, b1, b2 float64
+		d0 := distance(p, gws[0])
+
+		for j := 1; j < len(gws); j++ {
+			dj := distance(p, gws[j])
+			res := dj - d0 - diffs[j]
+			jx := (p.x-gws[j].x)/dj - (p.x-gws[0].x)/d0
+			jy := (p.y-gws[j].y)/dj - (p.y-gws[0].y)/d0
+
+			a11 += jx * jx
+			a12 += jx * jy
+			a22 += jy * jy
+			b1 -= jx * res
+			b2 -= jy * res
+		}
+
+		det := a11*a22 - a12*a12
+		if math.Abs(det) < 1e-12 {
+			return Location{}, ErrTDOANotPossible
+		}
+
+		dx := (b1*a22 - b2*a12) / det
+		dy := (a11*b2 - a12*b1) / det
+		p.x += dx
+		p.y += dy
+
+		if math.Hypot(dx, dy) < tdoaConvergence {
+			if math.Hypot(p.x, p.y) > tdoaMaxDistance {
+				return Location{}, ErrTDOANotPossible
+			}
+
+			loc := toLocation(start, p)
+			loc.Altitude = start.Altitude
+			return loc, nil
+		}
+	}
+
+	return Location{}, ErrTDOANotPossible
+}
+
+// tdoaReceivers returns the receivers having a fine-timestamp.
+func tdoaReceivers(receivers []Receiver) []Receiver {
+	var out []Receiver
+	for _, r := range receivers {
+		if r.FineTimeSinceGPSEpoch != nil {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// point defines a position (meters) on a plane tangent to the earth.
+type point struct {
+	x float64
+	y float64
+}
+
+// toPoint projects the given location on the plane tangent to the earth at
+// the origin location.
+func toPoint(origin, loc Location) point {
+	return point{
+		x: radians(loc.Longitude-origin.Longitude) * math.Cos(radians(origin.Latitude)) * earthRadius,
+		y: radians(loc.Latitude-origin.Latitude) * earthRadius,
+	}
+}
+
+// toLocation is the inverse of toPoint.
+func toLocation(origin Location, p point) Location {
+	return Location{
+		Latitude:  origin.Latitude + degrees(p.y/earthRadius),
+		Longitude: origin.Longitude + degrees(p.x/(earthRadius*math.Cos(radians(origin.Latitude)))),
+	}
+}
+
+func distance(a, b point) float64 {
+	d := math.Hypot(a.x-b.x, a.y-b.y)
+
+	// avoid a division by zero when the solver hits a receiver location
+	if d < 1e-6 {
+		return 1e-6
+	}
+	return d
+}
+
+func radians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+func degrees(rad float64) float64 {
+	return rad * 180 / math.Pi
+}
@@ -0,0 +1,146 @@
+package geolocation
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRSSICentroidLocation(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		tests := []struct {
+			Name             string
+			Receivers        []Receiver
+			ExpectedLocation Location
+			ExpectedError    error
+		}{
+			{
+				Name:          "no receivers",
+				ExpectedError: ErrNoReceivers,
+			},
+			{
+				Name: "single receiver",
+				Receivers: []Receiver{
+					{Location: Location{Latitude: 1.1234, Longitude: 1.1235, Altitude: 10.5}, RSSI: -100},
+				},
+				ExpectedLocation: Location{Latitude: 1.1234, Longitude: 1.1235, Altitude: 10.5},
+			},
+			{
+				Name: "two receivers with equal rssi",
+				Receivers: []Receiver{
+					{Location: Location{Latitude: 1, Longitude: 2, Altitude: 10}, RSSI: -80},
+					{Location: Location{Latitude: 2, Longitude: 4, Altitude: 20}, RSSI: -80},
+				},
+				ExpectedLocation: Location{Latitude: 1.5, Longitude: 3, Altitude: 15},
+			},
+			{
+				Name: "two receivers with 20dB rssi difference",
+				Receivers: []Receiver{
+					{Location: Location{Latitude: 0, Longitude: 0}, RSSI: -60},
+					{Location: Location{Latitude: 11, Longitude: 22}, RSSI: -80},
+				},
+				ExpectedLocation: Location{Latitude: 1, Longitude: 2},
+			},
+		}
+
+		for _, test := range tests {
+			Convey("Testing: "+test.Name, func() {
+				loc, err := RSSICentroidLocation(test.Receivers)
+				So(err, ShouldEqual, test.ExpectedError)
+				So(loc.Latitude, ShouldAlmostEqual, test.ExpectedLocation.Latitude)
+				So(loc.Longitude, ShouldAlmostEqual, test.ExpectedLocation.Longitude)
+				So(loc.Altitude, ShouldAlmostEqual, test.ExpectedLocation.Altitude)
+			})
+		}
+	})
+}
+
+func TestTDOALocation(t *testing.T) {
+	Convey("Given a device and a set of gateways", t, func() {
+		origin := Location{Latitude: 52.3676, Longitude: 4.9041}
+		device := toLocation(origin, point{x: 1200, y: -750})
+		gateways := []Location{
+			toLocation(origin, point{x: -3000, y: -2500}),
+			toLocation(origin, point{x: 4000, y: -1000}),
+			toLocation(origin, point{x: 500, y: 3500}),
+			toLocation(origin, point{x: -2000, y: 2000}),
+		}
+
+		// returns the receiver for the given gateway, with the time of
+		// arrival based on the distance to the device
+		receiver := func(gw Location) Receiver {
+			d := distance(toPoint(origin, device), toPoint(origin, gw))
+			ts := 10*time.Second + time.Duration(d/speedOfLight*float64(time.Second))
+
+			return Receiver{
+				Location:              gw,
+				RSSI:                  -100,
+				FineTimeSinceGPSEpoch: &ts,
+			}
+		}
+
+		Convey("When resolving the location using three receivers", func() {
+			receivers := []Receiver{
+				receiver(gateways[0]),
+				receiver(gateways[1]),
+				receiver(gateways[2]),
+			}
+			centroid, err := RSSICentroidLocation(receivers)
+			So(err, ShouldBeNil)
+
+			loc, err := TDOALocation(receivers, centroid)
+			So(err, ShouldBeNil)
+
+			Convey("Then the location is within 1 meter of the device", func() {
+				p := toPoint(device, loc)
+				So(math.Hypot(p.x, p.y), ShouldBeLessThan, 1)
+			})
+		})
+
+		Convey("When resolving the location using four receivers", func() {
+			var receivers []Receiver
+			for _, gw := range gateways {
+				receivers = append(receivers, receiver(gw))
+			}
+
+			res, err := Resolve(receivers)
+			So(err, ShouldBeNil)
+
+			Convey("Then TDOA has been used", func() {
+				So(res.Method, ShouldEqual, TDOA)
+				So(res.GatewayCount, ShouldEqual, 4)
+
+				p := toPoint(device, res.Location)
+				So(math.Hypot(p.x, p.y), ShouldBeLessThan, 1)
+			})
+		})
+
+		Convey("When only two receivers have a fine-timestamp", func() {
+			receivers := []Receiver{
+				receiver(gateways[0]),
+				receiver(gateways[1]),
+				{Location: gateways[2], RSSI: -100},
+			}
+
+			Convey("Then TDOA is not possible", func() {
+				_, err := TDOALocation(receivers, origin)
+				So(err, ShouldEqual, ErrTDOANotPossible)
+			})
+
+			Convey("Then Resolve falls back to the RSSI centroid", func() {
+				centroid, err := RSSICentroidLocation(receivers)
+				So(err, ShouldBeNil)
+
+				res, err := Resolve(receivers)
+				So(err, ShouldBeNil)
+				So(res, ShouldResemble, Result{
+					Location:     centroid,
+					Method:       RSSICentroid,
+					GatewayCount: 3,
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,53 @@
+package maccommand
+
+import (
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/loraserver/internal/storage"
+	"github.com/brocaar/lorawan"
+)
+
+// Default ADR_ACK_LIMIT (64) and ADR_ACK_DELAY (32) exponents, as specified
+// by the LoRaWAN specification.
+const (
+	DefaultADRAckLimitExp = 6
+	DefaultADRAckDelayExp = 5
+)
+
+// RequestADRParamSetup modifies the ADR_ACK_LIMIT (2^limitExp) and
+// ADR_ACK_DELAY (2^delayExp) of the device.
+func RequestADRParamSetup(limitExp, delayExp int) storage.MACCommandBlock {
+	return storage.MACCommandBlock{
+		CID: lorawan.ADRParamSetupReq,
+		MACCommands: []lorawan.MACCommand{
+			{
+				CID: lorawan.ADRParamSetupReq,
+				Payload: &lorawan.ADRParamSetupReqPayload{
+					ADRParam: lorawan.ADRParam{
+						LimitExp: uint8(limitExp),
+						DelayExp: uint8(delayExp),
+					},
+				},
+			},
+		},
+	}
+}
+
+func handleADRParamSetupAns(ds *storage.DeviceSession, block storage.MACCommandBlock, pendingBlock *storage.MACCommandBlock) ([]storage.MACCommandBlock, error) {
+	if pendingBlock == nil || len(pendingBlock.MACCommands) == 0 {
+		return nil, errors.New("expected pending mac-command")
+	}
+	req := pendingBlock.MACCommands[0].Payload.(*lorawan.ADRParamSetupReqPayload)
+
+	ds.ADRAckLimitExp = int(req.ADRParam.LimitExp)
+	ds.ADRAckDelayExp = int(req.ADRParam.DelayExp)
+
+	log.WithFields(log.Fields{
+		"dev_eui":           ds.DevEUI,
+		"adr_ack_limit_exp": ds.ADRAckLimitExp,
+		"adr_ack_delay_exp": ds.ADRAckDelayExp,
+	}).Info("adr_param_setup request acknowledged")
+
+	return nil, nil
+}
@@ -0,0 +1,84 @@
+package maccommand
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/brocaar/loraserver/internal/storage"
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRequestADRParamSetup(t *testing.T) {
+	Convey("When calling RequestADRParamSetup", t, func() {
+		block := RequestADRParamSetup(8, 6)
+
+		Convey("Then the expected block is returned", func() {
+			So(block, ShouldResemble, storage.MACCommandBlock{
+				CID: lorawan.ADRParamSetupReq,
+				MACCommands: []lorawan.MACCommand{
+					{
+						CID: lorawan.ADRParamSetupReq,
+						Payload: &lorawan.ADRParamSetupReqPayload{
+							ADRParam: lorawan.ADRParam{
+								LimitExp: 8,
+								DelayExp: 6,
+							},
+						},
+					},
+				},
+			})
+		})
+	})
+}
+
+func TestHandleADRParamSetupAns(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		tests := []struct {
+			Name                    string
+			DeviceSession           storage.DeviceSession
+			ReceivedMACCommandBlock storage.MACCommandBlock
+			PendingMACCommandBlock  *storage.MACCommandBlock
+			ExpectedDeviceSession   storage.DeviceSession
+			ExpectedError           error
+		}{
+			{
+				Name: "adr param setup ack",
+				DeviceSession: storage.DeviceSession{
+					ADRAckLimitExp: 6,
+					ADRAckDelayExp: 5,
+				},
+				ReceivedMACCommandBlock: storage.MACCommandBlock{
+					CID: lorawan.ADRParamSetupAns,
+				},
+				PendingMACCommandBlock: &storage.MACCommandBlock{
+					CID: lorawan.ADRParamSetupReq,
+					MACCommands: []lorawan.MACCommand{
+						{
+							CID: lorawan.ADRParamSetupReq,
+							Payload: &lorawan.ADRParamSetupReqPayload{
+								ADRParam: lorawan.ADRParam{
+									LimitExp: 8,
+									DelayExp: 6,
+								},
+							},
+						},
+					},
+				},
+				ExpectedDeviceSession: storage.DeviceSession{
+					ADRAckLimitExp: 8,
+					ADRAckDelayExp: 6,
+				},
+			},
+		}
+
+		for i, t := range tests {
+			Convey(fmt.Sprintf("Testing: %s [%d]", t.Name, i), func() {
+				ans, err := handleADRParamSetupAns(&t.DeviceSession, t.ReceivedMACCommandBlock, t.PendingMACCommandBlock)
+				So(err, ShouldResemble, t.ExpectedError)
+				So(ans, ShouldBeNil)
+				So(t.DeviceSession, ShouldResemble, t.ExpectedDeviceSession)
+			})
+		}
+	})
+}
@@ -0,0 +1,64 @@
+package maccommand
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/loraserver/internal/storage"
+	"github.com/brocaar/lorawan"
+)
+
+// RequestBeaconFreq modifies the frequency on which the end-device expects
+// the Class-B beacon. A frequency of 0 restores the default beacon
+// frequency.
+func RequestBeaconFreq(freq int) storage.MACCommandBlock {
+	return storage.MACCommandBlock{
+		CID: lorawan.BeaconFreqReq,
+		MACCommands: []lorawan.MACCommand{
+			{
+				CID: lorawan.BeaconFreqReq,
+				Payload: &lorawan.BeaconFreqReqPayload{
+					Frequency: uint32(freq),
+				},
+			},
+		},
+	}
+}
+
+func handleBeaconFreqAns(ds *storage.DeviceSession, block storage.MACCommandBlock, pendingBlock *storage.MACCommandBlock) ([]storage.MACCommandBlock, error) {
+	if len(block.MACCommands) != 1 {
+		return nil, fmt.Errorf("exactly one mac-command expected, got: %d", len(block.MACCommands))
+	}
+
+	if pendingBlock == nil || len(pendingBlock.MACCommands) == 0 {
+		return nil, errors.New("expected pending mac-command")
+	}
+	req, ok := pendingBlock.MACCommands[0].Payload.(*lorawan.BeaconFreqReqPayload)
+	if !ok {
+		return nil, fmt.Errorf("expected *lorawan.BeaconFreqReqPayload, got %T", pendingBlock.MACCommands[0].Payload)
+	}
+
+	pl, ok := block.MACCommands[0].Payload.(*lorawan.BeaconFreqAnsPayload)
+	if !ok {
+		return nil, fmt.Errorf("expected *lorawan.BeaconFreqAnsPayload, got %T", block.MACCommands[0].Payload)
+	}
+
+	if !pl.BeaconFrequencyOK {
+		log.WithFields(log.Fields{
+			"dev_eui":          ds.DevEUI,
+			"beacon_frequency": req.Frequency,
+		}).Warning("beacon_freq request not acknowledged")
+		return nil, nil
+	}
+
+	ds.BeaconFrequency = int(req.Frequency)
+
+	log.WithFields(log.Fields{
+		"dev_eui":          ds.DevEUI,
+		"beacon_frequency": ds.BeaconFrequency,
+	}).Info("beacon_freq request acknowledged")
+
+	return nil, nil
+}
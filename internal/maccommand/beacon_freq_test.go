@@ -0,0 +1,117 @@
+package maccommand
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/brocaar/loraserver/internal/storage"
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRequestBeaconFreq(t *testing.T) {
+	Convey("When calling RequestBeaconFreq", t, func() {
+		block := RequestBeaconFreq(869100000)
+
+		Convey("Then the expected block is returned", func() {
+			So(block, ShouldResemble, storage.MACCommandBlock{
+				CID: lorawan.BeaconFreqReq,
+				MACCommands: []lorawan.MACCommand{
+					{
+						CID: lorawan.BeaconFreqReq,
+						Payload: &lorawan.BeaconFreqReqPayload{
+							Frequency: 869100000,
+						},
+					},
+				},
+			})
+		})
+	})
+}
+
+func TestHandleBeaconFreqAns(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		pending := storage.MACCommandBlock{
+			CID: lorawan.BeaconFreqReq,
+			MACCommands: []lorawan.MACCommand{
+				{
+					CID: lorawan.BeaconFreqReq,
+					Payload: &lorawan.BeaconFreqReqPayload{
+						Frequency: 869100000,
+					},
+				},
+			},
+		}
+
+		tests := []struct {
+			Name                    string
+			DeviceSession           storage.DeviceSession
+			ReceivedMACCommandBlock storage.MACCommandBlock
+			PendingMACCommandBlock  *storage.MACCommandBlock
+			ExpectedDeviceSession   storage.DeviceSession
+			ExpectedError           error
+		}{
+			{
+				Name: "beacon freq ack",
+				ReceivedMACCommandBlock: storage.MACCommandBlock{
+					CID: lorawan.BeaconFreqAns,
+					MACCommands: []lorawan.MACCommand{
+						{
+							CID: lorawan.BeaconFreqAns,
+							Payload: &lorawan.BeaconFreqAnsPayload{
+								BeaconFrequencyOK: true,
+							},
+						},
+					},
+				},
+				PendingMACCommandBlock: &pending,
+				ExpectedDeviceSession: storage.DeviceSession{
+					BeaconFrequency: 869100000,
+				},
+			},
+			{
+				Name: "beacon freq nack",
+				ReceivedMACCommandBlock: storage.MACCommandBlock{
+					CID: lorawan.BeaconFreqAns,
+					MACCommands: []lorawan.MACCommand{
+						{
+							CID:     lorawan.BeaconFreqAns,
+							Payload: &lorawan.BeaconFreqAnsPayload{},
+						},
+					},
+				},
+				PendingMACCommandBlock: &pending,
+			},
+			{
+				Name: "beacon freq ack without pending request",
+				ReceivedMACCommandBlock: storage.MACCommandBlock{
+					CID: lorawan.BeaconFreqAns,
+					MACCommands: []lorawan.MACCommand{
+						{
+							CID: lorawan.BeaconFreqAns,
+							Payload: &lorawan.BeaconFreqAnsPayload{
+								BeaconFrequencyOK: true,
+							},
+						},
+					},
+				},
+				ExpectedError: errors.New("expected pending mac-command"),
+			},
+		}
+
+		for i, t := range tests {
+			Convey(fmt.Sprintf("Testing: %s [%d]", t.Name, i), func() {
+				ans, err := handleBeaconFreqAns(&t.DeviceSession, t.ReceivedMACCommandBlock, t.PendingMACCommandBlock)
+				if t.ExpectedError != nil {
+					So(err, ShouldNotBeNil)
+					So(err.Error(), ShouldEqual, t.ExpectedError.Error())
+				} else {
+					So(err, ShouldBeNil)
+				}
+				So(ans, ShouldBeNil)
+				So(t.DeviceSession, ShouldResemble, t.ExpectedDeviceSession)
+			})
+		}
+	})
+}
@@ -8,6 +8,7 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"github.com/brocaar/loraserver/api/as"
+	"github.com/brocaar/loraserver/internal/config"
 	"github.com/brocaar/loraserver/internal/storage"
 	"github.com/brocaar/lorawan"
 )
@@ -60,6 +61,19 @@ func handleDevStatusAns(ds *storage.DeviceSession, sp storage.ServiceProfile, as
 		if sp.ReportDevStatusMargin {
 			req.Margin = int32(pl.Margin)
 		}
+		if sp.MinGWDiversity > 0 {
+			stats, err := storage.GetGatewayDiversityStats(config.C.Redis.Pool, ds.DevEUI)
+			if err != nil {
+				log.WithField("dev_eui", ds.DevEUI).WithError(err).Error("get gateway diversity stats error")
+			} else {
+				req.GwDiversity = &as.GatewayDiversityStats{
+					UplinkCount:      uint32(stats.UplinkCount),
+					ViolationCount:   uint32(stats.ViolationCount),
+					AvgGatewayCount:  stats.AvgGatewayCount(),
+					LastGatewayCount: uint32(stats.LastGatewayCount),
+				}
+			}
+		}
 
 		_, err := asClient.SetDeviceStatus(context.Background(), &req)
 		if err != nil {
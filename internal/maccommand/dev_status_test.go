@@ -8,6 +8,8 @@ import (
 	"github.com/brocaar/lorawan"
 
 	"github.com/brocaar/loraserver/api/as"
+	"github.com/brocaar/loraserver/internal/common"
+	"github.com/brocaar/loraserver/internal/config"
 	"github.com/brocaar/loraserver/internal/storage"
 	"github.com/brocaar/loraserver/internal/test"
 	. "github.com/smartystreets/goconvey/convey"
@@ -121,8 +123,50 @@ func TestDevStatusAns(t *testing.T) {
 					Margin: 10,
 				},
 			},
+			{
+				Name: "report device-status with gateway diversity stats",
+				DeviceSession: storage.DeviceSession{
+					DevEUI: lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+				},
+				ServiceProfile: storage.ServiceProfile{
+					ReportDevStatusBattery: true,
+					ReportDevStatusMargin:  true,
+					MinGWDiversity:         2,
+				},
+				ReceivedMACCommandBlock: storage.MACCommandBlock{
+					CID: lorawan.DevStatusAns,
+					MACCommands: []lorawan.MACCommand{
+						{
+							CID: lorawan.DevStatusAns,
+							Payload: &lorawan.DevStatusAnsPayload{
+								Margin:  10,
+								Battery: 150,
+							},
+						},
+					},
+				},
+				ExpectedSetDeviceStatusRequest: as.SetDeviceStatusRequest{
+					DevEui:  []byte{1, 2, 3, 4, 5, 6, 7, 8},
+					Battery: 150,
+					Margin:  10,
+					GwDiversity: &as.GatewayDiversityStats{
+						UplinkCount:      2,
+						ViolationCount:   1,
+						AvgGatewayCount:  1.5,
+						LastGatewayCount: 1,
+					},
+				},
+			},
 		}
 
+		conf := test.GetConfig()
+		config.C.Redis.Pool = common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(config.C.Redis.Pool)
+
+		devEUI := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+		So(storage.UpdateGatewayDiversityStats(config.C.Redis.Pool, devEUI, 2, false), ShouldBeNil)
+		So(storage.UpdateGatewayDiversityStats(config.C.Redis.Pool, devEUI, 1, true), ShouldBeNil)
+
 		for i, t := range tests {
 			Convey(fmt.Sprintf("Testing: %s [%d]", t.Name, i), func() {
 				asClient := test.NewApplicationClient()
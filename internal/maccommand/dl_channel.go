@@ -0,0 +1,102 @@
+package maccommand
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/loraserver/internal/storage"
+	"github.com/brocaar/lorawan"
+)
+
+// RequestDLChannels modifies the RX1 downlink frequency of the uplink
+// channels in case of changes between the current and wanted frequencies
+// (uplink channel index to downlink frequency). The max number of channels
+// to modify must be given. In case of no changes, nil is returned.
+func RequestDLChannels(maxChannels int, currentFrequencies, wantedFrequencies map[int]int) *storage.MACCommandBlock {
+	var out []lorawan.MACCommand
+
+	// sort by channel index
+	var wantedChannelNumbers []int
+	for i := range wantedFrequencies {
+		wantedChannelNumbers = append(wantedChannelNumbers, i)
+	}
+	sort.Ints(wantedChannelNumbers)
+
+	for _, i := range wantedChannelNumbers {
+		wanted := wantedFrequencies[i]
+		if current, ok := currentFrequencies[i]; !ok || current != wanted {
+			out = append(out, lorawan.MACCommand{
+				CID: lorawan.DLChannelReq,
+				Payload: &lorawan.DLChannelReqPayload{
+					ChIndex: uint8(i),
+					Freq:    uint32(wanted),
+				},
+			})
+		}
+	}
+
+	if len(out) > maxChannels {
+		out = out[0:maxChannels]
+	}
+
+	if len(out) == 0 {
+		return nil
+	}
+
+	return &storage.MACCommandBlock{
+		CID:         lorawan.DLChannelReq,
+		MACCommands: storage.MACCommands(out),
+	}
+}
+
+func handleDLChannelAns(ds *storage.DeviceSession, block storage.MACCommandBlock, pending *storage.MACCommandBlock) ([]storage.MACCommandBlock, error) {
+	if len(block.MACCommands) == 0 {
+		return nil, errors.New("at least 1 mac-command expected, got none")
+	}
+
+	if pending == nil || len(pending.MACCommands) == 0 {
+		return nil, errors.New("expected pending mac-command")
+	}
+
+	if len(block.MACCommands) != len(pending.MACCommands) {
+		return nil, fmt.Errorf("received %d mac-command answers, but requested %d", len(block.MACCommands), len(pending.MACCommands))
+	}
+
+	for i := range block.MACCommands {
+		pl, ok := block.MACCommands[i].Payload.(*lorawan.DLChannelAnsPayload)
+		if !ok {
+			return nil, fmt.Errorf("expected *lorawan.DLChannelAnsPayload, got %T", block.MACCommands[i].Payload)
+		}
+
+		pendingPL, ok := pending.MACCommands[i].Payload.(*lorawan.DLChannelReqPayload)
+		if !ok {
+			return nil, fmt.Errorf("expected *lorawan.DLChannelReqPayload, got %T", pending.MACCommands[i].Payload)
+		}
+
+		if pl.UplinkFrequencyExists && pl.ChannelFrequencyOK {
+			if ds.DLChannelFrequencies == nil {
+				ds.DLChannelFrequencies = make(map[int]int)
+			}
+			ds.DLChannelFrequencies[int(pendingPL.ChIndex)] = int(pendingPL.Freq)
+
+			log.WithFields(log.Fields{
+				"dev_eui":   ds.DevEUI,
+				"frequency": pendingPL.Freq,
+				"channel":   pendingPL.ChIndex,
+			}).Info("dl_channel request acknowledged")
+		} else {
+			log.WithFields(log.Fields{
+				"dev_eui":                 ds.DevEUI,
+				"frequency":               pendingPL.Freq,
+				"channel":                 pendingPL.ChIndex,
+				"uplink_frequency_exists": pl.UplinkFrequencyExists,
+				"channel_frequency_ok":    pl.ChannelFrequencyOK,
+			}).Warning("dl_channel request not acknowledged")
+		}
+	}
+
+	return nil, nil
+}
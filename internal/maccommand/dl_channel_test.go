@@ -0,0 +1,148 @@
+package maccommand
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/loraserver/internal/storage"
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRequestDLChannels(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		tests := []struct {
+			Name                    string
+			CurrentFrequencies      map[int]int
+			WantedFrequencies       map[int]int
+			ExpectedMACCommandBlock *storage.MACCommandBlock
+		}{
+			{
+				Name:               "no changes",
+				CurrentFrequencies: map[int]int{1: 869525000},
+				WantedFrequencies:  map[int]int{1: 869525000},
+			},
+			{
+				Name:               "modifying channels",
+				CurrentFrequencies: map[int]int{1: 869525000},
+				WantedFrequencies:  map[int]int{0: 869525000, 1: 869525000, 2: 868500000},
+				ExpectedMACCommandBlock: &storage.MACCommandBlock{
+					CID: lorawan.DLChannelReq,
+					MACCommands: storage.MACCommands{
+						{
+							CID: lorawan.DLChannelReq,
+							Payload: &lorawan.DLChannelReqPayload{
+								ChIndex: 0,
+								Freq:    869525000,
+							},
+						},
+						{
+							CID: lorawan.DLChannelReq,
+							Payload: &lorawan.DLChannelReqPayload{
+								ChIndex: 2,
+								Freq:    868500000,
+							},
+						},
+					},
+				},
+			},
+		}
+
+		for i, test := range tests {
+			Convey(fmt.Sprintf("Testing: %s [%d]", test.Name, i), func() {
+				So(RequestDLChannels(3, test.CurrentFrequencies, test.WantedFrequencies), ShouldResemble, test.ExpectedMACCommandBlock)
+			})
+		}
+	})
+}
+
+func TestHandleDLChannelAns(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		pending := &storage.MACCommandBlock{
+			CID: lorawan.DLChannelReq,
+			MACCommands: storage.MACCommands{
+				{
+					CID: lorawan.DLChannelReq,
+					Payload: &lorawan.DLChannelReqPayload{
+						ChIndex: 1,
+						Freq:    869525000,
+					},
+				},
+			},
+		}
+
+		tests := []struct {
+			Name                    string
+			DeviceSession           storage.DeviceSession
+			ReceivedMACCommandBlock storage.MACCommandBlock
+			PendingMACCommandBlock  *storage.MACCommandBlock
+			ExpectedDeviceSession   storage.DeviceSession
+			ExpectedError           error
+		}{
+			{
+				Name: "dl channel ack",
+				ReceivedMACCommandBlock: storage.MACCommandBlock{
+					CID: lorawan.DLChannelAns,
+					MACCommands: storage.MACCommands{
+						{
+							CID: lorawan.DLChannelAns,
+							Payload: &lorawan.DLChannelAnsPayload{
+								UplinkFrequencyExists: true,
+								ChannelFrequencyOK:    true,
+							},
+						},
+					},
+				},
+				PendingMACCommandBlock: pending,
+				ExpectedDeviceSession: storage.DeviceSession{
+					DLChannelFrequencies: map[int]int{1: 869525000},
+				},
+			},
+			{
+				Name: "dl channel nack",
+				ReceivedMACCommandBlock: storage.MACCommandBlock{
+					CID: lorawan.DLChannelAns,
+					MACCommands: storage.MACCommands{
+						{
+							CID: lorawan.DLChannelAns,
+							Payload: &lorawan.DLChannelAnsPayload{
+								UplinkFrequencyExists: true,
+								ChannelFrequencyOK:    false,
+							},
+						},
+					},
+				},
+				PendingMACCommandBlock: pending,
+			},
+			{
+				Name: "no pending mac-command",
+				ReceivedMACCommandBlock: storage.MACCommandBlock{
+					CID: lorawan.DLChannelAns,
+					MACCommands: storage.MACCommands{
+						{
+							CID:     lorawan.DLChannelAns,
+							Payload: &lorawan.DLChannelAnsPayload{},
+						},
+					},
+				},
+				ExpectedError: errors.New("expected pending mac-command"),
+			},
+		}
+
+		for i, t := range tests {
+			Convey(fmt.Sprintf("Testing: %s [%d]", t.Name, i), func() {
+				ans, err := handleDLChannelAns(&t.DeviceSession, t.ReceivedMACCommandBlock, t.PendingMACCommandBlock)
+				if t.ExpectedError != nil {
+					So(err, ShouldNotBeNil)
+					So(err.Error(), ShouldEqual, t.ExpectedError.Error())
+					return
+				}
+				So(err, ShouldBeNil)
+				So(ans, ShouldBeNil)
+				So(t.DeviceSession, ShouldResemble, t.ExpectedDeviceSession)
+			})
+		}
+	})
+}
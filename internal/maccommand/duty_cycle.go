@@ -0,0 +1,41 @@
+package maccommand
+
+import (
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/loraserver/internal/storage"
+	"github.com/brocaar/lorawan"
+)
+
+// RequestDutyCycle modifies the max aggregated duty-cycle of the device
+// to 1 / 2^maxDCycle.
+func RequestDutyCycle(maxDCycle int) storage.MACCommandBlock {
+	return storage.MACCommandBlock{
+		CID: lorawan.DutyCycleReq,
+		MACCommands: []lorawan.MACCommand{
+			{
+				CID: lorawan.DutyCycleReq,
+				Payload: &lorawan.DutyCycleReqPayload{
+					MaxDCycle: uint8(maxDCycle),
+				},
+			},
+		},
+	}
+}
+
+func handleDutyCycleAns(ds *storage.DeviceSession, block storage.MACCommandBlock, pendingBlock *storage.MACCommandBlock) ([]storage.MACCommandBlock, error) {
+	if pendingBlock == nil || len(pendingBlock.MACCommands) == 0 {
+		return nil, errors.New("expected pending mac-command")
+	}
+	req := pendingBlock.MACCommands[0].Payload.(*lorawan.DutyCycleReqPayload)
+
+	ds.MaxDCycle = req.MaxDCycle
+
+	log.WithFields(log.Fields{
+		"dev_eui":     ds.DevEUI,
+		"max_d_cycle": ds.MaxDCycle,
+	}).Info("duty_cycle request acknowledged")
+
+	return nil, nil
+}
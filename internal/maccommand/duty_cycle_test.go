@@ -0,0 +1,76 @@
+package maccommand
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/brocaar/loraserver/internal/storage"
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRequestDutyCycle(t *testing.T) {
+	Convey("When calling RequestDutyCycle", t, func() {
+		block := RequestDutyCycle(4)
+
+		Convey("Then the expected block is returned", func() {
+			So(block, ShouldResemble, storage.MACCommandBlock{
+				CID: lorawan.DutyCycleReq,
+				MACCommands: []lorawan.MACCommand{
+					{
+						CID: lorawan.DutyCycleReq,
+						Payload: &lorawan.DutyCycleReqPayload{
+							MaxDCycle: 4,
+						},
+					},
+				},
+			})
+		})
+	})
+}
+
+func TestHandleDutyCycleAns(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		tests := []struct {
+			Name                    string
+			DeviceSession           storage.DeviceSession
+			ReceivedMACCommandBlock storage.MACCommandBlock
+			PendingMACCommandBlock  *storage.MACCommandBlock
+			ExpectedDeviceSession   storage.DeviceSession
+			ExpectedError           error
+		}{
+			{
+				Name: "duty cycle ack",
+				DeviceSession: storage.DeviceSession{
+					MaxDCycle: 7,
+				},
+				ReceivedMACCommandBlock: storage.MACCommandBlock{
+					CID: lorawan.DutyCycleAns,
+				},
+				PendingMACCommandBlock: &storage.MACCommandBlock{
+					CID: lorawan.DutyCycleReq,
+					MACCommands: []lorawan.MACCommand{
+						{
+							CID: lorawan.DutyCycleReq,
+							Payload: &lorawan.DutyCycleReqPayload{
+								MaxDCycle: 4,
+							},
+						},
+					},
+				},
+				ExpectedDeviceSession: storage.DeviceSession{
+					RXMaxDCycle: 4,
+				},
+			},
+		}
+
+		for i, t := range tests {
+			Convey(fmt.Sprintf("Testing: %s [%d]", t.Name, i), func() {
+				ans, err := handleDutyCycleAns(&t.DeviceSession, t.ReceivedMACCommandBlock, t.PendingMACCommandBlock)
+				So(err, ShouldResemble, t.ExpectedError)
+				So(ans, ShouldBeNil)
+				So(t.DeviceSession, ShouldResemble, t.ExpectedDeviceSession)
+			})
+		}
+	})
+}
@@ -0,0 +1,29 @@
+package maccommand
+
+import (
+	"github.com/brocaar/loraserver/internal/storage"
+	"github.com/brocaar/lorawan"
+)
+
+// RequestForceRejoin requests the device to transmit a rejoin-request of
+// the given type, using the given data-rate. The device retransmits the
+// rejoin-request maxRetries times, with a delay of 32 seconds * 2^period
+// (plus a random delay) between the retransmissions.
+// Note that there is no answer to this mac-command, the device responds with
+// a rejoin-request.
+func RequestForceRejoin(rejoinType lorawan.JoinType, dr, maxRetries, period int) storage.MACCommandBlock {
+	return storage.MACCommandBlock{
+		CID: lorawan.ForceRejoinReq,
+		MACCommands: []lorawan.MACCommand{
+			{
+				CID: lorawan.ForceRejoinReq,
+				Payload: &lorawan.ForceRejoinReqPayload{
+					Period:     uint8(period),
+					MaxRetries: uint8(maxRetries),
+					RejoinType: uint8(rejoinType),
+					DR:         uint8(dr),
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,32 @@
+package maccommand
+
+import (
+	"testing"
+
+	"github.com/brocaar/loraserver/internal/storage"
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRequestForceRejoin(t *testing.T) {
+	Convey("When calling RequestForceRejoin", t, func() {
+		block := RequestForceRejoin(lorawan.RejoinRequestType2, 3, 2, 1)
+
+		Convey("Then the expected block is returned", func() {
+			So(block, ShouldResemble, storage.MACCommandBlock{
+				CID: lorawan.ForceRejoinReq,
+				MACCommands: []lorawan.MACCommand{
+					{
+						CID: lorawan.ForceRejoinReq,
+						Payload: &lorawan.ForceRejoinReqPayload{
+							Period:     1,
+							MaxRetries: 2,
+							RejoinType: 2,
+							DR:         3,
+						},
+					},
+				},
+			})
+		})
+	})
+}
@@ -20,6 +20,18 @@ func handleLinkADRAns(ds *storage.DeviceSession, block storage.MACCommandBlock,
 		return nil, errors.New("expected pending mac-command")
 	}
 
+	var linkADRPayloads []lorawan.LinkADRReqPayload
+	for i := range pendingBlock.MACCommands {
+		pl, ok := pendingBlock.MACCommands[i].Payload.(*lorawan.LinkADRReqPayload)
+		if !ok {
+			return nil, fmt.Errorf("expected *lorawan.LinkADRReqPayload, got %T", pendingBlock.MACCommands[i].Payload)
+		}
+		linkADRPayloads = append(linkADRPayloads, *pl)
+	}
+
+	// The pending block is processed by the device as a single atomic
+	// command. It is only applied when every answer acknowledges every
+	// part of it.
 	channelMaskACK := true
 	dataRateACK := true
 	powerACK := true
@@ -41,9 +53,12 @@ func handleLinkADRAns(ds *storage.DeviceSession, block storage.MACCommandBlock,
 		}
 	}
 
-	var linkADRPayloads []lorawan.LinkADRReqPayload
-	for i := range pendingBlock.MACCommands {
-		linkADRPayloads = append(linkADRPayloads, *pendingBlock.MACCommands[i].Payload.(*lorawan.LinkADRReqPayload))
+	if len(block.MACCommands) != len(linkADRPayloads) {
+		log.WithFields(log.Fields{
+			"dev_eui":   ds.DevEUI,
+			"req_count": len(linkADRPayloads),
+			"ans_count": len(block.MACCommands),
+		}).Warning("link_adr answer count does not match request count")
 	}
 
 	// as we're sending the same txpower and nbrep for each channel we
@@ -55,6 +70,9 @@ func handleLinkADRAns(ds *storage.DeviceSession, block storage.MACCommandBlock,
 		if err != nil {
 			return nil, errors.Wrap(err, "get enalbed channels for link_adr_req payloads error")
 		}
+		if len(chans) == 0 {
+			return nil, errors.New("link_adr request acknowledged, but resulting in no enabled channels")
+		}
 
 		ds.TXPowerIndex = int(adrReq.TXPower)
 		ds.DR = int(adrReq.DataRate)
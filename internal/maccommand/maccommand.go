@@ -36,6 +36,16 @@ func Handle(ds *storage.DeviceSession, dp storage.DeviceProfile, sp storage.Serv
 		return handleResetInd(ds, dp, block)
 	case lorawan.RejoinParamSetupAns:
 		return handleRejoinParamSetupAns(ds, block, pending)
+	case lorawan.DutyCycleAns:
+		return handleDutyCycleAns(ds, block, pending)
+	case lorawan.TXParamSetupAns:
+		return handleTXParamSetupAns(ds, block, pending)
+	case lorawan.DLChannelAns:
+		return handleDLChannelAns(ds, block, pending)
+	case lorawan.ADRParamSetupAns:
+		return handleADRParamSetupAns(ds, block, pending)
+	case lorawan.BeaconFreqAns:
+		return handleBeaconFreqAns(ds, block, pending)
 	default:
 		return nil, fmt.Errorf("undefined CID %d", block.CID)
 	}
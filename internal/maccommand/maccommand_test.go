@@ -286,6 +286,71 @@ func TestHandleDownlink(t *testing.T) {
 				}
 			})
 
+			Convey("Testing LinkADRAns for a block of LinkADRReq payloads", func() {
+				ds := storage.DeviceSession{
+					EnabledUplinkChannels: []int{0, 1, 2},
+				}
+
+				pending := storage.MACCommandBlock{
+					CID: lorawan.LinkADRReq,
+					MACCommands: storage.MACCommands{
+						{
+							CID: lorawan.LinkADRReq,
+							Payload: &lorawan.LinkADRReqPayload{
+								ChMask: lorawan.ChMask{true, true},
+							},
+						},
+						{
+							CID: lorawan.LinkADRReq,
+							Payload: &lorawan.LinkADRReqPayload{
+								ChMask:   lorawan.ChMask{true},
+								DataRate: 5,
+								TXPower:  3,
+								Redundancy: lorawan.Redundancy{
+									NbRep: 2,
+								},
+							},
+						},
+					},
+				}
+
+				answer := func(acks ...bool) storage.MACCommandBlock {
+					block := storage.MACCommandBlock{
+						CID: lorawan.LinkADRAns,
+					}
+					for _, ack := range acks {
+						block.MACCommands = append(block.MACCommands, lorawan.MACCommand{
+							CID: lorawan.LinkADRAns,
+							Payload: &lorawan.LinkADRAnsPayload{
+								ChannelMaskACK: ack,
+								DataRateACK:    true,
+								PowerACK:       true,
+							},
+						})
+					}
+					return block
+				}
+
+				Convey("When all answers are positive, the block is applied", func() {
+					_, err := Handle(&ds, storage.DeviceProfile{}, storage.ServiceProfile{}, nil, answer(true, true), &pending, models.RXPacket{})
+					So(err, ShouldBeNil)
+					So(ds, ShouldResemble, storage.DeviceSession{
+						EnabledUplinkChannels: []int{0},
+						TXPowerIndex:          3,
+						NbTrans:               2,
+						DR:                    5,
+					})
+				})
+
+				Convey("When one of the answers is negative, the block is not applied", func() {
+					_, err := Handle(&ds, storage.DeviceProfile{}, storage.ServiceProfile{}, nil, answer(true, false), &pending, models.RXPacket{})
+					So(err, ShouldBeNil)
+					So(ds, ShouldResemble, storage.DeviceSession{
+						EnabledUplinkChannels: []int{0, 1, 2},
+					})
+				})
+			})
+
 			Convey("Testing PingSlotChannelAns", func() {
 				testTable := []struct {
 					Name                  string
@@ -25,6 +25,7 @@ func TestReset(t *testing.T) {
 				Name:            "LoRaWAN 1.1 device",
 				DevMinorVersion: 1,
 				DeviceProfile: storage.DeviceProfile{
+					RXParametersSet:    true,
 					RXDelay1:           1,
 					RXDROffset1:        0,
 					RXDataRate2:        0,
@@ -82,6 +83,128 @@ func TestReset(t *testing.T) {
 					ExtraUplinkChannels:      map[int]band.Channel{},
 				},
 			},
+			{
+				Name:            "LoRaWAN 1.1 device without rx2 frequency in device-profile",
+				DevMinorVersion: 1,
+				DeviceProfile: storage.DeviceProfile{
+					RXParametersSet:    true,
+					RXDelay1:           1,
+					RXDROffset1:        0,
+					RXDataRate2:        0,
+					FactoryPresetFreqs: []int{868100000, 868300000, 868500000},
+					PingSlotDR:         2,
+					PingSlotFreq:       868100000,
+					PingSlotPeriod:     1,
+				},
+				DeviceSession: storage.DeviceSession{
+					TXPowerIndex:             3,
+					MinSupportedTXPowerIndex: 1,
+					MaxSupportedTXPowerIndex: 5,
+					ExtraUplinkChannels: map[int]band.Channel{
+						3: band.Channel{},
+					},
+					RXDelay:               3,
+					RX1DROffset:           1,
+					RX2DR:                 5,
+					RX2Frequency:          868900000,
+					EnabledUplinkChannels: []int{0, 1},
+					PingSlotDR:            3,
+					PingSlotFrequency:     868100000,
+					NbTrans:               3,
+				},
+				ExpectedMACCommandBlock: storage.MACCommandBlock{
+					CID: lorawan.ResetConf,
+					MACCommands: []lorawan.MACCommand{
+						{
+							CID: lorawan.ResetConf,
+							Payload: &lorawan.ResetConfPayload{
+								ServLoRaWANVersion: lorawan.Version{
+									Minor: 1,
+								},
+							},
+						},
+					},
+				},
+				ExpectedDeviceSession: storage.DeviceSession{
+					RXDelay:      1,
+					RX1DROffset:  0,
+					RX2DR:        0,
+					RX2Frequency: 869525000,
+					TXPowerIndex: 0,
+					DR:           0,
+					MinSupportedTXPowerIndex: 0,
+					MaxSupportedTXPowerIndex: 0,
+					MaxSupportedDR:           0,
+					NbTrans:                  1,
+					EnabledUplinkChannels:    []int{0, 1, 2},
+					ChannelFrequencies:       []int{868100000, 868300000, 868500000},
+					PingSlotNb:               4096,
+					PingSlotDR:               2,
+					PingSlotFrequency:        868100000,
+					ExtraUplinkChannels:      map[int]band.Channel{},
+				},
+			},
+			{
+				Name:            "LoRaWAN 1.1 device without rx parameters in device-profile",
+				DevMinorVersion: 1,
+				DeviceProfile: storage.DeviceProfile{
+					RXDelay1:           5,
+					RXDROffset1:        2,
+					RXDataRate2:        3,
+					RXFreq2:            868300000,
+					FactoryPresetFreqs: []int{868100000, 868300000, 868500000},
+					PingSlotDR:         2,
+					PingSlotFreq:       868100000,
+					PingSlotPeriod:     1,
+				},
+				DeviceSession: storage.DeviceSession{
+					TXPowerIndex:             3,
+					MinSupportedTXPowerIndex: 1,
+					MaxSupportedTXPowerIndex: 5,
+					ExtraUplinkChannels: map[int]band.Channel{
+						3: band.Channel{},
+					},
+					RXDelay:               3,
+					RX1DROffset:           1,
+					RX2DR:                 5,
+					RX2Frequency:          868900000,
+					EnabledUplinkChannels: []int{0, 1},
+					PingSlotDR:            3,
+					PingSlotFrequency:     868100000,
+					NbTrans:               3,
+				},
+				ExpectedMACCommandBlock: storage.MACCommandBlock{
+					CID: lorawan.ResetConf,
+					MACCommands: []lorawan.MACCommand{
+						{
+							CID: lorawan.ResetConf,
+							Payload: &lorawan.ResetConfPayload{
+								ServLoRaWANVersion: lorawan.Version{
+									Minor: 1,
+								},
+							},
+						},
+					},
+				},
+				ExpectedDeviceSession: storage.DeviceSession{
+					RXDelay:                  1,
+					RX1DROffset:              0,
+					RX2DR:                    0,
+					RX2Frequency:             869525000,
+					TXPowerIndex:             0,
+					DR:                       0,
+					MinSupportedTXPowerIndex: 0,
+					MaxSupportedTXPowerIndex: 0,
+					MaxSupportedDR:           0,
+					NbTrans:                  1,
+					EnabledUplinkChannels:    []int{0, 1, 2},
+					ChannelFrequencies:       []int{868100000, 868300000, 868500000},
+					PingSlotNb:               4096,
+					PingSlotDR:               2,
+					PingSlotFrequency:        868100000,
+					ExtraUplinkChannels:      map[int]band.Channel{},
+				},
+			},
 		}
 
 		for i, test := range tests {
@@ -0,0 +1,89 @@
+package maccommand
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/loraserver/internal/storage"
+	"github.com/brocaar/lorawan"
+)
+
+// txParamSetupEIRPTable contains the max EIRP (dBm) for each MaxEIRP index
+// of the TxParamSetupReq mac-command.
+var txParamSetupEIRPTable = []int{8, 10, 12, 13, 14, 16, 18, 20, 21, 24, 26, 27, 29, 30, 33, 36}
+
+// GetTXParamSetupEIRPIndex returns the MaxEIRP index for the given EIRP
+// (dBm). This is the index of the highest EIRP not exceeding the given
+// value. For an EIRP of 0 (not set), the highest index is returned.
+func GetTXParamSetupEIRPIndex(eirp int) int {
+	if eirp == 0 {
+		return len(txParamSetupEIRPTable) - 1
+	}
+
+	var index int
+	for i, v := range txParamSetupEIRPTable {
+		if v <= eirp {
+			index = i
+		}
+	}
+	return index
+}
+
+// GetTXParamSetupEIRP returns the EIRP (dBm) for the given MaxEIRP index.
+func GetTXParamSetupEIRP(index int) (int, error) {
+	if index < 0 || index >= len(txParamSetupEIRPTable) {
+		return 0, fmt.Errorf("invalid max eirp index: %d", index)
+	}
+	return txParamSetupEIRPTable[index], nil
+}
+
+// RequestTXParamSetup modifies the uplink and downlink dwell-time and the
+// max EIRP of the device.
+func RequestTXParamSetup(uplinkDwellTime400ms, downlinkDwellTime400ms bool, maxEIRPIndex int) storage.MACCommandBlock {
+	pl := lorawan.TXParamSetupReqPayload{
+		MaxEIRP: uint8(maxEIRPIndex),
+	}
+	if uplinkDwellTime400ms {
+		pl.UplinkDwellTime = lorawan.DwellTime400ms
+	}
+	if downlinkDwellTime400ms {
+		pl.DownlinkDwelltime = lorawan.DwellTime400ms
+	}
+
+	return storage.MACCommandBlock{
+		CID: lorawan.TXParamSetupReq,
+		MACCommands: []lorawan.MACCommand{
+			{
+				CID:     lorawan.TXParamSetupReq,
+				Payload: &pl,
+			},
+		},
+	}
+}
+
+func handleTXParamSetupAns(ds *storage.DeviceSession, block storage.MACCommandBlock, pendingBlock *storage.MACCommandBlock) ([]storage.MACCommandBlock, error) {
+	if pendingBlock == nil || len(pendingBlock.MACCommands) == 0 {
+		return nil, errors.New("expected pending mac-command")
+	}
+	req := pendingBlock.MACCommands[0].Payload.(*lorawan.TXParamSetupReqPayload)
+
+	maxEIRP, err := GetTXParamSetupEIRP(int(req.MaxEIRP))
+	if err != nil {
+		return nil, err
+	}
+
+	ds.UplinkDwellTime400ms = req.UplinkDwellTime == lorawan.DwellTime400ms
+	ds.DownlinkDwellTime400ms = req.DownlinkDwelltime == lorawan.DwellTime400ms
+	ds.MaxEIRP = maxEIRP
+
+	log.WithFields(log.Fields{
+		"dev_eui":                   ds.DevEUI,
+		"uplink_dwell_time_400ms":   ds.UplinkDwellTime400ms,
+		"downlink_dwell_time_400ms": ds.DownlinkDwellTime400ms,
+		"max_eirp":                  ds.MaxEIRP,
+	}).Info("tx_param_setup request acknowledged")
+
+	return nil, nil
+}
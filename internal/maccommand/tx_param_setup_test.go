@@ -0,0 +1,101 @@
+package maccommand
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/brocaar/loraserver/internal/storage"
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGetTXParamSetupEIRPIndex(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		tests := []struct {
+			EIRP          int
+			ExpectedIndex int
+		}{
+			{EIRP: 0, ExpectedIndex: 15},
+			{EIRP: 8, ExpectedIndex: 0},
+			{EIRP: 16, ExpectedIndex: 5},
+			{EIRP: 17, ExpectedIndex: 5},
+			{EIRP: 40, ExpectedIndex: 15},
+		}
+
+		for _, tst := range tests {
+			Convey(fmt.Sprintf("Testing: eirp %d", tst.EIRP), func() {
+				So(GetTXParamSetupEIRPIndex(tst.EIRP), ShouldEqual, tst.ExpectedIndex)
+			})
+		}
+	})
+}
+
+func TestRequestTXParamSetup(t *testing.T) {
+	Convey("When calling RequestTXParamSetup", t, func() {
+		block := RequestTXParamSetup(true, false, 5)
+
+		Convey("Then the expected block is returned", func() {
+			So(block, ShouldResemble, storage.MACCommandBlock{
+				CID: lorawan.TXParamSetupReq,
+				MACCommands: []lorawan.MACCommand{
+					{
+						CID: lorawan.TXParamSetupReq,
+						Payload: &lorawan.TXParamSetupReqPayload{
+							UplinkDwellTime:   lorawan.DwellTime400ms,
+							DownlinkDwelltime: lorawan.DwellTimeNoLimit,
+							MaxEIRP:           5,
+						},
+					},
+				},
+			})
+		})
+	})
+}
+
+func TestHandleTXParamSetupAns(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		tests := []struct {
+			Name                    string
+			DeviceSession           storage.DeviceSession
+			ReceivedMACCommandBlock storage.MACCommandBlock
+			PendingMACCommandBlock  *storage.MACCommandBlock
+			ExpectedDeviceSession   storage.DeviceSession
+			ExpectedError           error
+		}{
+			{
+				Name: "tx param setup ack",
+				DeviceSession: storage.DeviceSession{
+					UplinkDwellTime400ms: true,
+				},
+				ReceivedMACCommandBlock: storage.MACCommandBlock{
+					CID: lorawan.TXParamSetupAns,
+				},
+				PendingMACCommandBlock: &storage.MACCommandBlock{
+					CID: lorawan.TXParamSetupReq,
+					MACCommands: []lorawan.MACCommand{
+						{
+							CID: lorawan.TXParamSetupReq,
+							Payload: &lorawan.TXParamSetupReqPayload{
+								DownlinkDwelltime: lorawan.DwellTime400ms,
+								MaxEIRP:           5,
+							},
+						},
+					},
+				},
+				ExpectedDeviceSession: storage.DeviceSession{
+					DownlinkDwellTime400ms: true,
+					MaxEIRP:                16,
+				},
+			},
+		}
+
+		for i, t := range tests {
+			Convey(fmt.Sprintf("Testing: %s [%d]", t.Name, i), func() {
+				ans, err := handleTXParamSetupAns(&t.DeviceSession, t.ReceivedMACCommandBlock, t.PendingMACCommandBlock)
+				So(err, ShouldResemble, t.ExpectedError)
+				So(ans, ShouldBeNil)
+				So(t.DeviceSession, ShouldResemble, t.ExpectedDeviceSession)
+			})
+		}
+	})
+}
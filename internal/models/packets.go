@@ -77,6 +77,10 @@ func (r RXPacket) GetGWUplinkRXInfoSet() []*gw.UplinkRXInfo {
 			rxInfo.TimeSinceGpsEpoch = ptypes.DurationProto(time.Duration(*r.RXInfoSet[i].TimeSinceGPSEpoch))
 		}
 
+		if r.RXInfoSet[i].FineTimeSinceGPSEpoch != nil {
+			rxInfo.FineTimeSinceGpsEpoch = ptypes.DurationProto(time.Duration(*r.RXInfoSet[i].FineTimeSinceGPSEpoch))
+		}
+
 		out = append(out, &rxInfo)
 	}
 
@@ -92,16 +96,17 @@ type TXInfo struct {
 
 // RXInfo defines the RX related metadata (for each receiving gateway).
 type RXInfo struct {
-	MAC               lorawan.EUI64
-	Time              *time.Time
-	TimeSinceGPSEpoch *gw.Duration
-	Timestamp         uint32
-	RSSI              int
-	LoRaSNR           float64
-	Board             int
-	Antenna           int
-	RFChain           int
-	Channel           int
+	MAC                   lorawan.EUI64
+	Time                  *time.Time
+	TimeSinceGPSEpoch     *gw.Duration
+	FineTimeSinceGPSEpoch *gw.Duration
+	Timestamp             uint32
+	RSSI                  int
+	LoRaSNR               float64
+	Board                 int
+	Antenna               int
+	RFChain               int
+	Channel               int
 }
 
 // RXInfoSet implements a sortable slice of RXInfo elements.
@@ -0,0 +1,127 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/loraserver/internal/config"
+	"github.com/brocaar/lorawan"
+)
+
+const (
+	beaconLockKeyTempl           = "lora:ns:beacon:%d:lock"
+	gatewayBeaconDevicesKeyTempl = "lora:ns:gw:%s:beacondevs"
+	gatewayBeaconFreqsKeyTempl   = "lora:ns:gw:%s:beaconfreqs"
+)
+
+// AcquireBeaconLock acquires the lock for sending the beacon of the given
+// beacon time (duration since GPS epoch), for the given ttl. It returns false
+// when the lock is held by an other network-server instance, meaning that
+// this instance must not send the beacon.
+func AcquireBeaconLock(p *redis.Pool, beaconTime, ttl time.Duration) (bool, error) {
+	key := fmt.Sprintf(beaconLockKeyTempl, int64(beaconTime/time.Second))
+
+	c := p.Get()
+	defer c.Close()
+
+	_, err := redis.String(c.Do("SET", key, "lock", "PX", int64(ttl/time.Millisecond), "NX"))
+	if err != nil {
+		if err == redis.ErrNil {
+			return false, nil
+		}
+		return false, errors.Wrap(err, "acquire beacon lock error")
+	}
+
+	return true, nil
+}
+
+// setGatewayDeviceBeaconFrequencyScript stores the beacon frequency of a
+// device for a gateway. The devices are stored in a sorted-set with the time
+// of their last uplink as score, so that devices which have not been seen
+// within the TTL can be pruned (together with their frequency).
+//
+// KEYS[1]: gateway devices key (sorted-set)
+// KEYS[2]: gateway beacon frequencies key (hash)
+// ARGV[1]: DevEUI
+// ARGV[2]: beacon frequency
+// ARGV[3]: current time (ms)
+// ARGV[4]: ttl (ms)
+var setGatewayDeviceBeaconFrequencyScript = redis.NewScript(2, `
+local now = tonumber(ARGV[3])
+local ttl = tonumber(ARGV[4])
+
+local stale = redis.call("ZRANGEBYSCORE", KEYS[1], "-inf", "(" .. (now - ttl))
+for _, devEUI in ipairs(stale) do
+	redis.call("HDEL", KEYS[2], devEUI)
+end
+redis.call("ZREMRANGEBYSCORE", KEYS[1], "-inf", "(" .. (now - ttl))
+
+redis.call("ZADD", KEYS[1], now, ARGV[1])
+redis.call("HSET", KEYS[2], ARGV[1], ARGV[2])
+redis.call("PEXPIRE", KEYS[1], ttl)
+redis.call("PEXPIRE", KEYS[2], ttl)
+
+return 0
+`)
+
+// SetGatewayDeviceBeaconFrequency stores for each of the given gateways the
+// beacon frequency (0 = band default) the given device is using. Devices
+// which have not been seen by a gateway within the device-session TTL are
+// removed.
+func SetGatewayDeviceBeaconFrequency(p *redis.Pool, macs []lorawan.EUI64, devEUI lorawan.EUI64, freq int) error {
+	now := time.Now().UnixNano() / int64(time.Millisecond)
+	ttl := int64(config.C.NetworkServer.DeviceSessionTTL) / int64(time.Millisecond)
+
+	c := p.Get()
+	defer c.Close()
+
+	for _, mac := range macs {
+		_, err := setGatewayDeviceBeaconFrequencyScript.Do(c,
+			fmt.Sprintf(gatewayBeaconDevicesKeyTempl, mac),
+			fmt.Sprintf(gatewayBeaconFreqsKeyTempl, mac),
+			devEUI.String(),
+			freq,
+			now,
+			ttl,
+		)
+		if err != nil {
+			return errors.Wrap(err, "set gateway device beacon frequency error")
+		}
+	}
+
+	return nil
+}
+
+// GetGatewayBeaconFrequencies returns for the given gateway the number of
+// devices by beacon frequency (0 = band default). Only the devices seen by
+// the gateway within the device-session TTL are counted.
+func GetGatewayBeaconFrequencies(p *redis.Pool, mac lorawan.EUI64) (map[int]int, error) {
+	since := time.Now().Add(-config.C.NetworkServer.DeviceSessionTTL).UnixNano() / int64(time.Millisecond)
+
+	c := p.Get()
+	defer c.Close()
+
+	devEUIs, err := redis.Strings(c.Do("ZRANGEBYSCORE", fmt.Sprintf(gatewayBeaconDevicesKeyTempl, mac), since, "+inf"))
+	if err != nil {
+		return nil, errors.Wrap(err, "get gateway beacon devices error")
+	}
+
+	devFreqs, err := redis.IntMap(c.Do("HGETALL", fmt.Sprintf(gatewayBeaconFreqsKeyTempl, mac)))
+	if err != nil {
+		return nil, errors.Wrap(err, "get gateway beacon frequencies error")
+	}
+
+	out := make(map[int]int)
+	for _, devEUI := range devEUIs {
+		freq, ok := devFreqs[devEUI]
+		if !ok {
+			continue
+		}
+		out[freq]++
+	}
+
+	return out, nil
+}
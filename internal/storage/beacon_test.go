@@ -0,0 +1,113 @@
+package storage
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/loraserver/internal/common"
+	"github.com/brocaar/loraserver/internal/config"
+	"github.com/brocaar/loraserver/internal/test"
+	"github.com/brocaar/lorawan"
+)
+
+func TestBeaconLock(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database", t, func() {
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+
+		Convey("When acquiring the lock for a beacon", func() {
+			ok, err := AcquireBeaconLock(p, 128*time.Second, time.Minute)
+			So(err, ShouldBeNil)
+			So(ok, ShouldBeTrue)
+
+			Convey("Then the lock for the same beacon can not be acquired", func() {
+				ok, err := AcquireBeaconLock(p, 128*time.Second, time.Minute)
+				So(err, ShouldBeNil)
+				So(ok, ShouldBeFalse)
+			})
+
+			Convey("Then the lock for the next beacon can be acquired", func() {
+				ok, err := AcquireBeaconLock(p, 256*time.Second, time.Minute)
+				So(err, ShouldBeNil)
+				So(ok, ShouldBeTrue)
+			})
+		})
+	})
+}
+
+func TestGatewayBeaconFrequencies(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database", t, func() {
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+
+		gw1 := lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}
+		gw2 := lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2}
+
+		Convey("Then GetGatewayBeaconFrequencies returns no frequencies", func() {
+			freqs, err := GetGatewayBeaconFrequencies(p, gw1)
+			So(err, ShouldBeNil)
+			So(freqs, ShouldHaveLength, 0)
+		})
+
+		Convey("When storing the beacon frequency of devices", func() {
+			So(SetGatewayDeviceBeaconFrequency(p, []lorawan.EUI64{gw1, gw2}, lorawan.EUI64{1}, 0), ShouldBeNil)
+			So(SetGatewayDeviceBeaconFrequency(p, []lorawan.EUI64{gw1}, lorawan.EUI64{2}, 869100000), ShouldBeNil)
+			So(SetGatewayDeviceBeaconFrequency(p, []lorawan.EUI64{gw1}, lorawan.EUI64{3}, 869100000), ShouldBeNil)
+
+			Convey("Then GetGatewayBeaconFrequencies returns the number of devices by frequency", func() {
+				freqs, err := GetGatewayBeaconFrequencies(p, gw1)
+				So(err, ShouldBeNil)
+				So(freqs, ShouldResemble, map[int]int{0: 1, 869100000: 2})
+
+				freqs, err = GetGatewayBeaconFrequencies(p, gw2)
+				So(err, ShouldBeNil)
+				So(freqs, ShouldResemble, map[int]int{0: 1})
+			})
+
+			Convey("When a device changes its beacon frequency", func() {
+				So(SetGatewayDeviceBeaconFrequency(p, []lorawan.EUI64{gw1, gw2}, lorawan.EUI64{1}, 869100000), ShouldBeNil)
+
+				Convey("Then it is only counted for the new frequency", func() {
+					freqs, err := GetGatewayBeaconFrequencies(p, gw1)
+					So(err, ShouldBeNil)
+					So(freqs, ShouldResemble, map[int]int{869100000: 3})
+				})
+			})
+
+			Convey("When a device has not been seen by the gateway within the device-session TTL", func() {
+				c := p.Get()
+				defer c.Close()
+
+				lastSeen := time.Now().Add(-2*config.C.NetworkServer.DeviceSessionTTL).UnixNano() / int64(time.Millisecond)
+				_, err := c.Do("ZADD", fmt.Sprintf(gatewayBeaconDevicesKeyTempl, gw1), lastSeen, lorawan.EUI64{2}.String())
+				So(err, ShouldBeNil)
+
+				Convey("Then it is not counted", func() {
+					freqs, err := GetGatewayBeaconFrequencies(p, gw1)
+					So(err, ShouldBeNil)
+					So(freqs, ShouldResemble, map[int]int{0: 1, 869100000: 1})
+				})
+
+				Convey("Then it is removed when storing the beacon frequency of an other device", func() {
+					So(SetGatewayDeviceBeaconFrequency(p, []lorawan.EUI64{gw1}, lorawan.EUI64{4}, 0), ShouldBeNil)
+
+					n, err := redis.Int(c.Do("ZCARD", fmt.Sprintf(gatewayBeaconDevicesKeyTempl, gw1)))
+					So(err, ShouldBeNil)
+					So(n, ShouldEqual, 3)
+
+					n, err = redis.Int(c.Do("HLEN", fmt.Sprintf(gatewayBeaconFreqsKeyTempl, gw1)))
+					So(err, ShouldBeNil)
+					So(n, ShouldEqual, 3)
+				})
+			})
+		})
+	})
+}
@@ -33,6 +33,10 @@ type DeviceActivation struct {
 	NwkSEncKey  lorawan.AES128Key `db:"nwk_s_enc_key"`
 	DevNonce    lorawan.DevNonce  `db:"dev_nonce"`
 	JoinReqType lorawan.JoinType  `db:"join_req_type"`
+
+	// ForceRejoinReqType holds the rejoin-type requested by the
+	// ForceRejoinReq which triggered this (re)activation (if any).
+	ForceRejoinReqType *lorawan.JoinType `db:"force_rejoin_req_type"`
 }
 
 // CreateDevice creates the given device.
@@ -148,8 +152,9 @@ func CreateDeviceActivation(db sqlx.Queryer, da *DeviceActivation) error {
 			f_nwk_s_int_key,
 			nwk_s_enc_key,
 			dev_nonce,
-			join_req_type
-		) values ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			join_req_type,
+			force_rejoin_req_type
+		) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 		returning id`,
 		da.CreatedAt,
 		da.DevEUI[:],
@@ -160,6 +165,7 @@ func CreateDeviceActivation(db sqlx.Queryer, da *DeviceActivation) error {
 		da.NwkSEncKey[:],
 		da.DevNonce,
 		da.JoinReqType,
+		da.ForceRejoinReqType,
 	)
 	if err != nil {
 		return handlePSQLError(err, "insert error")
@@ -195,6 +201,35 @@ func GetLastDeviceActivationForDevEUI(db sqlx.Queryer, devEUI lorawan.EUI64) (De
 	return da, nil
 }
 
+// GetMaxDevNonce returns the highest dev-nonce used by the activations of
+// the given DevEUI / JoinEUI combination and join-request type.
+// ErrDoesNotExist is returned when no such activation exists.
+func GetMaxDevNonce(db sqlx.Queryer, joinEUI, devEUI lorawan.EUI64, joinType lorawan.JoinType) (lorawan.DevNonce, error) {
+	var nonce *int
+	err := sqlx.Get(db, &nonce, `
+		select
+			max(dev_nonce)
+		from
+			device_activation
+		where
+			dev_eui = $1
+			and join_eui = $2
+			and join_req_type = $3`,
+		devEUI,
+		joinEUI,
+		joinType,
+	)
+	if err != nil {
+		return 0, handlePSQLError(err, "select error")
+	}
+
+	if nonce == nil {
+		return 0, ErrDoesNotExist
+	}
+
+	return lorawan.DevNonce(*nonce), nil
+}
+
 // ValidateDevNonce validates the given dev-nonce for the given
 // DevEUI / JoinEUI combination.
 func ValidateDevNonce(db sqlx.Queryer, joinEUI, devEUI lorawan.EUI64, nonce lorawan.DevNonce, joinType lorawan.JoinType) error {
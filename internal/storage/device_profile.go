@@ -39,7 +39,7 @@ type DeviceProfile struct {
 	RegParamsRevision  string    `db:"reg_params_revision"` // Example: "B" [RP102B]
 	RXDelay1           int       `db:"rx_delay_1"`
 	RXDROffset1        int       `db:"rx_dr_offset_1"`
-	RXDataRate2        int       `db:"rx_data_rate_2"`       // Unit: bits-per-second
+	RXDataRate2        int       `db:"rx_data_rate_2"`       // Data-rate index
 	RXFreq2            int       `db:"rx_freq_2"`            // In Hz
 	FactoryPresetFreqs []int     `db:"factory_preset_freqs"` // In Hz
 	MaxEIRP            int       `db:"max_eirp"`             // In dBm
@@ -47,10 +47,133 @@ type DeviceProfile struct {
 	SupportsJoin       bool      `db:"supports_join"`
 	RFRegion           string    `db:"rf_region"`
 	Supports32bitFCnt  bool      `db:"supports_32bit_fcnt"`
+	ADRAlgorithmID     string    `db:"adr_algorithm_id"` // Empty for the default algorithm
+
+	// UplinkDwellTime400ms and DownlinkDwellTime400ms define if the 400ms
+	// dwell-time limitation must be applied to devices using this
+	// device-profile (TxParamSetupReq).
+	UplinkDwellTime400ms   bool `db:"uplink_dwell_time_400ms"`
+	DownlinkDwellTime400ms bool `db:"downlink_dwell_time_400ms"`
+
+	// ADRAckLimitExp and ADRAckDelayExp define the ADR_ACK_LIMIT
+	// (2^ADRAckLimitExp) and ADR_ACK_DELAY (2^ADRAckDelayExp) of devices
+	// using this device-profile (ADRParamSetupReq). When both are 0, the
+	// LoRaWAN defaults are used.
+	ADRAckLimitExp int `db:"adr_ack_limit_exp"`
+	ADRAckDelayExp int `db:"adr_ack_delay_exp"`
+
+	// BeaconFreq defines the Class-B beacon frequency (Hz) of devices using
+	// this device-profile (BeaconFreqReq). When 0, the default beacon
+	// frequency of the band is used.
+	BeaconFreq int `db:"beacon_freq"`
+
+	// RXParametersSet defines if the RXDelay1, RXDROffset1, RXDataRate2 and
+	// RXFreq2 of the device-profile must be used. When false, the
+	// network-server settings are used.
+	RXParametersSet bool `db:"rx_parameters_set"`
+
+	// PingSlotParametersSet defines if the PingSlotDR and PingSlotFreq of
+	// the device-profile must be used. When false, the network-server
+	// Class-B settings are used.
+	PingSlotParametersSet bool `db:"ping_slot_parameters_set"`
+}
+
+// RXParameters defines the RX1 and RX2 parameters of a device.
+type RXParameters struct {
+	RX1Delay     int
+	RX1DROffset  int
+	RX2DR        int
+	RX2Frequency int
+}
+
+// GetRXParameters returns the RX parameters that must be used by devices
+// using this device-profile. When the RX parameters are not set in the
+// device-profile, the network-server settings are used. As 0 is not a valid
+// frequency, a RXFreq2 of 0 always falls back to the network-server setting.
+func (dp DeviceProfile) GetRXParameters() RXParameters {
+	p := RXParameters{
+		RX1Delay:     config.C.NetworkServer.NetworkSettings.RX1Delay,
+		RX1DROffset:  config.C.NetworkServer.NetworkSettings.RX1DROffset,
+		RX2DR:        config.C.NetworkServer.NetworkSettings.RX2DR,
+		RX2Frequency: config.C.NetworkServer.NetworkSettings.RX2Frequency,
+	}
+
+	if !dp.RXParametersSet {
+		return p
+	}
+
+	p.RX1Delay = dp.RXDelay1
+	p.RX1DROffset = dp.RXDROffset1
+	p.RX2DR = dp.RXDataRate2
+	if dp.RXFreq2 != 0 {
+		p.RX2Frequency = dp.RXFreq2
+	}
+
+	return p
+}
+
+// PingSlotParameters defines the Class-B ping-slot parameters of a device.
+type PingSlotParameters struct {
+	DR        int
+	Frequency int
+}
+
+// GetPingSlotParameters returns the ping-slot parameters that must be used
+// by devices using this device-profile. When the ping-slot parameters are not
+// set in the device-profile, the network-server Class-B settings are used.
+// A PingSlotFreq of 0 always falls back to the network-server setting.
+func (dp DeviceProfile) GetPingSlotParameters() PingSlotParameters {
+	p := PingSlotParameters{
+		DR:        config.C.NetworkServer.NetworkSettings.ClassB.PingSlotDR,
+		Frequency: config.C.NetworkServer.NetworkSettings.ClassB.PingSlotFrequency,
+	}
+
+	if !dp.PingSlotParametersSet {
+		return p
+	}
+
+	p.DR = dp.PingSlotDR
+	if dp.PingSlotFreq != 0 {
+		p.Frequency = dp.PingSlotFreq
+	}
+
+	return p
+}
+
+// GetMaxDCycle returns the MaxDCycle value (as used by the DutyCycleReq
+// mac-command) for the MaxDutyCycle of the device-profile. The max
+// aggregated duty-cycle of the device is 1 / 2^MaxDCycle, the returned value
+// is the smallest value for which this does not exceed the MaxDutyCycle.
+// A MaxDutyCycle of 0 (not set) or >= 100% returns 0 (no limitation).
+func (dp DeviceProfile) GetMaxDCycle() int {
+	if dp.MaxDutyCycle <= 0 || dp.MaxDutyCycle >= 100 {
+		return 0
+	}
+
+	var maxDCycle int
+	for maxDCycle < 15 && 100 > dp.MaxDutyCycle*(1<<uint(maxDCycle)) {
+		maxDCycle++
+	}
+
+	return maxDCycle
+}
+
+// Validate validates the DeviceProfile.
+func (dp DeviceProfile) Validate() error {
+	// the exponents are sent as 4 bit values (ADRParamSetupReq)
+	if dp.ADRAckLimitExp < 0 || dp.ADRAckLimitExp > 15 || dp.ADRAckDelayExp < 0 || dp.ADRAckDelayExp > 15 {
+		return ErrInvalidADRAckExp
+	}
+
+	return nil
 }
 
 // CreateDeviceProfile creates the given device-profile.
 func CreateDeviceProfile(db sqlx.Execer, dp *DeviceProfile) error {
+	if err := dp.Validate(); err != nil {
+		return errors.Wrap(err, "validate error")
+	}
+
 	now := time.Now()
 
 	if dp.ID == uuid.Nil {
@@ -88,8 +211,16 @@ func CreateDeviceProfile(db sqlx.Execer, dp *DeviceProfile) error {
             max_duty_cycle,
             supports_join,
             rf_region,
-            supports_32bit_fcnt
-        ) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22)`,
+            supports_32bit_fcnt,
+            adr_algorithm_id,
+            uplink_dwell_time_400ms,
+            downlink_dwell_time_400ms,
+            adr_ack_limit_exp,
+            adr_ack_delay_exp,
+            beacon_freq,
+            rx_parameters_set,
+            ping_slot_parameters_set
+        ) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29, $30)`,
 		dp.CreatedAt,
 		dp.UpdatedAt,
 		dp.ID,
@@ -112,6 +243,14 @@ func CreateDeviceProfile(db sqlx.Execer, dp *DeviceProfile) error {
 		dp.SupportsJoin,
 		dp.RFRegion,
 		dp.Supports32bitFCnt,
+		dp.ADRAlgorithmID,
+		dp.UplinkDwellTime400ms,
+		dp.DownlinkDwellTime400ms,
+		dp.ADRAckLimitExp,
+		dp.ADRAckDelayExp,
+		dp.BeaconFreq,
+		dp.RXParametersSet,
+		dp.PingSlotParametersSet,
 	)
 	if err != nil {
 		return handlePSQLError(err, "insert error")
@@ -245,7 +384,15 @@ func GetDeviceProfile(db sqlx.Queryer, id uuid.UUID) (DeviceProfile, error) {
             max_duty_cycle,
             supports_join,
             rf_region,
-            supports_32bit_fcnt
+            supports_32bit_fcnt,
+            adr_algorithm_id,
+            uplink_dwell_time_400ms,
+            downlink_dwell_time_400ms,
+            adr_ack_limit_exp,
+            adr_ack_delay_exp,
+            beacon_freq,
+            rx_parameters_set,
+            ping_slot_parameters_set
         from device_profile
         where
             device_profile_id = $1
@@ -276,6 +423,14 @@ func GetDeviceProfile(db sqlx.Queryer, id uuid.UUID) (DeviceProfile, error) {
 		&dp.SupportsJoin,
 		&dp.RFRegion,
 		&dp.Supports32bitFCnt,
+		&dp.ADRAlgorithmID,
+		&dp.UplinkDwellTime400ms,
+		&dp.DownlinkDwellTime400ms,
+		&dp.ADRAckLimitExp,
+		&dp.ADRAckDelayExp,
+		&dp.BeaconFreq,
+		&dp.RXParametersSet,
+		&dp.PingSlotParametersSet,
 	)
 	if err != nil {
 		return dp, handlePSQLError(err, "select error")
@@ -290,6 +445,10 @@ func GetDeviceProfile(db sqlx.Queryer, id uuid.UUID) (DeviceProfile, error) {
 
 // UpdateDeviceProfile updates the given device-profile.
 func UpdateDeviceProfile(db sqlx.Execer, dp *DeviceProfile) error {
+	if err := dp.Validate(); err != nil {
+		return errors.Wrap(err, "validate error")
+	}
+
 	dp.UpdatedAt = time.Now()
 
 	res, err := db.Exec(`
@@ -314,7 +473,15 @@ func UpdateDeviceProfile(db sqlx.Execer, dp *DeviceProfile) error {
             max_duty_cycle = $18,
             supports_join = $19,
             rf_region = $20,
-            supports_32bit_fcnt = $21
+            supports_32bit_fcnt = $21,
+            adr_algorithm_id = $22,
+            uplink_dwell_time_400ms = $23,
+            downlink_dwell_time_400ms = $24,
+            adr_ack_limit_exp = $25,
+            adr_ack_delay_exp = $26,
+            beacon_freq = $27,
+            rx_parameters_set = $28,
+            ping_slot_parameters_set = $29
         where
             device_profile_id = $1`,
 		dp.ID,
@@ -338,6 +505,14 @@ func UpdateDeviceProfile(db sqlx.Execer, dp *DeviceProfile) error {
 		dp.SupportsJoin,
 		dp.RFRegion,
 		dp.Supports32bitFCnt,
+		dp.ADRAlgorithmID,
+		dp.UplinkDwellTime400ms,
+		dp.DownlinkDwellTime400ms,
+		dp.ADRAckLimitExp,
+		dp.ADRAckDelayExp,
+		dp.BeaconFreq,
+		dp.RXParametersSet,
+		dp.PingSlotParametersSet,
 	)
 	if err != nil {
 		return handlePSQLError(err, "update error")
@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
@@ -24,6 +25,15 @@ func TestDeviceProfile(t *testing.T) {
 		test.MustResetDB(config.C.PostgreSQL.DB)
 		test.MustFlushRedis(config.C.Redis.Pool)
 
+		Convey("When creating a device-profile with an ADR_ACK_LIMIT exponent above 15", func() {
+			dp := DeviceProfile{ADRAckLimitExp: 16}
+
+			Convey("Then ErrInvalidADRAckExp is returned", func() {
+				err := CreateDeviceProfile(db, &dp)
+				So(errors.Cause(err), ShouldEqual, ErrInvalidADRAckExp)
+			})
+		})
+
 		Convey("When creating a device-profile", func() {
 			dp := DeviceProfile{
 				SupportsClassB:     true,
@@ -45,6 +55,7 @@ func TestDeviceProfile(t *testing.T) {
 				SupportsJoin:       true,
 				RFRegion:           "EU868",
 				Supports32bitFCnt:  true,
+				ADRAlgorithmID:     "lora-loss-aware",
 			}
 
 			So(CreateDeviceProfile(db, &dp), ShouldBeNil)
@@ -106,6 +117,12 @@ func TestDeviceProfile(t *testing.T) {
 				dp.SupportsJoin = false
 				dp.RFRegion = "US902"
 				dp.Supports32bitFCnt = false
+				dp.ADRAlgorithmID = ""
+				dp.UplinkDwellTime400ms = true
+				dp.DownlinkDwellTime400ms = true
+				dp.ADRAckLimitExp = 8
+				dp.ADRAckDelayExp = 6
+				dp.BeaconFreq = 869100000
 				So(UpdateDeviceProfile(db, &dp), ShouldBeNil)
 				dp.UpdatedAt = dp.UpdatedAt.UTC().Truncate(time.Millisecond)
 
@@ -119,3 +136,161 @@ func TestDeviceProfile(t *testing.T) {
 		})
 	})
 }
+
+func TestDeviceProfileGetRXParameters(t *testing.T) {
+	Convey("Given a set of network settings", t, func() {
+		networkSettings := config.C.NetworkServer.NetworkSettings
+		config.C.NetworkServer.NetworkSettings.RX1Delay = 1
+		config.C.NetworkServer.NetworkSettings.RX1DROffset = 2
+		config.C.NetworkServer.NetworkSettings.RX2DR = 3
+		config.C.NetworkServer.NetworkSettings.RX2Frequency = 869525000
+
+		Reset(func() {
+			config.C.NetworkServer.NetworkSettings = networkSettings
+		})
+
+		Convey("Given a set of tests", func() {
+			tests := []struct {
+				Name           string
+				DeviceProfile  DeviceProfile
+				ExpectedParams RXParameters
+			}{
+				{
+					Name: "device-profile without rx parameters",
+					ExpectedParams: RXParameters{
+						RX1Delay:     1,
+						RX1DROffset:  2,
+						RX2DR:        3,
+						RX2Frequency: 869525000,
+					},
+				},
+				{
+					Name: "device-profile with rx parameters",
+					DeviceProfile: DeviceProfile{
+						RXParametersSet: true,
+						RXDelay1:        5,
+						RXDROffset1:     1,
+						RXDataRate2:     2,
+						RXFreq2:         868300000,
+					},
+					ExpectedParams: RXParameters{
+						RX1Delay:     5,
+						RX1DROffset:  1,
+						RX2DR:        2,
+						RX2Frequency: 868300000,
+					},
+				},
+				{
+					Name: "device-profile with rx parameters that are not set",
+					DeviceProfile: DeviceProfile{
+						RXDelay1:    5,
+						RXDROffset1: 1,
+						RXDataRate2: 2,
+						RXFreq2:     868300000,
+					},
+					ExpectedParams: RXParameters{
+						RX1Delay:     1,
+						RX1DROffset:  2,
+						RX2DR:        3,
+						RX2Frequency: 869525000,
+					},
+				},
+				{
+					Name: "device-profile with rx parameters set to 0",
+					DeviceProfile: DeviceProfile{
+						RXParametersSet: true,
+					},
+					ExpectedParams: RXParameters{
+						RX1Delay:     0,
+						RX1DROffset:  0,
+						RX2DR:        0,
+						RX2Frequency: 869525000,
+					},
+				},
+			}
+
+			for _, tst := range tests {
+				Convey("Testing: "+tst.Name, func() {
+					So(tst.DeviceProfile.GetRXParameters(), ShouldResemble, tst.ExpectedParams)
+				})
+			}
+		})
+	})
+}
+
+func TestDeviceProfileGetPingSlotParameters(t *testing.T) {
+	Convey("Given a set of Class-B network settings", t, func() {
+		classB := config.C.NetworkServer.NetworkSettings.ClassB
+		config.C.NetworkServer.NetworkSettings.ClassB.PingSlotDR = 3
+		config.C.NetworkServer.NetworkSettings.ClassB.PingSlotFrequency = 869525000
+
+		Reset(func() {
+			config.C.NetworkServer.NetworkSettings.ClassB = classB
+		})
+
+		Convey("Given a set of tests", func() {
+			tests := []struct {
+				Name           string
+				DeviceProfile  DeviceProfile
+				ExpectedParams PingSlotParameters
+			}{
+				{
+					Name:           "device-profile without ping-slot parameters",
+					ExpectedParams: PingSlotParameters{DR: 3, Frequency: 869525000},
+				},
+				{
+					Name: "device-profile with ping-slot parameters",
+					DeviceProfile: DeviceProfile{
+						PingSlotParametersSet: true,
+						PingSlotDR:            5,
+						PingSlotFreq:          868300000,
+					},
+					ExpectedParams: PingSlotParameters{DR: 5, Frequency: 868300000},
+				},
+				{
+					Name: "device-profile with ping-slot parameters that are not set",
+					DeviceProfile: DeviceProfile{
+						PingSlotDR:   5,
+						PingSlotFreq: 868300000,
+					},
+					ExpectedParams: PingSlotParameters{DR: 3, Frequency: 869525000},
+				},
+				{
+					Name: "device-profile with ping-slot data-rate 0",
+					DeviceProfile: DeviceProfile{
+						PingSlotParametersSet: true,
+					},
+					ExpectedParams: PingSlotParameters{DR: 0, Frequency: 869525000},
+				},
+			}
+
+			for _, tst := range tests {
+				Convey("Testing: "+tst.Name, func() {
+					So(tst.DeviceProfile.GetPingSlotParameters(), ShouldResemble, tst.ExpectedParams)
+				})
+			}
+		})
+	})
+}
+
+func TestDeviceProfileGetMaxDCycle(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		tests := []struct {
+			MaxDutyCycle      int
+			ExpectedMaxDCycle int
+		}{
+			{MaxDutyCycle: 0, ExpectedMaxDCycle: 0},
+			{MaxDutyCycle: 100, ExpectedMaxDCycle: 0},
+			{MaxDutyCycle: 50, ExpectedMaxDCycle: 1},
+			{MaxDutyCycle: 10, ExpectedMaxDCycle: 4},
+			{MaxDutyCycle: 1, ExpectedMaxDCycle: 7},
+		}
+
+		for _, tst := range tests {
+			Convey(fmt.Sprintf("Testing: max duty-cycle %d%%", tst.MaxDutyCycle), func() {
+				dp := DeviceProfile{MaxDutyCycle: tst.MaxDutyCycle}
+				So(dp.GetMaxDCycle(), ShouldEqual, tst.ExpectedMaxDCycle)
+			})
+		}
+	})
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/brocaar/loraserver/internal/config"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 
@@ -30,6 +31,9 @@ type DeviceQueueItem struct {
 	IsPending               bool           `db:"is_pending"`
 	EmitAtTimeSinceGPSEpoch *time.Duration `db:"emit_at_time_since_gps_epoch"`
 	TimeoutAfter            *time.Time     `db:"timeout_after"`
+	RateLimitExceeded       bool           `db:"rate_limit_exceeded"`
+	RetryCount              int            `db:"retry_count"`
+	ExpiresAt               *time.Time     `db:"expires_at"`
 }
 
 // Validate validates the DeviceQueueItem.
@@ -61,8 +65,11 @@ func CreateDeviceQueueItem(db sqlx.Queryer, qi *DeviceQueueItem) error {
             confirmed,
             emit_at_time_since_gps_epoch,
             is_pending,
-            timeout_after
-        ) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+            timeout_after,
+            rate_limit_exceeded,
+            retry_count,
+            expires_at
+        ) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
         returning id`,
 		qi.CreatedAt,
 		qi.UpdatedAt,
@@ -74,6 +81,9 @@ func CreateDeviceQueueItem(db sqlx.Queryer, qi *DeviceQueueItem) error {
 		qi.EmitAtTimeSinceGPSEpoch,
 		qi.IsPending,
 		qi.TimeoutAfter,
+		qi.RateLimitExceeded,
+		qi.RetryCount,
+		qi.ExpiresAt,
 	)
 	if err != nil {
 		return handlePSQLError(err, "insert error")
@@ -112,7 +122,10 @@ func UpdateDeviceQueueItem(db sqlx.Execer, qi *DeviceQueueItem) error {
             confirmed = $7,
             emit_at_time_since_gps_epoch = $8,
             is_pending = $9,
-            timeout_after = $10
+            timeout_after = $10,
+            rate_limit_exceeded = $11,
+            retry_count = $12,
+            expires_at = $13
         where
             id = $1`,
 		qi.ID,
@@ -125,6 +138,9 @@ func UpdateDeviceQueueItem(db sqlx.Execer, qi *DeviceQueueItem) error {
 		qi.EmitAtTimeSinceGPSEpoch,
 		qi.IsPending,
 		qi.TimeoutAfter,
+		qi.RateLimitExceeded,
+		qi.RetryCount,
+		qi.ExpiresAt,
 	)
 	if err != nil {
 		return handlePSQLError(err, "update error")
@@ -143,11 +159,23 @@ func UpdateDeviceQueueItem(db sqlx.Execer, qi *DeviceQueueItem) error {
 		"is_pending":                   qi.IsPending,
 		"emit_at_time_since_gps_epoch": qi.EmitAtTimeSinceGPSEpoch,
 		"timeout_after":                qi.TimeoutAfter,
+		"retry_count":                  qi.RetryCount,
 	}).Info("device-queue item updated")
 
 	return nil
 }
 
+// GetDeviceQueueItemForDevEUIAndFCnt returns the device-queue item matching
+// the given DevEUI and frame-counter.
+func GetDeviceQueueItemForDevEUIAndFCnt(db sqlx.Queryer, devEUI lorawan.EUI64, fCnt uint32) (DeviceQueueItem, error) {
+	var qi DeviceQueueItem
+	err := sqlx.Get(db, &qi, "select * from device_queue where dev_eui = $1 and f_cnt = $2", devEUI[:], fCnt)
+	if err != nil {
+		return qi, handlePSQLError(err, "select error")
+	}
+	return qi, nil
+}
+
 // DeleteDeviceQueueItem deletes the device-queue item matching the given id.
 func DeleteDeviceQueueItem(db sqlx.Execer, id int64) error {
 	res, err := db.Exec("delete from device_queue where id = $1", id)
@@ -169,6 +197,80 @@ func DeleteDeviceQueueItem(db sqlx.Execer, id int64) error {
 	return nil
 }
 
+// DeleteExpiredDeviceQueueItems deletes the expired device-queue items and
+// returns these. Pending items are not deleted, as these have already been
+// transmitted and are waiting for an acknowledgement (see
+// DeleteTimedOutPendingDeviceQueueItems).
+func DeleteExpiredDeviceQueueItems(db sqlx.Queryer) ([]DeviceQueueItem, error) {
+	var items []DeviceQueueItem
+	err := sqlx.Select(db, &items, `
+        delete from
+            device_queue
+        where
+            is_pending = false
+            and expires_at <= now()
+        returning
+            *`,
+	)
+	if err != nil {
+		return nil, handlePSQLError(err, "delete error")
+	}
+
+	for _, qi := range items {
+		log.WithFields(log.Fields{
+			"dev_eui":    qi.DevEUI,
+			"f_cnt":      qi.FCnt,
+			"expires_at": qi.ExpiresAt,
+		}).Info("expired device-queue item deleted")
+	}
+
+	return items, nil
+}
+
+// DeleteTimedOutPendingDeviceQueueItems deletes the pending Class-B and
+// Class-C device-queue items which have timed-out and of which all
+// retransmissions (DLConfirmedRetries of the service-profile) have been
+// used, and returns these. Class-A items are not deleted, as these are
+// acknowledged by the next uplink of the device.
+func DeleteTimedOutPendingDeviceQueueItems(db sqlx.Queryer) ([]DeviceQueueItem, error) {
+	var items []DeviceQueueItem
+	err := sqlx.Select(db, &items, `
+        delete from
+            device_queue dq
+        using
+            device d,
+            device_profile dp,
+            service_profile sp
+        where
+            d.dev_eui = dq.dev_eui
+            and dp.device_profile_id = d.device_profile_id
+            and sp.service_profile_id = d.service_profile_id
+            and dq.is_pending = true
+            and dq.timeout_after <= now()
+            and dq.retry_count >= sp.dl_confirmed_retries
+            and (
+                dp.supports_class_c = true
+                or dq.emit_at_time_since_gps_epoch is not null
+            )
+        returning
+            dq.*`,
+	)
+	if err != nil {
+		return nil, handlePSQLError(err, "delete error")
+	}
+
+	for _, qi := range items {
+		log.WithFields(log.Fields{
+			"dev_eui":       qi.DevEUI,
+			"f_cnt":         qi.FCnt,
+			"retry_count":   qi.RetryCount,
+			"timeout_after": qi.TimeoutAfter,
+		}).Info("timed-out pending device-queue item deleted")
+	}
+
+	return items, nil
+}
+
 // FlushDeviceQueueForDevEUI deletes all device-queue items for the given DevEUI.
 func FlushDeviceQueueForDevEUI(db sqlx.Execer, devEUI lorawan.EUI64) error {
 	_, err := db.Exec("delete from device_queue where dev_eui = $1", devEUI[:])
@@ -185,6 +287,8 @@ func FlushDeviceQueueForDevEUI(db sqlx.Execer, devEUI lorawan.EUI64) error {
 
 // GetNextDeviceQueueItemForDevEUI returns the next device-queue item for the
 // given DevEUI, ordered by f_cnt (note that the f_cnt should never roll over).
+// A pending item always comes first. Expired items are ignored (see
+// DeleteExpiredDeviceQueueItems).
 func GetNextDeviceQueueItemForDevEUI(db sqlx.Queryer, devEUI lorawan.EUI64) (DeviceQueueItem, error) {
 	var qi DeviceQueueItem
 	err := sqlx.Get(db, &qi, `
@@ -194,7 +298,13 @@ func GetNextDeviceQueueItemForDevEUI(db sqlx.Queryer, devEUI lorawan.EUI64) (Dev
             device_queue
         where
             dev_eui = $1
+            and (
+                is_pending = true
+                or expires_at is null
+                or expires_at > now()
+            )
         order by
+            is_pending desc,
             f_cnt
         limit 1`,
 		devEUI[:],
@@ -224,6 +334,7 @@ func GetPendingDeviceQueueItemForDevEUI(db sqlx.Queryer, devEUI lorawan.EUI64) (
         where
             dev_eui = $1
         order by
+            is_pending desc,
             f_cnt
         limit 1`,
 		devEUI[:],
@@ -269,14 +380,39 @@ func GetDeviceQueueItemsForDevEUI(db sqlx.Queryer, devEUI lorawan.EUI64) ([]Devi
 // frame-counter is behind the actual frame-counter, the payload will be removed
 // from the queue and the next one will be retrieved. In such a case, the
 // application-server will be notified.
-func GetNextDeviceQueueItemForDevEUIMaxPayloadSizeAndFCnt(db sqlx.Ext, devEUI lorawan.EUI64, maxPayloadSize int, fCnt uint32, routingProfileID uuid.UUID) (DeviceQueueItem, error) {
+// A pending (confirmed) item is only considered once it has timed-out. It is
+// then returned for retransmission, using the same frame-counter, as long as
+// its retry count is below maxRetries. Else it is removed and a negative
+// acknowledgement is sent to the application-server. Timed-out Class-B and
+// Class-C items are also removed in the background, see
+// DeleteTimedOutPendingDeviceQueueItems.
+func GetNextDeviceQueueItemForDevEUIMaxPayloadSizeAndFCnt(db sqlx.Ext, devEUI lorawan.EUI64, maxPayloadSize int, fCnt uint32, maxRetries int, routingProfileID uuid.UUID) (DeviceQueueItem, error) {
 	for {
 		qi, err := GetNextDeviceQueueItemForDevEUI(db, devEUI)
 		if err != nil {
 			return DeviceQueueItem{}, errors.Wrap(err, "get next device-queue item error")
 		}
 
-		if qi.FCnt > fCnt || len(qi.FRMPayload) > maxPayloadSize || (qi.TimeoutAfter != nil && qi.TimeoutAfter.Before(time.Now())) {
+		// expired items are not retransmitted
+		expired := qi.ExpiresAt != nil && qi.ExpiresAt.Before(time.Now())
+
+		// a pending item is only retransmitted once it has timed-out
+		timedOut := qi.TimeoutAfter == nil || !qi.TimeoutAfter.After(time.Now())
+
+		if qi.IsPending && timedOut && qi.RetryCount < maxRetries && len(qi.FRMPayload) <= maxPayloadSize && !expired {
+			qi.IsPending = false
+			qi.RetryCount++
+
+			log.WithFields(log.Fields{
+				"dev_eui":                devEUI,
+				"device_queue_item_fcnt": qi.FCnt,
+				"retry_count":            qi.RetryCount,
+			}).Info("device-queue item not acknowledged, retransmitting")
+
+			return qi, nil
+		}
+
+		if qi.FCnt < fCnt || len(qi.FRMPayload) > maxPayloadSize || (qi.TimeoutAfter != nil && qi.TimeoutAfter.Before(time.Now())) {
 			rp, err := GetRoutingProfile(db, routingProfileID)
 			if err != nil {
 				return DeviceQueueItem{}, errors.Wrap(err, "get routing-profile error")
@@ -305,7 +441,7 @@ func GetNextDeviceQueueItemForDevEUIMaxPayloadSizeAndFCnt(db sqlx.Ext, devEUI lo
 				if err != nil {
 					return DeviceQueueItem{}, errors.Wrap(err, "application-server client error")
 				}
-			} else if qi.FCnt > fCnt {
+			} else if qi.FCnt < fCnt {
 				// handle frame-counter error
 				log.WithFields(log.Fields{
 					"dev_eui":                devEUI,
@@ -354,9 +490,44 @@ func GetNextDeviceQueueItemForDevEUIMaxPayloadSizeAndFCnt(db sqlx.Ext, devEUI lo
 // for downlink Class-C transmission.
 // The device records will be locked for update so that multiple instances can
 // run this query in parallel without the risk of duplicate scheduling.
-func GetDevicesWithClassBOrClassCDeviceQueueItems(db sqlx.Ext, count int) ([]Device, error) {
+// When partitions is greater than 0, only the devices within the given
+// partitions (see GetSchedulerPartitionForDevEUI) are returned.
+func GetDevicesWithClassBOrClassCDeviceQueueItems(db sqlx.Ext, count, partitions int, ownedPartitions []int) ([]Device, error) {
+	var owned pq.Int64Array
+	for _, p := range ownedPartitions {
+		owned = append(owned, int64(p))
+	}
+
+	return getDevicesWithClassBOrClassCDeviceQueueItems(db, count, nil, partitions, owned)
+}
+
+// GetDeviceWithClassBOrClassCDeviceQueueItems returns the device matching
+// the given DevEUI when it qualifies for downlink Class-B or Class-C
+// transmission, else ErrDoesNotExist is returned.
+// The device record will be locked for update (see
+// GetDevicesWithClassBOrClassCDeviceQueueItems).
+func GetDeviceWithClassBOrClassCDeviceQueueItems(db sqlx.Ext, devEUI lorawan.EUI64) (Device, error) {
+	devices, err := getDevicesWithClassBOrClassCDeviceQueueItems(db, 1, &devEUI, 0, nil)
+	if err != nil {
+		return Device{}, err
+	}
+
+	if len(devices) == 0 {
+		return Device{}, ErrDoesNotExist
+	}
+
+	return devices[0], nil
+}
+
+func getDevicesWithClassBOrClassCDeviceQueueItems(db sqlx.Ext, count int, devEUI *lorawan.EUI64, partitions int, ownedPartitions pq.Int64Array) ([]Device, error) {
 	gpsEpochScheduleTime := gps.Time(time.Now().Add(config.ClassCScheduleInterval * 2)).TimeSinceGPSEpoch()
 
+	// a nil DevEUI disables the DevEUI filter
+	var devEUIFilter interface{}
+	if devEUI != nil {
+		devEUIFilter = devEUI[:]
+	}
+
 	var devices []Device
 	err := sqlx.Select(db, &devices, `
         select
@@ -369,6 +540,16 @@ func GetDevicesWithClassBOrClassCDeviceQueueItems(db sqlx.Ext, count int) ([]Dev
             	dp.supports_class_c = true
             	or dp.supports_class_b = true
             )
+            -- filter on the given DevEUI
+            and (
+            	$3::bytea is null
+            	or d.dev_eui = $3
+            )
+            -- filter on the given partitions
+            and (
+            	$4::integer = 0
+            	or (get_byte(d.dev_eui, 6) * 256 + get_byte(d.dev_eui, 7)) % nullif($4::integer, 0) = any($5::integer[])
+            )
             -- we want devices with queue items
             and exists (
                 select
@@ -403,6 +584,9 @@ func GetDevicesWithClassBOrClassCDeviceQueueItems(db sqlx.Ext, count int) ([]Dev
         for update of d skip locked`,
 		count,
 		gpsEpochScheduleTime,
+		devEUIFilter,
+		partitions,
+		ownedPartitions,
 	)
 	if err != nil {
 		return nil, handlePSQLError(err, "select error")
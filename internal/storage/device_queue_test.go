@@ -176,6 +176,80 @@ func TestDeviceQueue(t *testing.T) {
 					So(err, ShouldBeNil)
 					So(items, ShouldHaveLength, 2)
 				})
+
+				Convey("Then GetDeviceQueueItemForDevEUIAndFCnt returns the requested item", func() {
+					qi, err := GetDeviceQueueItemForDevEUIAndFCnt(db, d.DevEUI, 2)
+					So(err, ShouldBeNil)
+					So(qi.ID, ShouldEqual, items[2].ID)
+
+					_, err = GetDeviceQueueItemForDevEUIAndFCnt(db, d.DevEUI, 4)
+					So(err, ShouldEqual, ErrDoesNotExist)
+				})
+
+				Convey("Given the first item in the queue has expired", func() {
+					oneMinuteAgo := time.Now().Add(-time.Minute)
+					items[0].ExpiresAt = &oneMinuteAgo
+					So(UpdateDeviceQueueItem(db, &items[0]), ShouldBeNil)
+
+					Convey("Then GetNextDeviceQueueItemForDevEUI skips this item", func() {
+						qi, err := GetNextDeviceQueueItemForDevEUI(db, d.DevEUI)
+						So(err, ShouldBeNil)
+						So(qi.FCnt, ShouldEqual, 2)
+					})
+
+					Convey("Then DeleteExpiredDeviceQueueItems deletes and returns this item", func() {
+						expired, err := DeleteExpiredDeviceQueueItems(db)
+						So(err, ShouldBeNil)
+						So(expired, ShouldHaveLength, 1)
+						So(expired[0].ID, ShouldEqual, items[0].ID)
+
+						items, err := GetDeviceQueueItemsForDevEUI(db, d.DevEUI)
+						So(err, ShouldBeNil)
+						So(items, ShouldHaveLength, 2)
+					})
+
+					Convey("Given the item is pending", func() {
+						items[0].IsPending = true
+						So(UpdateDeviceQueueItem(db, &items[0]), ShouldBeNil)
+
+						Convey("Then DeleteExpiredDeviceQueueItems does not delete it", func() {
+							expired, err := DeleteExpiredDeviceQueueItems(db)
+							So(err, ShouldBeNil)
+							So(expired, ShouldHaveLength, 0)
+						})
+					})
+				})
+
+				Convey("Given a timed-out pending Class-A and Class-B item", func() {
+					oneMinuteAgo := time.Now().Add(-time.Minute)
+					for _, i := range []int{0, 1} {
+						items[i].IsPending = true
+						items[i].TimeoutAfter = &oneMinuteAgo
+						So(UpdateDeviceQueueItem(db, &items[i]), ShouldBeNil)
+					}
+
+					Convey("Then DeleteTimedOutPendingDeviceQueueItems deletes and returns the Class-B item", func() {
+						timedOut, err := DeleteTimedOutPendingDeviceQueueItems(db)
+						So(err, ShouldBeNil)
+						So(timedOut, ShouldHaveLength, 1)
+						So(timedOut[0].ID, ShouldEqual, items[1].ID)
+
+						items, err := GetDeviceQueueItemsForDevEUI(db, d.DevEUI)
+						So(err, ShouldBeNil)
+						So(items, ShouldHaveLength, 2)
+					})
+
+					Convey("Given the service-profile allows one retry", func() {
+						sp.DLConfirmedRetries = 1
+						So(UpdateServiceProfile(db, &sp), ShouldBeNil)
+
+						Convey("Then DeleteTimedOutPendingDeviceQueueItems does not delete the Class-B item", func() {
+							timedOut, err := DeleteTimedOutPendingDeviceQueueItems(db)
+							So(err, ShouldBeNil)
+							So(timedOut, ShouldHaveLength, 0)
+						})
+					})
+				})
 			})
 
 			Convey("When testing GetNextDeviceQueueItemForDevEUIMaxPayloadSizeAndFCnt", func() {
@@ -223,8 +297,10 @@ func TestDeviceQueue(t *testing.T) {
 					Name          string
 					FCnt          uint32
 					MaxFRMPayload int
+					MaxRetries    int
 
 					ExpectedDeviceQueueItemID *int64
+					ExpectedRetryCount        int
 					ExpectedHandleError       []as.HandleErrorRequest
 					ExpectedHandleDownlinkACK []as.HandleDownlinkACKRequest
 					ExpectedError             error
@@ -238,6 +314,14 @@ func TestDeviceQueue(t *testing.T) {
 							{DevEui: d.DevEUI[:], FCnt: items[0].FCnt, Acknowledged: false},
 						},
 					},
+					{
+						Name:                      "retransmission of the timed-out item",
+						FCnt:                      101,
+						MaxFRMPayload:             7,
+						MaxRetries:                1,
+						ExpectedDeviceQueueItemID: &items[0].ID,
+						ExpectedRetryCount:        1,
+					},
 					{
 						Name:                      "nACK + first item discarded (payload size)",
 						FCnt:                      100,
@@ -278,6 +362,15 @@ func TestDeviceQueue(t *testing.T) {
 						},
 						ExpectedError: ErrDoesNotExist,
 					},
+					{
+						Name:                      "nACK + first item ahead of the frame-counter is not discarded",
+						FCnt:                      99,
+						MaxFRMPayload:             7,
+						ExpectedDeviceQueueItemID: &items[1].ID,
+						ExpectedHandleDownlinkACK: []as.HandleDownlinkACKRequest{
+							{DevEui: d.DevEUI[:], FCnt: items[0].FCnt, Acknowledged: false},
+						},
+					},
 					{
 						Name:                      "nACK + first item discarded (fCnt)",
 						FCnt:                      102,
@@ -294,10 +387,12 @@ func TestDeviceQueue(t *testing.T) {
 
 				for i, test := range tests {
 					Convey(fmt.Sprintf("Testing: %s [%d]", test.Name, i), func() {
-						qi, err := GetNextDeviceQueueItemForDevEUIMaxPayloadSizeAndFCnt(config.C.PostgreSQL.DB, d.DevEUI, test.MaxFRMPayload, test.FCnt, rp.ID)
+						qi, err := GetNextDeviceQueueItemForDevEUIMaxPayloadSizeAndFCnt(config.C.PostgreSQL.DB, d.DevEUI, test.MaxFRMPayload, test.FCnt, test.MaxRetries, rp.ID)
 						if test.ExpectedHandleError == nil {
 							So(*test.ExpectedDeviceQueueItemID, ShouldEqual, qi.ID)
 							So(err, ShouldBeNil)
+							So(qi.RetryCount, ShouldEqual, test.ExpectedRetryCount)
+							So(qi.IsPending, ShouldBeFalse)
 						} else {
 							So(errors.Cause(err), ShouldEqual, test.ExpectedError)
 						}
@@ -316,6 +411,7 @@ func TestDeviceQueue(t *testing.T) {
 					})
 				}
 			})
+
 		})
 	})
 }
@@ -324,6 +420,8 @@ type getDeviceQueueItemsTestCase struct {
 	Name            string
 	GetCallCount    int // the number of Get calls to make, each in a separate db transaction
 	GetCount        int
+	Partitions      int
+	OwnedPartitions []int
 	QueueItems      []DeviceQueueItem
 	ExpectedDevEUIs [][]lorawan.EUI64 // slice of EUIs per database transaction
 }
@@ -524,9 +622,40 @@ func TestGetDevEUIsWithClassCDeviceQueueItems(t *testing.T) {
 						nil,
 					},
 				},
+				{
+					Name:            "two queue items for two devices, one partition owned (limit 2)",
+					GetCallCount:    2,
+					GetCount:        2,
+					Partitions:      2,
+					OwnedPartitions: []int{GetSchedulerPartitionForDevEUI(devices[1].DevEUI, 2)},
+					QueueItems: []DeviceQueueItem{
+						{DevEUI: devices[0].DevEUI, FCnt: 1, FPort: 1, FRMPayload: []byte{1, 2, 3}},
+						{DevEUI: devices[1].DevEUI, FCnt: 1, FPort: 1, FRMPayload: []byte{1, 2, 3}},
+					},
+					ExpectedDevEUIs: [][]lorawan.EUI64{
+						{devices[1].DevEUI},
+						nil,
+					},
+				},
 			}
 
 			runGetDeviceQueueItemsTests(tests)
+
+			Convey("When calling GetDeviceWithClassBOrClassCDeviceQueueItems", func() {
+				qi := DeviceQueueItem{DevEUI: devices[1].DevEUI, FCnt: 1, FPort: 1, FRMPayload: []byte{1, 2, 3}}
+				So(CreateDeviceQueueItem(config.C.PostgreSQL.DB, &qi), ShouldBeNil)
+
+				Convey("Then the device with queue items is returned", func() {
+					d, err := GetDeviceWithClassBOrClassCDeviceQueueItems(config.C.PostgreSQL.DB, devices[1].DevEUI)
+					So(err, ShouldBeNil)
+					So(d.DevEUI, ShouldResemble, devices[1].DevEUI)
+				})
+
+				Convey("Then ErrDoesNotExist is returned for the device without queue items", func() {
+					_, err := GetDeviceWithClassBOrClassCDeviceQueueItems(config.C.PostgreSQL.DB, devices[0].DevEUI)
+					So(err, ShouldEqual, ErrDoesNotExist)
+				})
+			})
 		})
 	})
 }
@@ -552,7 +681,7 @@ func runGetDeviceQueueItemsTests(tests []getDeviceQueueItemsTestCase) {
 				So(err, ShouldBeNil)
 				transactions = append(transactions, tx)
 
-				devs, err := GetDevicesWithClassBOrClassCDeviceQueueItems(tx, test.GetCount)
+				devs, err := GetDevicesWithClassBOrClassCDeviceQueueItems(tx, test.GetCount, test.Partitions, test.OwnedPartitions)
 				So(err, ShouldBeNil)
 
 				var euis []lorawan.EUI64
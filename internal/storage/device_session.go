@@ -18,18 +18,24 @@ import (
 
 	commonPB "github.com/brocaar/loraserver/api/common"
 	"github.com/brocaar/loraserver/internal/config"
+	"github.com/brocaar/loraserver/internal/models"
 	"github.com/brocaar/lorawan"
 	"github.com/brocaar/lorawan/band"
 )
 
 const (
-	devAddrKeyTempl       = "lora:ns:devaddr:%s" // contains a set of DevEUIs using this DevAddr
-	deviceSessionKeyTempl = "lora:ns:device:%s"  // contains the session of a DevEUI
+	devAddrKeyTempl            = "lora:ns:devaddr:%s"            // contains a set of DevEUIs using this DevAddr
+	deviceSessionKeyTempl      = "lora:ns:device:%s"             // contains the session of a DevEUI
+	classCDownlinkLockKeyTempl = "lora:ns:device:%s:classc:lock" // set when the Class-C downlink lock of a DevEUI is active
 )
 
 // UplinkHistorySize contains the number of frames to store
 const UplinkHistorySize = 20
 
+// UplinkGatewayHistorySize contains the number of uplink frames for which
+// the receiving gateways are stored
+const UplinkGatewayHistorySize = 20
+
 // RXWindow defines the RX window option.
 type RXWindow int8
 
@@ -49,7 +55,14 @@ type UplinkHistory struct {
 
 // UplinkGatewayHistory contains the uplink gateway history meta-data.
 // This is used for Class-B and Class-C downlinks.
-type UplinkGatewayHistory struct{}
+type UplinkGatewayHistory struct {
+	FCnt    uint32
+	Time    time.Time
+	RSSI    int
+	LoRaSNR float64
+	Board   int
+	Antenna int
+}
 
 // KeyEnvelope defined a key-envelope.
 type KeyEnvelope struct {
@@ -119,6 +132,30 @@ type DeviceSession struct {
 	// This value is controlled by the ADR engine.
 	NbTrans uint8
 
+	// MaxDCycle defines the max duty-cycle acknowledged by the device, as
+	// 1 / 2^MaxDCycle. The default (0) means no duty-cycle limitation.
+	MaxDCycle uint8
+
+	// UplinkDwellTime400ms and DownlinkDwellTime400ms define if the 400ms
+	// dwell-time limitation has been acknowledged by the device.
+	UplinkDwellTime400ms   bool
+	DownlinkDwellTime400ms bool
+
+	// MaxEIRP defines the max EIRP (dBm) acknowledged by the device, or 0
+	// when not set.
+	MaxEIRP int
+
+	// ADRAckLimitExp and ADRAckDelayExp hold the ADR_ACK_LIMIT and
+	// ADR_ACK_DELAY exponents acknowledged by the device. When both are 0,
+	// the device uses the LoRaWAN defaults.
+	ADRAckLimitExp int
+	ADRAckDelayExp int
+
+	// DLChannelFrequencies contains the RX1 downlink frequency per uplink
+	// channel (by index), as acknowledged by the device. Channels which are
+	// not in this map use the band default.
+	DLChannelFrequencies map[int]int
+
 	EnabledChannels       []int                // deprecated, migrated by GetDeviceSession
 	EnabledUplinkChannels []int                // channels that are activated on the node
 	ExtraUplinkChannels   map[int]band.Channel // extra uplink channels, configured by the user
@@ -138,6 +175,7 @@ type DeviceSession struct {
 	PingSlotNb        int
 	PingSlotDR        int
 	PingSlotFrequency int
+	BeaconFrequency   int // 0 = band default
 
 	// RejoinRequestEnabled defines if the rejoin-request is enabled on the
 	// device.
@@ -174,6 +212,43 @@ func (s *DeviceSession) AppendUplinkHistory(up UplinkHistory) {
 	}
 }
 
+// AppendUplinkGatewayHistory updates the uplink gateway history with the
+// gateways that received the given uplink frame. Gateways that did not
+// receive any of the last UplinkGatewayHistorySize uplinks are removed.
+func (s *DeviceSession) AppendUplinkGatewayHistory(fCnt uint32, rxInfoSet models.RXInfoSet) {
+	if s.UplinkGatewayHistory == nil {
+		s.UplinkGatewayHistory = make(map[lorawan.EUI64]UplinkGatewayHistory)
+	}
+
+	for mac, h := range s.UplinkGatewayHistory {
+		// h.FCnt > fCnt means that the frame-counter has been reset
+		if h.FCnt > fCnt || fCnt-h.FCnt >= UplinkGatewayHistorySize {
+			delete(s.UplinkGatewayHistory, mac)
+		}
+	}
+
+	now := time.Now()
+	seen := make(map[lorawan.EUI64]struct{})
+	for _, rxInfo := range rxInfoSet {
+		// the set is sorted (best at index 0), in case a gateway received
+		// the frame multiple times (e.g. by multiple antennas) the best
+		// reception is kept
+		if _, ok := seen[rxInfo.MAC]; ok {
+			continue
+		}
+		seen[rxInfo.MAC] = struct{}{}
+
+		s.UplinkGatewayHistory[rxInfo.MAC] = UplinkGatewayHistory{
+			FCnt:    fCnt,
+			Time:    now,
+			RSSI:    rxInfo.RSSI,
+			LoRaSNR: rxInfo.LoRaSNR,
+			Board:   rxInfo.Board,
+			Antenna: rxInfo.Antenna,
+		}
+	}
+}
+
 // GetPacketLossPercentage returns the percentage of packet-loss over the
 // records stored in UplinkHistory.
 // Note it returns 0 when the uplink history table hasn't been filled yet
@@ -228,25 +303,55 @@ func (s *DeviceSession) ResetToBootParameters(dp DeviceProfile) {
 	s.RX1DROffset = uint8(dp.RXDROffset1)
 	s.RX2DR = uint8(dp.RXDataRate2)
 	s.RX2Frequency = int(dp.RXFreq2)
+
+	// the device boots with the band defaults in case the RX parameters are
+	// not set in the device-profile (the default RX1 data-rate offset is 0)
+	if !dp.RXParametersSet {
+		s.RXDelay = uint8(config.C.NetworkServer.Band.Band.GetDefaults().ReceiveDelay1 / time.Second)
+		s.RX1DROffset = 0
+		s.RX2DR = uint8(config.C.NetworkServer.Band.Band.GetDefaults().RX2DataRate)
+	}
+	if !dp.RXParametersSet || dp.RXFreq2 == 0 {
+		s.RX2Frequency = config.C.NetworkServer.Band.Band.GetDefaults().RX2Frequency
+	}
 	s.EnabledUplinkChannels = config.C.NetworkServer.Band.Band.GetStandardUplinkChannelIndices() // TODO: replace by ServiceProfile.ChannelMask?
 	s.ChannelFrequencies = channelFrequencies
 	s.PingSlotDR = dp.PingSlotDR
 	s.PingSlotFrequency = int(dp.PingSlotFreq)
 	s.NbTrans = 1
+	s.MaxDCycle = 0
+	s.UplinkDwellTime400ms = false
+	s.DownlinkDwellTime400ms = false
+	s.MaxEIRP = 0
+	s.ADRAckLimitExp = 0
+	s.ADRAckDelayExp = 0
+	s.DLChannelFrequencies = nil
+
+	// the device searches the beacon on the default frequency after
+	// activation, a custom frequency must be set using BeaconFreqReq
+	s.BeaconFrequency = 0
 
 	if dp.PingSlotPeriod != 0 {
 		s.PingSlotNb = (1 << 12) / dp.PingSlotPeriod
 	}
 }
 
-// GetDownlinkGatewayMAC returns the gateway MAC of the gateway close to the
-// device.
-func (s DeviceSession) GetDownlinkGatewayMAC() (lorawan.EUI64, error) {
-	for mac := range s.UplinkGatewayHistory {
-		return mac, nil
+// GetUplinkDwellTime returns the uplink dwell-time of the device. The
+// dwell-time is limited when enforced by the band configuration or when
+// acknowledged by the device.
+func (s DeviceSession) GetUplinkDwellTime() lorawan.DwellTime {
+	if s.UplinkDwellTime400ms || config.C.NetworkServer.Band.DwellTime400ms {
+		return lorawan.DwellTime400ms
 	}
+	return lorawan.DwellTimeNoLimit
+}
 
-	return lorawan.EUI64{}, errors.New("uplink gateway-history is empty")
+// GetDownlinkDwellTime returns the downlink dwell-time of the device.
+func (s DeviceSession) GetDownlinkDwellTime() lorawan.DwellTime {
+	if s.DownlinkDwellTime400ms || config.C.NetworkServer.Band.DwellTime400ms {
+		return lorawan.DwellTime400ms
+	}
+	return lorawan.DwellTimeNoLimit
 }
 
 // GetRandomDevAddr returns a random DevAddr, prefixed with NwkID based on the
@@ -313,8 +418,6 @@ func SaveDeviceSession(p *redis.Pool, s DeviceSession) error {
 
 // GetDeviceSession returns the device-session for the given DevEUI.
 func GetDeviceSession(p *redis.Pool, devEUI lorawan.EUI64) (DeviceSession, error) {
-	var dsPB DeviceSessionPB
-
 	c := p.Get()
 	defer c.Close()
 
@@ -326,7 +429,50 @@ func GetDeviceSession(p *redis.Pool, devEUI lorawan.EUI64) (DeviceSession, error
 		return DeviceSession{}, errors.Wrap(err, "get error")
 	}
 
-	err = proto.Unmarshal(val, &dsPB)
+	return decodeDeviceSession(val)
+}
+
+// GetDeviceSessionsForDevEUIs returns the device-sessions for the given
+// DevEUIs, using a single Redis round-trip. DevEUIs without device-session
+// are skipped.
+func GetDeviceSessionsForDevEUIs(p *redis.Pool, devEUIs []lorawan.EUI64) ([]DeviceSession, error) {
+	if len(devEUIs) == 0 {
+		return nil, nil
+	}
+
+	var keys []interface{}
+	for _, devEUI := range devEUIs {
+		keys = append(keys, fmt.Sprintf(deviceSessionKeyTempl, devEUI))
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	vals, err := redis.ByteSlices(c.Do("MGET", keys...))
+	if err != nil {
+		return nil, errors.Wrap(err, "mget error")
+	}
+
+	var out []DeviceSession
+	for _, val := range vals {
+		if val == nil {
+			continue
+		}
+
+		ds, err := decodeDeviceSession(val)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, ds)
+	}
+
+	return out, nil
+}
+
+func decodeDeviceSession(val []byte) (DeviceSession, error) {
+	var dsPB DeviceSessionPB
+
+	err := proto.Unmarshal(val, &dsPB)
 	if err != nil {
 		// fallback on old gob encoding
 		var dsOld DeviceSessionOld
@@ -474,6 +620,42 @@ func GetDeviceSessionForPHYPayload(p *redis.Pool, phy lorawan.PHYPayload, txDR,
 	return DeviceSession{}, ErrDoesNotExistOrFCntOrMICInvalid
 }
 
+// SetClassCDownlinkLock sets the Class-C downlink lock of the given DevEUIs
+// for the given duration. This is used when a downlink has been sent to
+// the devices without updating their device-session (e.g. multicast).
+func SetClassCDownlinkLock(p *redis.Pool, ttl time.Duration, devEUIs ...lorawan.EUI64) error {
+	exp := int64(ttl) / int64(time.Millisecond)
+	if exp <= 0 || len(devEUIs) == 0 {
+		return nil
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	c.Send("MULTI")
+	for _, devEUI := range devEUIs {
+		c.Send("PSETEX", fmt.Sprintf(classCDownlinkLockKeyTempl, devEUI), exp, "lock")
+	}
+	if _, err := c.Do("EXEC"); err != nil {
+		return errors.Wrap(err, "exec error")
+	}
+
+	return nil
+}
+
+// GetClassCDownlinkLock returns a bool indicating if the Class-C downlink
+// lock of the given DevEUI, set by SetClassCDownlinkLock, is active.
+func GetClassCDownlinkLock(p *redis.Pool, devEUI lorawan.EUI64) (bool, error) {
+	c := p.Get()
+	defer c.Close()
+
+	r, err := redis.Int(c.Do("EXISTS", fmt.Sprintf(classCDownlinkLockKeyTempl, devEUI)))
+	if err != nil {
+		return false, errors.Wrap(err, "get exists error")
+	}
+	return r == 1, nil
+}
+
 // DeviceSessionExists returns a bool indicating if a device session exist.
 func DeviceSessionExists(p *redis.Pool, devEUI lorawan.EUI64) (bool, error) {
 	c := p.Get()
@@ -522,6 +704,14 @@ func deviceSessionToPB(d DeviceSession) DeviceSessionPB {
 		MaxSupportedTxPowerIndex: uint32(d.MaxSupportedTXPowerIndex),
 		MaxSupportedDr:           uint32(d.MaxSupportedDR),
 		NbTrans:                  uint32(d.NbTrans),
+		MaxDCycle:                uint32(d.MaxDCycle),
+
+		UplinkDwellTime_400Ms:   d.UplinkDwellTime400ms,
+		DownlinkDwellTime_400Ms: d.DownlinkDwellTime400ms,
+		MaxEirp:                 uint32(d.MaxEIRP),
+
+		AdrAckLimitExp: uint32(d.ADRAckLimitExp),
+		AdrAckDelayExp: uint32(d.ADRAckDelayExp),
 
 		ExtraUplinkChannels:  make(map[uint32]*DeviceSessionPBChannel),
 		UplinkGatewayHistory: make(map[string]*DeviceSessionPBUplinkGatewayHistory),
@@ -533,6 +723,7 @@ func deviceSessionToPB(d DeviceSession) DeviceSessionPB {
 		PingSlotNb:                    uint32(d.PingSlotNb),
 		PingSlotDr:                    uint32(d.PingSlotDR),
 		PingSlotFrequency:             uint32(d.PingSlotFrequency),
+		BeaconFrequency:               uint32(d.BeaconFrequency),
 
 		RejoinRequestEnabled:   d.RejoinRequestEnabled,
 		RejoinRequestMaxCountN: uint32(d.RejoinRequestMaxCountN),
@@ -560,6 +751,13 @@ func deviceSessionToPB(d DeviceSession) DeviceSessionPB {
 		}
 	}
 
+	for i, f := range d.DLChannelFrequencies {
+		if out.DlChannelFrequencies == nil {
+			out.DlChannelFrequencies = make(map[uint32]uint32)
+		}
+		out.DlChannelFrequencies[uint32(i)] = uint32(f)
+	}
+
 	for _, c := range d.ChannelFrequencies {
 		out.ChannelFrequencies = append(out.ChannelFrequencies, uint32(c))
 	}
@@ -573,8 +771,15 @@ func deviceSessionToPB(d DeviceSession) DeviceSessionPB {
 		})
 	}
 
-	for mac := range d.UplinkGatewayHistory {
-		out.UplinkGatewayHistory[mac.String()] = nil
+	for mac, h := range d.UplinkGatewayHistory {
+		out.UplinkGatewayHistory[mac.String()] = &DeviceSessionPBUplinkGatewayHistory{
+			FCnt:            h.FCnt,
+			TimestampUnixNs: h.Time.UnixNano(),
+			Rssi:            int32(h.RSSI),
+			LoraSnr:         float32(h.LoRaSNR),
+			Board:           uint32(h.Board),
+			Antenna:         uint32(h.Antenna),
+		}
 	}
 
 	if d.PendingRejoinDeviceSession != nil {
@@ -620,6 +825,14 @@ func deviceSessionFromPB(d DeviceSessionPB) DeviceSession {
 		MaxSupportedTXPowerIndex: int(d.MaxSupportedTxPowerIndex),
 		MaxSupportedDR:           int(d.MaxSupportedDr),
 		NbTrans:                  uint8(d.NbTrans),
+		MaxDCycle:                uint8(d.MaxDCycle),
+
+		UplinkDwellTime400ms:   d.UplinkDwellTime_400Ms,
+		DownlinkDwellTime400ms: d.DownlinkDwellTime_400Ms,
+		MaxEIRP:                int(d.MaxEirp),
+
+		ADRAckLimitExp: int(d.AdrAckLimitExp),
+		ADRAckDelayExp: int(d.AdrAckDelayExp),
 
 		ExtraUplinkChannels:  make(map[int]band.Channel),
 		UplinkGatewayHistory: make(map[lorawan.EUI64]UplinkGatewayHistory),
@@ -628,6 +841,7 @@ func deviceSessionFromPB(d DeviceSessionPB) DeviceSession {
 		PingSlotNb:        int(d.PingSlotNb),
 		PingSlotDR:        int(d.PingSlotDr),
 		PingSlotFrequency: int(d.PingSlotFrequency),
+		BeaconFrequency:   int(d.BeaconFrequency),
 
 		RejoinRequestEnabled:   d.RejoinRequestEnabled,
 		RejoinRequestMaxCountN: int(d.RejoinRequestMaxCountN),
@@ -670,6 +884,13 @@ func deviceSessionFromPB(d DeviceSessionPB) DeviceSession {
 		}
 	}
 
+	for i, f := range d.DlChannelFrequencies {
+		if out.DLChannelFrequencies == nil {
+			out.DLChannelFrequencies = make(map[int]int)
+		}
+		out.DLChannelFrequencies[int(i)] = int(f)
+	}
+
 	for _, c := range d.ChannelFrequencies {
 		out.ChannelFrequencies = append(out.ChannelFrequencies, int(c))
 	}
@@ -683,12 +904,26 @@ func deviceSessionFromPB(d DeviceSessionPB) DeviceSession {
 		})
 	}
 
-	for macStr := range d.UplinkGatewayHistory {
+	for macStr, h := range d.UplinkGatewayHistory {
 		var mac lorawan.EUI64
 		if err := mac.UnmarshalText([]byte(macStr)); err != nil {
 			continue
 		}
-		out.UplinkGatewayHistory[mac] = UplinkGatewayHistory{}
+
+		var gh UplinkGatewayHistory
+		if h != nil {
+			gh = UplinkGatewayHistory{
+				FCnt:    h.FCnt,
+				RSSI:    int(h.Rssi),
+				LoRaSNR: float64(h.LoraSnr),
+				Board:   int(h.Board),
+				Antenna: int(h.Antenna),
+			}
+			if h.TimestampUnixNs > 0 {
+				gh.Time = time.Unix(0, h.TimestampUnixNs)
+			}
+		}
+		out.UplinkGatewayHistory[mac] = gh
 	}
 
 	if len(d.PendingRejoinDeviceSession) != 0 {
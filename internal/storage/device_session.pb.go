@@ -35,7 +35,7 @@ func (m *DeviceSessionPBChannel) Reset()         { *m = DeviceSessionPBChannel{}
 func (m *DeviceSessionPBChannel) String() string { return proto.CompactTextString(m) }
 func (*DeviceSessionPBChannel) ProtoMessage()    {}
 func (*DeviceSessionPBChannel) Descriptor() ([]byte, []int) {
-	return fileDescriptor_device_session_eca5c4c497bd73d8, []int{0}
+	return fileDescriptor_device_session_43dc4f75b522e1c6, []int{0}
 }
 func (m *DeviceSessionPBChannel) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeviceSessionPBChannel.Unmarshal(m, b)
@@ -94,7 +94,7 @@ func (m *DeviceSessionPBUplinkADRHistory) Reset()         { *m = DeviceSessionPB
 func (m *DeviceSessionPBUplinkADRHistory) String() string { return proto.CompactTextString(m) }
 func (*DeviceSessionPBUplinkADRHistory) ProtoMessage()    {}
 func (*DeviceSessionPBUplinkADRHistory) Descriptor() ([]byte, []int) {
-	return fileDescriptor_device_session_eca5c4c497bd73d8, []int{1}
+	return fileDescriptor_device_session_43dc4f75b522e1c6, []int{1}
 }
 func (m *DeviceSessionPBUplinkADRHistory) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeviceSessionPBUplinkADRHistory.Unmarshal(m, b)
@@ -143,6 +143,18 @@ func (m *DeviceSessionPBUplinkADRHistory) GetGatewayCount() uint32 {
 }
 
 type DeviceSessionPBUplinkGatewayHistory struct {
+	// Uplink frame-counter of the last uplink received by the gateway.
+	FCnt uint32 `protobuf:"varint,1,opt,name=f_cnt,json=fCnt,proto3" json:"f_cnt,omitempty"`
+	// Timestamp (unix ns) of the last uplink received by the gateway.
+	TimestampUnixNs int64 `protobuf:"varint,2,opt,name=timestamp_unix_ns,json=timestampUnixNs,proto3" json:"timestamp_unix_ns,omitempty"`
+	// RSSI.
+	Rssi int32 `protobuf:"varint,3,opt,name=rssi,proto3" json:"rssi,omitempty"`
+	// LoRa SNR.
+	LoraSnr float32 `protobuf:"fixed32,4,opt,name=lora_snr,json=loraSnr,proto3" json:"lora_snr,omitempty"`
+	// Board.
+	Board uint32 `protobuf:"varint,5,opt,name=board,proto3" json:"board,omitempty"`
+	// Antenna.
+	Antenna              uint32   `protobuf:"varint,6,opt,name=antenna,proto3" json:"antenna,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -152,7 +164,7 @@ func (m *DeviceSessionPBUplinkGatewayHistory) Reset()         { *m = DeviceSessi
 func (m *DeviceSessionPBUplinkGatewayHistory) String() string { return proto.CompactTextString(m) }
 func (*DeviceSessionPBUplinkGatewayHistory) ProtoMessage()    {}
 func (*DeviceSessionPBUplinkGatewayHistory) Descriptor() ([]byte, []int) {
-	return fileDescriptor_device_session_eca5c4c497bd73d8, []int{2}
+	return fileDescriptor_device_session_43dc4f75b522e1c6, []int{2}
 }
 func (m *DeviceSessionPBUplinkGatewayHistory) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeviceSessionPBUplinkGatewayHistory.Unmarshal(m, b)
@@ -172,6 +184,48 @@ func (m *DeviceSessionPBUplinkGatewayHistory) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_DeviceSessionPBUplinkGatewayHistory proto.InternalMessageInfo
 
+func (m *DeviceSessionPBUplinkGatewayHistory) GetFCnt() uint32 {
+	if m != nil {
+		return m.FCnt
+	}
+	return 0
+}
+
+func (m *DeviceSessionPBUplinkGatewayHistory) GetTimestampUnixNs() int64 {
+	if m != nil {
+		return m.TimestampUnixNs
+	}
+	return 0
+}
+
+func (m *DeviceSessionPBUplinkGatewayHistory) GetRssi() int32 {
+	if m != nil {
+		return m.Rssi
+	}
+	return 0
+}
+
+func (m *DeviceSessionPBUplinkGatewayHistory) GetLoraSnr() float32 {
+	if m != nil {
+		return m.LoraSnr
+	}
+	return 0
+}
+
+func (m *DeviceSessionPBUplinkGatewayHistory) GetBoard() uint32 {
+	if m != nil {
+		return m.Board
+	}
+	return 0
+}
+
+func (m *DeviceSessionPBUplinkGatewayHistory) GetAntenna() uint32 {
+	if m != nil {
+		return m.Antenna
+	}
+	return 0
+}
+
 type DeviceSessionPB struct {
 	// ID of the device-profile.
 	DeviceProfileId string `protobuf:"bytes,1,opt,name=device_profile_id,json=deviceProfileId,proto3" json:"device_profile_id,omitempty"`
@@ -227,7 +281,7 @@ type DeviceSessionPB struct {
 	MaxSupportedTxPowerIndex uint32 `protobuf:"varint,21,opt,name=max_supported_tx_power_index,json=maxSupportedTxPowerIndex,proto3" json:"max_supported_tx_power_index,omitempty"`
 	// MaxSupportedDR defines the maximum supported DR index by the node,
 	// or 0 when not set.
-	MaxSupportedDr uint32 `protobuf:"varint,22,opt,name=max_supported_dr,json=maxSupportedDr,proto3" json:"max_supported_dr,omitempty"`
+	MaxSupportedDr uint32 `protobuf:"varint,54,opt,name=max_supported_dr,json=maxSupportedDr,proto3" json:"max_supported_dr,omitempty"`
 	// NbTrans defines the number of transmissions for each unconfirmed uplink
 	// frame. In case of 0, the default value is used.
 	// This value is controlled by the ADR engine.
@@ -274,17 +328,33 @@ type DeviceSessionPB struct {
 	RejoinCount_0 uint32 `protobuf:"varint,42,opt,name=rejoin_count_0,json=rejoinCount0,proto3" json:"rejoin_count_0,omitempty"`
 	// Pending rejoin device-session contains a device-session which has not
 	// yet been activated by the device (by sending a first uplink).
-	PendingRejoinDeviceSession []byte   `protobuf:"bytes,43,opt,name=pending_rejoin_device_session,json=pendingRejoinDeviceSession,proto3" json:"pending_rejoin_device_session,omitempty"`
-	XXX_NoUnkeyedLiteral       struct{} `json:"-"`
-	XXX_unrecognized           []byte   `json:"-"`
-	XXX_sizecache              int32    `json:"-"`
+	PendingRejoinDeviceSession []byte `protobuf:"bytes,43,opt,name=pending_rejoin_device_session,json=pendingRejoinDeviceSession,proto3" json:"pending_rejoin_device_session,omitempty"`
+	// Max duty-cycle acknowledged by the device (1 / 2^MaxDCycle).
+	MaxDCycle uint32 `protobuf:"varint,46,opt,name=max_d_cycle,json=maxDCycle,proto3" json:"max_d_cycle,omitempty"`
+	// Uplink dwell-time limitation acknowledged by the device.
+	UplinkDwellTime_400Ms bool `protobuf:"varint,47,opt,name=uplink_dwell_time_400ms,json=uplinkDwellTime400ms,proto3" json:"uplink_dwell_time_400ms,omitempty"`
+	// Downlink dwell-time limitation acknowledged by the device.
+	DownlinkDwellTime_400Ms bool `protobuf:"varint,48,opt,name=downlink_dwell_time_400ms,json=downlinkDwellTime400ms,proto3" json:"downlink_dwell_time_400ms,omitempty"`
+	// Max EIRP (dBm) acknowledged by the device.
+	MaxEirp uint32 `protobuf:"varint,49,opt,name=max_eirp,json=maxEirp,proto3" json:"max_eirp,omitempty"`
+	// RX1 downlink frequency per uplink channel, acknowledged by the device.
+	DlChannelFrequencies map[uint32]uint32 `protobuf:"bytes,50,rep,name=dl_channel_frequencies,json=dlChannelFrequencies,proto3" json:"dl_channel_frequencies,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// ADR_ACK_LIMIT exponent acknowledged by the device.
+	AdrAckLimitExp uint32 `protobuf:"varint,51,opt,name=adr_ack_limit_exp,json=adrAckLimitExp,proto3" json:"adr_ack_limit_exp,omitempty"`
+	// ADR_ACK_DELAY exponent acknowledged by the device.
+	AdrAckDelayExp uint32 `protobuf:"varint,52,opt,name=adr_ack_delay_exp,json=adrAckDelayExp,proto3" json:"adr_ack_delay_exp,omitempty"`
+	// Class-B beacon frequency acknowledged by the device (0 = default).
+	BeaconFrequency      uint32   `protobuf:"varint,53,opt,name=beacon_frequency,json=beaconFrequency,proto3" json:"beacon_frequency,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *DeviceSessionPB) Reset()         { *m = DeviceSessionPB{} }
 func (m *DeviceSessionPB) String() string { return proto.CompactTextString(m) }
 func (*DeviceSessionPB) ProtoMessage()    {}
 func (*DeviceSessionPB) Descriptor() ([]byte, []int) {
-	return fileDescriptor_device_session_eca5c4c497bd73d8, []int{3}
+	return fileDescriptor_device_session_43dc4f75b522e1c6, []int{3}
 }
 func (m *DeviceSessionPB) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeviceSessionPB.Unmarshal(m, b)
@@ -605,94 +675,166 @@ func (m *DeviceSessionPB) GetPendingRejoinDeviceSession() []byte {
 	return nil
 }
 
+func (m *DeviceSessionPB) GetMaxDCycle() uint32 {
+	if m != nil {
+		return m.MaxDCycle
+	}
+	return 0
+}
+
+func (m *DeviceSessionPB) GetUplinkDwellTime_400Ms() bool {
+	if m != nil {
+		return m.UplinkDwellTime_400Ms
+	}
+	return false
+}
+
+func (m *DeviceSessionPB) GetDownlinkDwellTime_400Ms() bool {
+	if m != nil {
+		return m.DownlinkDwellTime_400Ms
+	}
+	return false
+}
+
+func (m *DeviceSessionPB) GetMaxEirp() uint32 {
+	if m != nil {
+		return m.MaxEirp
+	}
+	return 0
+}
+
+func (m *DeviceSessionPB) GetDlChannelFrequencies() map[uint32]uint32 {
+	if m != nil {
+		return m.DlChannelFrequencies
+	}
+	return nil
+}
+
+func (m *DeviceSessionPB) GetAdrAckLimitExp() uint32 {
+	if m != nil {
+		return m.AdrAckLimitExp
+	}
+	return 0
+}
+
+func (m *DeviceSessionPB) GetAdrAckDelayExp() uint32 {
+	if m != nil {
+		return m.AdrAckDelayExp
+	}
+	return 0
+}
+
+func (m *DeviceSessionPB) GetBeaconFrequency() uint32 {
+	if m != nil {
+		return m.BeaconFrequency
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*DeviceSessionPBChannel)(nil), "storage.DeviceSessionPBChannel")
 	proto.RegisterType((*DeviceSessionPBUplinkADRHistory)(nil), "storage.DeviceSessionPBUplinkADRHistory")
 	proto.RegisterType((*DeviceSessionPBUplinkGatewayHistory)(nil), "storage.DeviceSessionPBUplinkGatewayHistory")
 	proto.RegisterType((*DeviceSessionPB)(nil), "storage.DeviceSessionPB")
+	proto.RegisterMapType((map[uint32]uint32)(nil), "storage.DeviceSessionPB.DlChannelFrequenciesEntry")
 	proto.RegisterMapType((map[uint32]*DeviceSessionPBChannel)(nil), "storage.DeviceSessionPB.ExtraUplinkChannelsEntry")
 	proto.RegisterMapType((map[string]*DeviceSessionPBUplinkGatewayHistory)(nil), "storage.DeviceSessionPB.UplinkGatewayHistoryEntry")
 }
 
 func init() {
-	proto.RegisterFile("device_session.proto", fileDescriptor_device_session_eca5c4c497bd73d8)
-}
-
-var fileDescriptor_device_session_eca5c4c497bd73d8 = []byte{
-	// 1195 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x56, 0x5b, 0x53, 0x1b, 0x47,
-	0x13, 0x2d, 0x81, 0xb9, 0xb5, 0x10, 0x97, 0xe1, 0xe2, 0x81, 0x0f, 0x17, 0xb2, 0xb0, 0x3f, 0x2b,
-	0x8e, 0x23, 0x6c, 0x25, 0x4e, 0xb9, 0xfc, 0x90, 0x0a, 0x46, 0x38, 0xa1, 0x9c, 0x10, 0xd7, 0x82,
-	0xfd, 0x3a, 0x35, 0xda, 0x19, 0xc1, 0x46, 0xab, 0xd9, 0xcd, 0xec, 0xac, 0xb4, 0xfa, 0x2b, 0x79,
-	0xce, 0x0f, 0x4d, 0x4d, 0xcf, 0x08, 0x5d, 0x80, 0x3c, 0x81, 0xfa, 0x9c, 0x3e, 0x3d, 0x97, 0x3e,
-	0x3d, 0x0b, 0xdb, 0x42, 0xf6, 0xa3, 0x50, 0xb2, 0x4c, 0x66, 0x59, 0x94, 0xa8, 0x46, 0xaa, 0x13,
-	0x93, 0x90, 0xa5, 0xcc, 0x24, 0x9a, 0x5f, 0xcb, 0xfd, 0xb7, 0xd7, 0x91, 0xb9, 0xc9, 0xdb, 0x8d,
-	0x30, 0xe9, 0x1d, 0xb7, 0x75, 0x12, 0x72, 0xae, 0x8f, 0xe3, 0x44, 0xf3, 0x4c, 0xea, 0xbe, 0xd4,
-	0xc7, 0x3c, 0x8d, 0x8e, 0xc3, 0xa4, 0xd7, 0x4b, 0x94, 0xff, 0xe3, 0xf2, 0x6b, 0x02, 0x76, 0x5b,
-	0xa8, 0x7b, 0xe9, 0x64, 0x3f, 0x7f, 0x38, 0xbd, 0xe1, 0x4a, 0xc9, 0x98, 0x1c, 0xc0, 0x4a, 0x47,
-	0xcb, 0xbf, 0x72, 0xa9, 0xc2, 0x21, 0x2d, 0x55, 0x4b, 0xf5, 0x4a, 0x30, 0x0e, 0x90, 0x1d, 0x58,
-	0xec, 0x45, 0x8a, 0x09, 0x4d, 0xe7, 0x10, 0x5a, 0xe8, 0x45, 0xaa, 0xa5, 0x31, 0xcc, 0x0b, 0x1b,
-	0x9e, 0xf7, 0x61, 0x5e, 0xb4, 0x74, 0xed, 0xef, 0x12, 0x1c, 0xce, 0x94, 0xf9, 0x92, 0xc6, 0x91,
-	0xea, 0x9e, 0xb4, 0x82, 0x5f, 0x23, 0xbb, 0x85, 0x21, 0xd9, 0x82, 0x85, 0x0e, 0x0b, 0x95, 0xf1,
-	0xb5, 0x1e, 0x75, 0x4e, 0x95, 0x21, 0x8f, 0x61, 0xc9, 0xea, 0x65, 0xca, 0xd5, 0x99, 0x0b, 0xac,
-	0xfc, 0xa5, 0xd2, 0xe4, 0x19, 0xac, 0x99, 0x82, 0xa5, 0xc9, 0x40, 0x6a, 0x16, 0x29, 0x21, 0x0b,
-	0x5f, 0x70, 0xd5, 0x14, 0x9f, 0x6d, 0xf0, 0xdc, 0xc6, 0xc8, 0x11, 0x54, 0xae, 0xb9, 0x91, 0x03,
-	0x3e, 0x64, 0x61, 0x92, 0x2b, 0x43, 0x1f, 0x39, 0x92, 0x0f, 0x9e, 0xda, 0x58, 0xed, 0x39, 0x1c,
-	0xdd, 0xbb, 0xb6, 0x5f, 0x1c, 0xc9, 0xaf, 0xaf, 0xf6, 0xcf, 0x26, 0xac, 0xcf, 0xf0, 0xc8, 0x4b,
-	0xd8, 0xf4, 0xb7, 0x92, 0xea, 0xa4, 0x13, 0xc5, 0x92, 0x45, 0x02, 0xd7, 0xbf, 0x12, 0xac, 0x3b,
-	0xe0, 0xb3, 0x8b, 0x9f, 0x0b, 0xf2, 0x0a, 0x88, 0xbd, 0x8b, 0x19, 0xf2, 0x1c, 0x92, 0x37, 0x3c,
-	0x32, 0xc5, 0xd6, 0x49, 0x6e, 0x22, 0x75, 0x3d, 0xc9, 0x9e, 0x77, 0x6c, 0x8f, 0x8c, 0xd9, 0x7b,
-	0xb0, 0x2c, 0x64, 0x9f, 0x71, 0x21, 0x34, 0x6e, 0x71, 0x35, 0x58, 0x12, 0xb2, 0x7f, 0x22, 0x84,
-	0xb6, 0x27, 0x68, 0x21, 0x99, 0x47, 0x74, 0x01, 0x91, 0x45, 0x21, 0xfb, 0x67, 0x79, 0x64, 0x73,
-	0xfe, 0x4c, 0x22, 0x85, 0xc8, 0xa2, 0xcb, 0xb1, 0xbf, 0x2d, 0xf4, 0x0c, 0xd6, 0x3b, 0x4c, 0x0d,
-	0xba, 0x2c, 0x63, 0x91, 0x32, 0xac, 0x2b, 0x87, 0x74, 0x09, 0x19, 0xe5, 0xce, 0xc5, 0xa0, 0x7b,
-	0x79, 0xae, 0xcc, 0x27, 0x39, 0xb4, 0xac, 0x6c, 0x86, 0xb5, 0xec, 0x58, 0xd9, 0x04, 0xeb, 0x29,
-	0x54, 0x1c, 0x47, 0xaa, 0x10, 0x39, 0x2b, 0xc8, 0x01, 0x35, 0xe8, 0x5e, 0x9e, 0xa9, 0xd0, 0x52,
-	0x7e, 0x06, 0xc2, 0xd3, 0x94, 0x65, 0x16, 0x66, 0x52, 0xf5, 0x65, 0x9c, 0xa4, 0x92, 0x7e, 0x57,
-	0x2d, 0xd5, 0xcb, 0xcd, 0xad, 0x86, 0x6f, 0xd7, 0x4f, 0x72, 0x78, 0xe6, 0xa1, 0x60, 0x9d, 0xa7,
-	0xe9, 0xe5, 0x44, 0x80, 0x50, 0x58, 0xc6, 0xde, 0x61, 0x79, 0x4a, 0x01, 0xaf, 0x78, 0xd1, 0xb6,
-	0xcf, 0x97, 0x94, 0x1c, 0xc2, 0xaa, 0x62, 0x0e, 0x13, 0xc9, 0x40, 0xd1, 0xb2, 0x6b, 0x64, 0xf5,
-	0xf1, 0x54, 0x99, 0x56, 0x32, 0x50, 0x96, 0xc0, 0x27, 0x09, 0xab, 0x8e, 0xc0, 0x6f, 0x09, 0x07,
-	0x00, 0x61, 0xa2, 0x3a, 0x8e, 0x43, 0x5f, 0x20, 0xbc, 0x6c, 0x23, 0x96, 0x41, 0x5e, 0xc0, 0x46,
-	0xd6, 0x8d, 0x52, 0xaf, 0x10, 0xde, 0xc8, 0xb0, 0x4b, 0x2b, 0xd5, 0x52, 0x7d, 0x39, 0xa8, 0xd8,
-	0xb8, 0xe5, 0x9c, 0xda, 0xa0, 0x3d, 0x6e, 0x5d, 0x30, 0x21, 0x63, 0x3e, 0xa4, 0x6b, 0x28, 0xb2,
-	0xa4, 0x8b, 0x96, 0xfd, 0x49, 0x6a, 0x50, 0xd1, 0xc5, 0x1b, 0x26, 0x34, 0x4b, 0x3a, 0x9d, 0x4c,
-	0x1a, 0xba, 0x8e, 0x78, 0x59, 0x17, 0x6f, 0x5a, 0xfa, 0x0f, 0x0c, 0x59, 0x63, 0xe9, 0xa2, 0x69,
-	0x8d, 0xb5, 0xe1, 0x8c, 0xa5, 0x8b, 0x66, 0x4b, 0xdb, 0x06, 0xb7, 0xe1, 0xb1, 0x51, 0x37, 0x5d,
-	0x83, 0xeb, 0xa2, 0xf9, 0xf1, 0xd6, 0xab, 0x77, 0xbd, 0x42, 0xee, 0xf1, 0xca, 0x1a, 0xcc, 0x09,
-	0x4d, 0xb7, 0x10, 0x99, 0x13, 0x9a, 0x6c, 0xc0, 0x3c, 0x17, 0x9a, 0x6e, 0xe3, 0x66, 0xec, 0xbf,
-	0xe4, 0x27, 0x38, 0x40, 0x33, 0xe6, 0x69, 0x9a, 0x68, 0x23, 0x05, 0x9b, 0x51, 0xdd, 0xc1, 0x5c,
-	0x6a, 0x1d, 0x3a, 0xa2, 0x5c, 0x4d, 0x56, 0xa8, 0xc3, 0xc6, 0x74, 0xbe, 0xd0, 0x74, 0x17, 0x73,
-	0xd6, 0x26, 0x73, 0x5a, 0xda, 0x1e, 0x96, 0x6a, 0x33, 0xa3, 0xb9, 0xca, 0xe8, 0x63, 0x77, 0x58,
-	0xaa, 0x7d, 0x65, 0x7f, 0x92, 0x1f, 0xe1, 0xb1, 0x54, 0xbc, 0x1d, 0x4b, 0xc1, 0x72, 0xb4, 0x29,
-	0x0b, 0xdd, 0xc0, 0xca, 0x28, 0xad, 0xce, 0xd7, 0x2b, 0xc1, 0x8e, 0x87, 0x9d, 0x89, 0xfd, 0x34,
-	0xcb, 0x88, 0x84, 0x1d, 0x59, 0x18, 0xcd, 0xef, 0x64, 0xed, 0x55, 0xe7, 0xeb, 0xe5, 0xe6, 0x9b,
-	0x86, 0x1f, 0xa4, 0x8d, 0x19, 0x8f, 0x37, 0xce, 0x6c, 0xd6, 0xb4, 0xd8, 0x99, 0x32, 0x7a, 0x18,
-	0x6c, 0xc9, 0xbb, 0x08, 0x39, 0x86, 0x2d, 0xaf, 0x7c, 0x7b, 0x29, 0x91, 0xcc, 0xe8, 0x3e, 0x2e,
-	0x8d, 0x78, 0xe8, 0xe3, 0x18, 0x21, 0x5f, 0x81, 0xf8, 0x15, 0x71, 0xa1, 0xd9, 0x8d, 0x1b, 0x36,
-	0xf4, 0x7f, 0xb8, 0xa8, 0xfa, 0x43, 0x8b, 0x9a, 0x1d, 0x9e, 0xc1, 0x86, 0xd3, 0x38, 0x11, 0x7a,
-	0x34, 0x4e, 0x6f, 0x60, 0xd7, 0xeb, 0x8e, 0x26, 0xe0, 0x48, 0xfb, 0x00, 0xb5, 0x9b, 0x0f, 0x6e,
-	0xf8, 0xbe, 0xe9, 0xe7, 0x76, 0xbc, 0x9d, 0xdf, 0x03, 0x91, 0x00, 0x5e, 0xc4, 0x3c, 0x33, 0x6c,
-	0xf4, 0x3e, 0x19, 0x6e, 0xf2, 0x8c, 0xe1, 0x16, 0x33, 0xc3, 0x4c, 0xd4, 0x93, 0x2c, 0x57, 0x51,
-	0xc1, 0x54, 0x46, 0x9f, 0x54, 0x4b, 0xf5, 0xf9, 0xe0, 0xa9, 0xa5, 0xfb, 0xaa, 0x48, 0x0e, 0x1c,
-	0xf7, 0x2a, 0xea, 0xc9, 0x2f, 0x2a, 0x2a, 0x2e, 0x32, 0x72, 0x0e, 0x35, 0xa7, 0x99, 0x0c, 0x14,
-	0x6e, 0xc2, 0x14, 0xa8, 0x94, 0x19, 0xde, 0x4b, 0x6f, 0xe5, 0xaa, 0x28, 0xf7, 0x04, 0xe5, 0x3c,
-	0xf1, 0xaa, 0xb8, 0x1a, 0xd1, 0xbc, 0xd4, 0x11, 0x54, 0xda, 0x92, 0x87, 0x89, 0x62, 0x71, 0x12,
-	0x76, 0xa5, 0xa0, 0x4f, 0xb1, 0xa3, 0x57, 0x5d, 0xf0, 0x37, 0x8c, 0x91, 0x2a, 0xac, 0xa6, 0x76,
-	0xd6, 0x66, 0x71, 0x62, 0x98, 0x6a, 0xd3, 0x1a, 0x36, 0x1d, 0xd8, 0xd8, 0x65, 0x9c, 0x98, 0x8b,
-	0xf6, 0x34, 0x43, 0x68, 0x7a, 0x34, 0xcd, 0x68, 0x69, 0xd2, 0x80, 0xad, 0x31, 0x63, 0xec, 0xc8,
-	0x67, 0x48, 0xdc, 0x1c, 0x11, 0xc7, 0xb6, 0x3c, 0x84, 0x72, 0x8f, 0x87, 0xac, 0x2f, 0xb5, 0x3d,
-	0x78, 0xfa, 0x1c, 0x67, 0x3b, 0xf4, 0x78, 0xf8, 0xd5, 0x45, 0xd0, 0x6f, 0x91, 0x7a, 0xd8, 0x6f,
-	0xff, 0xf7, 0x7e, 0x8b, 0xd4, 0xfd, 0x7e, 0xfb, 0x01, 0x76, 0xb5, 0xc4, 0x19, 0x3f, 0xba, 0x0c,
-	0x6f, 0x0d, 0xfa, 0x0a, 0x8f, 0x60, 0xdb, 0xa1, 0xfe, 0xf4, 0xcf, 0x1c, 0x46, 0xde, 0xc3, 0xfe,
-	0x4c, 0x96, 0x35, 0x2d, 0x3e, 0x9f, 0x4c, 0xd1, 0x3a, 0xd6, 0xdc, 0x9d, 0xca, 0xfc, 0x9d, 0x17,
-	0xf8, 0x92, 0x5e, 0x90, 0x77, 0xb0, 0x77, 0x4f, 0x2e, 0xb6, 0x80, 0xa2, 0xdf, 0x60, 0xea, 0xce,
-	0x6c, 0xaa, 0xbd, 0xaf, 0x0b, 0x3b, 0xa3, 0x7c, 0xa6, 0xab, 0xf4, 0x9a, 0xbe, 0xf4, 0x93, 0x0c,
-	0xa3, 0xa8, 0xff, 0x9a, 0x9c, 0xc0, 0x93, 0x54, 0x2a, 0x61, 0x4f, 0xd9, 0xb3, 0xa7, 0x3f, 0x8a,
-	0xe8, 0xb7, 0xf8, 0xb8, 0xec, 0x7b, 0x52, 0x80, 0x9c, 0xa9, 0xfe, 0xde, 0xbf, 0x06, 0xfa, 0x90,
-	0xa3, 0xed, 0xc8, 0xb3, 0x2f, 0x94, 0xfb, 0x00, 0xb1, 0xff, 0x92, 0xb7, 0xb0, 0xd0, 0xe7, 0x71,
-	0x2e, 0xf1, 0x9d, 0x2e, 0x37, 0x0f, 0x1f, 0x32, 0x8d, 0xd7, 0x09, 0x1c, 0xfb, 0xfd, 0xdc, 0xbb,
-	0xd2, 0x7e, 0x0e, 0x7b, 0x0f, 0x3a, 0x69, 0xb2, 0xd2, 0x8a, 0xab, 0xf4, 0x61, 0xba, 0xd2, 0xab,
-	0xff, 0xb6, 0xfe, 0xb4, 0xe6, 0x44, 0xd9, 0xf6, 0x22, 0x7e, 0xd7, 0x7d, 0xff, 0x6f, 0x00, 0x00,
-	0x00, 0xff, 0xff, 0x2d, 0x47, 0xfa, 0x0f, 0x2f, 0x0a, 0x00, 0x00,
+	proto.RegisterFile("device_session.proto", fileDescriptor_device_session_43dc4f75b522e1c6)
+}
+
+var fileDescriptor_device_session_43dc4f75b522e1c6 = []byte{
+	// 1427 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x57, 0xeb, 0x56, 0x1b, 0xb7,
+	0x16, 0x5e, 0xdc, 0x8d, 0xc0, 0x60, 0xc4, 0x4d, 0xe6, 0x90, 0x03, 0x81, 0x9c, 0x13, 0x27, 0x4d,
+	0x0d, 0x38, 0x21, 0x2b, 0xcd, 0x8f, 0xae, 0x12, 0xec, 0xa4, 0x34, 0x29, 0xcd, 0x1a, 0x48, 0xfe,
+	0x6a, 0xc9, 0x23, 0x19, 0xa6, 0x1e, 0x6b, 0xa6, 0x9a, 0xb1, 0x3d, 0x7e, 0x86, 0xbe, 0x41, 0xdf,
+	0xa7, 0xef, 0xd5, 0xb5, 0xb7, 0x64, 0x7c, 0xc1, 0xa4, 0xbf, 0xb0, 0xbe, 0xfd, 0xed, 0x9b, 0xa4,
+	0xfd, 0x69, 0x20, 0x1b, 0x52, 0x75, 0x02, 0x5f, 0xf1, 0x44, 0x25, 0x49, 0x10, 0xe9, 0x72, 0x6c,
+	0xa2, 0x34, 0xa2, 0x0b, 0x49, 0x1a, 0x19, 0x71, 0xa3, 0x76, 0x4e, 0x6f, 0x82, 0xf4, 0xb6, 0x5d,
+	0x2f, 0xfb, 0x51, 0xeb, 0xa8, 0x6e, 0x22, 0x5f, 0x08, 0x73, 0x14, 0x46, 0x46, 0x24, 0xca, 0x74,
+	0x94, 0x39, 0x12, 0x71, 0x70, 0xe4, 0x47, 0xad, 0x56, 0xa4, 0xdd, 0x1f, 0xeb, 0x7f, 0x20, 0xc9,
+	0x56, 0x15, 0xe3, 0x5e, 0xd9, 0xb0, 0x9f, 0xdf, 0x9d, 0xdf, 0x0a, 0xad, 0x55, 0x48, 0x77, 0xc9,
+	0x62, 0xc3, 0xa8, 0x3f, 0xda, 0x4a, 0xfb, 0x3d, 0x36, 0xb5, 0x3f, 0x55, 0xca, 0x7b, 0x03, 0x80,
+	0x6e, 0x92, 0xf9, 0x56, 0xa0, 0xb9, 0x34, 0x6c, 0x1a, 0x4d, 0x73, 0xad, 0x40, 0x57, 0x0d, 0xc2,
+	0x22, 0x03, 0x78, 0xc6, 0xc1, 0x22, 0xab, 0x9a, 0x83, 0xbf, 0xa6, 0xc8, 0xde, 0x58, 0x9a, 0x2f,
+	0x71, 0x18, 0xe8, 0xe6, 0x59, 0xd5, 0xfb, 0x39, 0x80, 0x16, 0x7a, 0x74, 0x9d, 0xcc, 0x35, 0xb8,
+	0xaf, 0x53, 0x97, 0x6b, 0xb6, 0x71, 0xae, 0x53, 0xba, 0x4d, 0x16, 0x20, 0x5e, 0xa2, 0x6d, 0x9e,
+	0x69, 0x0f, 0xc2, 0x5f, 0x69, 0x43, 0x9f, 0x90, 0x95, 0x34, 0xe3, 0x71, 0xd4, 0x55, 0x86, 0x07,
+	0x5a, 0xaa, 0xcc, 0x25, 0x5c, 0x4e, 0xb3, 0xcf, 0x00, 0x5e, 0x00, 0x46, 0x0f, 0x49, 0xfe, 0x46,
+	0xa4, 0xaa, 0x2b, 0x7a, 0xdc, 0x8f, 0xda, 0x3a, 0x65, 0xb3, 0x96, 0xe4, 0xc0, 0x73, 0xc0, 0x0e,
+	0xfe, 0x9e, 0x22, 0x87, 0x13, 0x8b, 0xfb, 0x60, 0x59, 0xdf, 0x2c, 0xf0, 0x39, 0x59, 0x4b, 0x83,
+	0x96, 0x4a, 0x52, 0xd1, 0x8a, 0x79, 0x5b, 0x07, 0x19, 0xd7, 0x09, 0x96, 0x3a, 0xe3, 0xad, 0xde,
+	0x19, 0xbe, 0xe8, 0x20, 0xbb, 0x4c, 0x28, 0x25, 0xb3, 0x26, 0x49, 0x02, 0xac, 0x74, 0xce, 0xc3,
+	0xdf, 0xb4, 0x48, 0x72, 0x70, 0x4a, 0xd8, 0xe1, 0x2c, 0x76, 0xb8, 0x00, 0x6b, 0x68, 0x71, 0x83,
+	0xcc, 0xd5, 0x23, 0x61, 0x24, 0x9b, 0xb3, 0x5b, 0x89, 0x0b, 0xca, 0xc8, 0x82, 0xd0, 0xa9, 0xd2,
+	0x5a, 0xb0, 0x79, 0xc4, 0xfb, 0xcb, 0x83, 0x3f, 0x37, 0xc9, 0xea, 0x58, 0x1f, 0x50, 0x9e, 0xbb,
+	0x36, 0xb1, 0x89, 0x1a, 0x41, 0xa8, 0x78, 0x20, 0xb1, 0xfe, 0x45, 0x6f, 0xd5, 0x1a, 0x3e, 0x5b,
+	0xfc, 0x42, 0xd2, 0x17, 0x84, 0xc2, 0x65, 0x19, 0x23, 0x4f, 0x23, 0xb9, 0xe0, 0x2c, 0x23, 0x6c,
+	0x13, 0xb5, 0xd3, 0x40, 0xdf, 0x0c, 0xb3, 0x67, 0x2c, 0xdb, 0x59, 0x06, 0xec, 0x22, 0xc9, 0x49,
+	0xd5, 0xe1, 0x42, 0x4a, 0xdb, 0xe6, 0xb2, 0xb7, 0x20, 0x55, 0xe7, 0x4c, 0x4a, 0x03, 0x47, 0x0c,
+	0x26, 0xd5, 0x0e, 0xb0, 0xd1, 0x65, 0x6f, 0x5e, 0xaa, 0x4e, 0xad, 0x8d, 0x5b, 0xf3, 0x7b, 0x14,
+	0x68, 0xb4, 0xcc, 0x5b, 0x1f, 0x58, 0x83, 0xe9, 0x09, 0x59, 0x6d, 0x70, 0xdd, 0x6d, 0xf2, 0x84,
+	0x07, 0x3a, 0xe5, 0x4d, 0xd5, 0x63, 0x0b, 0xc8, 0x58, 0x6a, 0x5c, 0x76, 0x9b, 0x57, 0x17, 0x3a,
+	0xfd, 0xa8, 0x7a, 0xc0, 0x4a, 0xc6, 0x58, 0x39, 0xcb, 0x4a, 0x86, 0x58, 0x8f, 0x49, 0xde, 0x72,
+	0x94, 0xf6, 0x91, 0xb3, 0x88, 0x1c, 0xa2, 0xbb, 0xcd, 0xab, 0x9a, 0xf6, 0x81, 0xf2, 0x13, 0xa1,
+	0x22, 0x8e, 0x79, 0x02, 0x66, 0xae, 0x74, 0x47, 0x85, 0x51, 0xac, 0xd8, 0xf7, 0xfb, 0x53, 0xa5,
+	0xa5, 0xca, 0x7a, 0xd9, 0xcd, 0xd3, 0x47, 0xd5, 0xab, 0x39, 0x93, 0xb7, 0x2a, 0xe2, 0xf8, 0x6a,
+	0x08, 0xa0, 0x8c, 0xe4, 0xf0, 0xee, 0xf0, 0x76, 0xcc, 0x08, 0x1e, 0xdb, 0x3c, 0x5c, 0x9f, 0x2f,
+	0x31, 0xdd, 0x23, 0xcb, 0x9a, 0x5b, 0x9b, 0x8c, 0xba, 0x9a, 0x2d, 0xd9, 0x49, 0xd3, 0xef, 0xcf,
+	0x75, 0x5a, 0x8d, 0xba, 0x1a, 0x08, 0x62, 0x98, 0xb0, 0x6c, 0x09, 0xe2, 0x8e, 0xb0, 0x4b, 0x88,
+	0x1f, 0xe9, 0x86, 0xe5, 0xb0, 0xa7, 0x68, 0xce, 0x01, 0x02, 0x0c, 0xfa, 0x94, 0x14, 0x92, 0x66,
+	0x10, 0xbb, 0x08, 0xfe, 0xad, 0xf2, 0x9b, 0x2c, 0xbf, 0x3f, 0x55, 0xca, 0x79, 0x79, 0xc0, 0x81,
+	0x73, 0x0e, 0x20, 0x6c, 0xb7, 0xc9, 0xb8, 0x54, 0xa1, 0xe8, 0xb1, 0x15, 0x7b, 0xb3, 0x4c, 0x56,
+	0x85, 0x25, 0x3d, 0x20, 0x79, 0x93, 0x9d, 0x70, 0x69, 0x78, 0xd4, 0x68, 0x24, 0x2a, 0x65, 0xab,
+	0x68, 0x5f, 0x32, 0xd9, 0x49, 0xd5, 0xfc, 0x86, 0x10, 0x4c, 0xbe, 0xc9, 0x2a, 0x30, 0xf9, 0x05,
+	0x7b, 0x5d, 0x4d, 0x56, 0xa9, 0x1a, 0x98, 0x40, 0x80, 0x07, 0x4a, 0xb2, 0x66, 0x27, 0xd0, 0x64,
+	0x95, 0xf7, 0x7d, 0x6c, 0xc2, 0x30, 0xd3, 0x09, 0xc3, 0xbc, 0x42, 0xa6, 0xa5, 0x61, 0xeb, 0x68,
+	0x99, 0x96, 0x86, 0x16, 0xc8, 0x8c, 0x90, 0x86, 0x6d, 0x60, 0x33, 0xf0, 0x93, 0xfe, 0x48, 0x76,
+	0x51, 0x2d, 0xda, 0x71, 0x1c, 0x99, 0x54, 0x49, 0x3e, 0x16, 0x75, 0x13, 0x7d, 0x19, 0x48, 0x48,
+	0x9f, 0x72, 0x3d, 0x9c, 0xa1, 0x44, 0x0a, 0xa3, 0xfe, 0xd2, 0xb0, 0xd7, 0xe8, 0xb3, 0x32, 0xec,
+	0x53, 0x35, 0xb0, 0x59, 0xba, 0xce, 0x53, 0x23, 0x74, 0xc2, 0xb6, 0xed, 0x66, 0xe9, 0xfa, 0x35,
+	0x2c, 0xe9, 0x6b, 0xb2, 0xad, 0xb4, 0xa8, 0x87, 0x4a, 0xf2, 0x36, 0xca, 0x08, 0xf7, 0xad, 0xa2,
+	0x26, 0x8c, 0xed, 0xcf, 0x94, 0xf2, 0xde, 0xa6, 0x33, 0x5b, 0x91, 0x71, 0x72, 0x9b, 0x50, 0x45,
+	0x36, 0x55, 0x96, 0x1a, 0x71, 0xcf, 0xab, 0xb8, 0x3f, 0x53, 0x5a, 0xaa, 0x9c, 0x94, 0x9d, 0xd2,
+	0x97, 0xc7, 0x66, 0xbc, 0x5c, 0x03, 0xaf, 0xd1, 0x60, 0x35, 0x9d, 0x9a, 0x9e, 0xb7, 0xae, 0xee,
+	0x5b, 0xe8, 0x11, 0x59, 0x77, 0x91, 0xef, 0x0e, 0x25, 0x50, 0x09, 0xdb, 0xc1, 0xd2, 0xa8, 0x33,
+	0xbd, 0x1f, 0x58, 0xe8, 0x57, 0x42, 0x5d, 0x45, 0x42, 0x1a, 0x7e, 0x6b, 0xc5, 0x90, 0xfd, 0x07,
+	0x8b, 0x2a, 0x3d, 0x54, 0xd4, 0xb8, 0xba, 0x7b, 0x05, 0x1b, 0xe3, 0x4c, 0x1a, 0x87, 0xd0, 0x5b,
+	0xb2, 0xe5, 0xe2, 0xf6, 0x25, 0xba, 0x1f, 0x7b, 0x17, 0x63, 0x57, 0x1e, 0x6c, 0x78, 0x92, 0x3a,
+	0xdb, 0x8e, 0x37, 0xda, 0x13, 0x4c, 0xd4, 0x23, 0x4f, 0x43, 0x91, 0xa4, 0xbc, 0xff, 0x80, 0xa6,
+	0x22, 0x6d, 0x27, 0x1c, 0x5b, 0x4c, 0x52, 0x0e, 0x2a, 0x7d, 0xa7, 0xdc, 0x8f, 0x50, 0xb9, 0x1f,
+	0x03, 0xdd, 0x65, 0x45, 0xb2, 0x67, 0xb9, 0xd7, 0x41, 0x4b, 0x39, 0x2d, 0xbf, 0x20, 0x07, 0x36,
+	0x66, 0xd4, 0xd5, 0xd8, 0x44, 0x9a, 0xf1, 0xfb, 0x0f, 0xc1, 0x3e, 0x86, 0x7b, 0x84, 0xe1, 0x1c,
+	0xf1, 0x3a, 0xbb, 0x1e, 0x7b, 0x16, 0x0e, 0x49, 0xbe, 0xae, 0x84, 0x1f, 0x69, 0x1e, 0x46, 0x7e,
+	0x53, 0x49, 0xf6, 0x18, 0x6f, 0xf4, 0xb2, 0x05, 0x3f, 0x21, 0x46, 0xf7, 0xc9, 0x72, 0x0c, 0x5a,
+	0x9b, 0x84, 0x51, 0xca, 0x75, 0x9d, 0x1d, 0xe0, 0xa5, 0x23, 0x80, 0x5d, 0x85, 0x51, 0x7a, 0x59,
+	0x1f, 0x65, 0x48, 0xc3, 0x0e, 0x47, 0x19, 0x55, 0x43, 0xcb, 0x64, 0x7d, 0xc0, 0x18, 0x4c, 0xe4,
+	0x13, 0x24, 0xae, 0xf5, 0x89, 0x83, 0xb1, 0xdc, 0x23, 0x4b, 0x2d, 0xe1, 0xf3, 0x8e, 0x32, 0xb0,
+	0xf1, 0xec, 0x7f, 0xa8, 0xed, 0xa4, 0x25, 0xfc, 0xaf, 0x16, 0xc1, 0x79, 0x0b, 0xf4, 0xc3, 0xf3,
+	0xf6, 0x7f, 0x37, 0x6f, 0x81, 0x9e, 0x3c, 0x6f, 0xaf, 0xc8, 0x96, 0x51, 0xa8, 0xf1, 0xfd, 0xc3,
+	0x70, 0xa3, 0xc1, 0x5e, 0xe0, 0x16, 0x6c, 0x58, 0xab, 0xdb, 0xfd, 0x9a, 0xb5, 0xd1, 0xb7, 0x64,
+	0x67, 0xcc, 0x0b, 0x86, 0x16, 0xdf, 0x77, 0xae, 0x59, 0x09, 0x73, 0x6e, 0x8d, 0x78, 0xfe, 0x2a,
+	0x32, 0x7c, 0xea, 0x2f, 0xe9, 0x1b, 0x52, 0x9c, 0xe0, 0x8b, 0x57, 0x40, 0xb3, 0x67, 0xe8, 0xba,
+	0x39, 0xee, 0x0a, 0xe7, 0x75, 0x09, 0x1a, 0xe5, 0x3c, 0x6d, 0xa6, 0x63, 0xf6, 0xdc, 0x29, 0x19,
+	0xa2, 0x18, 0xff, 0x98, 0x9e, 0x91, 0x47, 0xb1, 0xd2, 0x12, 0x76, 0xd9, 0xb1, 0x47, 0xbf, 0xda,
+	0xd8, 0x77, 0xf8, 0xb8, 0xec, 0x38, 0x92, 0x87, 0x9c, 0x91, 0xfb, 0x4d, 0xff, 0x0b, 0xbb, 0x9e,
+	0x71, 0xc9, 0xfd, 0x9e, 0x1f, 0x2a, 0x56, 0xb6, 0x72, 0x0f, 0xdf, 0x51, 0xe7, 0x00, 0xd0, 0x53,
+	0xb2, 0xed, 0xe6, 0x46, 0x76, 0x55, 0x18, 0xda, 0xe2, 0x5f, 0x1d, 0x1f, 0xb7, 0x12, 0x76, 0x64,
+	0x77, 0xcd, 0x9a, 0xab, 0x60, 0x85, 0xda, 0xd1, 0x46, 0x7f, 0x20, 0xc5, 0xbb, 0xbb, 0x7a, 0xcf,
+	0xf1, 0x18, 0x1d, 0xb7, 0xfa, 0x84, 0x31, 0xd7, 0x22, 0xc9, 0x41, 0x45, 0x2a, 0x30, 0x31, 0x3b,
+	0xb1, 0x62, 0xd7, 0x12, 0x59, 0x2d, 0x30, 0x31, 0x0c, 0xb1, 0x0c, 0xf9, 0x24, 0x41, 0xa9, 0xfc,
+	0xcb, 0x10, 0x57, 0xc3, 0xf3, 0x7b, 0x5a, 0xe3, 0x86, 0x58, 0x4e, 0x30, 0xd1, 0x67, 0x64, 0x0d,
+	0xf4, 0x47, 0xf8, 0x4d, 0x1e, 0x06, 0xad, 0x20, 0xe5, 0x2a, 0x8b, 0xd9, 0x4b, 0x2b, 0xce, 0x42,
+	0x9a, 0x33, 0xbf, 0xf9, 0x09, 0xe0, 0x5a, 0x16, 0x0f, 0x53, 0xf1, 0x39, 0x43, 0xea, 0xab, 0x61,
+	0x2a, 0x3e, 0x6b, 0x96, 0x5a, 0x70, 0xb3, 0x37, 0x98, 0x87, 0x53, 0x64, 0xae, 0x5a, 0xfc, 0x6e,
+	0x1a, 0x76, 0x6e, 0x08, 0x7b, 0x48, 0x69, 0xe1, 0x29, 0x82, 0x2f, 0x07, 0xfb, 0x61, 0x08, 0x3f,
+	0xe9, 0x29, 0x99, 0xeb, 0x88, 0xb0, 0xad, 0xf0, 0xfb, 0x69, 0xa9, 0xb2, 0xf7, 0xd0, 0x3e, 0xb8,
+	0x38, 0x9e, 0x65, 0xbf, 0x9d, 0x7e, 0x33, 0xb5, 0xd3, 0x26, 0xc5, 0x07, 0x15, 0x6e, 0x38, 0xd3,
+	0xa2, 0xcd, 0xf4, 0x6e, 0x34, 0xd3, 0x8b, 0x6f, 0x4b, 0xf2, 0x68, 0xcc, 0xe1, 0xb4, 0x1f, 0x48,
+	0xf1, 0xc1, 0x33, 0x99, 0xd0, 0xe0, 0xc6, 0x70, 0xda, 0xfc, 0x50, 0xa0, 0x5f, 0x66, 0x73, 0x5b,
+	0x85, 0xed, 0xfa, 0x3c, 0xfe, 0x7f, 0xf1, 0xf2, 0x9f, 0x01, 0x00, 0x2f, 0x2f, 0x8a, 0x2d, 0xb7,
+	0x0c, 0x00, 0x00,
 }
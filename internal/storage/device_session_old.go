@@ -93,6 +93,8 @@ func migrateDeviceSessionOld(d DeviceSessionOld) DeviceSession {
 	spID, _ := uuid.FromString(d.ServiceProfileID)
 	rpID, _ := uuid.FromString(d.RoutingProfileID)
 
+	// MaxSupportedDR is not migrated, as it could hold a copy of the
+	// service-profile DRMax instead of the max data-rate of the device
 	out := DeviceSession{
 		MACVersion: "1.0.2",
 
@@ -120,7 +122,6 @@ func migrateDeviceSessionOld(d DeviceSessionOld) DeviceSession {
 		DR:           d.DR,
 		ADR:          d.ADR,
 		MaxSupportedTXPowerIndex: d.MaxSupportedTXPowerIndex,
-		MaxSupportedDR:           d.MaxSupportedDR,
 		NbTrans:                  d.NbTrans,
 		EnabledChannels:          d.EnabledChannels,
 		EnabledUplinkChannels:    d.EnabledUplinkChannels,
@@ -141,8 +142,8 @@ func migrateDeviceSessionOld(d DeviceSessionOld) DeviceSession {
 		out.EnabledUplinkChannels = out.EnabledChannels
 	}
 
-	for _, rx := range d.LastRXInfoSet {
-		out.UplinkGatewayHistory[rx.MAC] = UplinkGatewayHistory{}
+	if d.FCntUp > 0 {
+		out.AppendUplinkGatewayHistory(d.FCntUp-1, d.LastRXInfoSet)
 	}
 
 	if out.ExtraUplinkChannels == nil {
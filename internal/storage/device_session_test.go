@@ -3,11 +3,14 @@ package storage
 import (
 	"fmt"
 	"testing"
+	"time"
 
+	"github.com/golang/protobuf/proto"
 	. "github.com/smartystreets/goconvey/convey"
 
 	"github.com/brocaar/loraserver/internal/common"
 	"github.com/brocaar/loraserver/internal/config"
+	"github.com/brocaar/loraserver/internal/models"
 	"github.com/brocaar/loraserver/internal/test"
 	"github.com/brocaar/lorawan"
 	"github.com/brocaar/lorawan/band"
@@ -88,6 +91,62 @@ func TestUplinkHistory(t *testing.T) {
 	})
 }
 
+func TestAppendUplinkGatewayHistory(t *testing.T) {
+	Convey("Given an empty device-session", t, func() {
+		s := DeviceSession{}
+		gw1 := lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}
+		gw2 := lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2}
+
+		Convey("When appending an uplink received by two gateways", func() {
+			s.AppendUplinkGatewayHistory(10, models.RXInfoSet{
+				{MAC: gw1, RSSI: -60, LoRaSNR: 7, Board: 1, Antenna: 2},
+				{MAC: gw2, RSSI: -110, LoRaSNR: -5},
+				{MAC: gw1, RSSI: -80, LoRaSNR: 3},
+			})
+
+			Convey("Then the best reception of each gateway is stored", func() {
+				So(s.UplinkGatewayHistory, ShouldHaveLength, 2)
+
+				h := s.UplinkGatewayHistory[gw1]
+				So(h.FCnt, ShouldEqual, 10)
+				So(h.RSSI, ShouldEqual, -60)
+				So(h.LoRaSNR, ShouldEqual, 7)
+				So(h.Board, ShouldEqual, 1)
+				So(h.Antenna, ShouldEqual, 2)
+				So(h.Time.IsZero(), ShouldBeFalse)
+
+				So(s.UplinkGatewayHistory[gw2].RSSI, ShouldEqual, -110)
+			})
+
+			Convey("When appending the next uplinks received by one gateway", func() {
+				s.AppendUplinkGatewayHistory(20, models.RXInfoSet{{MAC: gw1, RSSI: -70}})
+
+				Convey("Then both gateways are kept within the history size", func() {
+					So(s.UplinkGatewayHistory, ShouldHaveLength, 2)
+					So(s.UplinkGatewayHistory[gw1].FCnt, ShouldEqual, 20)
+					So(s.UplinkGatewayHistory[gw1].RSSI, ShouldEqual, -70)
+				})
+
+				s.AppendUplinkGatewayHistory(30, models.RXInfoSet{{MAC: gw1, RSSI: -70}})
+
+				Convey("Then the gateway outside the history size is removed", func() {
+					So(s.UplinkGatewayHistory, ShouldHaveLength, 1)
+					So(s.UplinkGatewayHistory, ShouldContainKey, gw1)
+				})
+			})
+
+			Convey("When the frame-counter has been reset", func() {
+				s.AppendUplinkGatewayHistory(0, models.RXInfoSet{{MAC: gw2, RSSI: -90}})
+
+				Convey("Then the history only contains the last uplink", func() {
+					So(s.UplinkGatewayHistory, ShouldHaveLength, 1)
+					So(s.UplinkGatewayHistory[gw2].FCnt, ShouldEqual, 0)
+				})
+			})
+		})
+	})
+}
+
 func TestDeviceSession(t *testing.T) {
 	conf := test.GetConfig()
 
@@ -112,6 +171,32 @@ func TestDeviceSession(t *testing.T) {
 				})
 			})
 
+			Convey("When decoding a device-session with the max supported DR", func() {
+				s.MaxSupportedDR = 3
+				dsPB := deviceSessionToPB(s)
+				b, err := proto.Marshal(&dsPB)
+				So(err, ShouldBeNil)
+
+				Convey("Then the max supported DR is decoded", func() {
+					ds, err := decodeDeviceSession(b)
+					So(err, ShouldBeNil)
+					So(ds.MaxSupportedDR, ShouldEqual, 3)
+				})
+
+				Convey("Then the max supported DR stored in the reserved field is ignored", func() {
+					s.MaxSupportedDR = 0
+					dsPB := deviceSessionToPB(s)
+					b, err := proto.Marshal(&dsPB)
+					So(err, ShouldBeNil)
+					b = append(b, proto.EncodeVarint(22<<3)...)
+					b = append(b, proto.EncodeVarint(5)...)
+
+					ds, err := decodeDeviceSession(b)
+					So(err, ShouldBeNil)
+					So(ds.MaxSupportedDR, ShouldEqual, 0)
+				})
+			})
+
 			Convey("When saving the device-session", func() {
 				So(SaveDeviceSession(p, s), ShouldBeNil)
 
@@ -133,6 +218,27 @@ func TestDeviceSession(t *testing.T) {
 					So(DeleteDeviceSession(p, s.DevEUI), ShouldEqual, ErrDoesNotExist)
 
 				})
+
+				Convey("Then GetDeviceSessionsForDevEUIs returns the device-session and skips the unknown DevEUIs", func() {
+					sessions, err := GetDeviceSessionsForDevEUIs(p, []lorawan.EUI64{{8, 7, 6, 5, 4, 3, 2, 1}, s.DevEUI})
+					So(err, ShouldBeNil)
+					So(sessions, ShouldHaveLength, 1)
+					So(sessions[0], ShouldResemble, s)
+				})
+			})
+
+			Convey("When setting the Class-C downlink lock", func() {
+				So(SetClassCDownlinkLock(p, time.Second, s.DevEUI), ShouldBeNil)
+
+				Convey("Then the lock is active for the device", func() {
+					locked, err := GetClassCDownlinkLock(p, s.DevEUI)
+					So(err, ShouldBeNil)
+					So(locked, ShouldBeTrue)
+
+					locked, err = GetClassCDownlinkLock(p, lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1})
+					So(err, ShouldBeNil)
+					So(locked, ShouldBeFalse)
+				})
 			})
 
 			Convey("When calling validateAndGetFullFCntUp", func() {
@@ -126,6 +126,15 @@ func TestDevice(t *testing.T) {
 					Convey("Then ValidateDevNonce for an unused dev-nonce returns no error", func() {
 						So(ValidateDevNonce(config.C.PostgreSQL.DB, joinEUI, d.DevEUI, lorawan.DevNonce(513), lorawan.JoinRequestType), ShouldBeNil)
 					})
+
+					Convey("Then GetMaxDevNonce returns the highest dev-nonce", func() {
+						nonce, err := GetMaxDevNonce(config.C.PostgreSQL.DB, joinEUI, d.DevEUI, lorawan.JoinRequestType)
+						So(err, ShouldBeNil)
+						So(nonce, ShouldEqual, da.DevNonce)
+
+						_, err = GetMaxDevNonce(config.C.PostgreSQL.DB, joinEUI, d.DevEUI, lorawan.RejoinRequestType1)
+						So(err, ShouldEqual, ErrDoesNotExist)
+					})
 				})
 			})
 		})
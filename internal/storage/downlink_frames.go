@@ -0,0 +1,100 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/lorawan"
+)
+
+const downlinkFramesTempl = "lora:ns:frames:%s:%d"
+
+// downlinkFramesTTL defines how long the downlink frames are kept for
+// correlating the tx acknowledgement of the gateway.
+const downlinkFramesTTL = time.Minute
+
+// DownlinkFrames contains the downlink frame sent to the gateway together
+// with the fallback options (e.g. an other gateway or RX2), in case the
+// gateway reports that the frame could not be sent. The first item of
+// TXPackets is the frame that has been sent.
+type DownlinkFrames struct {
+	Token            uint16
+	DevEUI           lorawan.EUI64
+	RoutingProfileID uuid.UUID
+	FCnt             uint32
+	FPort            uint8
+	Confirmed        bool
+	TXPackets        []gw.TXPacketBytes
+}
+
+// SaveDownlinkFrames saves the given downlink frames, using the MAC of the
+// gateway to which the first frame was sent and the token as identifier.
+// As the token is only 16 bits, the MAC is included to avoid collisions
+// between the frames of different gateways.
+func SaveDownlinkFrames(p *redis.Pool, frames DownlinkFrames) error {
+	if len(frames.TXPackets) == 0 {
+		return errors.New("downlink frames must contain at least one tx packet")
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(frames); err != nil {
+		return errors.Wrap(err, "gob encode error")
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	exp := int64(downlinkFramesTTL) / int64(time.Millisecond)
+	_, err := c.Do("PSETEX", fmt.Sprintf(downlinkFramesTempl, frames.TXPackets[0].TXInfo.MAC, frames.Token), exp, buf.Bytes())
+	if err != nil {
+		return errors.Wrap(err, "save downlink frames error")
+	}
+
+	return nil
+}
+
+// GetDownlinkFrames returns the downlink frames for the given gateway MAC
+// and token.
+func GetDownlinkFrames(p *redis.Pool, mac lorawan.EUI64, token uint16) (DownlinkFrames, error) {
+	var frames DownlinkFrames
+
+	c := p.Get()
+	defer c.Close()
+
+	val, err := redis.Bytes(c.Do("GET", fmt.Sprintf(downlinkFramesTempl, mac, token)))
+	if err != nil {
+		if err == redis.ErrNil {
+			return frames, ErrDoesNotExist
+		}
+		return frames, errors.Wrap(err, "get downlink frames error")
+	}
+
+	if err := gob.NewDecoder(bytes.NewReader(val)).Decode(&frames); err != nil {
+		return frames, errors.Wrap(err, "gob decode error")
+	}
+
+	return frames, nil
+}
+
+// DeleteDownlinkFrames deletes the downlink frames for the given gateway MAC
+// and token.
+func DeleteDownlinkFrames(p *redis.Pool, mac lorawan.EUI64, token uint16) error {
+	c := p.Get()
+	defer c.Close()
+
+	val, err := redis.Int(c.Do("DEL", fmt.Sprintf(downlinkFramesTempl, mac, token)))
+	if err != nil {
+		return errors.Wrap(err, "delete downlink frames error")
+	}
+	if val == 0 {
+		return ErrDoesNotExist
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/loraserver/internal/common"
+	"github.com/brocaar/loraserver/internal/test"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/band"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDownlinkFrames(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database", t, func() {
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+
+		timestamp := uint32(12345)
+		frames := DownlinkFrames{
+			Token:     1234,
+			DevEUI:    lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			FCnt:      10,
+			FPort:     1,
+			Confirmed: true,
+			TXPackets: []gw.TXPacketBytes{
+				{
+					Token: 1234,
+					TXInfo: gw.TXInfo{
+						MAC:       lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1},
+						Timestamp: &timestamp,
+						Frequency: 868100000,
+						DataRate:  band.DataRate{Modulation: band.LoRaModulation, SpreadFactor: 12, Bandwidth: 125},
+					},
+					PHYPayload: []byte{1, 2, 3},
+				},
+				{
+					Token: 1234,
+					TXInfo: gw.TXInfo{
+						MAC:       lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2},
+						Timestamp: &timestamp,
+						Frequency: 868100000,
+						DataRate:  band.DataRate{Modulation: band.LoRaModulation, SpreadFactor: 12, Bandwidth: 125},
+					},
+					PHYPayload: []byte{1, 2, 3},
+				},
+			},
+		}
+
+		Convey("When getting non-existing downlink frames", func() {
+			_, err := GetDownlinkFrames(p, frames.TXPackets[0].TXInfo.MAC, frames.Token)
+
+			Convey("Then ErrDoesNotExist is returned", func() {
+				So(err, ShouldEqual, ErrDoesNotExist)
+			})
+		})
+
+		Convey("When saving the downlink frames", func() {
+			So(SaveDownlinkFrames(p, frames), ShouldBeNil)
+
+			Convey("Then they can be retrieved by token", func() {
+				f, err := GetDownlinkFrames(p, frames.TXPackets[0].TXInfo.MAC, frames.Token)
+				So(err, ShouldBeNil)
+				So(f, ShouldResemble, frames)
+			})
+
+			Convey("Then they can not be retrieved using the MAC of an other gateway", func() {
+				_, err := GetDownlinkFrames(p, frames.TXPackets[1].TXInfo.MAC, frames.Token)
+				So(err, ShouldEqual, ErrDoesNotExist)
+			})
+
+			Convey("When deleting the downlink frames", func() {
+				So(DeleteDownlinkFrames(p, frames.TXPackets[0].TXInfo.MAC, frames.Token), ShouldBeNil)
+
+				Convey("Then they have been removed", func() {
+					_, err := GetDownlinkFrames(p, frames.TXPackets[0].TXInfo.MAC, frames.Token)
+					So(err, ShouldEqual, ErrDoesNotExist)
+				})
+			})
+		})
+	})
+}
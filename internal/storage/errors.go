@@ -15,6 +15,11 @@ var (
 	ErrInvalidAggregationInterval     = errors.New("invalid aggregation interval")
 	ErrInvalidName                    = errors.New("invalid gateway name")
 	ErrInvalidFPort                   = errors.New("invalid fPort (must be > 0)")
+	ErrRateLimitExceeded              = errors.New("downlink rate-limit exceeded")
+	ErrInvalidMulticastGroupType      = errors.New("invalid multicast-group type")
+	ErrInvalidPingSlotPeriod          = errors.New("invalid ping-slot period (must be between 32 and 4096)")
+	ErrInvalidFCnt                    = errors.New("invalid frame-counter")
+	ErrInvalidADRAckExp               = errors.New("invalid ADR_ACK_LIMIT / ADR_ACK_DELAY exponent (must be between 0 and 15)")
 )
 
 func handlePSQLError(err error, description string) error {
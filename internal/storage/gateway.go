@@ -180,3 +180,83 @@ func GetGatewaysForMACs(db sqlx.Queryer, macs []lorawan.EUI64) (map[lorawan.EUI6
 
 	return out, nil
 }
+
+// GetRegisteredGatewayMACs returns the subset of the given gateway MACs
+// which are registered, in a single query.
+func GetRegisteredGatewayMACs(db sqlx.Queryer, macs []lorawan.EUI64) (map[lorawan.EUI64]struct{}, error) {
+	var macsB [][]byte
+	for i := range macs {
+		macsB = append(macsB, macs[i][:])
+	}
+
+	var registered []lorawan.EUI64
+	err := sqlx.Select(db, &registered, "select mac from gateway where mac = any($1)", pq.ByteaArray(macsB))
+	if err != nil {
+		return nil, handlePSQLError(err, "select error")
+	}
+
+	out := make(map[lorawan.EUI64]struct{})
+	for _, mac := range registered {
+		out[mac] = struct{}{}
+	}
+
+	return out, nil
+}
+
+// GetGatewayLastSeenAtForMACs returns the last-seen timestamp of the given
+// gateways, in a single query. Gateways which are not registered or have
+// not been seen are left out of the returned map.
+func GetGatewayLastSeenAtForMACs(db sqlx.Queryer, macs []lorawan.EUI64) (map[lorawan.EUI64]time.Time, error) {
+	var macsB [][]byte
+	for i := range macs {
+		macsB = append(macsB, macs[i][:])
+	}
+
+	var rows []struct {
+		MAC        lorawan.EUI64 `db:"mac"`
+		LastSeenAt time.Time     `db:"last_seen_at"`
+	}
+	err := sqlx.Select(db, &rows, `
+		select
+			mac,
+			last_seen_at
+		from
+			gateway
+		where
+			mac = any($1)
+			and last_seen_at is not null`,
+		pq.ByteaArray(macsB),
+	)
+	if err != nil {
+		return nil, handlePSQLError(err, "select error")
+	}
+
+	out := make(map[lorawan.EUI64]time.Time)
+	for _, r := range rows {
+		out[r.MAC] = r.LastSeenAt
+	}
+
+	return out, nil
+}
+
+// GetBeaconEnabledGateways returns the gateways of which the gateway-profile
+// has the Class-B beacon enabled.
+func GetBeaconEnabledGateways(db sqlx.Queryer) ([]Gateway, error) {
+	var gws []Gateway
+	err := sqlx.Select(db, &gws, `
+		select
+			g.*
+		from gateway g
+		inner join gateway_profile gp
+			on gp.gateway_profile_id = g.gateway_profile_id
+		where
+			gp.beacon_enabled = true
+		order by
+			g.mac`,
+	)
+	if err != nil {
+		return nil, handlePSQLError(err, "select error")
+	}
+
+	return gws, nil
+}
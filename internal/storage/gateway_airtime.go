@@ -0,0 +1,77 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+)
+
+const gatewayAirtimeKeyTempl = "lora:ns:gw:%s:airtime:%d:%d"
+
+// GatewayAirtimeBucket defines the resolution of the rolling window over
+// which the downlink airtime of a gateway is accounted.
+const GatewayAirtimeBucket = time.Minute
+
+func gatewayAirtimeKey(mac lorawan.EUI64, subBand int, bucket int64) string {
+	return fmt.Sprintf(gatewayAirtimeKeyTempl, mac, subBand, bucket)
+}
+
+// IncrGatewayAirtime adds the given airtime to the downlink airtime of the
+// given gateway and sub-band (identified by its min. frequency). The airtime
+// is kept for the given window.
+func IncrGatewayAirtime(p *redis.Pool, mac lorawan.EUI64, subBand int, airtime, window time.Duration) error {
+	key := gatewayAirtimeKey(mac, subBand, time.Now().UnixNano()/int64(GatewayAirtimeBucket))
+
+	c := p.Get()
+	defer c.Close()
+
+	c.Send("MULTI")
+	c.Send("INCRBY", key, int64(airtime/time.Microsecond))
+	c.Send("PEXPIRE", key, int64((window+GatewayAirtimeBucket)/time.Millisecond))
+	if _, err := c.Do("EXEC"); err != nil {
+		return errors.Wrap(err, "increment gateway airtime error")
+	}
+
+	return nil
+}
+
+// GetGatewayAirtime returns the downlink airtime of the given gateway and
+// sub-band within the given window. As the current bucket is only partially
+// elapsed, the returned airtime covers slightly more than the window.
+func GetGatewayAirtime(p *redis.Pool, mac lorawan.EUI64, subBand int, window time.Duration) (time.Duration, error) {
+	current := time.Now().UnixNano() / int64(GatewayAirtimeBucket)
+	buckets := int64(window / GatewayAirtimeBucket)
+
+	var keys []interface{}
+	for i := current - buckets; i <= current; i++ {
+		keys = append(keys, gatewayAirtimeKey(mac, subBand, i))
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	vals, err := redis.Values(c.Do("MGET", keys...))
+	if err != nil {
+		return 0, errors.Wrap(err, "get gateway airtime error")
+	}
+
+	var out time.Duration
+	for _, val := range vals {
+		// a nil value means there were no transmissions within the bucket
+		if val == nil {
+			continue
+		}
+
+		us, err := redis.Int64(val, nil)
+		if err != nil {
+			return 0, errors.Wrap(err, "read gateway airtime error")
+		}
+		out += time.Duration(us) * time.Microsecond
+	}
+
+	return out, nil
+}
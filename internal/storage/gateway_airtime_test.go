@@ -0,0 +1,46 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/loraserver/internal/common"
+	"github.com/brocaar/loraserver/internal/test"
+	"github.com/brocaar/lorawan"
+)
+
+func TestGatewayAirtime(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database", t, func() {
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+
+		mac := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+
+		Convey("Then GetGatewayAirtime returns 0", func() {
+			d, err := GetGatewayAirtime(p, mac, 869400000, time.Hour)
+			So(err, ShouldBeNil)
+			So(d, ShouldEqual, 0)
+		})
+
+		Convey("When incrementing the airtime of a sub-band twice", func() {
+			So(IncrGatewayAirtime(p, mac, 869400000, 100*time.Millisecond, time.Hour), ShouldBeNil)
+			So(IncrGatewayAirtime(p, mac, 869400000, 50*time.Millisecond, time.Hour), ShouldBeNil)
+
+			Convey("Then GetGatewayAirtime returns the sum", func() {
+				d, err := GetGatewayAirtime(p, mac, 869400000, time.Hour)
+				So(err, ShouldBeNil)
+				So(d, ShouldEqual, 150*time.Millisecond)
+			})
+
+			Convey("Then the airtime of the other sub-bands is not affected", func() {
+				d, err := GetGatewayAirtime(p, mac, 868000000, time.Hour)
+				So(err, ShouldBeNil)
+				So(d, ShouldEqual, 0)
+			})
+		})
+	})
+}
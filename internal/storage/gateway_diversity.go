@@ -0,0 +1,87 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/loraserver/internal/config"
+	"github.com/brocaar/lorawan"
+)
+
+const gwDiversityKeyTempl = "lora:ns:device:%s:gwdiversity"
+
+// GatewayDiversityStats contains the gateway diversity statistics of a
+// device.
+type GatewayDiversityStats struct {
+	// UplinkCount holds the number of uplinks.
+	UplinkCount int
+
+	// ViolationCount holds the number of uplinks received by fewer gateways
+	// than the MinGWDiversity of the service-profile.
+	ViolationCount int
+
+	// GatewayCount holds the sum of the number of receiving gateways of
+	// each uplink.
+	GatewayCount int
+
+	// LastGatewayCount holds the number of gateways that received the last
+	// uplink.
+	LastGatewayCount int
+}
+
+// AvgGatewayCount returns the average number of receiving gateways.
+func (s GatewayDiversityStats) AvgGatewayCount() float64 {
+	if s.UplinkCount == 0 {
+		return 0
+	}
+	return float64(s.GatewayCount) / float64(s.UplinkCount)
+}
+
+// UpdateGatewayDiversityStats updates the gateway diversity statistics of the
+// given device. The statistics expire after the device-session TTL.
+func UpdateGatewayDiversityStats(p *redis.Pool, devEUI lorawan.EUI64, gatewayCount int, violation bool) error {
+	key := fmt.Sprintf(gwDiversityKeyTempl, devEUI)
+	exp := int64(config.C.NetworkServer.DeviceSessionTTL) / int64(time.Millisecond)
+
+	var violationCount int
+	if violation {
+		violationCount = 1
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	c.Send("MULTI")
+	c.Send("HINCRBY", key, "uplinks", 1)
+	c.Send("HINCRBY", key, "violations", violationCount)
+	c.Send("HINCRBY", key, "gateways", gatewayCount)
+	c.Send("HSET", key, "last_gateways", gatewayCount)
+	c.Send("PEXPIRE", key, exp)
+	if _, err := c.Do("EXEC"); err != nil {
+		return errors.Wrap(err, "update gateway diversity stats error")
+	}
+
+	return nil
+}
+
+// GetGatewayDiversityStats returns the gateway diversity statistics of the
+// given device.
+func GetGatewayDiversityStats(p *redis.Pool, devEUI lorawan.EUI64) (GatewayDiversityStats, error) {
+	c := p.Get()
+	defer c.Close()
+
+	vals, err := redis.IntMap(c.Do("HGETALL", fmt.Sprintf(gwDiversityKeyTempl, devEUI)))
+	if err != nil {
+		return GatewayDiversityStats{}, errors.Wrap(err, "get gateway diversity stats error")
+	}
+
+	return GatewayDiversityStats{
+		UplinkCount:      vals["uplinks"],
+		ViolationCount:   vals["violations"],
+		GatewayCount:     vals["gateways"],
+		LastGatewayCount: vals["last_gateways"],
+	}, nil
+}
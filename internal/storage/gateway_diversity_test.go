@@ -0,0 +1,46 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/brocaar/loraserver/internal/common"
+	"github.com/brocaar/loraserver/internal/test"
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGatewayDiversityStats(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database", t, func() {
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+
+		devEUI := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+
+		Convey("Then GetGatewayDiversityStats returns empty stats", func() {
+			stats, err := GetGatewayDiversityStats(p, devEUI)
+			So(err, ShouldBeNil)
+			So(stats, ShouldResemble, GatewayDiversityStats{})
+			So(stats.AvgGatewayCount(), ShouldEqual, 0)
+		})
+
+		Convey("When updating the stats for three uplinks", func() {
+			So(UpdateGatewayDiversityStats(p, devEUI, 3, false), ShouldBeNil)
+			So(UpdateGatewayDiversityStats(p, devEUI, 1, true), ShouldBeNil)
+			So(UpdateGatewayDiversityStats(p, devEUI, 2, false), ShouldBeNil)
+
+			Convey("Then GetGatewayDiversityStats returns the expected stats", func() {
+				stats, err := GetGatewayDiversityStats(p, devEUI)
+				So(err, ShouldBeNil)
+				So(stats, ShouldResemble, GatewayDiversityStats{
+					UplinkCount:      3,
+					ViolationCount:   1,
+					GatewayCount:     6,
+					LastGatewayCount: 2,
+				})
+				So(stats.AvgGatewayCount(), ShouldEqual, 2)
+			})
+		})
+	})
+}
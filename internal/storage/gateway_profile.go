@@ -32,6 +32,10 @@ type GatewayProfile struct {
 	UpdatedAt     time.Time      `db:"updated_at"`
 	Channels      []int64        `db:"channels"`
 	ExtraChannels []ExtraChannel `db:"-"`
+
+	// BeaconEnabled defines if LoRa Server must schedule the Class-B beacons
+	// for the gateways using this gateway-profile.
+	BeaconEnabled bool `db:"beacon_enabled"`
 }
 
 // GetVersion returns the gateway-profile version.
@@ -60,12 +64,14 @@ func CreateGatewayProfile(db sqlx.Execer, c *GatewayProfile) error {
 			gateway_profile_id,
 			created_at,
 			updated_at,
-			channels
-		) values ($1, $2, $3, $4)`,
+			channels,
+			beacon_enabled
+		) values ($1, $2, $3, $4, $5)`,
 		c.ID,
 		c.CreatedAt,
 		c.UpdatedAt,
 		pq.Array(c.Channels),
+		c.BeaconEnabled,
 	)
 	if err != nil {
 		return handlePSQLError(err, "insert error")
@@ -109,7 +115,8 @@ func GetGatewayProfile(db sqlx.Queryer, id uuid.UUID) (GatewayProfile, error) {
 			gateway_profile_id,
 			created_at,
 			updated_at,
-			channels
+			channels,
+			beacon_enabled
 		from gateway_profile
 		where
 			gateway_profile_id = $1`,
@@ -119,6 +126,7 @@ func GetGatewayProfile(db sqlx.Queryer, id uuid.UUID) (GatewayProfile, error) {
 		&c.CreatedAt,
 		&c.UpdatedAt,
 		pq.Array(&c.Channels),
+		&c.BeaconEnabled,
 	)
 	if err != nil {
 		return c, handlePSQLError(err, "select error")
@@ -169,12 +177,14 @@ func UpdateGatewayProfile(db sqlx.Execer, c *GatewayProfile) error {
 		update gateway_profile
 		set
 			updated_at = $2,
-			channels = $3
+			channels = $3,
+			beacon_enabled = $4
 		where
 			gateway_profile_id = $1`,
 		c.ID,
 		c.UpdatedAt,
 		pq.Array(c.Channels),
+		c.BeaconEnabled,
 	)
 	if err != nil {
 		return handlePSQLError(err, "update error")
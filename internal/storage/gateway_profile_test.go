@@ -72,6 +72,7 @@ func TestGatewayProfile(t *testing.T) {
 						SpreadingFactors: []int64{10, 11, 12},
 					},
 				}
+				gc.BeaconEnabled = true
 				So(UpdateGatewayProfile(db, &gc), ShouldBeNil)
 				gc.UpdatedAt = gc.UpdatedAt.UTC().Truncate(time.Millisecond)
 
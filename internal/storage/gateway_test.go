@@ -47,6 +47,14 @@ func TestGateway(t *testing.T) {
 				gw3, ok := gws[gw.MAC]
 				So(ok, ShouldBeTrue)
 				So(gw3.MAC, ShouldResemble, gw.MAC)
+
+				lastSeen, err := GetGatewayLastSeenAtForMACs(db, []lorawan.EUI64{gw.MAC})
+				So(err, ShouldBeNil)
+				So(lastSeen, ShouldHaveLength, 0)
+
+				registered, err := GetRegisteredGatewayMACs(db, []lorawan.EUI64{gw.MAC, {1, 2, 3, 4, 5, 6, 7, 8}})
+				So(err, ShouldBeNil)
+				So(registered, ShouldResemble, map[lorawan.EUI64]struct{}{gw.MAC: {}})
 			})
 
 			Convey("Then it can be updated", func() {
@@ -70,6 +78,11 @@ func TestGateway(t *testing.T) {
 				So(gw2.LastSeenAt.UTC().Truncate(time.Millisecond), ShouldResemble, gw.LastSeenAt.UTC().Truncate(time.Millisecond))
 				So(gw2.Location, ShouldResemble, gw.Location)
 				So(gw2.Altitude, ShouldResemble, gw.Altitude)
+
+				lastSeen, err := GetGatewayLastSeenAtForMACs(db, []lorawan.EUI64{gw.MAC, {1, 2, 3, 4, 5, 6, 7, 8}})
+				So(err, ShouldBeNil)
+				So(lastSeen, ShouldHaveLength, 1)
+				So(lastSeen[gw.MAC].Equal(now), ShouldBeTrue)
 			})
 
 			Convey("Then it can be deleted", func() {
@@ -77,6 +90,32 @@ func TestGateway(t *testing.T) {
 				_, err := GetGateway(db, gw.MAC)
 				So(err, ShouldResemble, ErrDoesNotExist)
 			})
+
+			Convey("Given a gateway-profile with beacon enabled", func() {
+				gp := GatewayProfile{
+					Channels:      []int64{0, 1, 2},
+					BeaconEnabled: true,
+				}
+				So(CreateGatewayProfile(db, &gp), ShouldBeNil)
+
+				Convey("Then GetBeaconEnabledGateways returns no gateways", func() {
+					gws, err := GetBeaconEnabledGateways(db)
+					So(err, ShouldBeNil)
+					So(gws, ShouldHaveLength, 0)
+				})
+
+				Convey("When the gateway is using this gateway-profile", func() {
+					gw.GatewayProfileID = &gp.ID
+					So(UpdateGateway(db, &gw), ShouldBeNil)
+
+					Convey("Then GetBeaconEnabledGateways returns the gateway", func() {
+						gws, err := GetBeaconEnabledGateways(db)
+						So(err, ShouldBeNil)
+						So(gws, ShouldHaveLength, 1)
+						So(gws[0].MAC, ShouldEqual, gw.MAC)
+					})
+				})
+			})
 		})
 	})
 }
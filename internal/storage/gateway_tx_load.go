@@ -0,0 +1,77 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+)
+
+const gatewayTXLoadKeyTempl = "lora:ns:gw:%s:txload:%d"
+
+// GatewayTXLoadWindow defines the window over which the number of downlink
+// transmissions of a gateway is counted.
+const GatewayTXLoadWindow = time.Minute
+
+func gatewayTXLoadKey(mac lorawan.EUI64, ts time.Time) string {
+	return fmt.Sprintf(gatewayTXLoadKeyTempl, mac, ts.UnixNano()/int64(GatewayTXLoadWindow))
+}
+
+// IncrGatewayTXLoad increments the number of downlink transmissions of the
+// given gateway within the current window.
+func IncrGatewayTXLoad(p *redis.Pool, mac lorawan.EUI64) error {
+	key := gatewayTXLoadKey(mac, time.Now())
+
+	c := p.Get()
+	defer c.Close()
+
+	c.Send("MULTI")
+	c.Send("INCR", key)
+	c.Send("PEXPIRE", key, int64(2*GatewayTXLoadWindow/time.Millisecond))
+	if _, err := c.Do("EXEC"); err != nil {
+		return errors.Wrap(err, "increment gateway tx load error")
+	}
+
+	return nil
+}
+
+// GetGatewayTXLoads returns the number of downlink transmissions within the
+// current window for the given gateways.
+func GetGatewayTXLoads(p *redis.Pool, macs []lorawan.EUI64) (map[lorawan.EUI64]int, error) {
+	out := make(map[lorawan.EUI64]int)
+	if len(macs) == 0 {
+		return out, nil
+	}
+
+	now := time.Now()
+	var keys []interface{}
+	for _, mac := range macs {
+		keys = append(keys, gatewayTXLoadKey(mac, now))
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	vals, err := redis.Values(c.Do("MGET", keys...))
+	if err != nil {
+		return nil, errors.Wrap(err, "get gateway tx loads error")
+	}
+
+	for i, mac := range macs {
+		// a nil value means there were no transmissions within the window
+		if vals[i] == nil {
+			out[mac] = 0
+			continue
+		}
+
+		out[mac], err = redis.Int(vals[i], nil)
+		if err != nil {
+			return nil, errors.Wrap(err, "read gateway tx load error")
+		}
+	}
+
+	return out, nil
+}
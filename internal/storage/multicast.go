@@ -0,0 +1,284 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+)
+
+// MulticastGroupType defines the type of the multicast-group.
+type MulticastGroupType string
+
+// Available multicast-group types.
+const (
+	MulticastGroupB MulticastGroupType = "B"
+	MulticastGroupC MulticastGroupType = "C"
+)
+
+// MulticastGroup defines a multicast-group.
+type MulticastGroup struct {
+	ID             uuid.UUID          `db:"multicast_group_id"`
+	CreatedAt      time.Time          `db:"created_at"`
+	UpdatedAt      time.Time          `db:"updated_at"`
+	MCAddr         lorawan.DevAddr    `db:"mc_addr"`
+	MCNwkSKey      lorawan.AES128Key  `db:"mc_nwk_s_key"`
+	FCnt           uint32             `db:"f_cnt"`
+	GroupType      MulticastGroupType `db:"group_type"`
+	DR             int                `db:"dr"`
+	Frequency      int                `db:"frequency"`
+	PingSlotPeriod int                `db:"ping_slot_period"` // Class-B only
+}
+
+// Validate validates the MulticastGroup.
+func (mg MulticastGroup) Validate() error {
+	if mg.GroupType != MulticastGroupB && mg.GroupType != MulticastGroupC {
+		return ErrInvalidMulticastGroupType
+	}
+
+	if mg.GroupType == MulticastGroupB && (mg.PingSlotPeriod < 32 || mg.PingSlotPeriod > 4096) {
+		return ErrInvalidPingSlotPeriod
+	}
+
+	return nil
+}
+
+// GetPingSlotNb returns the number of ping-slots per beacon period of the
+// (Class-B) multicast-group.
+func (mg MulticastGroup) GetPingSlotNb() int {
+	if mg.PingSlotPeriod == 0 {
+		return 0
+	}
+	return (1 << 12) / mg.PingSlotPeriod
+}
+
+// CreateMulticastGroup creates the given multicast-group.
+func CreateMulticastGroup(db sqlx.Execer, mg *MulticastGroup) error {
+	if err := mg.Validate(); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	mg.CreatedAt = now
+	mg.UpdatedAt = now
+
+	if mg.ID == uuid.Nil {
+		var err error
+		mg.ID, err = uuid.NewV4()
+		if err != nil {
+			return errors.Wrap(err, "new uuid v4 error")
+		}
+	}
+
+	_, err := db.Exec(`
+        insert into multicast_group (
+            multicast_group_id,
+            created_at,
+            updated_at,
+            mc_addr,
+            mc_nwk_s_key,
+            f_cnt,
+            group_type,
+            dr,
+            frequency,
+            ping_slot_period
+        ) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		mg.ID,
+		mg.CreatedAt,
+		mg.UpdatedAt,
+		mg.MCAddr[:],
+		mg.MCNwkSKey[:],
+		mg.FCnt,
+		mg.GroupType,
+		mg.DR,
+		mg.Frequency,
+		mg.PingSlotPeriod,
+	)
+	if err != nil {
+		return handlePSQLError(err, "insert error")
+	}
+
+	log.WithFields(log.Fields{
+		"id": mg.ID,
+	}).Info("multicast-group created")
+
+	return nil
+}
+
+// GetMulticastGroup returns the multicast-group matching the given id. When
+// forUpdate is set, the multicast-group is locked until the end of the
+// transaction.
+func GetMulticastGroup(db sqlx.Queryer, id uuid.UUID, forUpdate bool) (MulticastGroup, error) {
+	var fu string
+	if forUpdate {
+		fu = " for update"
+	}
+
+	var mg MulticastGroup
+	err := sqlx.Get(db, &mg, `
+        select
+            *
+        from
+            multicast_group
+        where
+            multicast_group_id = $1`+fu,
+		id,
+	)
+	if err != nil {
+		return mg, handlePSQLError(err, "select error")
+	}
+
+	return mg, nil
+}
+
+// UpdateMulticastGroup updates the given multicast-group.
+func UpdateMulticastGroup(db sqlx.Execer, mg *MulticastGroup) error {
+	if err := mg.Validate(); err != nil {
+		return err
+	}
+
+	mg.UpdatedAt = time.Now()
+
+	res, err := db.Exec(`
+        update multicast_group
+        set
+            updated_at = $2,
+            mc_addr = $3,
+            mc_nwk_s_key = $4,
+            f_cnt = $5,
+            group_type = $6,
+            dr = $7,
+            frequency = $8,
+            ping_slot_period = $9
+        where
+            multicast_group_id = $1`,
+		mg.ID,
+		mg.UpdatedAt,
+		mg.MCAddr[:],
+		mg.MCNwkSKey[:],
+		mg.FCnt,
+		mg.GroupType,
+		mg.DR,
+		mg.Frequency,
+		mg.PingSlotPeriod,
+	)
+	if err != nil {
+		return handlePSQLError(err, "update error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrDoesNotExist
+	}
+
+	log.WithFields(log.Fields{
+		"id": mg.ID,
+	}).Info("multicast-group updated")
+
+	return nil
+}
+
+// DeleteMulticastGroup deletes the multicast-group matching the given id.
+func DeleteMulticastGroup(db sqlx.Execer, id uuid.UUID) error {
+	res, err := db.Exec("delete from multicast_group where multicast_group_id = $1", id)
+	if err != nil {
+		return handlePSQLError(err, "delete error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrDoesNotExist
+	}
+
+	log.WithFields(log.Fields{
+		"id": id,
+	}).Info("multicast-group deleted")
+
+	return nil
+}
+
+// AddDeviceToMulticastGroup adds the given device to the given
+// multicast-group.
+func AddDeviceToMulticastGroup(db sqlx.Execer, devEUI lorawan.EUI64, multicastGroupID uuid.UUID) error {
+	_, err := db.Exec(`
+        insert into device_multicast_group (
+            dev_eui,
+            multicast_group_id,
+            created_at
+        ) values ($1, $2, $3)`,
+		devEUI[:],
+		multicastGroupID,
+		time.Now(),
+	)
+	if err != nil {
+		return handlePSQLError(err, "insert error")
+	}
+
+	log.WithFields(log.Fields{
+		"dev_eui":            devEUI,
+		"multicast_group_id": multicastGroupID,
+	}).Info("device added to multicast-group")
+
+	return nil
+}
+
+// RemoveDeviceFromMulticastGroup removes the given device from the given
+// multicast-group.
+func RemoveDeviceFromMulticastGroup(db sqlx.Execer, devEUI lorawan.EUI64, multicastGroupID uuid.UUID) error {
+	res, err := db.Exec(`
+        delete from
+            device_multicast_group
+        where
+            dev_eui = $1
+            and multicast_group_id = $2`,
+		devEUI[:],
+		multicastGroupID,
+	)
+	if err != nil {
+		return handlePSQLError(err, "delete error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrDoesNotExist
+	}
+
+	log.WithFields(log.Fields{
+		"dev_eui":            devEUI,
+		"multicast_group_id": multicastGroupID,
+	}).Info("device removed from multicast-group")
+
+	return nil
+}
+
+// GetDevEUIsForMulticastGroup returns the DevEUIs of the devices within the
+// given multicast-group.
+func GetDevEUIsForMulticastGroup(db sqlx.Queryer, multicastGroupID uuid.UUID) ([]lorawan.EUI64, error) {
+	var devEUIs []lorawan.EUI64
+	err := sqlx.Select(db, &devEUIs, `
+        select
+            dev_eui
+        from
+            device_multicast_group
+        where
+            multicast_group_id = $1
+        order by
+            dev_eui`,
+		multicastGroupID,
+	)
+	if err != nil {
+		return nil, handlePSQLError(err, "select error")
+	}
+
+	return devEUIs, nil
+}
@@ -0,0 +1,211 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+)
+
+// MulticastQueueItem defines a multicast queue-item. As a multicast
+// downlink is sent by multiple gateways (one by one), the multicast queue
+// contains an item per gateway.
+type MulticastQueueItem struct {
+	ID                      int64          `db:"id"`
+	CreatedAt               time.Time      `db:"created_at"`
+	ScheduleAt              time.Time      `db:"schedule_at"`
+	EmitAtTimeSinceGPSEpoch *time.Duration `db:"emit_at_time_since_gps_epoch"`
+	MulticastGroupID        uuid.UUID      `db:"multicast_group_id"`
+	GatewayMAC              lorawan.EUI64  `db:"gateway_mac"`
+	FCnt                    uint32         `db:"f_cnt"`
+	FPort                   uint8          `db:"f_port"`
+	FRMPayload              []byte         `db:"frm_payload"`
+}
+
+// Validate validates the MulticastQueueItem.
+func (qi MulticastQueueItem) Validate() error {
+	if qi.FPort == 0 {
+		return ErrInvalidFPort
+	}
+	return nil
+}
+
+// CreateMulticastQueueItem adds the given item to the multicast queue.
+func CreateMulticastQueueItem(db sqlx.Queryer, qi *MulticastQueueItem) error {
+	if err := qi.Validate(); err != nil {
+		return err
+	}
+
+	qi.CreatedAt = time.Now()
+
+	err := sqlx.Get(db, &qi.ID, `
+        insert into multicast_queue (
+            created_at,
+            schedule_at,
+            emit_at_time_since_gps_epoch,
+            multicast_group_id,
+            gateway_mac,
+            f_cnt,
+            f_port,
+            frm_payload
+        ) values ($1, $2, $3, $4, $5, $6, $7, $8)
+        returning id`,
+		qi.CreatedAt,
+		qi.ScheduleAt,
+		qi.EmitAtTimeSinceGPSEpoch,
+		qi.MulticastGroupID,
+		qi.GatewayMAC[:],
+		qi.FCnt,
+		qi.FPort,
+		qi.FRMPayload,
+	)
+	if err != nil {
+		return handlePSQLError(err, "insert error")
+	}
+
+	log.WithFields(log.Fields{
+		"id":                 qi.ID,
+		"multicast_group_id": qi.MulticastGroupID,
+		"gateway_mac":        qi.GatewayMAC,
+		"f_cnt":              qi.FCnt,
+	}).Info("multicast queue-item created")
+
+	return nil
+}
+
+// DeleteMulticastQueueItem deletes the multicast queue-item matching the
+// given id.
+func DeleteMulticastQueueItem(db sqlx.Execer, id int64) error {
+	res, err := db.Exec("delete from multicast_queue where id = $1", id)
+	if err != nil {
+		return handlePSQLError(err, "delete error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrDoesNotExist
+	}
+
+	log.WithFields(log.Fields{
+		"id": id,
+	}).Info("multicast queue-item deleted")
+
+	return nil
+}
+
+// FlushMulticastQueueForMulticastGroup deletes all multicast queue-items of
+// the given multicast-group.
+func FlushMulticastQueueForMulticastGroup(db sqlx.Execer, multicastGroupID uuid.UUID) error {
+	_, err := db.Exec("delete from multicast_queue where multicast_group_id = $1", multicastGroupID)
+	if err != nil {
+		return handlePSQLError(err, "delete error")
+	}
+
+	log.WithFields(log.Fields{
+		"multicast_group_id": multicastGroupID,
+	}).Info("multicast queue flushed")
+
+	return nil
+}
+
+// GetMulticastQueueItemsForMulticastGroup returns the multicast queue-items
+// of the given multicast-group, ordered by schedule time.
+func GetMulticastQueueItemsForMulticastGroup(db sqlx.Queryer, multicastGroupID uuid.UUID) ([]MulticastQueueItem, error) {
+	var items []MulticastQueueItem
+	err := sqlx.Select(db, &items, `
+        select
+            *
+        from
+            multicast_queue
+        where
+            multicast_group_id = $1
+        order by
+            schedule_at,
+            id`,
+		multicastGroupID,
+	)
+	if err != nil {
+		return nil, handlePSQLError(err, "select error")
+	}
+
+	return items, nil
+}
+
+// GetSchedulableMulticastQueueItems returns a slice of multicast queue-items
+// that are due for scheduling.
+// The queue-items will be locked for update so that multiple instances can
+// run this query in parallel without the risk of duplicate scheduling.
+func GetSchedulableMulticastQueueItems(db sqlx.Ext, count int) ([]MulticastQueueItem, error) {
+	var items []MulticastQueueItem
+	err := sqlx.Select(db, &items, `
+        select
+            *
+        from
+            multicast_queue
+        where
+            schedule_at <= $2
+        order by
+            schedule_at,
+            id
+        limit $1
+        for update skip locked`,
+		count,
+		time.Now(),
+	)
+	if err != nil {
+		return nil, handlePSQLError(err, "select error")
+	}
+
+	return items, nil
+}
+
+// GetMaxScheduleAtForMulticastGroup returns the maximum / last schedule
+// timestamp of the given multicast-group. In case the queue is empty, a
+// zero timestamp is returned.
+func GetMaxScheduleAtForMulticastGroup(db sqlx.Queryer, multicastGroupID uuid.UUID) (time.Time, error) {
+	var ts *time.Time
+	err := sqlx.Get(db, &ts, `
+        select
+            max(schedule_at)
+        from
+            multicast_queue
+        where
+            multicast_group_id = $1`,
+		multicastGroupID,
+	)
+	if err != nil {
+		return time.Time{}, handlePSQLError(err, "select error")
+	}
+
+	if ts == nil {
+		return time.Time{}, nil
+	}
+
+	return *ts, nil
+}
+
+// GetMaxEmitAtTimeSinceGPSEpochForMulticastGroup returns the maximum / last
+// GPS epoch scheduling timestamp of the given multicast-group.
+func GetMaxEmitAtTimeSinceGPSEpochForMulticastGroup(db sqlx.Queryer, multicastGroupID uuid.UUID) (time.Duration, error) {
+	var timeSinceGPSEpoch time.Duration
+	err := sqlx.Get(db, &timeSinceGPSEpoch, `
+        select
+            coalesce(max(emit_at_time_since_gps_epoch), 0)
+        from
+            multicast_queue
+        where
+            multicast_group_id = $1`,
+		multicastGroupID,
+	)
+	if err != nil {
+		return 0, handlePSQLError(err, "select error")
+	}
+
+	return timeSinceGPSEpoch, nil
+}
@@ -0,0 +1,114 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/loraserver/internal/common"
+	"github.com/brocaar/loraserver/internal/config"
+	"github.com/brocaar/loraserver/internal/test"
+	"github.com/brocaar/lorawan"
+)
+
+func TestMulticastQueue(t *testing.T) {
+	conf := test.GetConfig()
+	db, err := common.OpenDatabase(conf.PostgresDSN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.C.PostgreSQL.DB = db
+
+	Convey("Given a clean database", t, func() {
+		test.MustResetDB(config.C.PostgreSQL.DB)
+
+		Convey("Given a gateway and a multicast-group", func() {
+			gw := Gateway{
+				MAC: lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			}
+			So(CreateGateway(db, &gw), ShouldBeNil)
+
+			mg := MulticastGroup{
+				GroupType: MulticastGroupC,
+			}
+			So(CreateMulticastGroup(db, &mg), ShouldBeNil)
+
+			Convey("Given a set of queue items", func() {
+				emitAt := 30 * time.Second
+				now := time.Now().UTC().Truncate(time.Millisecond)
+
+				items := []MulticastQueueItem{
+					{
+						ScheduleAt:       now,
+						MulticastGroupID: mg.ID,
+						GatewayMAC:       gw.MAC,
+						FCnt:             10,
+						FPort:            20,
+						FRMPayload:       []byte{1, 2, 3, 4},
+					},
+					{
+						ScheduleAt:              now.Add(time.Minute),
+						EmitAtTimeSinceGPSEpoch: &emitAt,
+						MulticastGroupID:        mg.ID,
+						GatewayMAC:              gw.MAC,
+						FCnt:                    11,
+						FPort:                   20,
+						FRMPayload:              []byte{1, 2, 3, 4},
+					},
+				}
+				for i := range items {
+					So(CreateMulticastQueueItem(db, &items[i]), ShouldBeNil)
+					items[i].CreatedAt = items[i].CreatedAt.UTC().Truncate(time.Millisecond)
+				}
+
+				Convey("Then GetMulticastQueueItemsForMulticastGroup returns the items", func() {
+					out, err := GetMulticastQueueItemsForMulticastGroup(db, mg.ID)
+					So(err, ShouldBeNil)
+					So(out, ShouldHaveLength, 2)
+					for i := range out {
+						out[i].CreatedAt = out[i].CreatedAt.UTC().Truncate(time.Millisecond)
+						out[i].ScheduleAt = out[i].ScheduleAt.UTC().Truncate(time.Millisecond)
+					}
+					So(out, ShouldResemble, items)
+				})
+
+				Convey("Then GetSchedulableMulticastQueueItems returns the item due for scheduling", func() {
+					out, err := GetSchedulableMulticastQueueItems(db, 10)
+					So(err, ShouldBeNil)
+					So(out, ShouldHaveLength, 1)
+					So(out[0].ID, ShouldEqual, items[0].ID)
+				})
+
+				Convey("Then GetMaxScheduleAtForMulticastGroup returns the last schedule timestamp", func() {
+					ts, err := GetMaxScheduleAtForMulticastGroup(db, mg.ID)
+					So(err, ShouldBeNil)
+					So(ts.Equal(items[1].ScheduleAt), ShouldBeTrue)
+				})
+
+				Convey("Then GetMaxEmitAtTimeSinceGPSEpochForMulticastGroup returns the last emit timestamp", func() {
+					ts, err := GetMaxEmitAtTimeSinceGPSEpochForMulticastGroup(db, mg.ID)
+					So(err, ShouldBeNil)
+					So(ts, ShouldEqual, emitAt)
+				})
+
+				Convey("Then DeleteMulticastQueueItem deletes the queue item", func() {
+					So(DeleteMulticastQueueItem(db, items[0].ID), ShouldBeNil)
+					So(DeleteMulticastQueueItem(db, items[0].ID), ShouldEqual, ErrDoesNotExist)
+				})
+
+				Convey("Then FlushMulticastQueueForMulticastGroup flushes the queue", func() {
+					So(FlushMulticastQueueForMulticastGroup(db, mg.ID), ShouldBeNil)
+
+					out, err := GetMulticastQueueItemsForMulticastGroup(db, mg.ID)
+					So(err, ShouldBeNil)
+					So(out, ShouldHaveLength, 0)
+
+					ts, err := GetMaxScheduleAtForMulticastGroup(db, mg.ID)
+					So(err, ShouldBeNil)
+					So(ts.IsZero(), ShouldBeTrue)
+				})
+			})
+		})
+	})
+}
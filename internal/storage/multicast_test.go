@@ -0,0 +1,145 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/loraserver/internal/common"
+	"github.com/brocaar/loraserver/internal/config"
+	"github.com/brocaar/loraserver/internal/test"
+	"github.com/brocaar/lorawan"
+)
+
+func TestMulticastGroupValidate(t *testing.T) {
+	Convey("Given a test-set", t, func() {
+		tests := []struct {
+			Group         MulticastGroup
+			ExpectedError error
+		}{
+			{
+				Group:         MulticastGroup{GroupType: "A"},
+				ExpectedError: ErrInvalidMulticastGroupType,
+			},
+			{
+				Group:         MulticastGroup{GroupType: MulticastGroupC},
+				ExpectedError: nil,
+			},
+			{
+				Group:         MulticastGroup{GroupType: MulticastGroupB, PingSlotPeriod: 16},
+				ExpectedError: ErrInvalidPingSlotPeriod,
+			},
+			{
+				Group:         MulticastGroup{GroupType: MulticastGroupB, PingSlotPeriod: 128},
+				ExpectedError: nil,
+			},
+		}
+
+		for _, test := range tests {
+			So(test.Group.Validate(), ShouldEqual, test.ExpectedError)
+		}
+	})
+}
+
+func TestMulticastGroup(t *testing.T) {
+	conf := test.GetConfig()
+	db, err := common.OpenDatabase(conf.PostgresDSN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.C.PostgreSQL.DB = db
+
+	Convey("Given a clean database", t, func() {
+		test.MustResetDB(config.C.PostgreSQL.DB)
+
+		Convey("When creating a multicast-group", func() {
+			mg := MulticastGroup{
+				MCAddr:         lorawan.DevAddr{1, 2, 3, 4},
+				MCNwkSKey:      lorawan.AES128Key{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8},
+				FCnt:           10,
+				GroupType:      MulticastGroupB,
+				DR:             5,
+				Frequency:      868100000,
+				PingSlotPeriod: 32,
+			}
+			So(CreateMulticastGroup(db, &mg), ShouldBeNil)
+			mg.CreatedAt = mg.CreatedAt.UTC().Truncate(time.Millisecond)
+			mg.UpdatedAt = mg.UpdatedAt.UTC().Truncate(time.Millisecond)
+			So(mg.ID, ShouldNotEqual, uuid.Nil)
+
+			Convey("Then GetMulticastGroup returns the expected multicast-group", func() {
+				mgGet, err := GetMulticastGroup(db, mg.ID, false)
+				So(err, ShouldBeNil)
+
+				mgGet.CreatedAt = mgGet.CreatedAt.UTC().Truncate(time.Millisecond)
+				mgGet.UpdatedAt = mgGet.UpdatedAt.UTC().Truncate(time.Millisecond)
+				So(mgGet, ShouldResemble, mg)
+				So(mgGet.GetPingSlotNb(), ShouldEqual, 128)
+			})
+
+			Convey("Then UpdateMulticastGroup updates the multicast-group", func() {
+				mg.MCAddr = lorawan.DevAddr{4, 3, 2, 1}
+				mg.MCNwkSKey = lorawan.AES128Key{8, 7, 6, 5, 4, 3, 2, 1, 8, 7, 6, 5, 4, 3, 2, 1}
+				mg.FCnt = 20
+				mg.GroupType = MulticastGroupC
+				mg.DR = 3
+				mg.Frequency = 869525000
+				mg.PingSlotPeriod = 0
+				So(UpdateMulticastGroup(db, &mg), ShouldBeNil)
+				mg.UpdatedAt = mg.UpdatedAt.UTC().Truncate(time.Millisecond)
+
+				mgGet, err := GetMulticastGroup(db, mg.ID, false)
+				So(err, ShouldBeNil)
+
+				mgGet.CreatedAt = mgGet.CreatedAt.UTC().Truncate(time.Millisecond)
+				mgGet.UpdatedAt = mgGet.UpdatedAt.UTC().Truncate(time.Millisecond)
+				So(mgGet, ShouldResemble, mg)
+			})
+
+			Convey("Then DeleteMulticastGroup deletes the multicast-group", func() {
+				So(DeleteMulticastGroup(db, mg.ID), ShouldBeNil)
+				So(DeleteMulticastGroup(db, mg.ID), ShouldEqual, ErrDoesNotExist)
+			})
+
+			Convey("Given a device", func() {
+				sp := ServiceProfile{}
+				So(CreateServiceProfile(db, &sp), ShouldBeNil)
+
+				dp := DeviceProfile{}
+				So(CreateDeviceProfile(db, &dp), ShouldBeNil)
+
+				rp := RoutingProfile{}
+				So(CreateRoutingProfile(db, &rp), ShouldBeNil)
+
+				d := Device{
+					DevEUI:           lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+					ServiceProfileID: sp.ID,
+					DeviceProfileID:  dp.ID,
+					RoutingProfileID: rp.ID,
+				}
+				So(CreateDevice(db, &d), ShouldBeNil)
+
+				Convey("When adding the device to the multicast-group", func() {
+					So(AddDeviceToMulticastGroup(db, d.DevEUI, mg.ID), ShouldBeNil)
+
+					Convey("Then GetDevEUIsForMulticastGroup returns the device", func() {
+						devEUIs, err := GetDevEUIsForMulticastGroup(db, mg.ID)
+						So(err, ShouldBeNil)
+						So(devEUIs, ShouldResemble, []lorawan.EUI64{d.DevEUI})
+					})
+
+					Convey("Then the device can be removed from the multicast-group", func() {
+						So(RemoveDeviceFromMulticastGroup(db, d.DevEUI, mg.ID), ShouldBeNil)
+						So(RemoveDeviceFromMulticastGroup(db, d.DevEUI, mg.ID), ShouldEqual, ErrDoesNotExist)
+
+						devEUIs, err := GetDevEUIsForMulticastGroup(db, mg.ID)
+						So(err, ShouldBeNil)
+						So(devEUIs, ShouldHaveLength, 0)
+					})
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,138 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+)
+
+const (
+	uplinkRateLimitKeyTempl   = "lora:ns:device:%s:ratelimit:ul"
+	downlinkRateLimitKeyTempl = "lora:ns:device:%s:ratelimit:dl"
+)
+
+// rateLimitScript implements a token-bucket, refilled at the given rate
+// (tokens / hour) up to the bucket size. As the refill and the take are
+// executed as a single script, the bucket can be shared between multiple
+// network-server instances.
+//
+// KEYS[1]: bucket key
+// ARGV[1]: rate (tokens / hour)
+// ARGV[2]: bucket size
+// ARGV[3]: current time (ms)
+//
+// It returns 1 when a token was taken, 0 when the bucket was empty.
+var rateLimitScript = redis.NewScript(1, `
+local rate = tonumber(ARGV[1])
+local size = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+
+local bucket = redis.call("HMGET", KEYS[1], "tokens", "ts")
+local tokens = tonumber(bucket[1])
+local ts = tonumber(bucket[2])
+if tokens == nil or ts == nil then
+	tokens = size
+	ts = now
+end
+
+if now > ts then
+	tokens = math.min(size, tokens + (now - ts) * rate / 3600000)
+end
+
+local taken = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	taken = 1
+end
+
+redis.call("HMSET", KEYS[1], "tokens", tostring(tokens), "ts", now)
+redis.call("PEXPIRE", KEYS[1], math.ceil(size * 3600000 / rate))
+
+return taken
+`)
+
+// returnRateLimitTokenScript returns a token to the token-bucket, up to the
+// bucket size. When the bucket does not exist (anymore), it is full and
+// nothing is returned.
+//
+// KEYS[1]: bucket key
+// ARGV[1]: bucket size
+var returnRateLimitTokenScript = redis.NewScript(1, `
+local size = tonumber(ARGV[1])
+
+local tokens = tonumber(redis.call("HGET", KEYS[1], "tokens"))
+if tokens == nil then
+	return 0
+end
+
+redis.call("HSET", KEYS[1], "tokens", tostring(math.min(size, tokens + 1)))
+
+return 1
+`)
+
+// TakeUplinkRateLimitToken takes a token from the uplink token-bucket of the
+// given device. It returns false when the bucket is empty, meaning that
+// the device exceeds its uplink rate. A rate of 0 disables the limit.
+func TakeUplinkRateLimitToken(p *redis.Pool, devEUI lorawan.EUI64, rate, bucketSize int) (bool, error) {
+	return takeRateLimitToken(p, fmt.Sprintf(uplinkRateLimitKeyTempl, devEUI), rate, bucketSize)
+}
+
+// TakeDownlinkRateLimitToken takes a token from the downlink token-bucket of
+// the given device. The same bucket is used when enqueueing downlink payloads
+// and when transmitting frames without application payload (e.g. ACKs and
+// mac-commands). A rate of 0 disables the limit.
+func TakeDownlinkRateLimitToken(p *redis.Pool, devEUI lorawan.EUI64, rate, bucketSize int) (bool, error) {
+	return takeRateLimitToken(p, fmt.Sprintf(downlinkRateLimitKeyTempl, devEUI), rate, bucketSize)
+}
+
+// ReturnDownlinkRateLimitToken returns a token, taken using
+// TakeDownlinkRateLimitToken, to the downlink token-bucket of the given
+// device (e.g. when the device-queue item could not be created).
+func ReturnDownlinkRateLimitToken(p *redis.Pool, devEUI lorawan.EUI64, rate, bucketSize int) error {
+	return returnRateLimitToken(p, fmt.Sprintf(downlinkRateLimitKeyTempl, devEUI), rate, bucketSize)
+}
+
+func takeRateLimitToken(p *redis.Pool, key string, rate, bucketSize int) (bool, error) {
+	if rate <= 0 {
+		return true, nil
+	}
+
+	// a bucket must be able to hold at least one token
+	if bucketSize < 1 {
+		bucketSize = 1
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	now := time.Now().UnixNano() / int64(time.Millisecond)
+	taken, err := redis.Int(rateLimitScript.Do(c, key, rate, bucketSize, now))
+	if err != nil {
+		return false, errors.Wrap(err, "take rate-limit token error")
+	}
+
+	return taken == 1, nil
+}
+
+func returnRateLimitToken(p *redis.Pool, key string, rate, bucketSize int) error {
+	if rate <= 0 {
+		return nil
+	}
+
+	if bucketSize < 1 {
+		bucketSize = 1
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	if _, err := returnRateLimitTokenScript.Do(c, key, bucketSize); err != nil {
+		return errors.Wrap(err, "return rate-limit token error")
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/brocaar/loraserver/internal/common"
+	"github.com/brocaar/loraserver/internal/test"
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRateLimit(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database", t, func() {
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+
+		devEUI := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+
+		Convey("When the rate is 0", func() {
+			Convey("Then a token can always be taken", func() {
+				for i := 0; i < 10; i++ {
+					ok, err := TakeUplinkRateLimitToken(p, devEUI, 0, 0)
+					So(err, ShouldBeNil)
+					So(ok, ShouldBeTrue)
+				}
+			})
+		})
+
+		Convey("Given a rate of 1 / hour and a bucket size of 3", func() {
+			Convey("Then the first 3 tokens can be taken", func() {
+				for i := 0; i < 3; i++ {
+					ok, err := TakeUplinkRateLimitToken(p, devEUI, 1, 3)
+					So(err, ShouldBeNil)
+					So(ok, ShouldBeTrue)
+				}
+
+				Convey("Then the next token can not be taken", func() {
+					ok, err := TakeUplinkRateLimitToken(p, devEUI, 1, 3)
+					So(err, ShouldBeNil)
+					So(ok, ShouldBeFalse)
+
+					Convey("Then the bucket of an other device is not affected", func() {
+						ok, err := TakeUplinkRateLimitToken(p, lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1}, 1, 3)
+						So(err, ShouldBeNil)
+						So(ok, ShouldBeTrue)
+					})
+
+					Convey("Then the downlink bucket of the device is not affected", func() {
+						ok, err := TakeDownlinkRateLimitToken(p, devEUI, 1, 3)
+						So(err, ShouldBeNil)
+						So(ok, ShouldBeTrue)
+					})
+				})
+			})
+
+			Convey("When a downlink token is taken and returned", func() {
+				ok, err := TakeDownlinkRateLimitToken(p, devEUI, 1, 1)
+				So(err, ShouldBeNil)
+				So(ok, ShouldBeTrue)
+				So(ReturnDownlinkRateLimitToken(p, devEUI, 1, 1), ShouldBeNil)
+
+				Convey("Then the token can be taken again", func() {
+					ok, err := TakeDownlinkRateLimitToken(p, devEUI, 1, 1)
+					So(err, ShouldBeNil)
+					So(ok, ShouldBeTrue)
+
+					Convey("Then the bucket does not exceed its size", func() {
+						So(ReturnDownlinkRateLimitToken(p, devEUI, 1, 1), ShouldBeNil)
+						So(ReturnDownlinkRateLimitToken(p, devEUI, 1, 1), ShouldBeNil)
+
+						ok, err := TakeDownlinkRateLimitToken(p, devEUI, 1, 1)
+						So(err, ShouldBeNil)
+						So(ok, ShouldBeTrue)
+
+						ok, err = TakeDownlinkRateLimitToken(p, devEUI, 1, 1)
+						So(err, ShouldBeNil)
+						So(ok, ShouldBeFalse)
+					})
+				})
+			})
+		})
+	})
+}
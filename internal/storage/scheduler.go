@@ -0,0 +1,194 @@
+package storage
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+)
+
+const (
+	schedulerInstancesKey           = "lora:ns:scheduler:instances"
+	schedulerPartitionLeaseKeyTempl = "lora:ns:scheduler:partition:%d:lease"
+	deviceQueueSchedulePubSubKey    = "lora:ns:device:queue:schedule"
+)
+
+// schedulerLeaseScript acquires or renews the lease of a scheduler partition.
+//
+// KEYS[1]: lease key
+// ARGV[1]: scheduler instance id
+// ARGV[2]: lease duration (ms)
+//
+// It returns 1 when the lease is held by the given instance, 0 when it is
+// held by an other instance.
+var schedulerLeaseScript = redis.NewScript(1, `
+local owner = redis.call("GET", KEYS[1])
+if owner == ARGV[1] then
+	redis.call("PEXPIRE", KEYS[1], ARGV[2])
+	return 1
+end
+
+if owner then
+	return 0
+end
+
+redis.call("SET", KEYS[1], ARGV[1], "PX", ARGV[2])
+return 1
+`)
+
+// schedulerReleaseScript releases the lease of a scheduler partition, in case
+// it is held by the given instance.
+//
+// KEYS[1]: lease key
+// ARGV[1]: scheduler instance id
+var schedulerReleaseScript = redis.NewScript(1, `
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// GetSchedulerPartitionForDevEUI returns the scheduler partition of the given
+// DevEUI. Note that GetDevicesWithClassBOrClassCDeviceQueueItems implements
+// the same partitioning in SQL.
+func GetSchedulerPartitionForDevEUI(devEUI lorawan.EUI64, partitions int) int {
+	return int(binary.BigEndian.Uint16(devEUI[6:])) % partitions
+}
+
+// RegisterSchedulerInstance registers (or refreshes) the given scheduler
+// instance for the given ttl. It returns the number of registered instances.
+func RegisterSchedulerInstance(p *redis.Pool, id string, ttl time.Duration) (int, error) {
+	now := time.Now().UnixNano() / int64(time.Millisecond)
+	ttlMS := int64(ttl / time.Millisecond)
+
+	c := p.Get()
+	defer c.Close()
+
+	c.Send("MULTI")
+	c.Send("ZADD", schedulerInstancesKey, now+ttlMS, id)
+	c.Send("ZREMRANGEBYSCORE", schedulerInstancesKey, "-inf", now)
+	c.Send("ZCARD", schedulerInstancesKey)
+	c.Send("PEXPIRE", schedulerInstancesKey, ttlMS)
+	vals, err := redis.Values(c.Do("EXEC"))
+	if err != nil {
+		return 0, errors.Wrap(err, "register scheduler instance error")
+	}
+
+	count, err := redis.Int(vals[2], nil)
+	if err != nil {
+		return 0, errors.Wrap(err, "read scheduler instance count error")
+	}
+
+	return count, nil
+}
+
+// AcquireSchedulerPartitionLease acquires or renews the lease of the given
+// partition for the given scheduler instance. It returns false when the
+// lease is held by an other instance.
+func AcquireSchedulerPartitionLease(p *redis.Pool, partition int, id string, duration time.Duration) (bool, error) {
+	c := p.Get()
+	defer c.Close()
+
+	key := fmt.Sprintf(schedulerPartitionLeaseKeyTempl, partition)
+	ok, err := redis.Int(schedulerLeaseScript.Do(c, key, id, int64(duration/time.Millisecond)))
+	if err != nil {
+		return false, errors.Wrap(err, "acquire scheduler partition lease error")
+	}
+
+	return ok == 1, nil
+}
+
+// ReleaseSchedulerPartitionLease releases the lease of the given partition
+// in case it is held by the given scheduler instance.
+func ReleaseSchedulerPartitionLease(p *redis.Pool, partition int, id string) error {
+	c := p.Get()
+	defer c.Close()
+
+	key := fmt.Sprintf(schedulerPartitionLeaseKeyTempl, partition)
+	if _, err := schedulerReleaseScript.Do(c, key, id); err != nil {
+		return errors.Wrap(err, "release scheduler partition lease error")
+	}
+
+	return nil
+}
+
+// PublishDeviceQueueScheduleEvent notifies the schedulers that the
+// device-queue of the given DevEUI must be scheduled.
+func PublishDeviceQueueScheduleEvent(p *redis.Pool, devEUI lorawan.EUI64) error {
+	c := p.Get()
+	defer c.Close()
+
+	if _, err := c.Do("PUBLISH", deviceQueueSchedulePubSubKey, devEUI[:]); err != nil {
+		return errors.Wrap(err, "publish device-queue schedule event error")
+	}
+
+	return nil
+}
+
+// SubscribeDeviceQueueScheduleEvents subscribes to the device-queue schedule
+// events and sends the DevEUI of each event to the given channel. It blocks
+// until the given context is cancelled or the subscription fails.
+func SubscribeDeviceQueueScheduleEvents(ctx context.Context, p *redis.Pool, devEUIChan chan lorawan.EUI64) error {
+	c := p.Get()
+	defer c.Close()
+
+	psc := redis.PubSubConn{Conn: c}
+	if err := psc.Subscribe(deviceQueueSchedulePubSubKey); err != nil {
+		return errors.Wrap(err, "subscribe error")
+	}
+
+	done := make(chan error, 1)
+
+	go func() {
+		for {
+			switch v := psc.Receive().(type) {
+			case redis.Message:
+				var devEUI lorawan.EUI64
+				if len(v.Data) != len(devEUI) {
+					log.WithField("data", v.Data).Error("invalid device-queue schedule event")
+					continue
+				}
+				copy(devEUI[:], v.Data)
+				devEUIChan <- devEUI
+			case redis.Subscription:
+				if v.Count == 0 {
+					done <- nil
+					return
+				}
+			case error:
+				done <- v
+				return
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(30 * time.Second)
+	defer ticker.Stop()
+
+loop:
+	for {
+		select {
+		case <-ticker.C:
+			if err := psc.Ping(""); err != nil {
+				log.WithError(err).Error("subscription ping error")
+				break loop
+			}
+		case <-ctx.Done():
+			break loop
+		case err := <-done:
+			return err
+		}
+	}
+
+	if err := psc.Unsubscribe(); err != nil {
+		return errors.Wrap(err, "unsubscribe error")
+	}
+
+	return <-done
+}
@@ -0,0 +1,113 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/loraserver/internal/common"
+	"github.com/brocaar/loraserver/internal/test"
+	"github.com/brocaar/lorawan"
+)
+
+func TestSchedulerPartitionLease(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database", t, func() {
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+
+		Convey("When registering two scheduler instances", func() {
+			count, err := RegisterSchedulerInstance(p, "a", time.Minute)
+			So(err, ShouldBeNil)
+			So(count, ShouldEqual, 1)
+
+			count, err = RegisterSchedulerInstance(p, "b", time.Minute)
+			So(err, ShouldBeNil)
+			So(count, ShouldEqual, 2)
+
+			Convey("Then refreshing an instance does not change the count", func() {
+				count, err := RegisterSchedulerInstance(p, "a", time.Minute)
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 2)
+			})
+		})
+
+		Convey("When instance a acquires the lease of partition 1", func() {
+			ok, err := AcquireSchedulerPartitionLease(p, 1, "a", time.Minute)
+			So(err, ShouldBeNil)
+			So(ok, ShouldBeTrue)
+
+			Convey("Then instance a can renew the lease", func() {
+				ok, err := AcquireSchedulerPartitionLease(p, 1, "a", time.Minute)
+				So(err, ShouldBeNil)
+				So(ok, ShouldBeTrue)
+			})
+
+			Convey("Then instance b can not acquire the lease", func() {
+				ok, err := AcquireSchedulerPartitionLease(p, 1, "b", time.Minute)
+				So(err, ShouldBeNil)
+				So(ok, ShouldBeFalse)
+			})
+
+			Convey("Then instance b can acquire the lease of partition 2", func() {
+				ok, err := AcquireSchedulerPartitionLease(p, 2, "b", time.Minute)
+				So(err, ShouldBeNil)
+				So(ok, ShouldBeTrue)
+			})
+
+			Convey("Then releasing the lease by instance b has no effect", func() {
+				So(ReleaseSchedulerPartitionLease(p, 1, "b"), ShouldBeNil)
+
+				ok, err := AcquireSchedulerPartitionLease(p, 1, "b", time.Minute)
+				So(err, ShouldBeNil)
+				So(ok, ShouldBeFalse)
+			})
+
+			Convey("When instance a releases the lease", func() {
+				So(ReleaseSchedulerPartitionLease(p, 1, "a"), ShouldBeNil)
+
+				Convey("Then instance b can acquire the lease", func() {
+					ok, err := AcquireSchedulerPartitionLease(p, 1, "b", time.Minute)
+					So(err, ShouldBeNil)
+					So(ok, ShouldBeTrue)
+				})
+			})
+		})
+
+		Convey("When subscribing to the device-queue schedule events", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			devEUIChan := make(chan lorawan.EUI64, 1)
+			done := make(chan error, 1)
+
+			go func() {
+				done <- SubscribeDeviceQueueScheduleEvents(ctx, p, devEUIChan)
+			}()
+
+			// give the subscription some time to be setup
+			time.Sleep(100 * time.Millisecond)
+
+			Convey("Then a published event is received", func() {
+				devEUI := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+				So(PublishDeviceQueueScheduleEvent(p, devEUI), ShouldBeNil)
+				So(<-devEUIChan, ShouldResemble, devEUI)
+
+				cancel()
+				So(<-done, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestGetSchedulerPartitionForDevEUI(t *testing.T) {
+	Convey("Given a DevEUI", t, func() {
+		devEUI := lorawan.EUI64{1, 2, 3, 4, 5, 6, 1, 2}
+
+		Convey("Then the partition is based on the last two bytes", func() {
+			So(GetSchedulerPartitionForDevEUI(devEUI, 1000), ShouldEqual, 258)
+			So(GetSchedulerPartitionForDevEUI(devEUI, 16), ShouldEqual, 2)
+		})
+	})
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/gofrs/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	log "github.com/sirupsen/logrus"
 
 	"github.com/brocaar/loraserver/internal/config"
@@ -30,6 +31,17 @@ const (
 	Mark RatePolicy = "Mark"
 )
 
+// GWDiversityPolicy defines the policy applied to uplinks received by fewer
+// gateways than the MinGWDiversity of the service-profile.
+type GWDiversityPolicy string
+
+// Available gateway diversity policies.
+const (
+	GWDiversityMark   GWDiversityPolicy = "Mark"
+	GWDiversityDrop   GWDiversityPolicy = "Drop"
+	GWDiversityReport GWDiversityPolicy = "Report"
+)
+
 // ServiceProfile defines the backend.ServiceProfile with some extra meta-data.
 type ServiceProfile struct {
 	CreatedAt              time.Time  `db:"created_at"`
@@ -54,6 +66,20 @@ type ServiceProfile struct {
 	NwkGeoLoc              bool       ` db:"nwk_geo_loc"`
 	TargetPER              int        ` db:"target_per"` // Example: 10 indicates 10%
 	MinGWDiversity         int        ` db:"min_gw_diversity"`
+
+	// MinGWDiversityPolicy defines how to handle uplinks violating the
+	// MinGWDiversity (not part of the LoRaWAN Backend Interfaces).
+	MinGWDiversityPolicy GWDiversityPolicy `db:"min_gw_diversity_policy"`
+
+	// DLChannelFrequencies defines the RX1 downlink frequency for each
+	// uplink channel (by index), 0 uses the band default (not part of the
+	// LoRaWAN Backend Interfaces).
+	DLChannelFrequencies pq.Int64Array `db:"dl_channel_frequencies"`
+
+	// DLConfirmedRetries defines the number of retransmissions of an
+	// unacknowledged confirmed downlink (not part of the LoRaWAN Backend
+	// Interfaces).
+	DLConfirmedRetries int `db:"dl_confirmed_retries"`
 }
 
 // CreateServiceProfile creates the given service-profile.
@@ -95,8 +121,11 @@ func CreateServiceProfile(db sqlx.Execer, sp *ServiceProfile) error {
 			ra_allowed,
 			nwk_geo_loc,
 			target_per,
-			min_gw_diversity
-		) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22)`,
+			min_gw_diversity,
+			min_gw_diversity_policy,
+			dl_channel_frequencies,
+			dl_confirmed_retries
+		) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25)`,
 		sp.CreatedAt,
 		sp.UpdatedAt,
 		sp.ID,
@@ -119,6 +148,9 @@ func CreateServiceProfile(db sqlx.Execer, sp *ServiceProfile) error {
 		sp.NwkGeoLoc,
 		sp.TargetPER,
 		sp.MinGWDiversity,
+		sp.MinGWDiversityPolicy,
+		sp.DLChannelFrequencies,
+		sp.DLConfirmedRetries,
 	)
 	if err != nil {
 		return handlePSQLError(err, "insert error")
@@ -261,7 +293,10 @@ func UpdateServiceProfile(db sqlx.Execer, sp *ServiceProfile) error {
 			ra_allowed = $18,
 			nwk_geo_loc = $19,
 			target_per = $20,
-			min_gw_diversity = $21
+			min_gw_diversity = $21,
+			min_gw_diversity_policy = $22,
+			dl_channel_frequencies = $23,
+			dl_confirmed_retries = $24
 		where
 			service_profile_id = $1`,
 		sp.ID,
@@ -285,6 +320,9 @@ func UpdateServiceProfile(db sqlx.Execer, sp *ServiceProfile) error {
 		sp.NwkGeoLoc,
 		sp.TargetPER,
 		sp.MinGWDiversity,
+		sp.MinGWDiversityPolicy,
+		sp.DLChannelFrequencies,
+		sp.DLConfirmedRetries,
 	)
 	if err != nil {
 		return handlePSQLError(err, "update error")
@@ -7,6 +7,7 @@ import (
 	"github.com/brocaar/loraserver/internal/common"
 	"github.com/brocaar/loraserver/internal/config"
 	"github.com/brocaar/loraserver/internal/test"
+	"github.com/lib/pq"
 	"github.com/pkg/errors"
 	. "github.com/smartystreets/goconvey/convey"
 )
@@ -46,6 +47,9 @@ func TestServiceProfile(t *testing.T) {
 				TargetPER:      1,
 				MinGWDiversity: 8,
 			}
+			sp.MinGWDiversityPolicy = GWDiversityReport
+			sp.DLChannelFrequencies = pq.Int64Array{0, 0, 868500000}
+			sp.DLConfirmedRetries = 2
 
 			So(CreateServiceProfile(db, &sp), ShouldBeNil)
 			sp.CreatedAt = sp.CreatedAt.UTC().Truncate(time.Millisecond)
@@ -80,6 +84,9 @@ func TestServiceProfile(t *testing.T) {
 				sp.NwkGeoLoc = false
 				sp.TargetPER = 2
 				sp.MinGWDiversity = 9
+				sp.MinGWDiversityPolicy = GWDiversityDrop
+				sp.DLChannelFrequencies = pq.Int64Array{868500000}
+				sp.DLConfirmedRetries = 4
 
 				So(UpdateServiceProfile(db, &sp), ShouldBeNil)
 				sp.UpdatedAt = sp.UpdatedAt.UTC().Truncate(time.Millisecond)
@@ -123,6 +123,7 @@ type GatewayBackend struct {
 	TXPacketChan            chan gw.TXPacket
 	GatewayConfigPacketChan chan gw.GatewayConfigPacket
 	statsPacketChan         chan gw.GatewayStatsPacket
+	txAckChan               chan gw.TXAck
 }
 
 // NewGatewayBackend returns a new GatewayBackend.
@@ -131,6 +132,7 @@ func NewGatewayBackend() *GatewayBackend {
 		rxPacketChan:            make(chan gw.RXPacket, 100),
 		TXPacketChan:            make(chan gw.TXPacket, 100),
 		GatewayConfigPacketChan: make(chan gw.GatewayConfigPacket, 100),
+		txAckChan:               make(chan gw.TXAck, 100),
 	}
 }
 
@@ -156,11 +158,19 @@ func (b *GatewayBackend) StatsPacketChan() chan gw.GatewayStatsPacket {
 	return b.statsPacketChan
 }
 
+// TXAckChan method.
+func (b *GatewayBackend) TXAckChan() chan gw.TXAck {
+	return b.txAckChan
+}
+
 // Close method.
 func (b *GatewayBackend) Close() error {
 	if b.rxPacketChan != nil {
 		close(b.rxPacketChan)
 	}
+	if b.txAckChan != nil {
+		close(b.txAckChan)
+	}
 	return nil
 }
 
@@ -62,11 +62,12 @@ func TestOTAAScenarios(t *testing.T) {
 		So(storage.CreateServiceProfile(config.C.PostgreSQL.DB, &sp), ShouldBeNil)
 
 		dp := storage.DeviceProfile{
-			MACVersion:   "1.0.2",
-			RXDelay1:     3,
-			RXDROffset1:  1,
-			RXDataRate2:  5,
-			SupportsJoin: true,
+			MACVersion:      "1.0.2",
+			RXParametersSet: true,
+			RXDelay1:        3,
+			RXDROffset1:     1,
+			RXDataRate2:     5,
+			SupportsJoin:    true,
 		}
 		So(storage.CreateDeviceProfile(config.C.PostgreSQL.DB, &dp), ShouldBeNil)
 
@@ -210,10 +211,10 @@ func TestOTAAScenarios(t *testing.T) {
 						PHYPayload: backend.HEXBytes(jrBytes),
 						DevEUI:     d.DevEUI,
 						DLSettings: lorawan.DLSettings{
-							RX2DataRate: uint8(config.C.NetworkServer.NetworkSettings.RX2DR),
-							RX1DROffset: uint8(config.C.NetworkServer.NetworkSettings.RX1DROffset),
+							RX2DataRate: uint8(dp.RXDataRate2),
+							RX1DROffset: uint8(dp.RXDROffset1),
 						},
-						RxDelay: config.C.NetworkServer.NetworkSettings.RX1Delay,
+						RxDelay: dp.RXDelay1,
 					},
 					ExpectedTXInfo: gw.TXInfo{
 						MAC:       rxInfo.MAC,
@@ -241,6 +242,9 @@ func TestOTAAScenarios(t *testing.T) {
 						EnabledUplinkChannels: []int{0, 1, 2},
 						ExtraUplinkChannels:   map[int]band.Channel{},
 						UplinkGatewayHistory:  map[lorawan.EUI64]storage.UplinkGatewayHistory{},
+						RXDelay:               3,
+						RX1DROffset:           1,
+						RX2DR:                 5,
 						RX2Frequency:          config.C.NetworkServer.Band.Band.GetDefaults().RX2Frequency,
 						NbTrans:               1,
 					},
@@ -293,10 +297,10 @@ func TestOTAAScenarios(t *testing.T) {
 						DevEUI:     d.DevEUI,
 						DLSettings: lorawan.DLSettings{
 							OptNeg:      true,
-							RX2DataRate: uint8(config.C.NetworkServer.NetworkSettings.RX2DR),
-							RX1DROffset: uint8(config.C.NetworkServer.NetworkSettings.RX1DROffset),
+							RX2DataRate: uint8(dp.RXDataRate2),
+							RX1DROffset: uint8(dp.RXDROffset1),
 						},
-						RxDelay: config.C.NetworkServer.NetworkSettings.RX1Delay,
+						RxDelay: dp.RXDelay1,
 					},
 					ExpectedTXInfo: gw.TXInfo{
 						MAC:       rxInfo.MAC,
@@ -324,6 +328,9 @@ func TestOTAAScenarios(t *testing.T) {
 						EnabledUplinkChannels: []int{0, 1, 2},
 						ExtraUplinkChannels:   map[int]band.Channel{},
 						UplinkGatewayHistory:  map[lorawan.EUI64]storage.UplinkGatewayHistory{},
+						RXDelay:               3,
+						RX1DROffset:           1,
+						RX2DR:                 5,
 						RX2Frequency:          config.C.NetworkServer.Band.Band.GetDefaults().RX2Frequency,
 						NbTrans:               1,
 					},
@@ -390,10 +397,10 @@ func TestOTAAScenarios(t *testing.T) {
 						DevEUI:     d.DevEUI,
 						DLSettings: lorawan.DLSettings{
 							OptNeg:      true,
-							RX2DataRate: uint8(config.C.NetworkServer.NetworkSettings.RX2DR),
-							RX1DROffset: uint8(config.C.NetworkServer.NetworkSettings.RX1DROffset),
+							RX2DataRate: uint8(dp.RXDataRate2),
+							RX1DROffset: uint8(dp.RXDROffset1),
 						},
-						RxDelay: config.C.NetworkServer.NetworkSettings.RX1Delay,
+						RxDelay: dp.RXDelay1,
 					},
 					ExpectedTXInfo: gw.TXInfo{
 						MAC:       rxInfo.MAC,
@@ -422,6 +429,9 @@ func TestOTAAScenarios(t *testing.T) {
 						EnabledUplinkChannels: []int{0, 1, 2},
 						ExtraUplinkChannels:   map[int]band.Channel{},
 						UplinkGatewayHistory:  map[lorawan.EUI64]storage.UplinkGatewayHistory{},
+						RXDelay:               3,
+						RX1DROffset:           1,
+						RX2DR:                 5,
 						RX2Frequency:          config.C.NetworkServer.Band.Band.GetDefaults().RX2Frequency,
 						NbTrans:               1,
 					},
@@ -456,10 +466,10 @@ func TestOTAAScenarios(t *testing.T) {
 						PHYPayload: jrBytes,
 						DevEUI:     d.DevEUI,
 						DLSettings: lorawan.DLSettings{
-							RX2DataRate: uint8(config.C.NetworkServer.NetworkSettings.RX2DR),
-							RX1DROffset: uint8(config.C.NetworkServer.NetworkSettings.RX1DROffset),
+							RX2DataRate: uint8(dp.RXDataRate2),
+							RX1DROffset: uint8(dp.RXDROffset1),
 						},
-						RxDelay: config.C.NetworkServer.NetworkSettings.RX1Delay,
+						RxDelay: dp.RXDelay1,
 					},
 					ExpectedTXInfo: gw.TXInfo{
 						MAC:       rxInfo.MAC,
@@ -484,6 +494,9 @@ func TestOTAAScenarios(t *testing.T) {
 						EnabledUplinkChannels: []int{0, 1, 2},
 						ExtraUplinkChannels:   map[int]band.Channel{},
 						UplinkGatewayHistory:  map[lorawan.EUI64]storage.UplinkGatewayHistory{},
+						RXDelay:               3,
+						RX1DROffset:           1,
+						RX2DR:                 5,
 						RX2Frequency:          config.C.NetworkServer.Band.Band.GetDefaults().RX2Frequency,
 						SkipFCntValidation:    true,
 						NbTrans:               1,
@@ -543,10 +556,10 @@ func TestOTAAScenarios(t *testing.T) {
 						PHYPayload: backend.HEXBytes(jrBytes),
 						DevEUI:     d.DevEUI,
 						DLSettings: lorawan.DLSettings{
-							RX2DataRate: uint8(config.C.NetworkServer.NetworkSettings.RX2DR),
-							RX1DROffset: uint8(config.C.NetworkServer.NetworkSettings.RX1DROffset),
+							RX2DataRate: uint8(dp.RXDataRate2),
+							RX1DROffset: uint8(dp.RXDROffset1),
 						},
-						RxDelay: config.C.NetworkServer.NetworkSettings.RX1Delay,
+						RxDelay: dp.RXDelay1,
 						// CFList is set in the BeforeFunc
 					},
 					ExpectedTXInfo: gw.TXInfo{
@@ -576,6 +589,9 @@ func TestOTAAScenarios(t *testing.T) {
 							5: band.Channel{Frequency: 868800000, MinDR: 0, MaxDR: 5},
 						},
 						UplinkGatewayHistory: map[lorawan.EUI64]storage.UplinkGatewayHistory{},
+						RXDelay:              3,
+						RX1DROffset:          1,
+						RX2DR:                5,
 						RX2Frequency:         config.C.NetworkServer.Band.Band.GetDefaults().RX2Frequency,
 						NbTrans:              1,
 					},
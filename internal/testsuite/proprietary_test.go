@@ -4,6 +4,10 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 
 	"github.com/brocaar/loraserver/api/as"
 	commonPB "github.com/brocaar/loraserver/api/common"
@@ -12,10 +16,12 @@ import (
 	"github.com/brocaar/loraserver/internal/api"
 	"github.com/brocaar/loraserver/internal/common"
 	"github.com/brocaar/loraserver/internal/config"
+	"github.com/brocaar/loraserver/internal/downlink/gateway"
 	"github.com/brocaar/loraserver/internal/storage"
 	"github.com/brocaar/loraserver/internal/test"
 	"github.com/brocaar/loraserver/internal/uplink"
 	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/band"
 	. "github.com/smartystreets/goconvey/convey"
 )
 
@@ -98,6 +104,41 @@ func TestSendProprietaryPayloadScenarios(t *testing.T) {
 				})
 			}
 		})
+
+		Convey("Given duty-cycle accounting is enabled and the budget of the gateway has been used", func() {
+			config.C.Redis.Pool = common.NewRedisPool(conf.RedisURL)
+			test.MustFlushRedis(config.C.Redis.Pool)
+
+			config.C.NetworkServer.Band.Name = band.EU_863_870
+			config.C.NetworkServer.Gateway.DutyCycle.Enabled = true
+			config.C.NetworkServer.Gateway.DutyCycle.Window = time.Minute
+
+			Reset(func() {
+				config.C.NetworkServer.Gateway.DutyCycle.Enabled = false
+			})
+
+			So(gateway.RecordAirtime(gw.TXInfo{
+				MAC:       lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1},
+				Frequency: 868100000,
+				CodeRate:  "4/5",
+				DataRate:  band.DataRate{Modulation: band.LoRaModulation, SpreadFactor: 12, Bandwidth: 125},
+			}, 13), ShouldBeNil)
+
+			Convey("When sending a proprietary payload to this gateway", func() {
+				_, err := api.SendProprietaryPayload(context.Background(), &ns.SendProprietaryPayloadRequest{
+					MacPayload:  []byte{1, 2, 3, 4},
+					Mic:         []byte{5, 6, 7, 8},
+					GatewayMacs: [][]byte{{8, 7, 6, 5, 4, 3, 2, 1}},
+					Frequency:   868100000,
+					Dr:          5,
+				})
+
+				Convey("Then a resource exhausted error is returned and no frame was sent", func() {
+					So(grpc.Code(err), ShouldEqual, codes.ResourceExhausted)
+					So(config.C.NetworkServer.Gateway.Backend.Backend.(*test.GatewayBackend).TXPacketChan, ShouldHaveLength, 0)
+				})
+			})
+		})
 	})
 }
 
@@ -24,6 +24,7 @@ type rejoinTestCase struct {
 	BeforeFunc                 func(*rejoinTestCase) error
 	Name                       string
 	DeviceSession              storage.DeviceSession
+	DeviceSessionLost          bool
 	RXInfo                     gw.RXInfo
 	PHYPayload                 lorawan.PHYPayload
 	JoinServerRejoinReqError   error
@@ -34,6 +35,10 @@ type rejoinTestCase struct {
 	ExpectedTXInfo           gw.TXInfo
 	ExpectedPHYPayload       lorawan.PHYPayload
 	ExpectedDeviceSession    storage.DeviceSession
+
+	// ExpectedForceRejoinReqType is the ForceRejoinReq rejoin-type expected
+	// to be stored with the device-activation.
+	ExpectedForceRejoinReqType *lorawan.JoinType
 }
 
 func TestRejoinScenarios(t *testing.T) {
@@ -71,11 +76,12 @@ func TestRejoinScenarios(t *testing.T) {
 		So(storage.CreateServiceProfile(db, &sp), ShouldBeNil)
 
 		dp := storage.DeviceProfile{
-			MACVersion:   "1.1.0",
-			RXDelay1:     3,
-			RXDROffset1:  1,
-			RXDataRate2:  5,
-			SupportsJoin: true,
+			MACVersion:      "1.1.0",
+			RXParametersSet: true,
+			RXDelay1:        3,
+			RXDROffset1:     1,
+			RXDataRate2:     5,
+			SupportsJoin:    true,
 		}
 		So(storage.CreateDeviceProfile(db, &dp), ShouldBeNil)
 
@@ -156,9 +162,9 @@ func TestRejoinScenarios(t *testing.T) {
 				{
 					BeforeFunc: func(tc *rejoinTestCase) error {
 						rejoinDS := ds
-						rejoinDS.RXDelay = 1
-						rejoinDS.RX1DROffset = 2
-						rejoinDS.RX2DR = 3
+						rejoinDS.RXDelay = 3
+						rejoinDS.RX1DROffset = 1
+						rejoinDS.RX2DR = 5
 						rejoinDS.RX2Frequency = 869525000
 						rejoinDS.FCntUp = 0
 						rejoinDS.NFCntDown = 0
@@ -231,10 +237,10 @@ func TestRejoinScenarios(t *testing.T) {
 						DevEUI:     d.DevEUI,
 						DLSettings: lorawan.DLSettings{
 							OptNeg:      true,
-							RX2DataRate: 3,
-							RX1DROffset: 2,
+							RX2DataRate: 5,
+							RX1DROffset: 1,
 						},
-						RxDelay: 1,
+						RxDelay: 3,
 						// CFList is set in the BeforeFunc
 					},
 					ExpectedTXInfo: gw.TXInfo{
@@ -260,9 +266,9 @@ func TestRejoinScenarios(t *testing.T) {
 						}
 
 						rejoinDS := ds
-						rejoinDS.RXDelay = 1
-						rejoinDS.RX1DROffset = 2
-						rejoinDS.RX2DR = 3
+						rejoinDS.RXDelay = 3
+						rejoinDS.RX1DROffset = 1
+						rejoinDS.RX2DR = 5
 						rejoinDS.RX2Frequency = 869525000
 						rejoinDS.FCntUp = 0
 						rejoinDS.NFCntDown = 0
@@ -340,10 +346,10 @@ func TestRejoinScenarios(t *testing.T) {
 						DevEUI:     d.DevEUI,
 						DLSettings: lorawan.DLSettings{
 							OptNeg:      true,
-							RX2DataRate: 3,
-							RX1DROffset: 2,
+							RX2DataRate: 5,
+							RX1DROffset: 1,
 						},
-						RxDelay: 1,
+						RxDelay: 3,
 						// CFList is set in the BeforeFunc
 					},
 					ExpectedTXInfo: gw.TXInfo{
@@ -473,10 +479,10 @@ func TestRejoinScenarios(t *testing.T) {
 						DevEUI:     d.DevEUI,
 						DLSettings: lorawan.DLSettings{
 							OptNeg:      true,
-							RX2DataRate: 3,
-							RX1DROffset: 2,
+							RX2DataRate: 5,
+							RX1DROffset: 1,
 						},
-						RxDelay: 1,
+						RxDelay: 3,
 					},
 					ExpectedTXInfo: gw.TXInfo{
 						MAC:       lorawan.EUI64{1, 1, 1, 1, 2, 2, 2, 2},
@@ -545,10 +551,10 @@ func TestRejoinScenarios(t *testing.T) {
 						DevEUI:     d.DevEUI,
 						DLSettings: lorawan.DLSettings{
 							OptNeg:      true,
-							RX2DataRate: 3,
-							RX1DROffset: 2,
+							RX2DataRate: 5,
+							RX1DROffset: 1,
 						},
-						RxDelay: 1,
+						RxDelay: 3,
 					},
 					ExpectedTXInfo: gw.TXInfo{
 						MAC:       lorawan.EUI64{1, 1, 1, 1, 2, 2, 2, 2},
@@ -562,6 +568,249 @@ func TestRejoinScenarios(t *testing.T) {
 				},
 			}
 
+			// the same rejoin-request, as response to a ForceRejoinReq
+			forcedTest := tests[0]
+			forcedBeforeFunc := forcedTest.BeforeFunc
+			forcedRejoinType := lorawan.RejoinRequestType2
+			forcedTest.Name = "valid rejoin-request type 2 (ForceRejoinReq)"
+			forcedTest.ExpectedForceRejoinReqType = &forcedRejoinType
+			forcedTest.BeforeFunc = func(tc *rejoinTestCase) error {
+				if err := forcedBeforeFunc(tc); err != nil {
+					return err
+				}
+
+				return storage.SetPendingMACCommand(redisPool, ds.DevEUI, storage.MACCommandBlock{
+					CID: lorawan.ForceRejoinReq,
+					MACCommands: storage.MACCommands{
+						{
+							CID: lorawan.ForceRejoinReq,
+							Payload: &lorawan.ForceRejoinReqPayload{
+								RejoinType: uint8(lorawan.RejoinRequestType2),
+							},
+						},
+					},
+				})
+			}
+			tests = append(tests, forcedTest)
+
+			runRejoinTests(asClient, jsClient, redisPool, db, tests)
+		})
+
+		Convey("Testing rejoin-request 1", func() {
+			rjPHY := lorawan.PHYPayload{
+				MHDR: lorawan.MHDR{
+					MType: lorawan.RejoinRequest,
+					Major: lorawan.LoRaWANR1,
+				},
+				MACPayload: &lorawan.RejoinRequestType1Payload{
+					RejoinType: lorawan.RejoinRequestType1,
+					JoinEUI:    ds.JoinEUI,
+					DevEUI:     d.DevEUI,
+					RJCount1:   123,
+				},
+			}
+			So(rjPHY.SetUplinkJoinMIC(jsIntKey), ShouldBeNil)
+			rjBytes, err := rjPHY.MarshalBinary()
+			So(err, ShouldBeNil)
+
+			jaPHY := lorawan.PHYPayload{
+				MHDR: lorawan.MHDR{
+					MType: lorawan.JoinAccept,
+					Major: lorawan.LoRaWANR1,
+				},
+				MACPayload: &lorawan.JoinAcceptPayload{},
+			}
+			So(jaPHY.EncryptJoinAcceptPayload(jsEncKey), ShouldBeNil)
+			jaBytes, err := jaPHY.MarshalBinary()
+			So(err, ShouldBeNil)
+
+			fiveSec := uint32(5000000)
+
+			rejoinAnsPayload := backend.RejoinAnsPayload{
+				PHYPayload: backend.HEXBytes(jaBytes),
+				Result: backend.Result{
+					ResultCode: backend.Success,
+				},
+				SNwkSIntKey: &backend.KeyEnvelope{
+					AESKey: []byte{2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 1},
+				},
+				FNwkSIntKey: &backend.KeyEnvelope{
+					AESKey: []byte{2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2},
+				},
+				NwkSEncKey: &backend.KeyEnvelope{
+					AESKey: []byte{2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 3},
+				},
+				AppSKey: &backend.KeyEnvelope{
+					AESKey: []byte{2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 4},
+				},
+			}
+
+			rejoinReqPayload := backend.RejoinReqPayload{
+				BasePayload: backend.BasePayload{
+					ProtocolVersion: backend.ProtocolVersion1_0,
+					SenderID:        "030201",
+					ReceiverID:      "0807060504030201",
+					MessageType:     backend.RejoinReq,
+				},
+				MACVersion: "1.1.0",
+				PHYPayload: backend.HEXBytes(rjBytes),
+				DevEUI:     d.DevEUI,
+				DLSettings: lorawan.DLSettings{
+					OptNeg:      true,
+					RX2DataRate: 5,
+					RX1DROffset: 1,
+				},
+				RxDelay: 3,
+				// CFList is set in the BeforeFunc
+			}
+
+			txInfo := gw.TXInfo{
+				MAC:       lorawan.EUI64{1, 1, 1, 1, 2, 2, 2, 2},
+				Timestamp: &fiveSec,
+				Frequency: c0.Frequency,
+				Power:     14,
+				DataRate:  c0MinDR,
+			}
+
+			// returns the expected pending device-session, based on the
+			// given device-session
+			getRejoinDS := func(ds storage.DeviceSession) storage.DeviceSession {
+				rejoinDS := ds
+				rejoinDS.RXDelay = 3
+				rejoinDS.RX1DROffset = 1
+				rejoinDS.RX2DR = 5
+				rejoinDS.RX2Frequency = 869525000
+				rejoinDS.FCntUp = 0
+				rejoinDS.NFCntDown = 0
+				rejoinDS.AFCntDown = 0
+				rejoinDS.NbTrans = 1
+				rejoinDS.EnabledUplinkChannels = []int{0, 1, 2, 3, 4, 5}
+				rejoinDS.ExtraUplinkChannels = map[int]band.Channel{
+					3: {Frequency: 867100000, MaxDR: 5},
+					4: {Frequency: 867300000, MaxDR: 5},
+					5: {Frequency: 867500000, MaxDR: 5},
+				}
+				rejoinDS.UplinkGatewayHistory = map[lorawan.EUI64]storage.UplinkGatewayHistory{}
+				rejoinDS.SNwkSIntKey = lorawan.AES128Key{2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 1}
+				rejoinDS.FNwkSIntKey = lorawan.AES128Key{2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2}
+				rejoinDS.NwkSEncKey = lorawan.AES128Key{2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 3}
+				rejoinDS.AppSKeyEvelope = &storage.KeyEnvelope{
+					AESKey: []byte{2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 4},
+				}
+				return rejoinDS
+			}
+
+			setCFList := func(tc *rejoinTestCase) error {
+				cFList := lorawan.CFList{
+					CFListType: lorawan.CFListChannel,
+					Payload: &lorawan.CFListChannelPayload{
+						Channels: [5]uint32{
+							867100000,
+							867300000,
+							867500000,
+						},
+					},
+				}
+				cFListB, err := cFList.MarshalBinary()
+				if err != nil {
+					return err
+				}
+				tc.ExpectedRejoinReqPayload.CFList = backend.HEXBytes(cFListB)
+				return nil
+			}
+
+			lostDS := storage.DeviceSession{
+				DeviceProfileID:      dp.ID,
+				ServiceProfileID:     sp.ID,
+				RoutingProfileID:     rp.ID,
+				MACVersion:           "1.1.0",
+				DevEUI:               d.DevEUI,
+				JoinEUI:              ds.JoinEUI,
+				ExtraUplinkChannels:  make(map[int]band.Channel),
+				UplinkGatewayHistory: make(map[lorawan.EUI64]storage.UplinkGatewayHistory),
+			}
+
+			tests := []rejoinTestCase{
+				{
+					BeforeFunc: func(tc *rejoinTestCase) error {
+						rejoinDS := getRejoinDS(ds)
+						tc.ExpectedDeviceSession.PendingRejoinDeviceSession = &rejoinDS
+						return setCFList(tc)
+					},
+					Name:                       "valid rejoin-request type 1",
+					DeviceSession:              ds,
+					RXInfo:                     rxInfo,
+					PHYPayload:                 rjPHY,
+					JoinServerRejoinAnsPayload: rejoinAnsPayload,
+					ExpectedRejoinReqPayload:   rejoinReqPayload,
+					ExpectedTXInfo:             txInfo,
+					ExpectedPHYPayload:         jaPHY,
+					ExpectedDeviceSession:      ds,
+				},
+				{
+					BeforeFunc: func(tc *rejoinTestCase) error {
+						return setCFList(tc)
+					},
+					Name:                       "valid rejoin-request type 1 (device-session lost)",
+					DeviceSession:              ds,
+					DeviceSessionLost:          true,
+					RXInfo:                     rxInfo,
+					PHYPayload:                 rjPHY,
+					JoinServerRejoinAnsPayload: rejoinAnsPayload,
+					ExpectedRejoinReqPayload:   rejoinReqPayload,
+					ExpectedTXInfo:             txInfo,
+					ExpectedPHYPayload:         jaPHY,
+					ExpectedDeviceSession:      getRejoinDS(lostDS),
+				},
+				{
+					Name:                     "join-server returns error (device-session lost)",
+					DeviceSession:            ds,
+					DeviceSessionLost:        true,
+					RXInfo:                   rxInfo,
+					PHYPayload:               rjPHY,
+					JoinServerRejoinReqError: errors.New("boom"),
+					ExpectedError:            errors.New("rejoin-request to join-server error: boom"),
+				},
+				{
+					BeforeFunc: func(tc *rejoinTestCase) error {
+						return storage.CreateDeviceActivation(db, &storage.DeviceActivation{
+							DevEUI:      d.DevEUI,
+							JoinEUI:     ds.JoinEUI,
+							DevNonce:    123,
+							JoinReqType: lorawan.RejoinRequestType1,
+						})
+					},
+					Name:          "invalid rejoin-counter",
+					DeviceSession: ds,
+					RXInfo:        rxInfo,
+					PHYPayload:    rjPHY,
+					ExpectedError: errors.New("invalid RJcount1"),
+				},
+				{
+					BeforeFunc: func(tc *rejoinTestCase) error {
+						return storage.CreateDeviceActivation(db, &storage.DeviceActivation{
+							DevEUI:      d.DevEUI,
+							JoinEUI:     ds.JoinEUI,
+							DevNonce:    124,
+							JoinReqType: lorawan.RejoinRequestType1,
+						})
+					},
+					Name:          "rejoin-counter did not increase",
+					DeviceSession: ds,
+					RXInfo:        rxInfo,
+					PHYPayload:    rjPHY,
+					ExpectedError: errors.New("invalid RJcount1"),
+				},
+				{
+					Name:                     "join-server returns error",
+					DeviceSession:            ds,
+					RXInfo:                   rxInfo,
+					PHYPayload:               rjPHY,
+					JoinServerRejoinReqError: errors.New("boom"),
+					ExpectedError:            errors.New("rejoin-request to join-server error: boom"),
+				},
+			}
+
 			runRejoinTests(asClient, jsClient, redisPool, db, tests)
 		})
 	})
@@ -580,7 +829,9 @@ func runRejoinTests(asClient *test.ApplicationClient, jsClient *test.JoinServerC
 			jsClient.RejoinReqError = t.JoinServerRejoinReqError
 
 			// create device-session
-			So(storage.SaveDeviceSession(redisPool, t.DeviceSession), ShouldBeNil)
+			if !t.DeviceSessionLost {
+				So(storage.SaveDeviceSession(redisPool, t.DeviceSession), ShouldBeNil)
+			}
 
 			err := uplink.HandleRXPacket(gw.RXPacket{
 				RXInfo:     t.RXInfo,
@@ -589,6 +840,12 @@ func runRejoinTests(asClient *test.ApplicationClient, jsClient *test.JoinServerC
 			if t.ExpectedError != nil {
 				So(err, ShouldNotBeNil)
 				So(err.Error(), ShouldEqual, t.ExpectedError.Error())
+
+				if t.DeviceSessionLost {
+					// nothing must be stored when the rejoin-request failed
+					_, err := storage.GetDeviceSession(redisPool, t.DeviceSession.DevEUI)
+					So(err, ShouldEqual, storage.ErrDoesNotExist)
+				}
 				return
 			}
 			So(err, ShouldBeNil)
@@ -624,21 +881,42 @@ func runRejoinTests(asClient *test.ApplicationClient, jsClient *test.JoinServerC
 				ds, err := storage.GetDeviceSession(redisPool, t.DeviceSession.DevEUI)
 				So(err, ShouldBeNil)
 
-				So(ds.PendingRejoinDeviceSession, ShouldNotBeNil)
-				So(ds.PendingRejoinDeviceSession.DevAddr, ShouldNotEqual, lorawan.DevAddr{})
-				ds.PendingRejoinDeviceSession.DevAddr = lorawan.DevAddr{}
+				if t.DeviceSessionLost {
+					// the new device-session is activated directly
+					So(ds.PendingRejoinDeviceSession, ShouldBeNil)
+					So(ds.DevAddr, ShouldNotEqual, lorawan.DevAddr{})
+					ds.DevAddr = lorawan.DevAddr{}
+				} else {
+					So(ds.PendingRejoinDeviceSession, ShouldNotBeNil)
+					So(ds.PendingRejoinDeviceSession.DevAddr, ShouldNotEqual, lorawan.DevAddr{})
+					ds.PendingRejoinDeviceSession.DevAddr = lorawan.DevAddr{}
+				}
 
 				So(ds, ShouldResemble, t.ExpectedDeviceSession)
 			})
 
 			Convey("Then a device-activation record was created", func() {
+				expectedDS := t.ExpectedDeviceSession
+				if expectedDS.PendingRejoinDeviceSession != nil {
+					expectedDS = *expectedDS.PendingRejoinDeviceSession
+				}
+
 				da, err := storage.GetLastDeviceActivationForDevEUI(db, t.DeviceSession.DevEUI)
 				So(err, ShouldBeNil)
 				So(da.DevAddr, ShouldNotEqual, lorawan.DevAddr{})
-				So(da.SNwkSIntKey, ShouldEqual, t.ExpectedDeviceSession.PendingRejoinDeviceSession.SNwkSIntKey)
-				So(da.FNwkSIntKey, ShouldEqual, t.ExpectedDeviceSession.PendingRejoinDeviceSession.FNwkSIntKey)
-				So(da.NwkSEncKey, ShouldEqual, t.ExpectedDeviceSession.PendingRejoinDeviceSession.NwkSEncKey)
+				So(da.SNwkSIntKey, ShouldEqual, expectedDS.SNwkSIntKey)
+				So(da.FNwkSIntKey, ShouldEqual, expectedDS.FNwkSIntKey)
+				So(da.NwkSEncKey, ShouldEqual, expectedDS.NwkSEncKey)
+				So(da.ForceRejoinReqType, ShouldResemble, t.ExpectedForceRejoinReqType)
 			})
+
+			if t.ExpectedForceRejoinReqType != nil {
+				Convey("Then the pending ForceRejoinReq has been removed", func() {
+					block, err := storage.GetPendingMACCommand(redisPool, t.DeviceSession.DevEUI, lorawan.ForceRejoinReq)
+					So(err, ShouldBeNil)
+					So(block, ShouldBeNil)
+				})
+			}
 		})
 	}
 }
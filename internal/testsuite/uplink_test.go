@@ -468,6 +468,90 @@ func TestUplinkScenarios(t *testing.T) {
 					ExpectedNFCntDown:              5,
 					ExpectedEnabledChannels:        []int{0, 1, 2},
 				},
+				{
+					BeforeFunc: func(tc *uplinkTestCase) error {
+						sp.ULRate = 1
+						sp.ULBucketSize = 1
+						sp.ULRatePolicy = storage.Mark
+						if err := storage.UpdateServiceProfile(config.C.PostgreSQL.DB, &sp); err != nil {
+							return err
+						}
+
+						// empty the bucket
+						if _, err := storage.TakeUplinkRateLimitToken(config.C.Redis.Pool, tc.DeviceSession.DevEUI, sp.ULRate, sp.ULBucketSize); err != nil {
+							return err
+						}
+
+						tc.ExpectedASHandleDataUp.Data = []byte{1, 2, 3, 4}
+						tc.ExpectedASHandleDataUp.RateLimitExceeded = true
+						return nil
+					},
+
+					Name:          "unconfirmed uplink data with payload exceeding the uplink rate (mark)",
+					DeviceSession: ds,
+					RXInfo:        rxInfo,
+					PHYPayload: lorawan.PHYPayload{
+						MHDR: lorawan.MHDR{
+							MType: lorawan.UnconfirmedDataUp,
+							Major: lorawan.LoRaWANR1,
+						},
+						MACPayload: &lorawan.MACPayload{
+							FHDR: lorawan.FHDR{
+								DevAddr: ds.DevAddr,
+								FCnt:    10,
+							},
+							FPort:      &fPortOne,
+							FRMPayload: []lorawan.Payload{&lorawan.DataPayload{Bytes: []byte{1, 2, 3, 4}}},
+						},
+					},
+					ExpectedUplinkMIC:              lorawan.MIC{104, 147, 35, 121},
+					ExpectedControllerHandleRXInfo: expectedControllerHandleRXInfo,
+					ExpectedASHandleDataUp:         expectedApplicationPushDataUpNoData,
+					ExpectedFCntUp:                 11,
+					ExpectedNFCntDown:              5,
+					ExpectedEnabledChannels:        []int{0, 1, 2},
+				},
+				{
+					BeforeFunc: func(tc *uplinkTestCase) error {
+						sp.ULRate = 1
+						sp.ULBucketSize = 1
+						sp.ULRatePolicy = storage.Drop
+						if err := storage.UpdateServiceProfile(config.C.PostgreSQL.DB, &sp); err != nil {
+							return err
+						}
+
+						// empty the bucket
+						if _, err := storage.TakeUplinkRateLimitToken(config.C.Redis.Pool, tc.DeviceSession.DevEUI, sp.ULRate, sp.ULBucketSize); err != nil {
+							return err
+						}
+						return nil
+					},
+
+					Name:          "unconfirmed uplink data with payload exceeding the uplink rate (drop)",
+					DeviceSession: ds,
+					RXInfo:        rxInfo,
+					PHYPayload: lorawan.PHYPayload{
+						MHDR: lorawan.MHDR{
+							MType: lorawan.UnconfirmedDataUp,
+							Major: lorawan.LoRaWANR1,
+						},
+						MACPayload: &lorawan.MACPayload{
+							FHDR: lorawan.FHDR{
+								DevAddr: ds.DevAddr,
+								FCnt:    10,
+							},
+							FPort:      &fPortOne,
+							FRMPayload: []lorawan.Payload{&lorawan.DataPayload{Bytes: []byte{1, 2, 3, 4}}},
+						},
+					},
+					// the payload is not forwarded, but the frame-counter
+					// is incremented so that the frame can't be replayed
+					ExpectedUplinkMIC:              lorawan.MIC{104, 147, 35, 121},
+					ExpectedControllerHandleRXInfo: expectedControllerHandleRXInfo,
+					ExpectedFCntUp:                 11,
+					ExpectedNFCntDown:              5,
+					ExpectedEnabledChannels:        []int{0, 1, 2},
+				},
 				{
 					BeforeFunc: func(tc *uplinkTestCase) error {
 						tc.ExpectedASHandleDataUp.Data = []byte{1, 2, 3, 4}
@@ -920,6 +1004,116 @@ func TestUplinkScenarios(t *testing.T) {
 					ExpectedNFCntDown:              5,
 					ExpectedEnabledChannels:        []int{0, 1, 2},
 				},
+				{
+
+					BeforeFunc: func(tc *uplinkTestCase) error {
+						tc.ExpectedASHandleDataUp.Data = []byte{1, 2, 3, 4}
+						tc.ExpectedASHandleDataUp.DeviceLocation = &as.DeviceLocation{
+							Latitude:     gw1.Location.Latitude,
+							Longitude:    gw1.Location.Longitude,
+							Altitude:     gw1.Altitude,
+							Source:       as.LocationSource_RSSI_CENTROID,
+							GatewayCount: 1,
+						}
+
+						// enable network geolocation
+						sp.NwkGeoLoc = true
+						return storage.UpdateServiceProfile(config.C.PostgreSQL.DB, &sp)
+					},
+
+					Name:          "unconfirmed uplink data with payload (service-profile: network geolocation)",
+					DeviceSession: ds,
+					RXInfo:        rxInfo,
+					PHYPayload: lorawan.PHYPayload{
+						MHDR: lorawan.MHDR{
+							MType: lorawan.UnconfirmedDataUp,
+							Major: lorawan.LoRaWANR1,
+						},
+						MACPayload: &lorawan.MACPayload{
+							FHDR: lorawan.FHDR{
+								DevAddr: ds.DevAddr,
+								FCnt:    10,
+							},
+							FPort:      &fPortOne,
+							FRMPayload: []lorawan.Payload{&lorawan.DataPayload{Bytes: []byte{1, 2, 3, 4}}},
+						},
+					},
+					ExpectedUplinkMIC:              lorawan.MIC{104, 147, 35, 121},
+					ExpectedControllerHandleRXInfo: expectedControllerHandleRXInfo,
+					ExpectedASHandleDataUp:         expectedApplicationPushDataUpNoData,
+					ExpectedFCntUp:                 11,
+					ExpectedNFCntDown:              5,
+					ExpectedEnabledChannels:        []int{0, 1, 2},
+				},
+				{
+
+					BeforeFunc: func(tc *uplinkTestCase) error {
+						tc.ExpectedASHandleDataUp.Data = []byte{1, 2, 3, 4}
+						tc.ExpectedASHandleDataUp.MinGwDiversityViolated = true
+
+						// require two receiving gateways
+						sp.MinGWDiversity = 2
+						sp.MinGWDiversityPolicy = storage.GWDiversityMark
+						return storage.UpdateServiceProfile(config.C.PostgreSQL.DB, &sp)
+					},
+
+					Name:          "unconfirmed uplink data with payload (service-profile: min gateway diversity violated)",
+					DeviceSession: ds,
+					RXInfo:        rxInfo,
+					PHYPayload: lorawan.PHYPayload{
+						MHDR: lorawan.MHDR{
+							MType: lorawan.UnconfirmedDataUp,
+							Major: lorawan.LoRaWANR1,
+						},
+						MACPayload: &lorawan.MACPayload{
+							FHDR: lorawan.FHDR{
+								DevAddr: ds.DevAddr,
+								FCnt:    10,
+							},
+							FPort:      &fPortOne,
+							FRMPayload: []lorawan.Payload{&lorawan.DataPayload{Bytes: []byte{1, 2, 3, 4}}},
+						},
+					},
+					ExpectedUplinkMIC:              lorawan.MIC{104, 147, 35, 121},
+					ExpectedControllerHandleRXInfo: expectedControllerHandleRXInfo,
+					ExpectedASHandleDataUp:         expectedApplicationPushDataUpNoData,
+					ExpectedFCntUp:                 11,
+					ExpectedNFCntDown:              5,
+					ExpectedEnabledChannels:        []int{0, 1, 2},
+				},
+				{
+					BeforeFunc: func(tc *uplinkTestCase) error {
+						// require two receiving gateways
+						sp.MinGWDiversity = 2
+						sp.MinGWDiversityPolicy = storage.GWDiversityDrop
+						return storage.UpdateServiceProfile(config.C.PostgreSQL.DB, &sp)
+					},
+
+					Name:          "unconfirmed uplink data with payload (service-profile: min gateway diversity violated, drop)",
+					DeviceSession: ds,
+					RXInfo:        rxInfo,
+					PHYPayload: lorawan.PHYPayload{
+						MHDR: lorawan.MHDR{
+							MType: lorawan.UnconfirmedDataUp,
+							Major: lorawan.LoRaWANR1,
+						},
+						MACPayload: &lorawan.MACPayload{
+							FHDR: lorawan.FHDR{
+								DevAddr: ds.DevAddr,
+								FCnt:    10,
+							},
+							FPort:      &fPortOne,
+							FRMPayload: []lorawan.Payload{&lorawan.DataPayload{Bytes: []byte{1, 2, 3, 4}}},
+						},
+					},
+					// the payload is not forwarded, but the frame-counter
+					// is incremented so that the frame can't be replayed
+					ExpectedUplinkMIC:              lorawan.MIC{104, 147, 35, 121},
+					ExpectedControllerHandleRXInfo: expectedControllerHandleRXInfo,
+					ExpectedFCntUp:                 11,
+					ExpectedNFCntDown:              5,
+					ExpectedEnabledChannels:        []int{0, 1, 2},
+				},
 			}
 
 			runUplinkTests(asClient, tests)
@@ -2252,9 +2446,12 @@ func runUplinkTests(asClient *test.ApplicationClient, tests []uplinkTestCase) {
 				Convey("Then the expected RXInfoSet has been added to the node-session", func() {
 					ns, err := storage.GetDeviceSession(config.C.Redis.Pool, t.DeviceSession.DevEUI)
 					So(err, ShouldBeNil)
-					So(ns.UplinkGatewayHistory, ShouldResemble, map[lorawan.EUI64]storage.UplinkGatewayHistory{
-						t.RXInfo.MAC: storage.UplinkGatewayHistory{},
-					})
+					So(ns.UplinkGatewayHistory, ShouldHaveLength, 1)
+					So(ns.UplinkGatewayHistory, ShouldContainKey, t.RXInfo.MAC)
+
+					h := ns.UplinkGatewayHistory[t.RXInfo.MAC]
+					So(h.RSSI, ShouldEqual, t.RXInfo.RSSI)
+					So(h.LoRaSNR, ShouldEqual, t.RXInfo.LoRaSNR)
 				})
 			}
 		})
@@ -104,16 +104,17 @@ func collectAndCallOnce(p *redis.Pool, rxPacket gw.RXPacket, callback func(packe
 		}
 
 		out.RXInfoSet = append(out.RXInfoSet, models.RXInfo{
-			MAC:               packet.RXInfo.MAC,
-			Time:              packet.RXInfo.Time,
-			TimeSinceGPSEpoch: packet.RXInfo.TimeSinceGPSEpoch,
-			Timestamp:         packet.RXInfo.Timestamp,
-			RSSI:              packet.RXInfo.RSSI,
-			LoRaSNR:           packet.RXInfo.LoRaSNR,
-			Board:             packet.RXInfo.Board,
-			Antenna:           packet.RXInfo.Antenna,
-			RFChain:           packet.RXInfo.RFChain,
-			Channel:           packet.RXInfo.Channel,
+			MAC:                   packet.RXInfo.MAC,
+			Time:                  packet.RXInfo.Time,
+			TimeSinceGPSEpoch:     packet.RXInfo.TimeSinceGPSEpoch,
+			FineTimeSinceGPSEpoch: packet.RXInfo.FineTimeSinceGPSEpoch,
+			Timestamp:             packet.RXInfo.Timestamp,
+			RSSI:                  packet.RXInfo.RSSI,
+			LoRaSNR:               packet.RXInfo.LoRaSNR,
+			Board:                 packet.RXInfo.Board,
+			Antenna:               packet.RXInfo.Antenna,
+			RFChain:               packet.RXInfo.RFChain,
+			Channel:               packet.RXInfo.Channel,
 		})
 
 	}
@@ -16,6 +16,7 @@ import (
 	datadown "github.com/brocaar/loraserver/internal/downlink/data"
 	"github.com/brocaar/loraserver/internal/downlink/data/classb"
 	"github.com/brocaar/loraserver/internal/framelog"
+	"github.com/brocaar/loraserver/internal/geolocation"
 	"github.com/brocaar/loraserver/internal/maccommand"
 	"github.com/brocaar/loraserver/internal/models"
 	"github.com/brocaar/loraserver/internal/storage"
@@ -24,6 +25,9 @@ import (
 
 const applicationClientTimeout = time.Second
 
+// ErrAbort is used to abort the flow without error
+var ErrAbort = errors.New("nothing to do")
+
 var tasks = []func(*dataContext) error{
 	setContextFromDataPHYPayload,
 	getDeviceSessionForPHYPayload,
@@ -32,16 +36,19 @@ var tasks = []func(*dataContext) error{
 	logUplinkFrame,
 	getDeviceProfile,
 	getServiceProfile,
+	checkUplinkRateLimit,
 	setADR,
 	setUplinkDataRate,
 	getApplicationServerClientForDataUp,
+	checkGatewayDiversity,
 	setBeaconLocked,
+	setGatewayBeaconFrequency,
 	sendRXInfoToNetworkController,
 	handleFOptsMACCommands,
 	handleFRMPayloadMACCommands,
 	appendMetaDataToUplinkHistory,
 	sendFRMPayloadToApplicationServer,
-	setLastRXInfoSet,
+	setUplinkGatewayHistory,
 	syncUplinkFCnt,
 	saveDeviceSession,
 	handleUplinkACK,
@@ -56,6 +63,20 @@ type dataContext struct {
 	ServiceProfile          storage.ServiceProfile
 	ApplicationServerClient as.ApplicationServerServiceClient
 	MACCommandResponses     []storage.MACCommandBlock
+
+	// RateLimitExceeded is set when the uplink exceeds the service-profile
+	// ULRate and the ULRatePolicy is set to Mark.
+	RateLimitExceeded bool
+
+	// Dropped is set when the uplink must be dropped by the ULRatePolicy or
+	// the MinGWDiversityPolicy. The frame-counter, mac-commands and ACK of
+	// the uplink are still handled (so that the frame can't be replayed),
+	// but its payload is not forwarded to the application-server.
+	Dropped bool
+
+	// MinGWDiversityViolated is set when the uplink was received by fewer
+	// gateways than the service-profile MinGWDiversity.
+	MinGWDiversityViolated bool
 }
 
 // Handle handles an uplink data frame
@@ -66,6 +87,9 @@ func Handle(rxPacket models.RXPacket) error {
 
 	for _, t := range tasks {
 		if err := t(&ctx); err != nil {
+			if err == ErrAbort {
+				return nil
+			}
 			return err
 		}
 	}
@@ -151,6 +175,35 @@ func getServiceProfile(ctx *dataContext) error {
 	return nil
 }
 
+func checkUplinkRateLimit(ctx *dataContext) error {
+	ok, err := storage.TakeUplinkRateLimitToken(config.C.Redis.Pool, ctx.DeviceSession.DevEUI, ctx.ServiceProfile.ULRate, ctx.ServiceProfile.ULBucketSize)
+	if err != nil {
+		return errors.Wrap(err, "take uplink rate-limit token error")
+	}
+	if ok {
+		return nil
+	}
+
+	switch ctx.ServiceProfile.ULRatePolicy {
+	case storage.Drop:
+		log.WithFields(log.Fields{
+			"dev_eui":  ctx.DeviceSession.DevEUI,
+			"ul_rate":  ctx.ServiceProfile.ULRate,
+			"f_cnt_up": ctx.MACPayload.FHDR.FCnt,
+		}).Warning("uplink rate exceeded, dropping uplink frame")
+		ctx.Dropped = true
+	default:
+		log.WithFields(log.Fields{
+			"dev_eui":  ctx.DeviceSession.DevEUI,
+			"ul_rate":  ctx.ServiceProfile.ULRate,
+			"f_cnt_up": ctx.MACPayload.FHDR.FCnt,
+		}).Info("uplink rate exceeded, marking uplink frame")
+		ctx.RateLimitExceeded = true
+	}
+
+	return nil
+}
+
 func setADR(ctx *dataContext) error {
 	ctx.DeviceSession.ADR = ctx.MACPayload.FHDR.FCtrl.ADR
 	return nil
@@ -215,6 +268,59 @@ func getApplicationServerClientForDataUp(ctx *dataContext) error {
 	return nil
 }
 
+func checkGatewayDiversity(ctx *dataContext) error {
+	if ctx.ServiceProfile.MinGWDiversity <= 0 {
+		return nil
+	}
+
+	// the same gateway might have received the uplink on multiple antennas
+	gws := make(map[lorawan.EUI64]struct{})
+	for _, rxInfo := range ctx.RXPacket.RXInfoSet {
+		gws[rxInfo.MAC] = struct{}{}
+	}
+	violated := len(gws) < ctx.ServiceProfile.MinGWDiversity
+
+	if err := storage.UpdateGatewayDiversityStats(config.C.Redis.Pool, ctx.DeviceSession.DevEUI, len(gws), violated); err != nil {
+		log.WithField("dev_eui", ctx.DeviceSession.DevEUI).WithError(err).Error("update gateway diversity stats error")
+	}
+
+	if !violated {
+		return nil
+	}
+
+	logFields := log.Fields{
+		"dev_eui":          ctx.DeviceSession.DevEUI,
+		"f_cnt_up":         ctx.MACPayload.FHDR.FCnt,
+		"gateway_count":    len(gws),
+		"min_gw_diversity": ctx.ServiceProfile.MinGWDiversity,
+	}
+
+	switch ctx.ServiceProfile.MinGWDiversityPolicy {
+	case storage.GWDiversityDrop:
+		log.WithFields(logFields).Warning("min gateway diversity violated, dropping uplink frame")
+		ctx.Dropped = true
+	case storage.GWDiversityReport:
+		log.WithFields(logFields).Warning("min gateway diversity violated, reporting uplink frame")
+		ctx.MinGWDiversityViolated = true
+
+		go func(asClient as.ApplicationServerServiceClient, req as.HandleErrorRequest) {
+			if _, err := asClient.HandleError(context.Background(), &req); err != nil {
+				log.WithError(err).Error("as.HandleError error")
+			}
+		}(ctx.ApplicationServerClient, as.HandleErrorRequest{
+			DevEui: ctx.DeviceSession.DevEUI[:],
+			Type:   as.ErrorType_DATA_UP_GW_DIVERSITY,
+			FCnt:   ctx.MACPayload.FHDR.FCnt,
+			Error:  fmt.Sprintf("uplink received by %d gateway(s), min gateway diversity is %d", len(gws), ctx.ServiceProfile.MinGWDiversity),
+		})
+	default:
+		log.WithFields(logFields).Info("min gateway diversity violated, marking uplink frame")
+		ctx.MinGWDiversityViolated = true
+	}
+
+	return nil
+}
+
 func decryptFOptsMACCommands(ctx *dataContext) error {
 	if ctx.DeviceSession.GetMACVersion() == lorawan.LoRaWAN1_0 {
 		if err := ctx.RXPacket.PHYPayload.DecodeFOptsToMACCommands(); err != nil {
@@ -266,6 +372,27 @@ func setBeaconLocked(ctx *dataContext) error {
 	return nil
 }
 
+// setGatewayBeaconFrequency stores the beacon frequency of the Class-B
+// device for the receiving gateways, so that the beacon is sent on the
+// frequency used by the devices in range of each gateway. As this is not
+// critical for handling the uplink, errors are logged but not returned.
+func setGatewayBeaconFrequency(ctx *dataContext) error {
+	if !ctx.DeviceProfile.SupportsClassB {
+		return nil
+	}
+
+	var macs []lorawan.EUI64
+	for _, rxInfo := range ctx.RXPacket.RXInfoSet {
+		macs = append(macs, rxInfo.MAC)
+	}
+
+	if err := storage.SetGatewayDeviceBeaconFrequency(config.C.Redis.Pool, macs, ctx.DeviceSession.DevEUI, ctx.DeviceSession.BeaconFrequency); err != nil {
+		log.WithField("dev_eui", ctx.DeviceSession.DevEUI).WithError(err).Error("set gateway device beacon frequency error")
+	}
+
+	return nil
+}
+
 func sendRXInfoToNetworkController(ctx *dataContext) error {
 	// TODO: change so that errors get logged but not returned
 	if err := sendRXInfoPayload(ctx.DeviceSession, ctx.RXPacket); err != nil {
@@ -320,16 +447,22 @@ func handleFRMPayloadMACCommands(ctx *dataContext) error {
 }
 
 func sendFRMPayloadToApplicationServer(ctx *dataContext) error {
+	if ctx.Dropped {
+		return nil
+	}
+
 	if ctx.MACPayload.FPort == nil || (ctx.MACPayload.FPort != nil && *ctx.MACPayload.FPort == 0) {
 		return nil
 	}
 
 	publishDataUpReq := as.HandleUplinkDataRequest{
-		DevEui:  ctx.DeviceSession.DevEUI[:],
-		JoinEui: ctx.DeviceSession.JoinEUI[:],
-		FCnt:    ctx.MACPayload.FHDR.FCnt,
-		Adr:     ctx.MACPayload.FHDR.FCtrl.ADR,
-		TxInfo:  ctx.RXPacket.GetGWUplinkTXInfo(),
+		DevEui:                 ctx.DeviceSession.DevEUI[:],
+		JoinEui:                ctx.DeviceSession.JoinEUI[:],
+		FCnt:                   ctx.MACPayload.FHDR.FCnt,
+		Adr:                    ctx.MACPayload.FHDR.FCtrl.ADR,
+		TxInfo:                 ctx.RXPacket.GetGWUplinkTXInfo(),
+		RateLimitExceeded:      ctx.RateLimitExceeded,
+		MinGwDiversityViolated: ctx.MinGWDiversityViolated,
 	}
 
 	dr, err := config.C.NetworkServer.Band.Band.GetDataRateIndex(true, ctx.RXPacket.TXInfo.DataRate)
@@ -350,23 +483,22 @@ func sendFRMPayloadToApplicationServer(ctx *dataContext) error {
 		ctx.DeviceSession.AppSKeyEvelope = nil
 	}
 
-	if ctx.ServiceProfile.AddGWMetadata {
+	var gws map[lorawan.EUI64]storage.Gateway
+	if ctx.ServiceProfile.AddGWMetadata || ctx.ServiceProfile.NwkGeoLoc {
 		var macs []lorawan.EUI64
-		publishDataUpReq.RxInfo = ctx.RXPacket.GetGWUplinkRXInfoSet()
-
-		// get gateway info
-		for i := range publishDataUpReq.RxInfo {
-			var mac lorawan.EUI64
-			copy(mac[:], publishDataUpReq.RxInfo[i].GatewayId)
-			macs = append(macs, mac)
+		for _, rxInfo := range ctx.RXPacket.RXInfoSet {
+			macs = append(macs, rxInfo.MAC)
 		}
 
-		gws, err := storage.GetGatewaysForMACs(config.C.PostgreSQL.DB, macs)
+		gws, err = storage.GetGatewaysForMACs(config.C.PostgreSQL.DB, macs)
 		if err != nil {
-			fmt.Println(err)
 			log.WithField("macs", macs).Warningf("get gateways for macs error: %s", err)
 			gws = make(map[lorawan.EUI64]storage.Gateway)
 		}
+	}
+
+	if ctx.ServiceProfile.AddGWMetadata {
+		publishDataUpReq.RxInfo = ctx.RXPacket.GetGWUplinkRXInfoSet()
 
 		for i := range publishDataUpReq.RxInfo {
 			var mac lorawan.EUI64
@@ -382,6 +514,10 @@ func sendFRMPayloadToApplicationServer(ctx *dataContext) error {
 		}
 	}
 
+	if ctx.ServiceProfile.NwkGeoLoc {
+		publishDataUpReq.DeviceLocation = getDeviceLocation(ctx.RXPacket.RXInfoSet, gws)
+	}
+
 	if ctx.MACPayload.FPort != nil {
 		publishDataUpReq.FPort = uint32(*ctx.MACPayload.FPort)
 	}
@@ -408,12 +544,58 @@ func sendFRMPayloadToApplicationServer(ctx *dataContext) error {
 	return nil
 }
 
-func setLastRXInfoSet(ctx *dataContext) error {
-	if len(ctx.RXPacket.RXInfoSet) != 0 {
-		ctx.DeviceSession.UplinkGatewayHistory = map[lorawan.EUI64]storage.UplinkGatewayHistory{
-			ctx.RXPacket.RXInfoSet[0].MAC: storage.UplinkGatewayHistory{},
+// getDeviceLocation resolves the location of the device using the locations
+// of the receiving gateways. Gateways without location are ignored.
+// It returns nil when the location can't be resolved.
+func getDeviceLocation(rxInfoSet models.RXInfoSet, gws map[lorawan.EUI64]storage.Gateway) *as.DeviceLocation {
+	var receivers []geolocation.Receiver
+	for _, rxInfo := range rxInfoSet {
+		gw, ok := gws[rxInfo.MAC]
+		if !ok || (gw.Location.Latitude == 0 && gw.Location.Longitude == 0) {
+			continue
+		}
+
+		r := geolocation.Receiver{
+			Location: geolocation.Location{
+				Latitude:  gw.Location.Latitude,
+				Longitude: gw.Location.Longitude,
+				Altitude:  gw.Altitude,
+			},
+			RSSI: rxInfo.RSSI,
 		}
+		if rxInfo.FineTimeSinceGPSEpoch != nil {
+			d := time.Duration(*rxInfo.FineTimeSinceGPSEpoch)
+			r.FineTimeSinceGPSEpoch = &d
+		}
+		receivers = append(receivers, r)
 	}
+
+	if len(receivers) == 0 {
+		return nil
+	}
+
+	res, err := geolocation.Resolve(receivers)
+	if err != nil {
+		log.WithError(err).Warning("resolve device location error")
+		return nil
+	}
+
+	loc := as.DeviceLocation{
+		Latitude:     res.Location.Latitude,
+		Longitude:    res.Location.Longitude,
+		Altitude:     res.Location.Altitude,
+		Source:       as.LocationSource_RSSI_CENTROID,
+		GatewayCount: uint32(res.GatewayCount),
+	}
+	if res.Method == geolocation.TDOA {
+		loc.Source = as.LocationSource_TDOA
+	}
+
+	return &loc
+}
+
+func setUplinkGatewayHistory(ctx *dataContext) error {
+	ctx.DeviceSession.AppendUplinkGatewayHistory(ctx.MACPayload.FHDR.FCnt, ctx.RXPacket.RXInfoSet)
 	return nil
 }
 
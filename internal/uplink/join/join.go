@@ -166,6 +166,7 @@ func getJoinAcceptFromAS(ctx *context) error {
 	// the join-server and device how to derrive the session-keys and how to
 	// sign the join-accept message
 
+	rxParams := ctx.DeviceProfile.GetRXParameters()
 	joinReqPL := backend.JoinReqPayload{
 		BasePayload: backend.BasePayload{
 			ProtocolVersion: backend.ProtocolVersion1_0,
@@ -180,10 +181,10 @@ func getJoinAcceptFromAS(ctx *context) error {
 		DevAddr:    ctx.DevAddr,
 		DLSettings: lorawan.DLSettings{
 			OptNeg:      !strings.HasPrefix(ctx.DeviceProfile.MACVersion, "1.0"), // must be set to true for != "1.0" devices
-			RX2DataRate: uint8(config.C.NetworkServer.NetworkSettings.RX2DR),
-			RX1DROffset: uint8(config.C.NetworkServer.NetworkSettings.RX1DROffset),
+			RX2DataRate: uint8(rxParams.RX2DR),
+			RX1DROffset: uint8(rxParams.RX1DROffset),
 		},
-		RxDelay: config.C.NetworkServer.NetworkSettings.RX1Delay,
+		RxDelay: rxParams.RX1Delay,
 		CFList:  backend.HEXBytes(cFListB),
 	}
 
@@ -208,6 +209,9 @@ func flushDeviceQueue(ctx *context) error {
 }
 
 func createDeviceSession(ctx *context) error {
+	// the RX2 frequency can't be set by the join-accept, it is updated
+	// using the RXParamSetupReq mac-command when needed
+	rxParams := ctx.DeviceProfile.GetRXParameters()
 	ds := storage.DeviceSession{
 		DeviceProfileID:  ctx.Device.DeviceProfileID,
 		ServiceProfileID: ctx.Device.ServiceProfileID,
@@ -218,14 +222,13 @@ func createDeviceSession(ctx *context) error {
 		JoinEUI:               ctx.JoinRequestPayload.JoinEUI,
 		DevEUI:                ctx.JoinRequestPayload.DevEUI,
 		RXWindow:              storage.RX1,
-		RXDelay:               uint8(config.C.NetworkServer.NetworkSettings.RX1Delay),
-		RX1DROffset:           uint8(config.C.NetworkServer.NetworkSettings.RX1DROffset),
-		RX2DR:                 uint8(config.C.NetworkServer.NetworkSettings.RX2DR),
+		RXDelay:               uint8(rxParams.RX1Delay),
+		RX1DROffset:           uint8(rxParams.RX1DROffset),
+		RX2DR:                 uint8(rxParams.RX2DR),
 		RX2Frequency:          config.C.NetworkServer.Band.Band.GetDefaults().RX2Frequency,
 		EnabledUplinkChannels: config.C.NetworkServer.Band.Band.GetStandardUplinkChannelIndices(),
 		ExtraUplinkChannels:   make(map[int]band.Channel),
 		UplinkGatewayHistory:  map[lorawan.EUI64]storage.UplinkGatewayHistory{},
-		MaxSupportedDR:        ctx.ServiceProfile.DRMax,
 		SkipFCntValidation:    ctx.Device.SkipFCntCheck,
 		PingSlotDR:            ctx.DeviceProfile.PingSlotDR,
 		PingSlotFrequency:     int(ctx.DeviceProfile.PingSlotFreq),
@@ -23,6 +23,7 @@ var tasks = []func(*context) error{
 	setContextFromRejoinRequestPHY,
 	logRejoinRequestFramesCollected,
 	getDeviceAndProfiles,
+	getPendingForceRejoin,
 	forRejoinType([]lorawan.JoinType{lorawan.RejoinRequestType0, lorawan.RejoinRequestType2},
 		getDeviceSession,
 		validateRejoinCounter0,
@@ -30,7 +31,14 @@ var tasks = []func(*context) error{
 		getRandomDevAddr,
 		getRejoinAcceptFromJS,
 	),
-	forRejoinType([]lorawan.JoinType{lorawan.RejoinRequestType0},
+	forRejoinType([]lorawan.JoinType{lorawan.RejoinRequestType1},
+		getDeviceSessionIfExists,
+		validateRejoinCounter1,
+		getRandomDevAddr,
+		getRejoinAcceptFromJS,
+		flushDeviceQueueForLostDeviceSession,
+	),
+	forRejoinType([]lorawan.JoinType{lorawan.RejoinRequestType0, lorawan.RejoinRequestType1},
 		setRejoin0PendingDeviceSession,
 	),
 	forRejoinType([]lorawan.JoinType{lorawan.RejoinRequestType2},
@@ -38,6 +46,7 @@ var tasks = []func(*context) error{
 	),
 	createDeviceActivation,
 	sendJoinAcceptDownlink,
+	handleForceRejoinOutcome,
 }
 
 type context struct {
@@ -54,9 +63,16 @@ type context struct {
 	DeviceProfile  storage.DeviceProfile
 	DeviceSession  storage.DeviceSession
 
+	// DeviceSessionLost is set when a rejoin-request type 1 was received
+	// for a device without device-session.
+	DeviceSessionLost bool
+
 	DevAddr lorawan.DevAddr
 
 	RejoinAnsPayload backend.RejoinAnsPayload
+
+	// ForceRejoinReq holds the pending ForceRejoinReq (if any).
+	ForceRejoinReq *lorawan.ForceRejoinReqPayload
 }
 
 // Handle handles a rejoin-request.
@@ -160,12 +176,51 @@ func getDeviceAndProfiles(ctx *context) error {
 	return nil
 }
 
+func getPendingForceRejoin(ctx *context) error {
+	block, err := storage.GetPendingMACCommand(config.C.Redis.Pool, ctx.DevEUI, lorawan.ForceRejoinReq)
+	if err != nil {
+		return errors.Wrap(err, "get pending force rejoin mac-command error")
+	}
+	if block == nil || len(block.MACCommands) == 0 {
+		return nil
+	}
+
+	pl, ok := block.MACCommands[0].Payload.(*lorawan.ForceRejoinReqPayload)
+	if !ok {
+		return fmt.Errorf("expected *lorawan.ForceRejoinReqPayload, got %T", block.MACCommands[0].Payload)
+	}
+	ctx.ForceRejoinReq = pl
+
+	return nil
+}
+
 func getDeviceSession(ctx *context) error {
 	var err error
 	ctx.DeviceSession, err = storage.GetDeviceSession(config.C.Redis.Pool, ctx.DevEUI)
 	if err != nil {
 		return errors.Wrap(err, "get device-session error")
 	}
+	ctx.JoinEUI = ctx.DeviceSession.JoinEUI
+	return nil
+}
+
+// getDeviceSessionIfExists gets the device-session. As a rejoin-request
+// type 1 can be used to recover a device of which the device-session has been
+// lost, a missing device-session is not an error. In this case the
+// rejoin-request is handled as a join-request and nothing is stored until
+// the join-server has accepted the rejoin-request.
+func getDeviceSessionIfExists(ctx *context) error {
+	var err error
+	ctx.DeviceSession, err = storage.GetDeviceSession(config.C.Redis.Pool, ctx.DevEUI)
+	if err != nil {
+		if errors.Cause(err) != storage.ErrDoesNotExist {
+			return errors.Wrap(err, "get device-session error")
+		}
+
+		log.WithField("dev_eui", ctx.DevEUI).Info("device-session does not exist, handling rejoin-request as join-request")
+		ctx.DeviceSessionLost = true
+	}
+
 	return nil
 }
 
@@ -185,6 +240,26 @@ func validateRejoinCounter0(ctx *context) error {
 	return errors.New("invalid RJcount0")
 }
 
+// validateRejoinCounter1 validates that the RJcount1 is greater than the
+// RJcount1 of the previous rejoin-request type 1. Note that the MIC of the
+// rejoin-request type 1 is validated by the join-server, as it is signed using
+// the JSIntKey.
+func validateRejoinCounter1(ctx *context) error {
+	last, err := storage.GetMaxDevNonce(config.C.PostgreSQL.DB, ctx.JoinEUI, ctx.DevEUI, lorawan.RejoinRequestType1)
+	if err != nil {
+		if errors.Cause(err) == storage.ErrDoesNotExist {
+			return nil
+		}
+		return errors.Wrap(err, "get last RJcount1 error")
+	}
+
+	if lorawan.DevNonce(ctx.RJCount) <= last {
+		return errors.New("invalid RJcount1")
+	}
+
+	return nil
+}
+
 func validateMIC(ctx *context) error {
 	ok, err := ctx.RXPacket.PHYPayload.ValidateUplinkJoinMIC(ctx.DeviceSession.SNwkSIntKey)
 	if err != nil {
@@ -220,11 +295,12 @@ func getRejoinAcceptFromJS(ctx *context) error {
 	}
 	transactionID := binary.LittleEndian.Uint32(randomBytes)
 
+	rxParams := ctx.DeviceProfile.GetRXParameters()
 	rejoinReqPL := backend.RejoinReqPayload{
 		BasePayload: backend.BasePayload{
 			ProtocolVersion: backend.ProtocolVersion1_0,
 			SenderID:        config.C.NetworkServer.NetID.String(),
-			ReceiverID:      ctx.DeviceSession.JoinEUI.String(),
+			ReceiverID:      ctx.JoinEUI.String(),
 			TransactionID:   transactionID,
 			MessageType:     backend.RejoinReq,
 		},
@@ -234,10 +310,10 @@ func getRejoinAcceptFromJS(ctx *context) error {
 		DevAddr:    ctx.DevAddr,
 		DLSettings: lorawan.DLSettings{
 			OptNeg:      !strings.HasPrefix(ctx.DeviceProfile.MACVersion, "1.0"),
-			RX2DataRate: uint8(config.C.NetworkServer.NetworkSettings.RX2DR),
-			RX1DROffset: uint8(config.C.NetworkServer.NetworkSettings.RX1DROffset),
+			RX2DataRate: uint8(rxParams.RX2DR),
+			RX1DROffset: uint8(rxParams.RX1DROffset),
 		},
-		RxDelay: config.C.NetworkServer.NetworkSettings.RX1Delay,
+		RxDelay: rxParams.RX1Delay,
 	}
 
 	// 0: Used to reset a device context including all radio parameters.
@@ -247,7 +323,7 @@ func getRejoinAcceptFromJS(ctx *context) error {
 	// 2: Used to rekey a device or change its DevAddr (DevAddr, session keys,
 	//    frame counters). Radio parameters are kept unchanged.
 	if ctx.RejoinType == lorawan.RejoinRequestType0 || ctx.RejoinType == lorawan.RejoinRequestType1 {
-		cFList := config.C.NetworkServer.Band.Band.GetCFList(ctx.DeviceSession.MACVersion)
+		cFList := config.C.NetworkServer.Band.Band.GetCFList(ctx.DeviceProfile.MACVersion)
 		if cFList != nil {
 			cFListB, err := cFList.MarshalBinary()
 			if err != nil {
@@ -257,7 +333,7 @@ func getRejoinAcceptFromJS(ctx *context) error {
 		}
 	}
 
-	jsClient, err := config.C.JoinServer.Pool.Get(ctx.DeviceSession.JoinEUI)
+	jsClient, err := config.C.JoinServer.Pool.Get(ctx.JoinEUI)
 	if err != nil {
 		return errors.Wrap(err, "get join-server client error")
 	}
@@ -277,7 +353,22 @@ func flushDeviceQueue(ctx *context) error {
 	return nil
 }
 
+// flushDeviceQueueForLostDeviceSession flushes the device-queue when the
+// rejoin-request is handled as a join-request, as the queue items can't be
+// decrypted by the device using the new session-keys.
+func flushDeviceQueueForLostDeviceSession(ctx *context) error {
+	if !ctx.DeviceSessionLost {
+		return nil
+	}
+	return flushDeviceQueue(ctx)
+}
+
+// setRejoin0PendingDeviceSession sets the pending device-session for a
+// rejoin-request type 0 or 1. All radio parameters are reset to the defaults.
+// When the device-session was lost, the new device-session is activated
+// directly, as is done for a join-request.
 func setRejoin0PendingDeviceSession(ctx *context) error {
+	rxParams := ctx.DeviceProfile.GetRXParameters()
 	pendingDS := storage.DeviceSession{
 		DeviceProfileID:  ctx.Device.DeviceProfileID,
 		ServiceProfileID: ctx.Device.ServiceProfileID,
@@ -285,17 +376,16 @@ func setRejoin0PendingDeviceSession(ctx *context) error {
 
 		MACVersion:            ctx.DeviceProfile.MACVersion,
 		DevAddr:               ctx.DevAddr,
-		JoinEUI:               ctx.DeviceSession.JoinEUI,
-		DevEUI:                ctx.DeviceSession.DevEUI,
+		JoinEUI:               ctx.JoinEUI,
+		DevEUI:                ctx.DevEUI,
 		RXWindow:              storage.RX1,
-		RXDelay:               uint8(config.C.NetworkServer.NetworkSettings.RX1Delay),
-		RX1DROffset:           uint8(config.C.NetworkServer.NetworkSettings.RX1DROffset),
-		RX2DR:                 uint8(config.C.NetworkServer.NetworkSettings.RX2DR),
+		RXDelay:               uint8(rxParams.RX1Delay),
+		RX1DROffset:           uint8(rxParams.RX1DROffset),
+		RX2DR:                 uint8(rxParams.RX2DR),
 		RX2Frequency:          config.C.NetworkServer.Band.Band.GetDefaults().RX2Frequency,
 		EnabledUplinkChannels: config.C.NetworkServer.Band.Band.GetStandardUplinkChannelIndices(),
 		ExtraUplinkChannels:   make(map[int]band.Channel),
 		UplinkGatewayHistory:  map[lorawan.EUI64]storage.UplinkGatewayHistory{},
-		MaxSupportedDR:        ctx.ServiceProfile.DRMax,
 		SkipFCntValidation:    ctx.Device.SkipFCntCheck,
 		PingSlotDR:            ctx.DeviceProfile.PingSlotDR,
 		PingSlotFrequency:     int(ctx.DeviceProfile.PingSlotFreq),
@@ -347,7 +437,7 @@ func setRejoin0PendingDeviceSession(ctx *context) error {
 		pendingDS.NwkSEncKey = key
 	}
 
-	if cfList := config.C.NetworkServer.Band.Band.GetCFList(ctx.DeviceSession.MACVersion); cfList != nil && cfList.CFListType == lorawan.CFListChannel {
+	if cfList := config.C.NetworkServer.Band.Band.GetCFList(ctx.DeviceProfile.MACVersion); cfList != nil && cfList.CFListType == lorawan.CFListChannel {
 		channelPL, ok := cfList.Payload.(*lorawan.CFListChannelPayload)
 		if !ok {
 			return fmt.Errorf("expected *lorawan.CFListChannelPayload, got %T", cfList.Payload)
@@ -384,7 +474,11 @@ func setRejoin0PendingDeviceSession(ctx *context) error {
 		pendingDS.PingSlotNb = (1 << 12) / ctx.DeviceProfile.PingSlotPeriod
 	}
 
-	ctx.DeviceSession.PendingRejoinDeviceSession = &pendingDS
+	if ctx.DeviceSessionLost {
+		ctx.DeviceSession = pendingDS
+	} else {
+		ctx.DeviceSession.PendingRejoinDeviceSession = &pendingDS
+	}
 
 	if err := storage.SaveDeviceSession(config.C.Redis.Pool, ctx.DeviceSession); err != nil {
 		return errors.Wrap(err, "save device-session error")
@@ -456,17 +550,29 @@ func setRejoin2PendingDeviceSession(ctx *context) error {
 }
 
 func createDeviceActivation(ctx *context) error {
+	ds := ctx.DeviceSession
+	if ds.PendingRejoinDeviceSession != nil {
+		ds = *ds.PendingRejoinDeviceSession
+	}
+
 	da := storage.DeviceActivation{
-		DevEUI:      ctx.DeviceSession.PendingRejoinDeviceSession.DevEUI,
-		JoinEUI:     ctx.DeviceSession.PendingRejoinDeviceSession.JoinEUI,
-		DevAddr:     ctx.DeviceSession.PendingRejoinDeviceSession.DevAddr,
-		SNwkSIntKey: ctx.DeviceSession.PendingRejoinDeviceSession.SNwkSIntKey,
-		FNwkSIntKey: ctx.DeviceSession.PendingRejoinDeviceSession.FNwkSIntKey,
-		NwkSEncKey:  ctx.DeviceSession.PendingRejoinDeviceSession.NwkSEncKey,
+		DevEUI:      ds.DevEUI,
+		JoinEUI:     ds.JoinEUI,
+		DevAddr:     ds.DevAddr,
+		SNwkSIntKey: ds.SNwkSIntKey,
+		FNwkSIntKey: ds.FNwkSIntKey,
+		NwkSEncKey:  ds.NwkSEncKey,
 		DevNonce:    lorawan.DevNonce(ctx.RJCount),
 		JoinReqType: lorawan.JoinType(ctx.RejoinType),
 	}
 
+	// store the outcome of the ForceRejoinReq, so that it can be retrieved
+	// through the API
+	if ctx.ForceRejoinReq != nil {
+		rejoinType := lorawan.JoinType(ctx.ForceRejoinReq.RejoinType)
+		da.ForceRejoinReqType = &rejoinType
+	}
+
 	if err := storage.CreateDeviceActivation(config.C.PostgreSQL.DB, &da); err != nil {
 		return errors.Wrap(err, "create device-activation error")
 	}
@@ -486,3 +592,31 @@ func sendJoinAcceptDownlink(ctx *context) error {
 
 	return nil
 }
+
+// handleForceRejoinOutcome logs the outcome of a pending ForceRejoinReq
+// and removes it from the pending mac-commands. The outcome is stored with
+// the device-activation (see createDeviceActivation).
+func handleForceRejoinOutcome(ctx *context) error {
+	if ctx.ForceRejoinReq == nil {
+		return nil
+	}
+
+	logFields := log.Fields{
+		"dev_eui":               ctx.DevEUI,
+		"dev_addr":              ctx.DevAddr,
+		"rejoin_type":           ctx.RejoinType,
+		"requested_rejoin_type": ctx.ForceRejoinReq.RejoinType,
+	}
+
+	if lorawan.JoinType(ctx.ForceRejoinReq.RejoinType) == ctx.RejoinType {
+		log.WithFields(logFields).Info("force rejoin request completed")
+	} else {
+		log.WithFields(logFields).Warning("force rejoin request completed with different rejoin-type")
+	}
+
+	if err := storage.DeletePendingMACCommand(config.C.Redis.Pool, ctx.DevEUI, lorawan.ForceRejoinReq); err != nil {
+		return errors.Wrap(err, "delete pending mac-command error")
+	}
+
+	return nil
+}
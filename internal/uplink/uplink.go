@@ -9,6 +9,7 @@ import (
 
 	"github.com/brocaar/loraserver/api/gw"
 	"github.com/brocaar/loraserver/internal/config"
+	"github.com/brocaar/loraserver/internal/downlink/ack"
 	"github.com/brocaar/loraserver/internal/framelog"
 	"github.com/brocaar/loraserver/internal/models"
 	"github.com/brocaar/loraserver/internal/uplink/data"
@@ -35,6 +36,12 @@ func (s *Server) Start() error {
 		defer s.wg.Done()
 		HandleRXPackets(&s.wg)
 	}()
+
+	go func() {
+		s.wg.Add(1)
+		defer s.wg.Done()
+		HandleDownlinkTXAcks(&s.wg)
+	}()
 	return nil
 }
 
@@ -64,6 +71,23 @@ func HandleRXPackets(wg *sync.WaitGroup) {
 	}
 }
 
+// HandleDownlinkTXAcks consumes the downlink tx acknowledgements received by
+// the gateway and handles them in a separate go-routine. Errors are logged.
+func HandleDownlinkTXAcks(wg *sync.WaitGroup) {
+	for txAck := range config.C.NetworkServer.Gateway.Backend.Backend.TXAckChan() {
+		go func(txAck gw.TXAck) {
+			wg.Add(1)
+			defer wg.Done()
+			if err := ack.HandleDownlinkTXAck(txAck); err != nil {
+				log.WithFields(log.Fields{
+					"mac":   txAck.MAC,
+					"token": txAck.Token,
+				}).Errorf("handle downlink tx ack error: %s", err)
+			}
+		}(txAck)
+	}
+}
+
 // HandleRXPacket handles a single rxpacket.
 func HandleRXPacket(rxPacket gw.RXPacket) error {
 	return collectPackets(rxPacket)
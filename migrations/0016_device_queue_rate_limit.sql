@@ -0,0 +1,7 @@
+-- +migrate Up
+alter table device_queue
+	add column rate_limit_exceeded boolean not null default false;
+
+-- +migrate Down
+alter table device_queue
+	drop column rate_limit_exceeded;
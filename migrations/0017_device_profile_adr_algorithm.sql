@@ -0,0 +1,7 @@
+-- +migrate Up
+alter table device_profile
+	add column adr_algorithm_id varchar(100) not null default '';
+
+-- +migrate Down
+alter table device_profile
+	drop column adr_algorithm_id;
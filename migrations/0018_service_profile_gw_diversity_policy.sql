@@ -0,0 +1,7 @@
+-- +migrate Up
+alter table service_profile
+	add column min_gw_diversity_policy varchar(6) not null default 'Mark';
+
+-- +migrate Down
+alter table service_profile
+	drop column min_gw_diversity_policy;
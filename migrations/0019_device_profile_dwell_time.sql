@@ -0,0 +1,9 @@
+-- +migrate Up
+alter table device_profile
+	add column uplink_dwell_time_400ms boolean not null default false,
+	add column downlink_dwell_time_400ms boolean not null default false;
+
+-- +migrate Down
+alter table device_profile
+	drop column downlink_dwell_time_400ms,
+	drop column uplink_dwell_time_400ms;
@@ -0,0 +1,7 @@
+-- +migrate Up
+alter table service_profile
+	add column dl_channel_frequencies integer[];
+
+-- +migrate Down
+alter table service_profile
+	drop column dl_channel_frequencies;
@@ -0,0 +1,9 @@
+-- +migrate Up
+alter table device_profile
+	add column adr_ack_limit_exp smallint not null default 0,
+	add column adr_ack_delay_exp smallint not null default 0;
+
+-- +migrate Down
+alter table device_profile
+	drop column adr_ack_delay_exp,
+	drop column adr_ack_limit_exp;
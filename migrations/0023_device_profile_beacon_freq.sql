@@ -0,0 +1,7 @@
+-- +migrate Up
+alter table device_profile
+	add column beacon_freq integer not null default 0;
+
+-- +migrate Down
+alter table device_profile
+	drop column beacon_freq;
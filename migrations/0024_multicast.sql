@@ -0,0 +1,55 @@
+-- +migrate Up
+create table multicast_group (
+    multicast_group_id uuid primary key,
+    created_at timestamp with time zone not null,
+    updated_at timestamp with time zone not null,
+    mc_addr bytea not null,
+    mc_nwk_s_key bytea not null,
+    f_cnt bigint not null,
+    group_type char(1) not null,
+    dr smallint not null,
+    frequency integer not null,
+    ping_slot_period integer not null
+);
+
+create index idx_multicast_group_created_at on multicast_group(created_at);
+create index idx_multicast_group_updated_at on multicast_group(updated_at);
+
+create table device_multicast_group (
+    dev_eui bytea not null references device on delete cascade,
+    multicast_group_id uuid not null references multicast_group on delete cascade,
+    created_at timestamp with time zone not null,
+
+    primary key(multicast_group_id, dev_eui)
+);
+
+create index idx_device_multicast_group_dev_eui on device_multicast_group(dev_eui);
+
+create table multicast_queue (
+    id bigserial primary key,
+    created_at timestamp with time zone not null,
+    schedule_at timestamp with time zone not null,
+    emit_at_time_since_gps_epoch bigint,
+    multicast_group_id uuid not null references multicast_group on delete cascade,
+    gateway_mac bytea not null references gateway on delete cascade,
+    f_cnt bigint not null,
+    f_port smallint not null,
+    frm_payload bytea
+);
+
+create index idx_multicast_queue_schedule_at on multicast_queue(schedule_at);
+create index idx_multicast_queue_multicast_group_id on multicast_queue(multicast_group_id);
+create index idx_multicast_queue_emit_at_time_since_gps_epoch on multicast_queue(emit_at_time_since_gps_epoch);
+
+-- +migrate Down
+drop index idx_multicast_queue_emit_at_time_since_gps_epoch;
+drop index idx_multicast_queue_multicast_group_id;
+drop index idx_multicast_queue_schedule_at;
+drop table multicast_queue;
+
+drop index idx_device_multicast_group_dev_eui;
+drop table device_multicast_group;
+
+drop index idx_multicast_group_updated_at;
+drop index idx_multicast_group_created_at;
+drop table multicast_group;
@@ -0,0 +1,13 @@
+-- +migrate Up
+alter table service_profile
+	add column dl_confirmed_retries integer not null default 0;
+
+alter table device_queue
+	add column retry_count integer not null default 0;
+
+-- +migrate Down
+alter table device_queue
+	drop column retry_count;
+
+alter table service_profile
+	drop column dl_confirmed_retries;
@@ -0,0 +1,11 @@
+-- +migrate Up
+alter table device_queue
+	add column expires_at timestamp with time zone;
+
+create index idx_device_queue_expires_at on device_queue(expires_at);
+
+-- +migrate Down
+drop index idx_device_queue_expires_at;
+
+alter table device_queue
+	drop column expires_at;
@@ -0,0 +1,7 @@
+-- +migrate Up
+alter table device_activation
+	add column force_rejoin_req_type smallint;
+
+-- +migrate Down
+alter table device_activation
+	drop column force_rejoin_req_type;
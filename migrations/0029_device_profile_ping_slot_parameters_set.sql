@@ -0,0 +1,7 @@
+-- +migrate Up
+alter table device_profile
+	add column ping_slot_parameters_set boolean not null default false;
+
+-- +migrate Down
+alter table device_profile
+	drop column ping_slot_parameters_set;